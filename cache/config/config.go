@@ -35,6 +35,9 @@ type DriversConfig struct {
 
 	// MongoDB driver configuration
 	MongoDB *DriverMongodbConfig `mapstructure:"mongodb" yaml:"mongodb"`
+
+	// Tiered driver configuration (memory L1 trên một driver L2 đã đăng ký)
+	Tiered *DriverTieredConfig `mapstructure:"tiered" yaml:"tiered"`
 }
 
 // DriverMemoryConfig là cấu hình cho memory driver.
@@ -49,6 +52,13 @@ type DriverMemoryConfig struct {
 
 	// MaxItems là số lượng item tối đa trong memory cache (0 = unlimited)
 	MaxItems int `mapstructure:"max_items" yaml:"max_items"`
+
+	// MaxBytes là tổng kích thước tối đa (byte) của các item theo sizer (0 = unlimited)
+	MaxBytes int64 `mapstructure:"max_bytes" yaml:"max_bytes"`
+
+	// EvictionPolicy là chính sách loại bỏ item khi vượt giới hạn
+	// Options: lru, lfu, fifo (mặc định lru)
+	EvictionPolicy string `mapstructure:"eviction_policy" yaml:"eviction_policy"`
 }
 
 // DriverFileConfig là cấu hình cho file driver.
@@ -66,6 +76,19 @@ type DriverFileConfig struct {
 
 	// CleanupInterval là khoảng thời gian dọn dẹp các file hết hạn (giây)
 	CleanupInterval int `mapstructure:"cleanup_interval" yaml:"cleanup_interval"`
+
+	// Prefix là tiền tố của các key trong thư mục cache (rỗng = dùng Config.Prefix)
+	Prefix string `mapstructure:"prefix" yaml:"prefix"`
+
+	// ShardDepth là số cấp thư mục con chia file cache theo hash của key (0 = lưu phẳng, tối đa 4)
+	ShardDepth int `mapstructure:"shard_depth" yaml:"shard_depth"`
+
+	// MaxSize là tổng kích thước tối đa của các file cache (byte, 0 = không giới hạn).
+	// Entry ghi sớm nhất bị loại bỏ ở nền khi vượt giới hạn, trừ version của tag và bộ đếm
+	MaxSize int64 `mapstructure:"max_size" yaml:"max_size"`
+
+	// Transform là cấu hình nén và mã hóa các file cache (nil = không biến đổi)
+	Transform *TransformConfig `mapstructure:"transform" yaml:"transform"`
 }
 
 // DriverRedisConfig là cấu hình cho redis driver.
@@ -78,6 +101,16 @@ type DriverRedisConfig struct {
 
 	// Serializer là định dạng serialization: json, gob, msgpack
 	Serializer string `mapstructure:"serializer" yaml:"serializer"`
+
+	// Prefix là tiền tố của các key trong Redis (rỗng = dùng Config.Prefix, mặc định "cache:")
+	Prefix string `mapstructure:"prefix" yaml:"prefix"`
+
+	// OperationTimeout là thời gian tối đa cho mỗi thao tác trên Redis (mili giây)
+	// 0 = chỉ dùng deadline của context truyền vào
+	OperationTimeout int `mapstructure:"operation_timeout" yaml:"operation_timeout"`
+
+	// Transform là cấu hình nén và mã hóa giá trị lưu trong Redis (nil = không biến đổi)
+	Transform *TransformConfig `mapstructure:"transform" yaml:"transform"`
 }
 
 // DriverMongodbConfig là cấu hình cho mongodb driver.
@@ -94,6 +127,16 @@ type DriverMongodbConfig struct {
 	// DefaultTTL là thời gian hết hạn mặc định cho MongoDB cache (giây)
 	DefaultTTL int `mapstructure:"default_ttl" yaml:"default_ttl"`
 
+	// Prefix là tiền tố của _id các document trong collection (rỗng = dùng Config.Prefix)
+	Prefix string `mapstructure:"prefix" yaml:"prefix"`
+
+	// OperationTimeout là thời gian tối đa cho mỗi thao tác trên MongoDB (mili giây)
+	// 0 = chỉ dùng deadline của context truyền vào
+	OperationTimeout int `mapstructure:"operation_timeout" yaml:"operation_timeout"`
+
+	// Transform là cấu hình nén và mã hóa giá trị lưu trong MongoDB (nil = không biến đổi)
+	Transform *TransformConfig `mapstructure:"transform" yaml:"transform"`
+
 	// Hits là số lần cache hit (readonly, được quản lý bởi driver)
 	Hits int64 `mapstructure:"hits" yaml:"hits"`
 
@@ -101,6 +144,72 @@ type DriverMongodbConfig struct {
 	Misses int64 `mapstructure:"misses" yaml:"misses"`
 }
 
+// TransformConfig là cấu hình biến đổi giá trị trước khi driver lưu trữ.
+//
+// Khi bật cả hai, giá trị được nén trước rồi mã hóa.
+type TransformConfig struct {
+	// Compression là cấu hình nén giá trị
+	Compression *CompressionConfig `mapstructure:"compression" yaml:"compression"`
+
+	// Encryption là cấu hình mã hóa giá trị
+	Encryption *EncryptionConfig `mapstructure:"encryption" yaml:"encryption"`
+}
+
+// CompressionConfig là cấu hình nén giá trị cache.
+type CompressionConfig struct {
+	// Enabled xác định có nén giá trị không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Algorithm là thuật toán nén
+	// Options: gzip, zstd (mặc định gzip)
+	Algorithm string `mapstructure:"algorithm" yaml:"algorithm"`
+
+	// Threshold là kích thước tối thiểu (byte) của giá trị đã serialize để được nén
+	// 0 = nén mọi giá trị
+	Threshold int `mapstructure:"threshold" yaml:"threshold"`
+
+	// Level là mức nén (0 = mặc định của thuật toán; gzip 1-9, zstd 1-22)
+	Level int `mapstructure:"level" yaml:"level"`
+}
+
+// EncryptionConfig là cấu hình mã hóa AES-GCM giá trị cache.
+//
+// Key ID được lưu cùng mỗi giá trị, nên có thể xoay vòng khóa bằng cách thêm khóa mới vào Keys
+// và đổi KeyID; khóa cũ chỉ nên xóa khỏi Keys khi các entry mã hóa bằng nó đã hết hạn.
+type EncryptionConfig struct {
+	// Enabled xác định có mã hóa giá trị không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// KeyID là key ID của khóa trong Keys dùng để mã hóa giá trị mới
+	KeyID string `mapstructure:"key_id" yaml:"key_id"`
+
+	// Keys là map key ID tới khóa AES 16, 24 hoặc 32 byte được mã hóa base64
+	Keys map[string]string `mapstructure:"keys" yaml:"keys"`
+}
+
+// DriverTieredConfig là cấu hình cho tiered driver.
+//
+// Tiered driver đặt một memory cache riêng (L1) trước một driver đã đăng ký (L2),
+// thường là redis hoặc mongodb, để các key được truy cập nhiều không phải round-trip tới L2.
+type DriverTieredConfig struct {
+	// Enabled xác định có kích hoạt Tiered driver không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// L2 là tên driver đã kích hoạt dùng làm tầng L2: redis, mongodb, file
+	L2 string `mapstructure:"l2" yaml:"l2"`
+
+	// L1TTL là thời gian sống tối đa của entry trong L1 (giây)
+	L1TTL int `mapstructure:"l1_ttl" yaml:"l1_ttl"`
+
+	// L1MaxItems là số lượng item tối đa trong L1 (0 = unlimited)
+	L1MaxItems int `mapstructure:"l1_max_items" yaml:"l1_max_items"`
+
+	// InvalidationChannel là tên kênh Redis pub/sub để invalidate L1 giữa các instance
+	// khi key được ghi hoặc xóa ở bất kỳ instance nào (rỗng = tắt, cần Redis provider).
+	// Kênh thực tế được thêm prefix key của L2, xem Config.GetInvalidationChannel
+	InvalidationChannel string `mapstructure:"invalidation_channel" yaml:"invalidation_channel"`
+}
+
 // DefaultConfig trả về cấu hình mặc định cho cache.
 //
 // Cấu hình mặc định sử dụng memory driver với TTL 1 giờ.
@@ -117,6 +226,7 @@ func DefaultConfig() *Config {
 				DefaultTTL:      3600, // 1 hour
 				CleanupInterval: 600,  // 10 minutes
 				MaxItems:        10000,
+				EvictionPolicy:  "lru",
 			},
 			File: &DriverFileConfig{
 				Path:            "./storage/cache",
@@ -125,17 +235,25 @@ func DefaultConfig() *Config {
 				CleanupInterval: 600, // 10 minutes
 			},
 			Redis: &DriverRedisConfig{
-				Enabled:    true,
-				DefaultTTL: 3600, // 1 hour
-				Serializer: "json",
+				Enabled:          true,
+				DefaultTTL:       3600, // 1 hour
+				Serializer:       "json",
+				OperationTimeout: 1000, // 1 second
 			},
 			MongoDB: &DriverMongodbConfig{
-				Enabled:    true,
-				Database:   "cache_db",
-				Collection: "cache_items",
-				DefaultTTL: 3600, // 1 hour
-				Hits:       0,
-				Misses:     0,
+				Enabled:          true,
+				Database:         "cache_db",
+				Collection:       "cache_items",
+				DefaultTTL:       3600, // 1 hour
+				OperationTimeout: 1000, // 1 second
+				Hits:             0,
+				Misses:           0,
+			},
+			Tiered: &DriverTieredConfig{
+				L2:                  "redis",
+				L1TTL:               60, // 1 minute
+				L1MaxItems:          10000,
+				InvalidationChannel: "invalidate",
 			},
 		},
 	}
@@ -149,6 +267,64 @@ func (c *Config) GetDefaultExpiration() time.Duration {
 	return time.Duration(c.DefaultTTL) * time.Second
 }
 
+// ApplyPrefix gán Prefix cho các driver dùng chung bộ lưu trữ (file, redis, mongodb)
+// chưa cấu hình Prefix riêng.
+//
+// Memory driver chỉ tồn tại trong process nên không cần prefix.
+func (c *Config) ApplyPrefix() {
+	if c.Prefix == "" {
+		return
+	}
+	if c.Drivers.File != nil && c.Drivers.File.Prefix == "" {
+		c.Drivers.File.Prefix = c.Prefix
+	}
+	if c.Drivers.Redis != nil && c.Drivers.Redis.Prefix == "" {
+		c.Drivers.Redis.Prefix = c.Prefix
+	}
+	if c.Drivers.MongoDB != nil && c.Drivers.MongoDB.Prefix == "" {
+		c.Drivers.MongoDB.Prefix = c.Prefix
+	}
+}
+
+// GetInvalidationChannel trả về kênh Redis pub/sub invalidate L1 của tiered driver.
+//
+// Tên kênh được thêm prefix key của driver L2 (prefix riêng của driver, hoặc Prefix chung,
+// với Redis mặc định là "cache:"), nên các ứng dụng dùng chung Redis với prefix khác nhau
+// không invalidate L1 của nhau. Ví dụ với prefix "app:" và tên kênh "invalidate",
+// kênh thực tế là "app:invalidate".
+//
+// Returns:
+//   - string: Kênh invalidate (rỗng nếu tiered driver không được cấu hình hoặc invalidate bị tắt)
+func (c *Config) GetInvalidationChannel() string {
+	tiered := c.Drivers.Tiered
+	if tiered == nil || tiered.InvalidationChannel == "" {
+		return ""
+	}
+
+	prefix := ""
+	switch tiered.L2 {
+	case "redis":
+		if c.Drivers.Redis != nil {
+			prefix = c.Drivers.Redis.Prefix
+		}
+	case "file":
+		if c.Drivers.File != nil {
+			prefix = c.Drivers.File.Prefix
+		}
+	case "mongodb":
+		if c.Drivers.MongoDB != nil {
+			prefix = c.Drivers.MongoDB.Prefix
+		}
+	}
+	if prefix == "" {
+		prefix = c.Prefix
+	}
+	if prefix == "" {
+		prefix = "cache:" // Tiền tố mặc định của Redis driver
+	}
+	return prefix + tiered.InvalidationChannel
+}
+
 // GetMemoryDefaultExpiration trả về thời gian hết hạn mặc định cho memory driver.
 //
 // Returns:
@@ -189,6 +365,14 @@ func (r *DriverRedisConfig) GetDefaultExpiration() time.Duration {
 	return time.Duration(r.DefaultTTL) * time.Second
 }
 
+// GetOperationTimeout trả về thời gian tối đa cho mỗi thao tác trên Redis.
+//
+// Returns:
+//   - time.Duration: Thời gian tối đa cho mỗi thao tác (0 nếu không giới hạn)
+func (r *DriverRedisConfig) GetOperationTimeout() time.Duration {
+	return time.Duration(r.OperationTimeout) * time.Millisecond
+}
+
 // GetMongoDBDefaultExpiration trả về thời gian hết hạn mặc định cho mongodb driver.
 //
 // Returns:
@@ -196,3 +380,19 @@ func (r *DriverRedisConfig) GetDefaultExpiration() time.Duration {
 func (m *DriverMongodbConfig) GetDefaultExpiration() time.Duration {
 	return time.Duration(m.DefaultTTL) * time.Second
 }
+
+// GetOperationTimeout trả về thời gian tối đa cho mỗi thao tác trên MongoDB.
+//
+// Returns:
+//   - time.Duration: Thời gian tối đa cho mỗi thao tác (0 nếu không giới hạn)
+func (m *DriverMongodbConfig) GetOperationTimeout() time.Duration {
+	return time.Duration(m.OperationTimeout) * time.Millisecond
+}
+
+// GetL1TTL trả về thời gian sống tối đa của entry trong L1 của tiered driver.
+//
+// Returns:
+//   - time.Duration: Thời gian sống tối đa của entry trong L1
+func (t *DriverTieredConfig) GetL1TTL() time.Duration {
+	return time.Duration(t.L1TTL) * time.Second
+}
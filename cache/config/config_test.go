@@ -36,6 +36,8 @@ func TestDefaultConfig(t *testing.T) {
 		assert.Equal(t, 3600, memory.DefaultTTL)
 		assert.Equal(t, 600, memory.CleanupInterval)
 		assert.Equal(t, 10000, memory.MaxItems)
+		assert.Equal(t, int64(0), memory.MaxBytes)
+		assert.Equal(t, "lru", memory.EvictionPolicy)
 	})
 
 	t.Run("file driver has correct default values", func(t *testing.T) {
@@ -74,6 +76,21 @@ func TestDefaultConfig(t *testing.T) {
 		assert.Equal(t, int64(0), mongodb.Hits)
 		assert.Equal(t, int64(0), mongodb.Misses)
 	})
+
+	t.Run("tiered driver has correct default values", func(t *testing.T) {
+		// Arrange & Act
+		config := DefaultConfig()
+
+		// Assert
+		tiered := config.Drivers.Tiered
+		assert.NotNil(t, tiered)
+		assert.False(t, tiered.Enabled)
+		assert.Equal(t, "redis", tiered.L2)
+		assert.Equal(t, 60, tiered.L1TTL)
+		assert.Equal(t, 10000, tiered.L1MaxItems)
+		assert.Equal(t, "invalidate", tiered.InvalidationChannel)
+		assert.Equal(t, "cache:invalidate", config.GetInvalidationChannel())
+	})
 }
 
 // TestConfigGetDefaultExpiration tests the GetDefaultExpiration method
@@ -119,6 +136,95 @@ func TestConfigGetDefaultExpiration(t *testing.T) {
 	}
 }
 
+// TestConfigApplyPrefix tests propagating the global prefix to shared-storage drivers
+func TestConfigApplyPrefix(t *testing.T) {
+	t.Run("fills drivers without their own prefix", func(t *testing.T) {
+		// Arrange
+		config := &Config{
+			Prefix: "app:",
+			Drivers: DriversConfig{
+				File:    &DriverFileConfig{},
+				Redis:   &DriverRedisConfig{Prefix: "custom:"},
+				MongoDB: &DriverMongodbConfig{},
+			},
+		}
+
+		// Act
+		config.ApplyPrefix()
+
+		// Assert
+		assert.Equal(t, "app:", config.Drivers.File.Prefix)
+		assert.Equal(t, "custom:", config.Drivers.Redis.Prefix)
+		assert.Equal(t, "app:", config.Drivers.MongoDB.Prefix)
+	})
+
+	t.Run("ignores missing drivers and empty prefix", func(t *testing.T) {
+		// Arrange
+		config := &Config{Drivers: DriversConfig{File: &DriverFileConfig{}}}
+
+		// Act & Assert
+		assert.NotPanics(t, config.ApplyPrefix)
+		assert.Empty(t, config.Drivers.File.Prefix)
+
+		config.Prefix = "app:"
+		config.Drivers.File = nil
+		assert.NotPanics(t, config.ApplyPrefix)
+	})
+}
+
+// TestConfigGetInvalidationChannel tests deriving the tiered invalidation channel from the L2 prefix
+func TestConfigGetInvalidationChannel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:     "without tiered driver",
+			config:   &Config{Prefix: "app:"},
+			expected: "",
+		},
+		{
+			name:     "invalidation disabled",
+			config:   &Config{Prefix: "app:", Drivers: DriversConfig{Tiered: &DriverTieredConfig{L2: "redis"}}},
+			expected: "",
+		},
+		{
+			name: "uses global prefix",
+			config: &Config{Prefix: "app:", Drivers: DriversConfig{
+				Redis:  &DriverRedisConfig{},
+				Tiered: &DriverTieredConfig{L2: "redis", InvalidationChannel: "invalidate"},
+			}},
+			expected: "app:invalidate",
+		},
+		{
+			name: "uses prefix of the L2 driver",
+			config: &Config{Prefix: "app:", Drivers: DriversConfig{
+				MongoDB: &DriverMongodbConfig{Prefix: "shared:"},
+				Tiered:  &DriverTieredConfig{L2: "mongodb", InvalidationChannel: "invalidate"},
+			}},
+			expected: "shared:invalidate",
+		},
+		{
+			name: "falls back to the redis default prefix",
+			config: &Config{Drivers: DriversConfig{
+				Tiered: &DriverTieredConfig{L2: "file", InvalidationChannel: "invalidate"},
+			}},
+			expected: "cache:invalidate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			channel := tc.config.GetInvalidationChannel()
+
+			// Assert
+			assert.Equal(t, tc.expected, channel)
+		})
+	}
+}
+
 // TestDriverMemoryConfigMethods tests DriverMemoryConfig methods
 func TestDriverMemoryConfigMethods(t *testing.T) {
 	t.Run("GetDefaultExpiration returns correct duration", func(t *testing.T) {
@@ -225,6 +331,17 @@ func TestDriverRedisConfigMethods(t *testing.T) {
 		// Assert
 		assert.Equal(t, 7*24*time.Hour, duration)
 	})
+
+	t.Run("GetOperationTimeout returns milliseconds", func(t *testing.T) {
+		// Arrange
+		config := &DriverRedisConfig{OperationTimeout: 250}
+
+		// Act
+		timeout := config.GetOperationTimeout()
+
+		// Assert
+		assert.Equal(t, 250*time.Millisecond, timeout)
+	})
 }
 
 // TestDriverMongodbConfigMethods tests DriverMongodbConfig methods
@@ -240,6 +357,17 @@ func TestDriverMongodbConfigMethods(t *testing.T) {
 		assert.Equal(t, 45*time.Minute, duration)
 	})
 
+	t.Run("GetOperationTimeout returns milliseconds", func(t *testing.T) {
+		// Arrange
+		config := &DriverMongodbConfig{OperationTimeout: 1500}
+
+		// Act
+		timeout := config.GetOperationTimeout()
+
+		// Assert
+		assert.Equal(t, 1500*time.Millisecond, timeout)
+	})
+
 	t.Run("GetDefaultExpiration with minimum positive value", func(t *testing.T) {
 		// Arrange
 		config := &DriverMongodbConfig{DefaultTTL: 1}
@@ -252,6 +380,20 @@ func TestDriverMongodbConfigMethods(t *testing.T) {
 	})
 }
 
+// TestDriverTieredConfigMethods tests DriverTieredConfig methods
+func TestDriverTieredConfigMethods(t *testing.T) {
+	t.Run("GetL1TTL returns correct duration", func(t *testing.T) {
+		// Arrange
+		config := &DriverTieredConfig{L1TTL: 30}
+
+		// Act
+		duration := config.GetL1TTL()
+
+		// Assert
+		assert.Equal(t, 30*time.Second, duration)
+	})
+}
+
 // TestConfigStructValidation tests config struct validation
 func TestConfigStructValidation(t *testing.T) {
 	t.Run("empty config struct", func(t *testing.T) {
@@ -333,6 +475,8 @@ func TestDriverConfigStructValidation(t *testing.T) {
 			DefaultTTL:      1800,
 			Extension:       ".tmp",
 			CleanupInterval: 300,
+			ShardDepth:      2,
+			MaxSize:         1 << 30,
 		}
 
 		// Act & Assert
@@ -341,6 +485,8 @@ func TestDriverConfigStructValidation(t *testing.T) {
 		assert.Equal(t, 1800, config.DefaultTTL)
 		assert.Equal(t, ".tmp", config.Extension)
 		assert.Equal(t, 300, config.CleanupInterval)
+		assert.Equal(t, 2, config.ShardDepth)
+		assert.Equal(t, int64(1<<30), config.MaxSize)
 	})
 
 	t.Run("DriverRedisConfig with all fields", func(t *testing.T) {
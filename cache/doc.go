@@ -9,17 +9,29 @@
 //
 //   - Đa dạng driver: Memory, File, Redis, MongoDB với khả năng tùy chỉnh
 //   - TTL (Time To Live): Quản lý thời gian sống tự động cho cache entries
-//   - Remember Pattern: Lazy computation với caching kết quả tự động
+//   - Remember Pattern: Lazy computation với caching kết quả tự động, chống cache stampede (singleflight, khóa phân tán, XFetch, stale-while-revalidate)
+//   - Context: Biến thể GetContext, SetContext, RememberContext, ... truyền deadline và cancellation tới driver
+//   - Typed Reads: GetInto và cache.Get[T], cache.Remember[T] giải mã trực tiếp vào kiểu của caller
+//   - Tagged Cache: Tags("tenant:7", "users") gắn tag cho entry và Flush theo nhóm tag
+//   - Namespace: Prefix chung cho mọi driver và Namespace("users") giới hạn key, Flush chỉ xóa key của namespace
+//   - Observers: AddObserver và driver.Metrics đo hit ratio, độ trễ và tỷ lệ lỗi theo từng driver
+//   - Compression/Encryption: Nén gzip/zstd và mã hóa AES-GCM giá trị của file, Redis, MongoDB với xoay vòng khóa
+//   - Tiered Cache: L1 trong RAM phía trước Redis, invalidate L1 giữa các instance qua pub/sub
 //   - Batch Operations: GetMultiple, SetMultiple, DeleteMultiple để tối ưu hiệu suất
+//   - Atomic Operations: Increment, Decrement, Add, CompareAndSwap nguyên tử trên mọi driver
 //   - Thread-Safe: An toàn cho môi trường đa luồng với sync.RWMutex
 //   - Dependency Injection: ServiceProvider tích hợp với DI container
 //   - Monitoring: Stats() method cho metrics và performance tracking
 //   - High Performance: Memory driver với automatic cleanup của expired entries
+//   - Bounded Memory: Memory driver giới hạn max_items/max_bytes, loại bỏ item theo lru, lfu hoặc fifo
+//   - File Storage: File driver chia thư mục con theo hash, ghi nguyên tử, giới hạn max_size và báo số byte janitor thu hồi
 //
 // # Cấu trúc Package
 //
 //	cache/
 //	├── manager.go              # Manager interface và DefaultManager implementation
+//	├── tagged.go               # TaggedCache cho các entry gắn tag
+//	├── typed.go                # Generic helpers Get[T] và Remember[T]
 //	├── provider.go             # ServiceProvider cho DI integration
 //	├── doc.go                  # Package documentation
 //	├── config/
@@ -27,9 +39,15 @@
 //	│   └── config_test.go      # Configuration tests
 //	├── driver/
 //	│   ├── driver.go           # Driver interface definition
+//	│   ├── tags.go             # Taggable interface cho version của tag
+//	│   ├── transform.go        # Transformer nén và mã hóa giá trị
+//	│   ├── remember.go         # RememberOptions và chống cache stampede
 //	│   ├── memory.go           # In-memory cache driver
 //	│   ├── file.go             # File-based cache driver
+//	│   ├── flock_unix.go       # Khóa file giữa các process (Unix)
 //	│   ├── redis.go            # Redis cache driver (v9+)
+//	│   ├── tiered.go           # Tiered (L1/L2) cache driver
+//	│   ├── invalidator.go      # Invalidate L1 qua Redis pub/sub
 //	│   └── mongodb.go          # MongoDB cache driver
 //	├── mocks/                  # Auto-generated mocks cho testing
 //	└── configs/                # Sample configuration files
@@ -140,6 +158,11 @@
 //
 //	// ... implement other methods
 //
+// Từ v0.1.0 interface Driver yêu cầu thêm GetInto, Increment, Decrement, Add,
+// CompareAndSwap và RememberWithOptions. Các thao tác nguyên tử phải nguyên tử với nhau
+// (ví dụ giữ chung một khóa), còn RememberWithOptions có thể hoạt động như Remember
+// nếu driver không hỗ trợ khóa phân tán hay stale-while-revalidate.
+//
 // # Driver Types
 //
 // Memory Driver: Lưu cache trong RAM, tốc độ cao nhất, phù hợp cho single instance.
@@ -0,0 +1,172 @@
+package driver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Các thuật toán nén được hỗ trợ.
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// compressionMagic là tiền tố của dữ liệu đã qua compressionTransformer, theo sau là một byte thuật toán.
+var compressionMagic = []byte{0xFC, 'C'}
+
+// Byte thuật toán trong khung dữ liệu nén.
+const (
+	compressionNone byte = 'n' // Không nén, dữ liệu gốc bắt đầu bằng compressionMagic
+	compressionGzip byte = 'g'
+	compressionZstd byte = 'z'
+)
+
+// maxDecompressedSize là kích thước tối đa (byte) của dữ liệu sau khi giải nén.
+//
+// Giới hạn này chặn dữ liệu nén bị sửa đổi hoặc cố ý tạo ra (decompression bomb)
+// làm process cấp phát bộ nhớ không giới hạn khi đọc cache.
+const maxDecompressedSize = 64 << 20
+
+// zstdDecoder là decoder zstd dùng chung, DecodeAll an toàn khi gọi đồng thời.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedSize))
+})
+
+// compressionTransformer nén dữ liệu có kích thước từ ngưỡng trở lên.
+//
+// Dữ liệu nén có dạng compressionMagic + byte thuật toán + dữ liệu nén. Dữ liệu nhỏ hơn ngưỡng
+// được giữ nguyên, nên giá trị ghi trước khi bật nén vẫn đọc được.
+type compressionTransformer struct {
+	algorithm byte          // Thuật toán dùng khi Encode
+	threshold int           // Kích thước tối thiểu (byte) để nén
+	level     int           // Mức nén của gzip
+	zstd      *zstd.Encoder // Encoder zstd, nil nếu dùng gzip
+}
+
+// NewCompressionTransformer tạo transformer nén dữ liệu từ threshold byte trở lên.
+//
+// Decode đọc được dữ liệu nén bằng cả gzip và zstd, nên có thể đổi thuật toán mà không làm mất cache.
+//
+// Params:
+//   - algorithm: Thuật toán nén: gzip, zstd (rỗng = gzip)
+//   - threshold: Kích thước tối thiểu (byte) của dữ liệu được nén, 0 để nén mọi giá trị
+//   - level: Mức nén (0 = mặc định của thuật toán; gzip 1-9, zstd 1-22)
+//
+// Returns:
+//   - Transformer: Transformer nén
+//   - error: ErrUnsupportedCompression nếu thuật toán không được hỗ trợ, hoặc lỗi nếu level không hợp lệ
+func NewCompressionTransformer(algorithm string, threshold, level int) (Transformer, error) {
+	t := &compressionTransformer{threshold: threshold, level: level}
+	switch algorithm {
+	case "", CompressionGzip:
+		t.algorithm = compressionGzip
+		if level == 0 {
+			t.level = gzip.DefaultCompression
+		}
+		if _, err := gzip.NewWriterLevel(io.Discard, t.level); err != nil {
+			return nil, fmt.Errorf("invalid gzip level: %w", err)
+		}
+	case CompressionZstd:
+		t.algorithm = compressionZstd
+		opts := []zstd.EOption{}
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		encoder, err := zstd.NewWriter(nil, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not create zstd encoder: %w", err)
+		}
+		t.zstd = encoder
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCompression, algorithm)
+	}
+	return t, nil
+}
+
+// Encode nén data nếu data có kích thước từ ngưỡng trở lên.
+func (t *compressionTransformer) Encode(data []byte) ([]byte, error) {
+	framed := bytes.HasPrefix(data, compressionMagic)
+	if len(data) < t.threshold {
+		if !framed {
+			return data, nil
+		}
+		// Dữ liệu gốc trùng tiền tố khung, đóng khung để Decode không nhầm là dữ liệu nén
+		return t.frame(compressionNone, data), nil
+	}
+
+	switch t.algorithm {
+	case compressionZstd:
+		return t.zstd.EncodeAll(data, t.frame(compressionZstd, nil)), nil
+	default:
+		buf := bytes.NewBuffer(t.frame(compressionGzip, nil))
+		writer, err := gzip.NewWriterLevel(buf, t.level)
+		if err != nil {
+			return nil, fmt.Errorf("could not compress value: %w", err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return nil, fmt.Errorf("could not compress value: %w", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("could not compress value: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// Decode giải nén data nếu data có khung nén, nếu không trả về nguyên data.
+//
+// Dữ liệu giải nén lớn hơn maxDecompressedSize (64 MiB) trả về ErrDecompressedTooLarge.
+func (t *compressionTransformer) Decode(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, compressionMagic) || len(data) <= len(compressionMagic) {
+		return data, nil
+	}
+
+	payload := data[len(compressionMagic)+1:]
+	switch data[len(compressionMagic)] {
+	case compressionNone:
+		return payload, nil
+	case compressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress value: %w", err)
+		}
+		defer reader.Close()
+		decoded, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress value: %w", err)
+		}
+		if len(decoded) > maxDecompressedSize {
+			return nil, ErrDecompressedTooLarge
+		}
+		return decoded, nil
+	case compressionZstd:
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("could not create zstd decoder: %w", err)
+		}
+		decoded, err := decoder.DecodeAll(payload, nil)
+		if errors.Is(err, zstd.ErrDecoderSizeExceeded) {
+			return nil, ErrDecompressedTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress value: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCompression, data[len(compressionMagic)])
+	}
+}
+
+// frame tạo khung dữ liệu gồm tiền tố, byte thuật toán và payload.
+func (t *compressionTransformer) frame(algorithm byte, payload []byte) []byte {
+	framed := make([]byte, 0, len(compressionMagic)+1+len(payload))
+	framed = append(framed, compressionMagic...)
+	framed = append(framed, algorithm)
+	return append(framed, payload...)
+}
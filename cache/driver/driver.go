@@ -10,6 +10,9 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"time"
 )
 
@@ -33,6 +36,21 @@ type Driver interface {
 	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 	Get(ctx context.Context, key string) (interface{}, bool)
 
+	// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào dest.
+	//
+	// Khác với Get, giá trị được giải mã một lần vào kiểu của dest nên struct được
+	// trả về đúng kiểu thay vì map[string]interface{}.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần tìm trong cache
+	//   - dest: Con trỏ khác nil tới biến nhận giá trị
+	//
+	// Returns:
+	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+	//   - error: ErrInvalidDestination nếu dest không phải con trỏ, ErrTypeMismatch hoặc lỗi giải mã nếu giá trị không khớp kiểu của dest
+	GetInto(ctx context.Context, key string, dest interface{}) (bool, error)
+
 	// Set đặt một giá trị vào cache với TTL (Time To Live) tùy chọn.
 	//
 	// Phương thức này lưu trữ một cặp key-value vào cache với thời gian sống
@@ -141,6 +159,86 @@ type Driver interface {
 	//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
 	Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
 
+	// RememberWithOptions lấy một giá trị từ cache hoặc thực thi callback, có chống cache stampede.
+	//
+	// Các lời gọi đồng thời cho cùng một key trong process chỉ thực thi callback một lần.
+	// opts bổ sung khóa phân tán giữa các instance, tính lại sớm theo xác suất (XFetch)
+	// và stale-while-revalidate, xem RememberOptions.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần tìm hoặc lưu vào cache
+	//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+	//   - opts: TTL và các tùy chọn chống cache stampede
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+	RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error)
+
+	// Increment tăng giá trị số nguyên của một key một cách nguyên tử.
+	//
+	// Nếu key không tồn tại hoặc đã hết hạn, giá trị được khởi tạo bằng delta và không hết hạn.
+	// Nếu key đã tồn tại, thời gian hết hạn hiện tại của key được giữ nguyên.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi tăng
+	//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khác trong quá trình thực hiện
+	Increment(ctx context.Context, key string, delta int64) (int64, error)
+
+	// Decrement giảm giá trị số nguyên của một key một cách nguyên tử.
+	//
+	// Tương đương Increment với -delta.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần trừ đi
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi giảm
+	//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khác trong quá trình thực hiện
+	Decrement(ctx context.Context, key string, delta int64) (int64, error)
+
+	// Add đặt một giá trị vào cache chỉ khi key chưa tồn tại (hoặc đã hết hạn).
+	//
+	// Thao tác kiểm tra và ghi là nguyên tử, phù hợp cho idempotency key hoặc khóa đơn giản.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+	//   - error: Lỗi nếu có trong quá trình thực hiện
+	Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// CompareAndSwap thay giá trị của key bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+	//
+	// Thao tác so sánh và ghi là nguyên tử. Key không tồn tại hoặc đã hết hạn không bao giờ khớp
+	// (dùng Add để ghi key chưa tồn tại). Memory và file driver so sánh bằng reflect.DeepEqual,
+	// Redis so sánh dạng đã serialize và MongoDB so sánh bằng phép so sánh của MongoDB,
+	// nên nên dùng CompareAndSwap với giá trị đơn giản như số, chuỗi hoặc version.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần cập nhật
+	//   - oldValue: Giá trị mong đợi hiện tại
+	//   - newValue: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+	//   - error: Lỗi nếu có trong quá trình thực hiện
+	CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error)
+
 	// Stats trả về thông tin thống kê về cache.
 	//
 	// Phương thức này thu thập và trả về các thông tin thống kê về trạng thái
@@ -162,3 +260,131 @@ type Driver interface {
 	//   - error: Lỗi nếu có trong quá trình giải phóng tài nguyên
 	Close() error
 }
+
+// PrefixFlusher định nghĩa thao tác xóa theo tiền tố key, dùng cho namespace của cache.Manager.
+//
+// Memory, file, redis, mongodb và tiered driver đều cài đặt PrefixFlusher.
+type PrefixFlusher interface {
+	// FlushPrefix xóa tất cả các key bắt đầu bằng prefix, các key khác được giữ nguyên.
+	//
+	// prefix được so khớp trên key do người dùng truyền vào, không gồm prefix
+	// mà driver tự thêm theo cấu hình.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - prefix: Tiền tố của các key cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa
+	FlushPrefix(ctx context.Context, prefix string) error
+}
+
+// toInt64 chuyển giá trị số nguyên (hoặc số thực có giá trị nguyên) sang int64.
+//
+// Returns:
+//   - int64: Giá trị đã chuyển đổi
+//   - bool: false nếu value không phải số nguyên
+func toInt64(value interface{}) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != float64(int64(f)) {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+// operationContext giới hạn ctx theo thời gian tối đa của một thao tác trên backend.
+//
+// context.WithTimeout giữ deadline sớm hơn của ctx nếu có; timeout <= 0 trả về ctx không đổi.
+func operationContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// destinationOf trả về giá trị mà dest trỏ tới.
+//
+// Returns:
+//   - reflect.Value: Giá trị có thể gán mà dest trỏ tới
+//   - error: ErrInvalidDestination nếu dest không phải con trỏ khác nil
+func destinationOf(dest interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%w, got %T", ErrInvalidDestination, dest)
+	}
+	return v.Elem(), nil
+}
+
+// assignValue gán giá trị đang giữ trong bộ nhớ vào dest mà không qua serialize.
+//
+// Giá trị được gán trực tiếp nếu kiểu khớp với dest; số được chuyển kiểu
+// nếu việc chuyển không làm mất giá trị.
+//
+// Params:
+//   - dest: Con trỏ khác nil tới biến nhận giá trị
+//   - value: Giá trị cần gán
+//
+// Returns:
+//   - error: ErrInvalidDestination hoặc ErrTypeMismatch nếu không thể gán
+func assignValue(dest interface{}, value interface{}) error {
+	target, err := destinationOf(dest)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		target.SetZero()
+		return nil
+	}
+
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return nil
+	}
+	if isNumberKind(source.Kind()) && isNumberKind(target.Type().Kind()) {
+		converted := source.Convert(target.Type())
+		negative := (source.CanInt() && source.Int() < 0) || (source.CanFloat() && source.Float() < 0)
+		if converted.Convert(source.Type()).Equal(source) && !(negative && converted.CanUint()) {
+			target.Set(converted)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: cannot assign %T to %s", ErrTypeMismatch, value, target.Type())
+}
+
+// isNumberKind kiểm tra kind có phải số nguyên hoặc số thực không.
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+var (
+	// ErrInvalidDestination được trả về khi dest của GetInto không phải con trỏ khác nil
+	ErrInvalidDestination = errors.New("cache: destination must be a non-nil pointer")
+
+	// ErrTypeMismatch được trả về khi giá trị trong cache không thể gán vào kiểu của dest
+	ErrTypeMismatch = errors.New("cache: cached value does not match destination type")
+
+	// ErrNotInteger được trả về khi Increment/Decrement được gọi trên giá trị không phải số nguyên
+	ErrNotInteger = errors.New("cache: value is not an integer")
+
+	// ErrPrefixFlushNotSupported được trả về khi driver không cài đặt PrefixFlusher
+	ErrPrefixFlushNotSupported = errors.New("cache: driver does not support flushing by prefix")
+
+	// ErrTagsNotSupported được trả về khi driver không cài đặt Taggable
+	ErrTagsNotSupported = errors.New("cache: driver does not support tags")
+)
@@ -0,0 +1,110 @@
+package driver
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+)
+
+// encryptionMagic là tiền tố của dữ liệu đã mã hóa bởi encryptionTransformer.
+var encryptionMagic = []byte{0xFC, 'E'}
+
+// encryptionTransformer mã hóa dữ liệu bằng AES-GCM.
+//
+// Dữ liệu mã hóa có dạng encryptionMagic + độ dài key ID (1 byte) + key ID + nonce + ciphertext.
+// Header được dùng làm additional data của GCM nên không thể đổi key ID mà không bị phát hiện.
+type encryptionTransformer struct {
+	keyID string                 // Key ID dùng khi Encode
+	aeads map[string]cipher.AEAD // AEAD theo key ID, dùng khi Decode
+}
+
+// NewEncryptionTransformer tạo transformer mã hóa AES-GCM với hỗ trợ xoay vòng khóa.
+//
+// Dữ liệu mới luôn được mã hóa bằng khóa keyID, và key ID được lưu cùng dữ liệu nên dữ liệu
+// mã hóa bằng khóa cũ vẫn giải mã được khi khóa cũ còn trong keys. Để xoay vòng khóa, thêm khóa
+// mới vào keys, đổi keyID sang khóa mới và chỉ xóa khóa cũ khi các entry cũ đã hết hạn.
+//
+// Params:
+//   - keyID: Key ID của khóa dùng để mã hóa dữ liệu mới (tối đa 255 byte)
+//   - keys: Map key ID tới khóa AES 16, 24 hoặc 32 byte
+//
+// Returns:
+//   - Transformer: Transformer mã hóa
+//   - error: ErrUnknownEncryptionKey nếu keyID không có trong keys, hoặc lỗi nếu khóa không hợp lệ
+func NewEncryptionTransformer(keyID string, keys map[string][]byte) (Transformer, error) {
+	if keyID == "" || len(keyID) > 255 {
+		return nil, fmt.Errorf("invalid encryption key id %q", keyID)
+	}
+	if _, ok := keys[keyID]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEncryptionKey, keyID)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		if len(id) > 255 {
+			return nil, fmt.Errorf("invalid encryption key id %q", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key '%s': %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key '%s': %w", id, err)
+		}
+		aeads[id] = aead
+	}
+	return &encryptionTransformer{keyID: keyID, aeads: aeads}, nil
+}
+
+// Encode mã hóa data bằng khóa hiện tại với nonce ngẫu nhiên.
+func (t *encryptionTransformer) Encode(data []byte) ([]byte, error) {
+	aead := t.aeads[t.keyID]
+	header := t.header(t.keyID)
+
+	out := make([]byte, len(header)+aead.NonceSize(), len(header)+aead.NonceSize()+len(data)+aead.Overhead())
+	copy(out, header)
+	nonce := out[len(header):]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+	return aead.Seal(out, nonce, data, header), nil
+}
+
+// Decode giải mã data bằng khóa có key ID lưu trong header.
+func (t *encryptionTransformer) Decode(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptionMagic) || len(data) <= len(encryptionMagic) {
+		return nil, ErrDecryptionFailed
+	}
+
+	idLen := int(data[len(encryptionMagic)])
+	headerLen := len(encryptionMagic) + 1 + idLen
+	if len(data) < headerLen {
+		return nil, ErrDecryptionFailed
+	}
+	keyID := string(data[len(encryptionMagic)+1 : headerLen])
+	aead, ok := t.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEncryptionKey, keyID)
+	}
+	if len(data) < headerLen+aead.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+
+	nonce := data[headerLen : headerLen+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, data[headerLen+aead.NonceSize():], data[:headerLen])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
+
+// header tạo header gồm tiền tố, độ dài key ID và key ID.
+func (t *encryptionTransformer) header(keyID string) []byte {
+	header := make([]byte, 0, len(encryptionMagic)+1+len(keyID))
+	header = append(header, encryptionMagic...)
+	header = append(header, byte(len(keyID)))
+	return append(header, keyID...)
+}
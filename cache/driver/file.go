@@ -1,24 +1,85 @@
 package driver
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha1"
 	"encoding/gob"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.fork.vn/providers/cache/config"
 )
 
+// Các file đặc biệt trong thư mục cache. Tên file cache là hash dạng hex nên không bắt đầu bằng ".".
+const (
+	fileLockPrefix      = ".lock-"        // Tiền tố của các file khóa cho các thao tác ghi theo key
+	fileJanitorLockName = ".janitor.lock" // File khóa để chỉ một process dọn dẹp thư mục tại một thời điểm
+	fileTempPrefix      = ".tmp-"         // Tiền tố của file tạm trước khi được đổi tên vào vị trí
+)
+
+const (
+	// maxFileShardDepth là số cấp thư mục con tối đa của file driver
+	maxFileShardDepth = 4
+
+	// fileLockStripes là số nhóm khóa theo key, mỗi nhóm có một file khóa trong thư mục cache
+	fileLockStripes = 64
+
+	// fileTempMaxAge là thời gian sau đó file tạm bị bỏ lại (do process dừng giữa chừng) được dọn dẹp
+	fileTempMaxAge = time.Hour
+)
+
 type FileDriver interface {
 	// Driver định nghĩa các phương thức cần thiết cho một cache driver.
 	Driver
+	// Taggable quản lý version của tag cho cache có gắn tag.
+	Taggable
+	// PrefixFlusher xóa các key theo tiền tố, dùng cho namespace.
+	PrefixFlusher
+
+	// Cleanup xóa các entry đã hết hạn và file tạm bị bỏ lại, rồi loại bỏ entry cũ nhất nếu vượt MaxSize.
+	//
+	// Janitor gọi cùng thao tác này theo CleanupInterval. Nếu process khác đang dọn dẹp
+	// thư mục, Cleanup chờ đến khi process đó hoàn tất.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//
+	// Returns:
+	//   - FileCleanupResult: Số file đã xóa và số byte thu hồi được
+	//   - error: Lỗi nếu không thể khóa hoặc đọc thư mục cache
+	Cleanup(ctx context.Context) (FileCleanupResult, error)
+
+	// OnCleanup đăng ký callback nhận kết quả của mỗi lần dọn dẹp có xóa file,
+	// gồm các lần chạy của janitor và các lần loại bỏ entry do vượt MaxSize.
+	OnCleanup(callback FileCleanupCallback) FileDriver
+}
+
+// FileCleanupResult là kết quả của một lần dọn dẹp thư mục cache.
+type FileCleanupResult struct {
+	Removed        int   // Tổng số file đã xóa (entry hết hạn, entry bị loại bỏ và file tạm)
+	Evicted        int   // Số entry còn hạn bị loại bỏ do vượt MaxSize
+	ReclaimedBytes int64 // Tổng kích thước các file đã xóa
+	Size           int64 // Tổng kích thước các file cache còn lại
 }
 
+// FileCleanupCallback được gọi sau mỗi lần dọn dẹp thư mục cache có xóa file.
+//
+// Callback được gọi từ goroutine janitor hoặc từ goroutine đang ghi entry làm vượt MaxSize,
+// nên không được gọi lại các thao tác ghi của driver.
+type FileCleanupCallback func(result FileCleanupResult)
+
 // FileDriver cài đặt cache driver sử dụng file system.
 //
 // FileDriver lưu trữ dữ liệu cache dưới dạng các file trên hệ thống file,
@@ -26,23 +87,55 @@ type FileDriver interface {
 // cho các ứng dụng cần persistence và có thể phục hồi dữ liệu cache sau khi khởi động lại.
 // Nó cũng hỗ trợ TTL (Time To Live) và tự động dọn dẹp các entry đã hết hạn.
 type fileDriver struct {
-	directory         string        // Đường dẫn thư mục lưu trữ cache
-	defaultExpiration time.Duration // Thời gian sống mặc định cho các entry không chỉ định TTL
-	mu                sync.RWMutex  // Mutex cho các thao tác thread-safe
-	janitorInterval   time.Duration // Khoảng thời gian giữa các lần dọn dẹp
-	stopJanitor       chan bool     // Channel để dừng goroutine dọn dẹp
-	janitorRunning    bool          // Flag đánh dấu goroutine dọn dẹp đang chạy
-	hits              int64         // Số lần cache hit
-	misses            int64         // Số lần cache miss
+	directory         string                      // Đường dẫn thư mục lưu trữ cache
+	prefix            string                      // Tiền tố cho các key cache để tránh xung đột khi dùng chung thư mục
+	defaultExpiration time.Duration               // Thời gian sống mặc định cho các entry không chỉ định TTL
+	mu                sync.RWMutex                // Mutex bảo vệ onCleanup; các bộ đếm được cập nhật bằng sync/atomic
+	tagMu             sync.Mutex                  // Mutex tuần tự hóa việc tạo version của tag
+	lockMu            [fileLockStripes]sync.Mutex // Mutex trong process đi kèm từng file khóa theo key
+	janitorInterval   time.Duration               // Khoảng thời gian giữa các lần dọn dẹp
+	stopJanitor       chan bool                   // Channel để dừng goroutine dọn dẹp
+	janitorRunning    bool                        // Flag đánh dấu goroutine dọn dẹp đang chạy
+	transformer       Transformer                 // Nén và mã hóa nội dung file (nil nếu không dùng)
+	shardDepth        int                         // Số cấp thư mục con theo hash của key (0 nếu lưu phẳng)
+	maxSize           int64                       // Tổng kích thước tối đa của các file cache (0 nếu không giới hạn)
+	size              int64                       // Tổng kích thước ước lượng của các file cache, chỉ theo dõi khi maxSize > 0
+	quotaPending      atomic.Bool                 // true khi đã có goroutine nền áp dụng maxSize
+	cleanupMu         sync.Mutex                  // Mutex trong process đi kèm khóa dọn dẹp của thư mục
+	onCleanup         FileCleanupCallback         // Callback nhận kết quả dọn dẹp
+	evictions         int64                       // Số entry bị loại bỏ do vượt maxSize
+	reclaimed         int64                       // Tổng số byte thu hồi được khi dọn dẹp
+	hits              int64                       // Số lần cache hit
+	misses            int64                       // Số lần cache miss
+	flights           flightGroup                 // Gộp các lời gọi Remember đồng thời cho cùng key
 }
 
 // FileCache là cấu trúc lưu trữ dữ liệu trong file.
 //
 // Cấu trúc này được dùng để serialize và deserialize dữ liệu cache
 // khi lưu trữ và đọc từ file. Nó chứa giá trị cần cache và thời gian hết hạn.
+//
+// Giá trị có kiểu chưa được gob.Register không thể mã hóa trong trường interface Value,
+// nên được lưu dạng JSON trong Data (Value khi đó là nil).
+// Tên file là hash của key nên key (gồm prefix) được lưu trong Key để Flush theo prefix.
 type FileCache struct {
+	Key        string      // Key đầy đủ (gồm prefix) của entry
 	Value      interface{} // Giá trị được lưu trong cache
 	Expiration int64       // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+	Data       []byte      // Giá trị dạng JSON khi kiểu của giá trị chưa được gob.Register
+	Counter    bool        // true nếu entry là bộ đếm được ghi bởi Increment, Decrement
+}
+
+// value trả về giá trị của entry, giải mã Data nếu giá trị được lưu dạng JSON.
+func (c FileCache) value() (interface{}, error) {
+	if c.Data == nil {
+		return c.Value, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(c.Data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
 }
 
 // NewFileDriver tạo một file driver mới với các tùy chọn mặc định.
@@ -55,8 +148,16 @@ type FileCache struct {
 //
 // Returns:
 //   - *FileDriver: Driver đã được khởi tạo
-//   - error: Lỗi nếu không thể tạo thư mục cache
+//   - error: Lỗi nếu không thể tạo thư mục cache, ShardDepth hoặc cấu hình transform không hợp lệ
 func NewFileDriver(cfg config.DriverFileConfig) (FileDriver, error) {
+	if cfg.ShardDepth < 0 || cfg.ShardDepth > maxFileShardDepth {
+		return nil, fmt.Errorf("invalid file shard depth %d: must be between 0 and %d", cfg.ShardDepth, maxFileShardDepth)
+	}
+	transformer, err := NewTransformer(cfg.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file transform config: %w", err)
+	}
+
 	// Tạo thư mục nếu không tồn tại
 	if err := os.MkdirAll(cfg.Path, 0755); err != nil {
 		return nil, fmt.Errorf("unable to create cache directory: %w", err)
@@ -64,11 +165,26 @@ func NewFileDriver(cfg config.DriverFileConfig) (FileDriver, error) {
 
 	driver := &fileDriver{
 		directory:         cfg.Path,
+		prefix:            cfg.Prefix,
 		defaultExpiration: time.Duration(cfg.DefaultTTL) * time.Second,
+		transformer:       transformer,
+		shardDepth:        cfg.ShardDepth,
+		maxSize:           cfg.MaxSize,
 		janitorInterval:   time.Duration(cfg.CleanupInterval) * time.Second,
 		stopJanitor:       make(chan bool),
 	}
 
+	// Khởi tạo tổng kích thước từ các file đã có để áp dụng MaxSize
+	if driver.maxSize > 0 {
+		entries, _, err := driver.listFiles()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cache directory: %w", err)
+		}
+		for _, entry := range entries {
+			driver.size += entry.size
+		}
+	}
+
 	// Chỉ chạy janitor nếu có khoảng thời gian dọn dẹp > 0
 	if cfg.CleanupInterval > 0 {
 		go driver.startJanitor()
@@ -84,6 +200,10 @@ func NewFileDriver(cfg config.DriverFileConfig) (FileDriver, error) {
 // Trong thực tế, nên sử dụng một hàm hash như md5 hoặc sha1 để tránh các vấn đề với ký tự đặc biệt
 // và đảm bảo tên file hợp lệ trên hệ thống file.
 //
+// Hash được tính trên key đã thêm prefix, nên cùng một key với prefix khác nhau
+// được lưu ở các file khác nhau. Khi ShardDepth > 0, file nằm trong các thư mục con
+// đặt tên theo từng cặp ký tự đầu của hash (ví dụ ab/cd/abcd...) để mỗi thư mục chứa ít file.
+//
 // Params:
 //   - key: Cache key cần chuyển đổi
 //
@@ -99,12 +219,18 @@ func (d *fileDriver) keyToFilename(key string) (string, error) {
 		}
 	}
 	h := sha1.New()
-	_, err := h.Write([]byte(key))
+	_, err := h.Write([]byte(d.prefixKey(key)))
 	if err != nil {
 		return "", fmt.Errorf("invalid key: %w", err)
 	}
 	hash := hex.EncodeToString(h.Sum(nil))
-	return filepath.Join(d.directory, hash), nil
+
+	parts := make([]string, 0, d.shardDepth+2)
+	parts = append(parts, d.directory)
+	for i := 0; i < d.shardDepth; i++ {
+		parts = append(parts, hash[i*2:i*2+2])
+	}
+	return filepath.Join(append(parts, hash)...), nil
 }
 
 // Get lấy một giá trị từ cache.
@@ -123,53 +249,73 @@ func (d *fileDriver) keyToFilename(key string) (string, error) {
 func (d *fileDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 	filename, err := d.keyToFilename(key)
 	if err != nil {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
+		atomic.AddInt64(&d.misses, 1)
 		return nil, false
 	}
 
-	// Kiểm tra xem file có tồn tại không
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
+	// Đọc và giải mã dữ liệu
+	cache, err := d.readEntry(filename)
+	if err != nil {
+		atomic.AddInt64(&d.misses, 1)
 		return nil, false
 	}
 
-	// Mở file
-	file, err := os.Open(filename)
-	if err != nil {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
+	// Kiểm tra xem đã hết hạn chưa
+	if cache.Expiration > 0 && time.Now().UnixNano() > cache.Expiration {
+		atomic.AddInt64(&d.misses, 1)
+		d.removeExpired(filename) // Xóa file đã hết hạn
 		return nil, false
 	}
-	defer file.Close()
 
-	// Giải mã dữ liệu
-	var cache FileCache
-	decoder := gob.NewDecoder(file)
-	if err = decoder.Decode(&cache); err != nil {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
+	value, err := cache.value()
+	if err != nil {
+		atomic.AddInt64(&d.misses, 1)
 		return nil, false
 	}
 
-	// Kiểm tra xem đã hết hạn chưa
-	if cache.Expiration > 0 && time.Now().UnixNano() > cache.Expiration {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
-		os.Remove(filename) // Xóa file đã hết hạn
-		return nil, false
+	atomic.AddInt64(&d.hits, 1)
+	return value, true
+}
+
+// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào dest.
+//
+// Giá trị lưu dạng JSON (kiểu chưa được gob.Register) được giải mã thẳng vào dest,
+// giá trị lưu bằng gob được gán vào dest nếu cùng kiểu.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy
+//   - dest: Con trỏ khác nil tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi nếu dest không hợp lệ hoặc giá trị không khớp kiểu của dest
+func (d *fileDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	if _, err := destinationOf(dest); err != nil {
+		return false, err
+	}
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return false, err
 	}
 
-	d.mu.Lock()
-	d.hits++
-	d.mu.Unlock()
-	return cache.Value, true
+	cache, found := d.readCacheFile(filename)
+	if found {
+		atomic.AddInt64(&d.hits, 1)
+	} else {
+		atomic.AddInt64(&d.misses, 1)
+	}
+	if !found {
+		return false, nil
+	}
+
+	if cache.Data != nil {
+		if err := json.Unmarshal(cache.Data, dest); err != nil {
+			return true, fmt.Errorf("could not decode cached value: %w", err)
+		}
+		return true, nil
+	}
+	return true, assignValue(dest, cache.Value)
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -178,6 +324,9 @@ func (d *fileDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 // Thời gian sống (TTL) có thể được chỉ định, hoặc sử dụng giá trị mặc định nếu ttl = 0,
 // hoặc không có thời hạn nếu ttl = -1.
 //
+// Thao tác giữ khóa file của key như Increment, Add và CompareAndSwap, nên giá trị
+// ghi bởi Set không bị các thao tác đọc-sửa-ghi của process khác ghi đè mất.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key để lưu giá trị
@@ -185,38 +334,31 @@ func (d *fileDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 //   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
 //
 // Returns:
-//   - error: Lỗi nếu có trong quá trình tạo, mã hóa hoặc ghi file
+//   - error: Lỗi nếu có trong quá trình khóa, tạo, mã hóa hoặc ghi file
 func (d *fileDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	filename, err := d.keyToFilename(key)
 	if err != nil {
 		return err
 	}
-	var exp int64
 
-	if ttl == 0 {
-		if d.defaultExpiration > 0 {
-			exp = time.Now().Add(d.defaultExpiration).UnixNano()
-		}
-	} else if ttl > 0 {
-		exp = time.Now().Add(ttl).UnixNano()
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
 	// Tạo cấu trúc cache
 	cache := FileCache{
+		Key:        d.prefixKey(key),
 		Value:      value,
-		Expiration: exp,
-	}
-
-	// Mở file để ghi
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("could not create cache file: %w", err)
+		Expiration: d.expiration(ttl),
 	}
-	defer file.Close()
 
 	// Mã hóa và ghi vào file
-	encoder := gob.NewEncoder(file)
-	return encoder.Encode(cache)
+	if err := d.writeCacheFile(filename, cache, true); err != nil {
+		return fmt.Errorf("could not write cache file: %w", err)
+	}
+	return nil
 }
 
 // Has kiểm tra xem một key có tồn tại trong cache không.
@@ -239,27 +381,37 @@ func (d *fileDriver) Has(ctx context.Context, key string) bool {
 //
 // Phương thức này xóa file cache tương ứng với key được chỉ định.
 // Nếu file không tồn tại, thao tác này không có tác dụng và không trả về lỗi.
+// Thao tác giữ khóa file của key như Set.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần xóa
 //
 // Returns:
-//   - error: Lỗi nếu có trong quá trình xóa file
+//   - error: Lỗi nếu có trong quá trình khóa hoặc xóa file
 func (d *fileDriver) Delete(ctx context.Context, key string) error {
 	filename, err := d.keyToFilename(key)
 	if err != nil {
 		return err
 	}
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		return nil // File không tồn tại, không cần xóa
+
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := d.removeFile(filename); err != nil && !os.IsNotExist(err) {
+		return err
 	}
-	return os.Remove(filename)
+	return nil // File không tồn tại thì không cần xóa
 }
 
 // Flush xóa tất cả các key khỏi cache.
 //
-// Phương thức này xóa tất cả các file trong thư mục cache, làm trống hoàn toàn bộ nhớ cache.
+// Khi không cấu hình prefix, phương thức này xóa tất cả các file trong thư mục cache.
+// Khi có prefix, chỉ các file có key bắt đầu bằng prefix bị xóa, nên các ứng dụng
+// dùng chung thư mục với prefix khác nhau không xóa cache của nhau.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -267,22 +419,43 @@ func (d *fileDriver) Delete(ctx context.Context, key string) error {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa files
 func (d *fileDriver) Flush(ctx context.Context) error {
-	dir, err := os.Open(d.directory)
-	if err != nil {
-		return err
-	}
-	defer dir.Close()
+	return d.removeMatching(d.prefix)
+}
 
-	names, err := dir.Readdirnames(-1)
+// FlushPrefix xóa tất cả các key bắt đầu bằng prefix khỏi cache.
+//
+// Key của từng file được đọc từ nội dung file (tên file là hash), nên thao tác này
+// phải đọc mọi file trong thư mục cache.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của các key cần xóa (không gồm prefix của driver)
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa files
+func (d *fileDriver) FlushPrefix(ctx context.Context, prefix string) error {
+	return d.removeMatching(d.prefix + prefix)
+}
+
+// removeMatching xóa các file có key bắt đầu bằng prefix, hoặc mọi file cache nếu prefix rỗng.
+//
+// File khóa và file tạm của các thao tác ghi đang diễn ra không bị xóa.
+func (d *fileDriver) removeMatching(prefix string) error {
+	entries, _, err := d.listFiles()
 	if err != nil {
 		return err
 	}
 
 	var errs []error
-	for _, name := range names {
-		err = os.Remove(filepath.Join(d.directory, name))
-		if err != nil {
-			errs = append(errs, fmt.Errorf("file '%s': %w", name, err))
+	for _, entry := range entries {
+		if prefix != "" {
+			key, ok := d.readCacheKey(entry.path)
+			if !ok || !strings.HasPrefix(key, prefix) {
+				continue
+			}
+		}
+		if err := d.removeEntry(entry); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("file '%s': %w", entry.path, err))
 		}
 	}
 	if len(errs) > 0 {
@@ -362,9 +535,8 @@ func (d *fileDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 
 // Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
 //
-// Phương thức này kiểm tra xem một key có tồn tại trong cache không, nếu có thì
-// trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
-// sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
+// Các lời gọi đồng thời cho cùng một key chỉ thực thi callback một lần, các lời gọi còn lại
+// chờ và nhận cùng kết quả. Tương đương RememberWithOptions với RememberOptions{TTL: ttl}.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -376,25 +548,22 @@ func (d *fileDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 //   - interface{}: Giá trị từ cache hoặc từ callback
 //   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
 func (d *fileDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	value, found := d.Get(ctx, key)
-	if found {
-		return value, nil
-	}
-
-	if callback == nil {
-		return nil, fmt.Errorf("callback function is required")
-	}
-
-	value, err := callback()
-	if err != nil {
-		return nil, err
-	}
+	return d.RememberWithOptions(ctx, key, callback, RememberOptions{TTL: ttl})
+}
 
-	err = d.Set(ctx, key, value, ttl)
-	if err != nil {
-		return nil, err
-	}
-	return value, nil
+// RememberWithOptions lấy một giá trị từ cache hoặc thực thi callback, có chống cache stampede.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+//   - opts: TTL và các tùy chọn singleflight, khóa phân tán, XFetch, stale-while-revalidate
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *fileDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	return rememberWithOptions(ctx, d, &d.flights, d.defaultExpiration, key, callback, opts)
 }
 
 // Stats trả về thông tin thống kê về cache.
@@ -412,28 +581,497 @@ func (d *fileDriver) Stats(ctx context.Context) map[string]interface{} {
 	var size int64
 
 	// Đếm số lượng file và kích thước
-	filepath.Walk(d.directory, func(path string, info os.FileInfo, err error) error {
+	entries, _, _ := d.listFiles()
+	for _, entry := range entries {
+		itemCount++
+		size += entry.size
+	}
+
+	return map[string]interface{}{
+		"count":           itemCount,
+		"size":            size,
+		"max_size":        d.maxSize,
+		"shard_depth":     d.shardDepth,
+		"evictions":       atomic.LoadInt64(&d.evictions),
+		"reclaimed_bytes": atomic.LoadInt64(&d.reclaimed),
+		"hits":            atomic.LoadInt64(&d.hits),
+		"misses":          atomic.LoadInt64(&d.misses),
+		"type":            "file",
+		"path":            d.directory,
+	}
+}
+
+// Increment tăng giá trị số nguyên của một key một cách nguyên tử.
+//
+// Thao tác giữ khóa file của key nên nguyên tử giữa các process dùng chung thư mục
+// (trên nền tảng hỗ trợ flock), và giá trị mới được ghi ra file tạm rồi đổi tên vào vị trí.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khóa/ghi file
+func (d *fileDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return 0, err
+	}
+
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	var current, exp int64
+	if cache, found := d.readCacheFile(filename); found {
+		value, ok := toInt64(cache.Value)
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+		}
+		current, exp = value, cache.Expiration
+	}
+
+	current += delta
+	if err := d.writeCacheFile(filename, FileCache{Key: d.prefixKey(key), Value: current, Expiration: exp, Counter: true}, true); err != nil {
+		return 0, fmt.Errorf("could not write cache file: %w", err)
+	}
+	return current, nil
+}
+
+// Decrement giảm giá trị số nguyên của một key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khóa/ghi file
+func (d *fileDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	return d.Increment(ctx, key, -delta)
+}
+
+// Add đặt một giá trị vào cache chỉ khi key chưa tồn tại hoặc đã hết hạn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+//   - error: Lỗi khóa hoặc ghi file
+func (d *fileDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return false, err
+	}
+
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	if _, found := d.readCacheFile(filename); found {
+		return false, nil
+	}
+	if err := d.writeCacheFile(filename, FileCache{Key: d.prefixKey(key), Value: value, Expiration: d.expiration(ttl)}, true); err != nil {
+		return false, fmt.Errorf("could not write cache file: %w", err)
+	}
+	return true, nil
+}
+
+// CompareAndSwap thay giá trị của key bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+//
+// Giá trị đã giải mã từ file được so sánh với oldValue bằng reflect.DeepEqual.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần cập nhật
+//   - oldValue: Giá trị mong đợi hiện tại
+//   - newValue: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+//   - error: Lỗi khóa hoặc ghi file
+func (d *fileDriver) CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return false, err
+	}
+
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	cache, found := d.readCacheFile(filename)
+	if !found {
+		return false, nil
+	}
+	current, err := cache.value()
+	if err != nil || !reflect.DeepEqual(current, oldValue) {
+		return false, nil
+	}
+	if err := d.writeCacheFile(filename, FileCache{Key: d.prefixKey(key), Value: newValue, Expiration: d.expiration(ttl)}, true); err != nil {
+		return false, fmt.Errorf("could not write cache file: %w", err)
+	}
+	return true, nil
+}
+
+// compareAndDelete xóa file của key chỉ khi giá trị đã giải mã bằng expected, trong khóa file của key.
+func (d *fileDriver) compareAndDelete(ctx context.Context, key string, expected interface{}) (bool, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return false, err
+	}
+
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	cache, found := d.readCacheFile(filename)
+	if !found {
+		return false, nil
+	}
+	current, err := cache.value()
+	if err != nil || !reflect.DeepEqual(current, expected) {
+		return false, nil
+	}
+	if err := d.removeFile(filename); err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// TagVersions trả về version hiện tại của các tag, tạo version mới cho tag chưa có.
+//
+// Version của tag được lưu thành file không hết hạn. File version mới được ghi ra file tạm
+// rồi tạo liên kết cứng tới tên file đích, nên nhiều process dùng chung thư mục cache
+// luôn đọc được cùng một version đầy đủ cho cùng một tag.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần lấy version
+//
+// Returns:
+//   - []string: Version của từng tag theo thứ tự của tags
+//   - error: Lỗi nếu tag không hợp lệ hoặc không thể đọc, ghi file version
+func (d *fileDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	d.tagMu.Lock()
+	defer d.tagMu.Unlock()
+
+	versions := make([]string, len(tags))
+	for i, tag := range tags {
+		filename, err := d.keyToFilename(tagVersionKey(tag))
+		if err != nil {
+			return nil, err
+		}
+
+		version, found := d.readTagVersion(filename)
+		if !found {
+			if version, err = newTagVersion(); err != nil {
+				return nil, err
+			}
+			err = d.writeCacheFile(filename, FileCache{Key: d.prefixKey(tagVersionKey(tag)), Value: version}, false)
+			if os.IsExist(err) {
+				// Process khác vừa tạo version trước, dùng version đó
+				if version, found = d.readTagVersion(filename); !found {
+					err = fmt.Errorf("invalid tag version file")
+				} else {
+					err = nil
+				}
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load version of tag '%s': %w", tag, err)
+		}
+		versions[i] = version
+	}
+	return versions, nil
+}
+
+// FlushTags làm mất hiệu lực các entry gắn với tags bằng cách ghi version mới cho từng tag.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần làm mất hiệu lực
+//
+// Returns:
+//   - error: Lỗi nếu tag không hợp lệ hoặc không thể ghi file version
+func (d *fileDriver) FlushTags(ctx context.Context, tags []string) error {
+	d.tagMu.Lock()
+	defer d.tagMu.Unlock()
+
+	for _, tag := range tags {
+		filename, err := d.keyToFilename(tagVersionKey(tag))
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() {
-			itemCount++
-			size += info.Size()
+		version, err := newTagVersion()
+		if err != nil {
+			return err
 		}
-		return nil
-	})
+		if err := d.writeCacheFile(filename, FileCache{Key: d.prefixKey(tagVersionKey(tag)), Value: version}, true); err != nil {
+			return fmt.Errorf("could not flush tag '%s': %w", tag, err)
+		}
+	}
+	return nil
+}
 
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+// readTagVersion đọc version của tag từ file.
+func (d *fileDriver) readTagVersion(filename string) (string, bool) {
+	cache, found := d.readCacheFile(filename)
+	if !found {
+		return "", false
+	}
+	version, ok := cache.Value.(string)
+	return version, ok && version != ""
+}
 
-	return map[string]interface{}{
-		"count":  itemCount,
-		"size":   size,
-		"hits":   d.hits,
-		"misses": d.misses,
-		"type":   "file",
-		"path":   d.directory,
+// prefixKey thêm prefix đã cấu hình vào key.
+func (d *fileDriver) prefixKey(key string) string {
+	return d.prefix + key
+}
+
+// expiration tính thời điểm hết hạn (UnixNano) từ ttl, 0 nếu không hết hạn.
+func (d *fileDriver) expiration(ttl time.Duration) int64 {
+	if ttl == 0 {
+		if d.defaultExpiration > 0 {
+			return time.Now().Add(d.defaultExpiration).UnixNano()
+		}
+	} else if ttl > 0 {
+		return time.Now().Add(ttl).UnixNano()
 	}
+	return 0
+}
+
+// lock giữ khóa của key lưu ở filename cho các thao tác ghi và đọc-sửa-ghi nguyên tử.
+//
+// Các key được chia vào fileLockStripes nhóm theo byte đầu của hash trong tên file. Mỗi nhóm
+// có mutex trong process và flock trên file khóa riêng để loại trừ các process khác dùng
+// chung thư mục, nên các thao tác ghi trên key thuộc nhóm khác nhau chạy song song.
+//
+// Returns:
+//   - func(): Hàm nhả khóa
+//   - error: Lỗi nếu không thể mở hoặc khóa file khóa
+func (d *fileDriver) lock(filename string) (func(), error) {
+	stripe := lockStripe(filename)
+	mu := &d.lockMu[stripe]
+	mu.Lock()
+
+	name := fmt.Sprintf("%s%02x", fileLockPrefix, stripe)
+	file, err := os.OpenFile(filepath.Join(d.directory, name), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("could not open cache lock file: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		mu.Unlock()
+		return nil, fmt.Errorf("could not lock cache key: %w", err)
+	}
+
+	return func() {
+		unlockFile(file)
+		file.Close()
+		mu.Unlock()
+	}, nil
+}
+
+// lockStripe trả về nhóm khóa của file cache theo byte đầu của hash trong tên file.
+func lockStripe(filename string) int {
+	stripe, err := strconv.ParseUint(filepath.Base(filename)[:2], 16, 8)
+	if err != nil {
+		return 0
+	}
+	return int(stripe) % fileLockStripes
+}
+
+// readCacheFile đọc entry còn hạn từ file, trả về false nếu file không tồn tại,
+// không giải mã được hoặc đã hết hạn.
+func (d *fileDriver) readCacheFile(filename string) (FileCache, bool) {
+	cache, err := d.readEntry(filename)
+	if err != nil {
+		return cache, false
+	}
+	if cache.Expiration > 0 && time.Now().UnixNano() > cache.Expiration {
+		return cache, false
+	}
+	return cache, true
+}
+
+// readCacheKey đọc key của entry trong file, kể cả entry đã hết hạn.
+func (d *fileDriver) readCacheKey(filename string) (string, bool) {
+	cache, err := d.readEntry(filename)
+	if err != nil {
+		return "", false
+	}
+	return cache.Key, true
+}
+
+// readEntry đọc file, khôi phục nội dung bằng transformer nếu có và giải mã entry, kể cả entry đã hết hạn.
+func (d *fileDriver) readEntry(filename string) (FileCache, error) {
+	var cache FileCache
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return cache, err
+	}
+	if d.transformer != nil {
+		if data, err = d.transformer.Decode(data); err != nil {
+			return cache, err
+		}
+	}
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&cache)
+	return cache, err
+}
+
+// writeCacheFile ghi entry (đã biến đổi bằng transformer nếu có) ra file tạm rồi đưa vào vị trí file đích.
+//
+// Khi replace = false, file đích chỉ được tạo nếu chưa tồn tại (trả về lỗi os.ErrExist nếu đã có);
+// khi replace = true, file đích được thay thế nguyên tử bằng os.Rename. Người đọc không bao giờ
+// thấy file ghi dở, kể cả khi process dừng giữa chừng.
+//
+// Khi MaxSize được cấu hình và tổng kích thước vượt MaxSize sau khi ghi, các entry cũ nhất bị loại bỏ
+// ở goroutine nền, nên thao tác ghi (có thể đang giữ khóa của key) không phải duyệt thư mục.
+func (d *fileDriver) writeCacheFile(filename string, cache FileCache, replace bool) error {
+	data, err := encodeFileCache(cache)
+	if err != nil {
+		return err
+	}
+	if d.transformer != nil {
+		if data, err = d.transformer.Encode(data); err != nil {
+			return err
+		}
+	}
+	if d.maxSize > 0 && int64(len(data)) > d.maxSize {
+		return ErrItemTooLarge
+	}
+	if d.shardDepth > 0 {
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return err
+		}
+	}
+
+	temp, err := os.CreateTemp(d.directory, fileTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	tempName := temp.Name()
+	defer os.Remove(tempName)
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	var oldSize int64
+	if d.maxSize > 0 && replace {
+		if info, err := os.Stat(filename); err == nil {
+			oldSize = info.Size()
+		}
+	}
+
+	if replace {
+		err = os.Rename(tempName, filename)
+	} else {
+		err = os.Link(tempName, filename)
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.maxSize > 0 && atomic.AddInt64(&d.size, int64(len(data))-oldSize) > d.maxSize {
+		d.enforceQuota()
+	}
+	return nil
+}
+
+// enforceQuota áp dụng MaxSize ở goroutine nền, tối đa một goroutine tại một thời điểm.
+//
+// Lần ghi vượt MaxSize trong lúc goroutine đang chạy không tạo goroutine mới; tổng kích thước
+// được tính lại khi dọn dẹp xong nên lần ghi tiếp theo còn vượt MaxSize sẽ dọn dẹp lại.
+func (d *fileDriver) enforceQuota() {
+	if !d.quotaPending.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer d.quotaPending.Store(false)
+		d.tryCleanup(false)
+	}()
+}
+
+// encodeFileCache mã hóa entry bằng gob.
+//
+// Nếu gob không mã hóa được Value (thường do kiểu chưa được gob.Register),
+// giá trị được chuyển sang JSON trong Data để entry vẫn được lưu.
+func encodeFileCache(cache FileCache) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(cache)
+	if err == nil {
+		return buf.Bytes(), nil
+	}
+	if cache.Value == nil {
+		return nil, err
+	}
+
+	data, jsonErr := json.Marshal(cache.Value)
+	if jsonErr != nil {
+		return nil, err
+	}
+	buf.Reset()
+	if err := gob.NewEncoder(&buf).Encode(FileCache{Key: cache.Key, Expiration: cache.Expiration, Data: data}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Cleanup xóa các entry đã hết hạn và file tạm bị bỏ lại, rồi loại bỏ entry cũ nhất nếu vượt MaxSize.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - FileCleanupResult: Số file đã xóa và số byte thu hồi được
+//   - error: Lỗi nếu không thể khóa hoặc đọc thư mục cache
+func (d *fileDriver) Cleanup(ctx context.Context) (FileCleanupResult, error) {
+	unlock, _, err := d.cleanupLock(true)
+	if err != nil {
+		return FileCleanupResult{}, err
+	}
+	defer unlock()
+
+	return d.cleanup(true)
+}
+
+// OnCleanup đăng ký callback nhận kết quả của mỗi lần dọn dẹp có xóa file.
+//
+// Params:
+//   - callback: Hàm được gọi với kết quả dọn dẹp, nil để bỏ đăng ký
+//
+// Returns:
+//   - FileDriver: Chính driver để gọi nối tiếp
+func (d *fileDriver) OnCleanup(callback FileCleanupCallback) FileDriver {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onCleanup = callback
+	return d
 }
 
 // Close giải phóng tài nguyên của driver.
@@ -452,8 +1090,8 @@ func (d *fileDriver) Close() error {
 
 // startJanitor bắt đầu một routine định kỳ dọn dẹp các file đã hết hạn.
 //
-// Phương thức này chạy một goroutine định kỳ gọi deleteExpired để
-// xóa các file cache đã hết hạn theo khoảng thời gian đã cấu hình.
+// Phương thức này chạy một goroutine định kỳ gọi tryCleanup để xóa các file cache
+// đã hết hạn theo khoảng thời gian đã cấu hình.
 func (d *fileDriver) startJanitor() {
 	ticker := time.NewTicker(d.janitorInterval)
 	defer ticker.Stop()
@@ -461,47 +1099,262 @@ func (d *fileDriver) startJanitor() {
 	for {
 		select {
 		case <-ticker.C:
-			d.deleteExpired()
+			d.tryCleanup(true)
 		case <-d.stopJanitor:
 			return
 		}
 	}
 }
 
-// deleteExpired xóa tất cả các file đã hết hạn.
+// tryCleanup dọn dẹp thư mục cache nếu không có goroutine hoặc process khác đang dọn dẹp.
 //
-// Phương thức này quét qua tất cả các file trong thư mục cache,
-// đọc thông tin thời gian hết hạn và xóa file nếu đã quá hạn.
-func (d *fileDriver) deleteExpired() {
-	now := time.Now().UnixNano()
-	dir, err := os.Open(d.directory)
-	if err != nil {
+// Params:
+//   - removeExpired: true để đọc từng file và xóa entry đã hết hạn, false để chỉ áp dụng MaxSize
+func (d *fileDriver) tryCleanup(removeExpired bool) {
+	unlock, locked, err := d.cleanupLock(false)
+	if err != nil || !locked {
 		return
 	}
-	defer dir.Close()
+	defer unlock()
+
+	d.cleanup(removeExpired)
+}
 
-	names, err := dir.Readdirnames(-1)
+// cleanup xóa file tạm bị bỏ lại, entry đã hết hạn (nếu removeExpired) và loại bỏ
+// các entry có thời gian ghi cũ nhất cho đến khi tổng kích thước về dưới 90% MaxSize.
+// Version của tag và bộ đếm không bị loại bỏ để áp dụng MaxSize, chỉ bị xóa khi hết hạn.
+//
+// Phương thức này phải được gọi khi đang giữ khóa dọn dẹp.
+func (d *fileDriver) cleanup(removeExpired bool) (FileCleanupResult, error) {
+	var result FileCleanupResult
+
+	entries, temps, err := d.listFiles()
 	if err != nil {
-		return
+		return result, err
 	}
 
-	for _, name := range names {
-		filename := filepath.Join(d.directory, name)
-		file, err := os.Open(filename)
-		if err != nil {
-			continue
+	now := time.Now()
+	for _, temp := range temps {
+		if now.Sub(temp.modTime) > fileTempMaxAge && os.Remove(temp.path) == nil {
+			result.Removed++
+			result.ReclaimedBytes += temp.size
+		}
+	}
+
+	live := entries[:0]
+	for _, entry := range entries {
+		if removeExpired {
+			cache, err := d.readEntry(entry.path)
+			if err == nil && cache.Expiration > 0 && now.UnixNano() > cache.Expiration {
+				if d.removeExpired(entry.path) {
+					result.Removed++
+					result.ReclaimedBytes += entry.size
+					continue
+				}
+			}
 		}
+		live = append(live, entry)
+		result.Size += entry.size
+	}
+
+	if d.maxSize > 0 && result.Size > d.maxSize {
+		// Loại bỏ xuống dưới MaxSize một khoảng để các lần ghi tiếp theo không phải dọn dẹp ngay
+		target := d.maxSize - d.maxSize/10
+		sort.Slice(live, func(i, j int) bool { return live[i].modTime.Before(live[j].modTime) })
+		for _, entry := range live {
+			if result.Size <= target {
+				break
+			}
+			evicted, gone := d.evictEntry(entry)
+			if !gone {
+				continue
+			}
+			result.Size -= entry.size
+			if evicted {
+				result.Removed++
+				result.Evicted++
+				result.ReclaimedBytes += entry.size
+			}
+		}
+	}
+
+	if d.maxSize > 0 {
+		atomic.StoreInt64(&d.size, result.Size)
+	}
+	atomic.AddInt64(&d.evictions, int64(result.Evicted))
+	atomic.AddInt64(&d.reclaimed, result.ReclaimedBytes)
+
+	d.mu.RLock()
+	callback := d.onCleanup
+	d.mu.RUnlock()
+	if callback != nil && result.Removed > 0 {
+		callback(result)
+	}
+	return result, nil
+}
+
+// cleanupLock giữ khóa dọn dẹp của thư mục cache.
+//
+// Khóa gồm mutex trong process và flock trên file khóa dọn dẹp, tách biệt với khóa của
+// các thao tác đọc-sửa-ghi để việc dọn dẹp không chặn Increment, Add, CompareAndSwap.
+//
+// Params:
+//   - wait: true để chờ đến khi lấy được khóa, false để trả về ngay nếu khóa đang được giữ
+//
+// Returns:
+//   - func(): Hàm nhả khóa (nil nếu không lấy được khóa)
+//   - bool: true nếu lấy được khóa
+//   - error: Lỗi nếu không thể mở hoặc khóa file khóa
+func (d *fileDriver) cleanupLock(wait bool) (func(), bool, error) {
+	if wait {
+		d.cleanupMu.Lock()
+	} else if !d.cleanupMu.TryLock() {
+		return nil, false, nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(d.directory, fileJanitorLockName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		d.cleanupMu.Unlock()
+		return nil, false, fmt.Errorf("could not open cache cleanup lock file: %w", err)
+	}
 
-		var cache FileCache
-		decoder := gob.NewDecoder(file)
-		if err = decoder.Decode(&cache); err != nil {
-			file.Close()
-			continue
+	locked := true
+	if wait {
+		err = lockFile(file)
+	} else {
+		locked, err = tryLockFile(file)
+	}
+	if err != nil || !locked {
+		file.Close()
+		d.cleanupMu.Unlock()
+		if err != nil {
+			return nil, false, fmt.Errorf("could not lock cache directory for cleanup: %w", err)
 		}
+		return nil, false, nil
+	}
+
+	return func() {
+		unlockFile(file)
 		file.Close()
+		d.cleanupMu.Unlock()
+	}, true, nil
+}
+
+// cacheFile là thông tin của một file trong thư mục cache.
+type cacheFile struct {
+	path    string    // Đường dẫn đầy đủ của file
+	size    int64     // Kích thước file (byte)
+	modTime time.Time // Thời điểm file được ghi
+}
+
+// listFiles liệt kê các file cache và file tạm trong thư mục cache, kể cả trong các thư mục con.
+//
+// Returns:
+//   - []cacheFile: Các file cache (không gồm file khóa và file tạm)
+//   - []cacheFile: Các file tạm của thao tác ghi
+//   - error: Lỗi nếu không thể đọc thư mục cache
+func (d *fileDriver) listFiles() ([]cacheFile, []cacheFile, error) {
+	var entries, temps []cacheFile
+	err := filepath.WalkDir(d.directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == d.directory {
+				return err
+			}
+			return nil // File hoặc thư mục con bị xóa trong lúc duyệt
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
 
-		if cache.Expiration > 0 && now > cache.Expiration {
-			_ = os.Remove(filename) // Ignore error, continue
+		file := cacheFile{path: path, size: info.Size(), modTime: info.ModTime()}
+		switch name := entry.Name(); {
+		case strings.HasPrefix(name, fileTempPrefix):
+			temps = append(temps, file)
+		case !strings.HasPrefix(name, "."):
+			entries = append(entries, file)
 		}
+		return nil
+	})
+	return entries, temps, err
+}
+
+// removeExpired xóa file cache nếu entry trong file đã hết hạn, trong khóa file của key.
+//
+// Entry được đọc lại sau khi lấy khóa, nên file vừa được thao tác ghi khác thay bằng entry mới
+// không bị xóa.
+//
+// Returns:
+//   - bool: true nếu file đã được xóa
+func (d *fileDriver) removeExpired(filename string) bool {
+	unlock, err := d.lock(filename)
+	if err != nil {
+		return false
+	}
+	defer unlock()
+
+	cache, err := d.readEntry(filename)
+	if err != nil || cache.Expiration == 0 || time.Now().UnixNano() <= cache.Expiration {
+		return false
+	}
+	return d.removeFile(filename) == nil
+}
+
+// evictEntry loại bỏ entry để áp dụng MaxSize, trong khóa file của key.
+//
+// Entry được giữ lại nếu file đã được ghi lại sau khi liệt kê, hoặc là version của tag
+// (mất version làm mọi entry gắn tag bị flush) hay bộ đếm (mất bộ đếm làm nó bị đặt lại).
+//
+// Returns:
+//   - bool: true nếu file đã được xóa
+//   - bool: true nếu file đã được xóa hoặc không còn tồn tại
+func (d *fileDriver) evictEntry(entry cacheFile) (bool, bool) {
+	unlock, err := d.lock(entry.path)
+	if err != nil {
+		return false, false
+	}
+	defer unlock()
+
+	info, err := os.Stat(entry.path)
+	if os.IsNotExist(err) {
+		return false, true
+	}
+	if err != nil || !info.ModTime().Equal(entry.modTime) || info.Size() != entry.size {
+		return false, false
+	}
+	if cache, err := d.readEntry(entry.path); err == nil && (cache.Counter || isTagVersionKey(cache.Key)) {
+		return false, false
+	}
+
+	err = d.removeEntry(entry)
+	return err == nil, err == nil || os.IsNotExist(err)
+}
+
+// isTagVersionKey kiểm tra key đầy đủ có phải key version của tag hay không.
+//
+// Thư mục có thể được dùng chung bởi driver với prefix khác, nên tiền tố version của tag
+// được tìm ở bất kỳ vị trí nào trong key thay vì ngay sau prefix của driver này.
+func isTagVersionKey(key string) bool {
+	return strings.Contains(key, tagVersionKeyPrefix)
+}
+
+// removeFile xóa file cache và cập nhật tổng kích thước.
+func (d *fileDriver) removeFile(filename string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	return d.removeEntry(cacheFile{path: filename, size: info.Size()})
+}
+
+// removeEntry xóa file cache đã biết kích thước và cập nhật tổng kích thước.
+func (d *fileDriver) removeEntry(file cacheFile) error {
+	if err := os.Remove(file.path); err != nil {
+		return err
 	}
+	atomic.AddInt64(&d.size, -file.size)
+	return nil
 }
@@ -2,6 +2,8 @@ package driver_test
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -432,6 +434,157 @@ func TestFileDriverIntegration(t *testing.T) {
 		assert.True(t, found)
 		assert.Equal(t, value, result)
 	})
+
+	t.Run("Tag Versions", func(t *testing.T) {
+		versions, err := fileDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.Len(t, versions, 2)
+		assert.NotEqual(t, versions[0], versions[1])
+
+		// Version ổn định giữa các lần gọi và theo đúng thứ tự tag
+		again, err := fileDriver.TagVersions(ctx, []string{"users", "tenant:7"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{versions[1], versions[0]}, again)
+
+		// FlushTags chỉ đổi version của tag được flush
+		assert.NoError(t, fileDriver.FlushTags(ctx, []string{"tenant:7"}))
+		flushed, err := fileDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, versions[0], flushed[0])
+		assert.Equal(t, versions[1], flushed[1])
+	})
+
+	t.Run("Atomic Operations", func(t *testing.T) {
+		// Increment khởi tạo key chưa tồn tại bằng delta
+		value, err := fileDriver.Increment(ctx, "atomic:counter", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = fileDriver.Increment(ctx, "atomic:counter", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), value)
+
+		value, err = fileDriver.Decrement(ctx, "atomic:counter", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-2), value)
+
+		// Increment trên giá trị số đã ghi bằng Set
+		assert.NoError(t, fileDriver.Set(ctx, "atomic:set", 40, time.Minute))
+		value, err = fileDriver.Increment(ctx, "atomic:set", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), value)
+
+		assert.NoError(t, fileDriver.Set(ctx, "atomic:text", "alice", time.Minute))
+		_, err = fileDriver.Increment(ctx, "atomic:text", 1)
+		assert.ErrorIs(t, err, driver.ErrNotInteger)
+
+		// Add chỉ ghi khi key chưa tồn tại
+		added, err := fileDriver.Add(ctx, "atomic:once", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		added, err = fileDriver.Add(ctx, "atomic:once", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		result, found := fileDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "first", result)
+
+		// CompareAndSwap chỉ ghi khi giá trị hiện tại khớp
+		swapped, err := fileDriver.CompareAndSwap(ctx, "atomic:once", "other", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+		swapped, err = fileDriver.CompareAndSwap(ctx, "atomic:once", "first", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		result, found = fileDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "third", result)
+
+		swapped, err = fileDriver.CompareAndSwap(ctx, "atomic:missing", nil, "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+
+		// Add và Increment coi key đã hết hạn như chưa tồn tại
+		assert.NoError(t, fileDriver.Set(ctx, "atomic:expired", "old", 50*time.Millisecond))
+		assert.NoError(t, fileDriver.Set(ctx, "atomic:expired_counter", 100, 50*time.Millisecond))
+		time.Sleep(100 * time.Millisecond)
+
+		added, err = fileDriver.Add(ctx, "atomic:expired", "new", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		value, err = fileDriver.Increment(ctx, "atomic:expired_counter", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), value)
+	})
+
+	t.Run("GetInto", func(t *testing.T) {
+		// Kiểu chưa được gob.Register vẫn được lưu và đọc lại đúng kiểu
+		type profile struct {
+			Name string
+			Age  int
+		}
+		assert.NoError(t, fileDriver.Set(ctx, "typed:profile", profile{Name: "An", Age: 30}, time.Minute))
+
+		var result profile
+		found, err := fileDriver.GetInto(ctx, "typed:profile", &result)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "An", Age: 30}, result)
+
+		// Get trả về dạng giải mã JSON tổng quát
+		value, found := fileDriver.Get(ctx, "typed:profile")
+		assert.True(t, found)
+		assert.Equal(t, map[string]interface{}{"Name": "An", "Age": float64(30)}, value)
+
+		assert.NoError(t, fileDriver.Set(ctx, "typed:text", "hello", time.Minute))
+		var text string
+		found, err = fileDriver.GetInto(ctx, "typed:text", &text)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "hello", text)
+
+		found, err = fileDriver.GetInto(ctx, "typed:missing", &result)
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Prefix Isolation", func(t *testing.T) {
+		sharedDir := t.TempDir()
+		first, err := driver.NewFileDriver(config.DriverFileConfig{Path: sharedDir, DefaultTTL: 10, Prefix: "first:"})
+		assert.NoError(t, err)
+		defer first.Close()
+		second, err := driver.NewFileDriver(config.DriverFileConfig{Path: sharedDir, DefaultTTL: 10, Prefix: "second:"})
+		assert.NoError(t, err)
+		defer second.Close()
+
+		// Cùng key ở hai prefix được lưu ở hai file khác nhau
+		assert.NoError(t, first.Set(ctx, "user:1", "alice", time.Minute))
+		assert.NoError(t, second.Set(ctx, "user:1", "bob", time.Minute))
+		value, found := first.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+
+		// Flush chỉ xóa các key của prefix của driver
+		assert.NoError(t, first.Flush(ctx))
+		assert.False(t, first.Has(ctx, "user:1"))
+		value, found = second.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "bob", value)
+	})
+
+	t.Run("FlushPrefix", func(t *testing.T) {
+		assert.NoError(t, fileDriver.Set(ctx, "users:1", "alice", time.Minute))
+		assert.NoError(t, fileDriver.Set(ctx, "users:2", map[string]int{"age": 30}, time.Minute))
+		assert.NoError(t, fileDriver.Set(ctx, "orders:1", "order", time.Minute))
+
+		assert.NoError(t, fileDriver.FlushPrefix(ctx, "users:"))
+
+		assert.False(t, fileDriver.Has(ctx, "users:1"))
+		assert.False(t, fileDriver.Has(ctx, "users:2"))
+		assert.True(t, fileDriver.Has(ctx, "orders:1"))
+	})
 }
 
 func TestFileDriverMocked(t *testing.T) {
@@ -537,6 +690,182 @@ func TestFileDriverTestSuite(t *testing.T) {
 	suite.Run(t, new(FileDriverTestSuite))
 }
 
+// shardedCachePath trả về đường dẫn file cache của key với hai cấp thư mục con.
+func shardedCachePath(dir, key string) string {
+	sum := sha1.Sum([]byte(key))
+	hash := hex.EncodeToString(sum[:])
+	return filepath.Join(dir, hash[0:2], hash[2:4], hash)
+}
+
+func TestFileDriverShardingAndQuota(t *testing.T) {
+	ctx := context.Background()
+
+	newFileDriver := func(t *testing.T, cfg config.DriverFileConfig) driver.FileDriver {
+		fileDriver, err := driver.NewFileDriver(cfg)
+		assert.NoError(t, err)
+		t.Cleanup(func() { fileDriver.Close() })
+		return fileDriver
+	}
+
+	t.Run("Sharded Directories", func(t *testing.T) {
+		dir := t.TempDir()
+		fileDriver := newFileDriver(t, config.DriverFileConfig{Path: dir, DefaultTTL: 300, ShardDepth: 2})
+
+		assert.NoError(t, fileDriver.Set(ctx, "users:1", "alice", time.Minute))
+		assert.NoError(t, fileDriver.Set(ctx, "posts:1", "hello", time.Minute))
+
+		_, err := os.Stat(shardedCachePath(dir, "users:1"))
+		assert.NoError(t, err)
+		value, found := fileDriver.Get(ctx, "users:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+		assert.Equal(t, 2, fileDriver.Stats(ctx)["count"])
+
+		assert.NoError(t, fileDriver.FlushPrefix(ctx, "users:"))
+		assert.False(t, fileDriver.Has(ctx, "users:1"))
+		assert.True(t, fileDriver.Has(ctx, "posts:1"))
+
+		assert.NoError(t, fileDriver.Flush(ctx))
+		assert.False(t, fileDriver.Has(ctx, "posts:1"))
+		assert.Equal(t, 0, fileDriver.Stats(ctx)["count"])
+	})
+
+	t.Run("Invalid Shard Depth", func(t *testing.T) {
+		_, err := driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), ShardDepth: 5})
+		assert.Error(t, err)
+	})
+
+	t.Run("Size Quota Evicts Oldest", func(t *testing.T) {
+		// Đo kích thước một entry để cấu hình quota vừa đủ ba entry
+		probeDir := t.TempDir()
+		probe := newFileDriver(t, config.DriverFileConfig{Path: probeDir, ShardDepth: 2})
+		assert.NoError(t, probe.Set(ctx, "key:0", "value", -1))
+		info, err := os.Stat(shardedCachePath(probeDir, "key:0"))
+		assert.NoError(t, err)
+		entrySize := info.Size()
+
+		dir := t.TempDir()
+		fileDriver := newFileDriver(t, config.DriverFileConfig{Path: dir, ShardDepth: 2, MaxSize: 3*entrySize + entrySize/2})
+		for i := 1; i <= 3; i++ {
+			key := fmt.Sprintf("key:%d", i)
+			assert.NoError(t, fileDriver.Set(ctx, key, "value", -1))
+			written := time.Now().Add(-time.Duration(10-i) * time.Minute)
+			assert.NoError(t, os.Chtimes(shardedCachePath(dir, key), written, written))
+		}
+
+		assert.NoError(t, fileDriver.Set(ctx, "key:4", "value", -1))
+
+		// MaxSize được áp dụng ở goroutine nền sau lần ghi vượt giới hạn
+		assert.Eventually(t, func() bool {
+			return !fileDriver.Has(ctx, "key:1")
+		}, time.Second, 10*time.Millisecond)
+		assert.True(t, fileDriver.Has(ctx, "key:2"))
+		assert.True(t, fileDriver.Has(ctx, "key:3"))
+		assert.True(t, fileDriver.Has(ctx, "key:4"))
+		stats := fileDriver.Stats(ctx)
+		assert.Equal(t, int64(1), stats["evictions"])
+		assert.Equal(t, entrySize, stats["reclaimed_bytes"])
+		assert.LessOrEqual(t, stats["size"].(int64), 3*entrySize+entrySize/2)
+	})
+
+	t.Run("Size Quota Keeps Tag Versions And Counters", func(t *testing.T) {
+		dir := t.TempDir()
+		fileDriver := newFileDriver(t, config.DriverFileConfig{Path: dir, ShardDepth: 2, MaxSize: 4096})
+
+		// Version của tag và bộ đếm là các file cũ nhất trong thư mục
+		versions, err := fileDriver.TagVersions(ctx, []string{"users"})
+		assert.NoError(t, err)
+		_, err = fileDriver.Increment(ctx, "visits", 5)
+		assert.NoError(t, err)
+		old := time.Now().Add(-time.Hour)
+		assert.NoError(t, os.Chtimes(shardedCachePath(dir, "__tag:users"), old, old))
+		assert.NoError(t, os.Chtimes(shardedCachePath(dir, "visits"), old, old))
+
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, fileDriver.Set(ctx, fmt.Sprintf("key:%d", i), strings.Repeat("x", 256), -1))
+		}
+
+		assert.Eventually(t, func() bool {
+			return fileDriver.Stats(ctx)["evictions"].(int64) > 0
+		}, time.Second, 10*time.Millisecond)
+		_, err = fileDriver.Cleanup(ctx)
+		assert.NoError(t, err)
+
+		current, err := fileDriver.TagVersions(ctx, []string{"users"})
+		assert.NoError(t, err)
+		assert.Equal(t, versions, current)
+		visits, err := fileDriver.Increment(ctx, "visits", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), visits)
+	})
+
+	t.Run("Expired Read Keeps Rewritten File", func(t *testing.T) {
+		dir := t.TempDir()
+		fileDriver := newFileDriver(t, config.DriverFileConfig{Path: dir})
+		other := newFileDriver(t, config.DriverFileConfig{Path: dir})
+
+		assert.NoError(t, fileDriver.Set(ctx, "session", "old", time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+
+		// Process khác ghi lại key trong lúc entry hết hạn được đọc
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, other.Set(ctx, "session", "new", time.Minute))
+		}()
+		fileDriver.Get(ctx, "session")
+		wg.Wait()
+
+		value, found := other.Get(ctx, "session")
+		assert.True(t, found)
+		assert.Equal(t, "new", value)
+	})
+
+	t.Run("Item Too Large", func(t *testing.T) {
+		fileDriver := newFileDriver(t, config.DriverFileConfig{Path: t.TempDir(), MaxSize: 64})
+
+		err := fileDriver.Set(ctx, "large", strings.Repeat("x", 1024), time.Minute)
+
+		assert.ErrorIs(t, err, driver.ErrItemTooLarge)
+		assert.False(t, fileDriver.Has(ctx, "large"))
+	})
+
+	t.Run("Cleanup Reports Reclaimed Bytes", func(t *testing.T) {
+		dir := t.TempDir()
+		fileDriver := newFileDriver(t, config.DriverFileConfig{Path: dir, ShardDepth: 1})
+		var reported []driver.FileCleanupResult
+		fileDriver.OnCleanup(func(result driver.FileCleanupResult) {
+			reported = append(reported, result)
+		})
+
+		assert.NoError(t, fileDriver.Set(ctx, "expired", "value", time.Millisecond))
+		assert.NoError(t, fileDriver.Set(ctx, "live", "value", time.Minute))
+		staleTemp := filepath.Join(dir, ".tmp-stale")
+		freshTemp := filepath.Join(dir, ".tmp-fresh")
+		assert.NoError(t, os.WriteFile(staleTemp, []byte("torn"), 0644))
+		assert.NoError(t, os.WriteFile(freshTemp, []byte("writing"), 0644))
+		old := time.Now().Add(-2 * time.Hour)
+		assert.NoError(t, os.Chtimes(staleTemp, old, old))
+		time.Sleep(10 * time.Millisecond)
+
+		result, err := fileDriver.Cleanup(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Removed)
+		assert.Equal(t, 0, result.Evicted)
+		assert.Greater(t, result.ReclaimedBytes, int64(len("torn")))
+		assert.Equal(t, fileDriver.Stats(ctx)["size"], result.Size)
+		assert.Equal(t, []driver.FileCleanupResult{result}, reported)
+		assert.True(t, fileDriver.Has(ctx, "live"))
+		_, err = os.Stat(staleTemp)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(freshTemp)
+		assert.NoError(t, err)
+		assert.Equal(t, result.ReclaimedBytes, fileDriver.Stats(ctx)["reclaimed_bytes"])
+	})
+}
+
 func TestFileDriverConcurrency(t *testing.T) {
 	ctx := context.Background()
 
@@ -592,6 +921,57 @@ func TestFileDriverConcurrency(t *testing.T) {
 		stats := fileDriver.Stats(ctx)
 		assert.Greater(t, stats["count"], 0)
 	})
+
+	t.Run("Concurrent Increment", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					_, err := fileDriver.Increment(ctx, "concurrent:counter", 1)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		value, err := fileDriver.Increment(ctx, "concurrent:counter", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(200), value)
+	})
+
+	t.Run("Set And Delete Use Per-Key Locks", func(t *testing.T) {
+		// A second driver on the same directory behaves like another process.
+		other, err := driver.NewFileDriver(fileConfig)
+		assert.NoError(t, err)
+		defer other.Close()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					_, err := fileDriver.Increment(ctx, "concurrent:shared", 1)
+					assert.NoError(t, err)
+				}
+			}()
+			go func(id int) {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					key := fmt.Sprintf("concurrent:other:%d:%d", id, j)
+					assert.NoError(t, other.Set(ctx, key, j, 0))
+					assert.NoError(t, other.Delete(ctx, key))
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		value, err := other.Increment(ctx, "concurrent:shared", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), value)
+	})
 }
 
 func BenchmarkFileDriver(b *testing.B) {
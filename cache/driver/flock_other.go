@@ -0,0 +1,21 @@
+//go:build !unix
+
+package driver
+
+import "os"
+
+// lockFile không khóa được giữa các process trên nền tảng này; file driver
+// chỉ tuần tự hóa các thao tác nguyên tử trong cùng một process.
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// unlockFile không làm gì trên nền tảng không hỗ trợ khóa file.
+func unlockFile(file *os.File) error {
+	return nil
+}
+
+// tryLockFile luôn thành công trên nền tảng không hỗ trợ khóa file.
+func tryLockFile(file *os.File) (bool, error) {
+	return true, nil
+}
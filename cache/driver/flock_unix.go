@@ -0,0 +1,31 @@
+//go:build unix
+
+package driver
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile giữ khóa độc quyền trên file, chờ đến khi lấy được khóa.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile nhả khóa trên file.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
+
+// tryLockFile thử giữ khóa độc quyền trên file mà không chờ.
+//
+// Returns:
+//   - bool: true nếu lấy được khóa, false nếu khóa đang được giữ bởi file descriptor khác
+//   - error: Lỗi nếu không thể khóa file
+func tryLockFile(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		return false, nil
+	}
+	return err == nil, err
+}
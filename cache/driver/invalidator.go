@@ -0,0 +1,132 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// InvalidationMessage là thông báo invalidate L1 được phát giữa các instance dùng chung L2.
+type InvalidationMessage struct {
+	// Source là định danh của instance phát thông báo, instance nhận bỏ qua thông báo của chính nó
+	Source string `json:"source"`
+
+	// Keys là danh sách key cần xóa khỏi L1
+	Keys []string `json:"keys,omitempty"`
+
+	// Flush yêu cầu xóa toàn bộ L1
+	Flush bool `json:"flush,omitempty"`
+
+	// Prefix yêu cầu xóa các key bắt đầu bằng Prefix khỏi L1
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Invalidator phát và nhận thông báo invalidate L1 giữa các instance của tiered driver.
+type Invalidator interface {
+	// Publish phát thông báo invalidate tới mọi instance đang nhận.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - message: Thông báo cần phát
+	//
+	// Returns:
+	//   - error: Lỗi nếu không thể phát thông báo
+	Publish(ctx context.Context, message InvalidationMessage) error
+
+	// Subscribe bắt đầu nhận thông báo, gọi handler cho mỗi thông báo nhận được.
+	//
+	// Phương thức trả về sau khi việc đăng ký nhận đã được xác nhận, handler được gọi
+	// trên một goroutine riêng cho tới khi Close được gọi.
+	//
+	// Params:
+	//   - handler: Hàm xử lý thông báo
+	//
+	// Returns:
+	//   - error: Lỗi nếu không thể đăng ký nhận thông báo
+	Subscribe(handler func(InvalidationMessage)) error
+
+	// Close dừng nhận thông báo và giải phóng tài nguyên.
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình giải phóng tài nguyên
+	Close() error
+}
+
+// redisInvalidator cài đặt Invalidator sử dụng Redis pub/sub.
+type redisInvalidator struct {
+	client  *redis.Client  // Redis client để phát và nhận thông báo
+	channel string         // Kênh pub/sub
+	mu      sync.Mutex     // Mutex bảo vệ pubsub
+	pubsub  *redis.PubSub  // Subscription hiện tại (nil nếu chưa Subscribe)
+	done    sync.WaitGroup // Chờ goroutine nhận thông báo kết thúc khi Close
+}
+
+// NewRedisInvalidator tạo một Invalidator sử dụng Redis pub/sub.
+//
+// Params:
+//   - client: Redis client để phát và nhận thông báo
+//   - channel: Tên kênh pub/sub dùng chung giữa các instance
+//
+// Returns:
+//   - Invalidator: Invalidator đã được khởi tạo
+func NewRedisInvalidator(client *redis.Client, channel string) Invalidator {
+	return &redisInvalidator{client: client, channel: channel}
+}
+
+// Publish phát thông báo invalidate dưới dạng JSON lên kênh pub/sub.
+func (i *redisInvalidator) Publish(ctx context.Context, message InvalidationMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("could not encode invalidation message: %w", err)
+	}
+	return i.client.Publish(ctx, i.channel, data).Err()
+}
+
+// Subscribe đăng ký nhận thông báo trên kênh pub/sub.
+func (i *redisInvalidator) Subscribe(handler func(InvalidationMessage)) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.pubsub != nil {
+		return fmt.Errorf("invalidator is already subscribed to '%s'", i.channel)
+	}
+
+	ctx := context.Background()
+	pubsub := i.client.Subscribe(ctx, i.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("could not subscribe to '%s': %w", i.channel, err)
+	}
+	i.pubsub = pubsub
+
+	i.done.Add(1)
+	go func() {
+		defer i.done.Done()
+		for msg := range pubsub.Channel() {
+			var message InvalidationMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+				continue // Bỏ qua thông báo không hợp lệ
+			}
+			handler(message)
+		}
+	}()
+	return nil
+}
+
+// Close hủy đăng ký và chờ goroutine nhận thông báo kết thúc.
+func (i *redisInvalidator) Close() error {
+	i.mu.Lock()
+	pubsub := i.pubsub
+	i.pubsub = nil
+	i.mu.Unlock()
+
+	if pubsub == nil {
+		return nil
+	}
+	err := pubsub.Close()
+	i.done.Wait()
+	return err
+}
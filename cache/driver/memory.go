@@ -1,8 +1,15 @@
 package driver
 
 import (
+	"container/heap"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.fork.vn/providers/cache/config"
@@ -31,8 +38,123 @@ func (i *Item) Expired() bool {
 	return time.Now().UnixNano() > i.Expiration
 }
 
+// Các chính sách loại bỏ item của memory driver khi vượt giới hạn MaxItems hoặc MaxBytes.
+const (
+	// EvictionPolicyLRU loại bỏ item lâu nhất chưa được truy cập
+	EvictionPolicyLRU = "lru"
+	// EvictionPolicyLFU loại bỏ item ít được truy cập nhất, hòa thì chọn item lâu chưa truy cập nhất
+	EvictionPolicyLFU = "lfu"
+	// EvictionPolicyFIFO loại bỏ item được thêm vào sớm nhất
+	EvictionPolicyFIFO = "fifo"
+)
+
+// Sizer ước lượng kích thước (byte) của một cache entry để áp dụng giới hạn MaxBytes.
+type Sizer func(key string, value interface{}) int64
+
+// EvictCallback được gọi khi một item bị loại bỏ do vượt giới hạn dung lượng.
+//
+// Callback được gọi sau khi driver đã nhả khóa nên có thể gọi lại các phương thức của driver.
+// Item hết hạn hoặc bị xóa qua Delete/Flush không kích hoạt callback.
+type EvictCallback func(key string, value interface{})
+
 type MemoryDriver interface {
 	Driver
+	Taggable
+	PrefixFlusher
+
+	// WithSizer thay hàm ước lượng kích thước item.
+	//
+	// Nên gọi trước khi ghi dữ liệu vì kích thước của các item đã lưu không được tính lại.
+	// Khi MaxBytes > 0 và không có sizer nào được đặt, driver sử dụng DefaultSizer.
+	WithSizer(sizer Sizer) MemoryDriver
+
+	// OnEvict đăng ký callback được gọi khi item bị loại bỏ do vượt giới hạn dung lượng.
+	OnEvict(callback EvictCallback) MemoryDriver
+}
+
+// DefaultSizer ước lượng kích thước item bằng độ dài key cộng độ dài giá trị.
+//
+// Giá trị string và []byte được tính theo độ dài thực tế; các kiểu khác được tính
+// theo độ dài biểu diễn JSON (hoặc fmt nếu không encode được JSON).
+//
+// Params:
+//   - key: Cache key
+//   - value: Giá trị cần ước lượng
+//
+// Returns:
+//   - int64: Kích thước ước lượng tính bằng byte
+func DefaultSizer(key string, value interface{}) int64 {
+	size := int64(len(key))
+	switch v := value.(type) {
+	case nil:
+	case string:
+		size += int64(len(v))
+	case []byte:
+		size += int64(len(v))
+	default:
+		if data, err := json.Marshal(v); err == nil {
+			size += int64(len(data))
+		} else {
+			size += int64(len(fmt.Sprint(v)))
+		}
+	}
+	return size
+}
+
+// memoryEntry là một item trong memory cache kèm thông tin phục vụ việc loại bỏ.
+type memoryEntry struct {
+	key       string
+	item      Item
+	size      int64  // Kích thước theo sizer
+	frequency uint64 // Số lần được truy cập (lfu)
+	sequence  uint64 // Thứ tự thêm vào (fifo) hoặc lần truy cập gần nhất (lru, lfu)
+	index     int    // Vị trí trong evictionQueue
+}
+
+// evictionQueue là min-heap các entry, phần tử đầu tiên là ứng viên bị loại bỏ tiếp theo.
+type evictionQueue struct {
+	entries []*memoryEntry
+	less    func(a, b *memoryEntry) bool
+}
+
+func (q *evictionQueue) Len() int { return len(q.entries) }
+
+func (q *evictionQueue) Less(i, j int) bool { return q.less(q.entries[i], q.entries[j]) }
+
+func (q *evictionQueue) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.entries[i].index = i
+	q.entries[j].index = j
+}
+
+func (q *evictionQueue) Push(x interface{}) {
+	entry := x.(*memoryEntry)
+	entry.index = len(q.entries)
+	q.entries = append(q.entries, entry)
+}
+
+func (q *evictionQueue) Pop() interface{} {
+	n := len(q.entries)
+	entry := q.entries[n-1]
+	q.entries[n-1] = nil
+	q.entries = q.entries[:n-1]
+	entry.index = -1
+	return entry
+}
+
+// evictionLess trả về hàm so sánh thứ tự loại bỏ cho chính sách policy.
+func evictionLess(policy string) func(a, b *memoryEntry) bool {
+	if policy == EvictionPolicyLFU {
+		return func(a, b *memoryEntry) bool {
+			if a.frequency != b.frequency {
+				return a.frequency < b.frequency
+			}
+			return a.sequence < b.sequence
+		}
+	}
+	return func(a, b *memoryEntry) bool {
+		return a.sequence < b.sequence
+	}
 }
 
 // memoryDriver cài đặt cache driver sử dụng memory (in-memory).
@@ -42,29 +164,53 @@ type MemoryDriver interface {
 // bị mất khi ứng dụng khởi động lại. Nó hỗ trợ TTL và tự động dọn dẹp
 // các entry đã hết hạn.
 type memoryDriver struct {
-	items             map[string]Item // Map lưu trữ các cache item
-	mu                sync.RWMutex    // Mutex cho các thao tác thread-safe
-	janitorInterval   time.Duration   // Khoảng thời gian giữa các lần dọn dẹp
-	stopJanitor       chan bool       // Channel để dừng goroutine dọn dẹp
-	janitorRunning    bool            // Flag đánh dấu goroutine dọn dẹp đang chạy
-	defaultExpiration time.Duration   // Thời gian sống mặc định cho các entry không chỉ định TTL
-	hits              int64           // Số lần cache hit
-	misses            int64           // Số lần cache miss
+	items             map[string]*memoryEntry // Map lưu trữ các cache item
+	queue             evictionQueue           // Thứ tự loại bỏ các item theo chính sách
+	mu                sync.RWMutex            // Mutex cho các thao tác thread-safe
+	janitorInterval   time.Duration           // Khoảng thời gian giữa các lần dọn dẹp
+	stopJanitor       chan bool               // Channel để dừng goroutine dọn dẹp
+	janitorRunning    bool                    // Flag đánh dấu goroutine dọn dẹp đang chạy
+	defaultExpiration time.Duration           // Thời gian sống mặc định cho các entry không chỉ định TTL
+	policy            string                  // Chính sách loại bỏ: lru, lfu, fifo
+	maxItems          int                     // Số lượng item tối đa (0 = không giới hạn)
+	maxBytes          int64                   // Tổng kích thước tối đa (0 = không giới hạn)
+	sizer             Sizer                   // Hàm ước lượng kích thước item (nil = không tính)
+	onEvict           EvictCallback           // Callback khi item bị loại bỏ do vượt giới hạn
+	bytes             int64                   // Tổng kích thước hiện tại theo sizer
+	sequence          uint64                  // Bộ đếm thứ tự thêm/truy cập
+	hits              int64                   // Số lần cache hit
+	misses            int64                   // Số lần cache miss
+	evictions         int64                   // Số item bị loại bỏ do vượt giới hạn
+	flights           flightGroup             // Gộp các lời gọi Remember đồng thời cho cùng key
 }
 
 // NewMemoryDriver tạo một memory driver mới với các tùy chọn mặc định.
 //
 // Phương thức này khởi tạo một MemoryDriver mới với các giá trị mặc định cho
 // defaultExpiration (5 phút) và cleanupInterval (10 phút).
+// Khi cấu hình MaxItems hoặc MaxBytes, driver loại bỏ item theo EvictionPolicy
+// (lru, lfu, fifo); chính sách không hợp lệ hoặc để trống được xem là lru.
 //
 // Returns:
 //   - *MemoryDriver: Driver đã được khởi tạo
 func NewMemoryDriver(cfg config.DriverMemoryConfig) MemoryDriver {
+	policy := cfg.EvictionPolicy
+	if policy != EvictionPolicyLFU && policy != EvictionPolicyFIFO {
+		policy = EvictionPolicyLRU
+	}
+
 	driver := &memoryDriver{
-		items:             make(map[string]Item),
+		items:             make(map[string]*memoryEntry),
+		queue:             evictionQueue{less: evictionLess(policy)},
 		janitorInterval:   time.Duration(cfg.CleanupInterval) * time.Second,
 		defaultExpiration: time.Duration(cfg.DefaultTTL) * time.Second,
 		stopJanitor:       make(chan bool),
+		policy:            policy,
+		maxItems:          cfg.MaxItems,
+		maxBytes:          cfg.MaxBytes,
+	}
+	if driver.maxBytes > 0 {
+		driver.sizer = DefaultSizer
 	}
 
 	// Chỉ chạy janitor nếu có khoảng thời gian dọn dẹp > 0
@@ -91,29 +237,43 @@ func NewMemoryDriver(cfg config.DriverMemoryConfig) MemoryDriver {
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
 //   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 func (d *memoryDriver) Get(ctx context.Context, key string) (interface{}, bool) {
-	d.mu.RLock()
-	item, found := d.items[key]
-	d.mu.RUnlock()
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
+	entry, found := d.live(key)
 	if !found {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
+		atomic.AddInt64(&d.misses, 1)
 		return nil, false
 	}
 
-	if item.Expired() {
-		d.mu.Lock()
-		d.misses++
-		delete(d.items, key)
-		d.mu.Unlock()
-		return nil, false
+	atomic.AddInt64(&d.hits, 1)
+	d.touch(entry)
+	heap.Fix(&d.queue, entry.index)
+	return entry.item.Value, true
+}
+
+// GetInto lấy một giá trị từ cache và gán vào dest.
+//
+// Memory driver giữ nguyên giá trị gốc nên dest nhận đúng giá trị đã Set mà không cần giải mã.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy
+//   - dest: Con trỏ khác nil tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: ErrInvalidDestination hoặc ErrTypeMismatch nếu không thể gán giá trị vào dest
+func (d *memoryDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	if _, err := destinationOf(dest); err != nil {
+		return false, err
 	}
 
-	d.mu.Lock()
-	d.hits++
-	d.mu.Unlock()
-	return item.Value, true
+	value, found := d.Get(ctx, key)
+	if !found {
+		return false, nil
+	}
+	return true, assignValue(dest, value)
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -121,6 +281,9 @@ func (d *memoryDriver) Get(ctx context.Context, key string) (interface{}, bool)
 // Phương thức này lưu trữ một cặp key-value vào cache với thời gian sống
 // được chỉ định. Nếu key đã tồn tại, giá trị sẽ bị ghi đè.
 //
+// Nếu việc thêm item làm vượt MaxItems hoặc MaxBytes, các item khác sẽ bị loại bỏ
+// theo chính sách đã cấu hình trước khi item mới được lưu.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key để lưu giá trị
@@ -128,26 +291,17 @@ func (d *memoryDriver) Get(ctx context.Context, key string) (interface{}, bool)
 //   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
 //
 // Returns:
-//   - error: Luôn trả về nil trong memory driver
+//   - error: ErrItemTooLarge nếu riêng item đã vượt MaxBytes, nil nếu thành công
 func (d *memoryDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	var exp int64
-
-	if ttl == 0 {
-		if d.defaultExpiration > 0 {
-			exp = time.Now().Add(d.defaultExpiration).UnixNano()
-		}
-	} else if ttl > 0 {
-		exp = time.Now().Add(ttl).UnixNano()
-	}
+	exp := d.expiration(ttl)
 
 	d.mu.Lock()
-	defer d.mu.Unlock()
+	evicted, err := d.store(key, value, exp)
+	onEvict := d.onEvict
+	d.mu.Unlock()
 
-	d.items[key] = Item{
-		Value:      value,
-		Expiration: exp,
-	}
-	return nil
+	notifyEvicted(onEvict, evicted)
+	return err
 }
 
 // Has kiểm tra xem một key có tồn tại trong cache không.
@@ -181,7 +335,9 @@ func (d *memoryDriver) Delete(ctx context.Context, key string) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	delete(d.items, key)
+	if entry, found := d.items[key]; found {
+		d.removeEntry(entry)
+	}
 	return nil
 }
 
@@ -198,7 +354,32 @@ func (d *memoryDriver) Flush(ctx context.Context) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	d.items = make(map[string]Item)
+	d.items = make(map[string]*memoryEntry)
+	d.queue.entries = nil
+	d.bytes = 0
+	return nil
+}
+
+// FlushPrefix xóa tất cả các key bắt đầu bằng prefix khỏi cache.
+//
+// Memory driver chỉ tồn tại trong process nên không cần prefix riêng, nhưng vẫn hỗ trợ
+// xóa theo tiền tố để namespace của cache.Manager chỉ xóa các key của chính nó.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của các key cần xóa
+//
+// Returns:
+//   - error: Luôn trả về nil trong memory driver
+func (d *memoryDriver) FlushPrefix(ctx context.Context, prefix string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, entry := range d.items {
+		if strings.HasPrefix(key, prefix) {
+			d.removeEntry(entry)
+		}
+	}
 	return nil
 }
 
@@ -271,11 +452,132 @@ func (d *memoryDriver) DeleteMultiple(ctx context.Context, keys []string) error
 	return nil
 }
 
+// Increment tăng giá trị số nguyên của một key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên
+func (d *memoryDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	d.mu.Lock()
+
+	var current, exp int64
+	if entry, found := d.live(key); found {
+		value, ok := toInt64(entry.item.Value)
+		if !ok {
+			d.mu.Unlock()
+			return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+		}
+		current, exp = value, entry.item.Expiration
+	}
+
+	current += delta
+	evicted, err := d.store(key, current, exp)
+	onEvict := d.onEvict
+	d.mu.Unlock()
+
+	notifyEvicted(onEvict, evicted)
+	if err != nil {
+		return 0, err
+	}
+	return current, nil
+}
+
+// Decrement giảm giá trị số nguyên của một key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên
+func (d *memoryDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	return d.Increment(ctx, key, -delta)
+}
+
+// Add đặt một giá trị vào cache chỉ khi key chưa tồn tại hoặc đã hết hạn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+//   - error: ErrItemTooLarge nếu riêng item đã vượt MaxBytes
+func (d *memoryDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	exp := d.expiration(ttl)
+
+	d.mu.Lock()
+	if _, found := d.live(key); found {
+		d.mu.Unlock()
+		return false, nil
+	}
+
+	evicted, err := d.store(key, value, exp)
+	onEvict := d.onEvict
+	d.mu.Unlock()
+
+	notifyEvicted(onEvict, evicted)
+	return err == nil, err
+}
+
+// CompareAndSwap thay giá trị của key bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+//
+// Giá trị được so sánh bằng reflect.DeepEqual.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần cập nhật
+//   - oldValue: Giá trị mong đợi hiện tại
+//   - newValue: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+//   - error: ErrItemTooLarge nếu riêng item mới đã vượt MaxBytes
+func (d *memoryDriver) CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	exp := d.expiration(ttl)
+
+	d.mu.Lock()
+	entry, found := d.live(key)
+	if !found || !reflect.DeepEqual(entry.item.Value, oldValue) {
+		d.mu.Unlock()
+		return false, nil
+	}
+
+	evicted, err := d.store(key, newValue, exp)
+	onEvict := d.onEvict
+	d.mu.Unlock()
+
+	notifyEvicted(onEvict, evicted)
+	return err == nil, err
+}
+
+// compareAndDelete xóa key chỉ khi giá trị hiện tại bằng expected (so sánh bằng reflect.DeepEqual).
+func (d *memoryDriver) compareAndDelete(ctx context.Context, key string, expected interface{}) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, found := d.live(key)
+	if !found || !reflect.DeepEqual(entry.item.Value, expected) {
+		return false, nil
+	}
+	d.removeEntry(entry)
+	return true, nil
+}
+
 // Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
 //
-// Phương thức này kiểm tra xem một key có tồn tại trong cache không, nếu có thì
-// trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
-// sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
+// Các lời gọi đồng thời cho cùng một key chỉ thực thi callback một lần, các lời gọi còn lại
+// chờ và nhận cùng kết quả. Tương đương RememberWithOptions với RememberOptions{TTL: ttl}.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -287,21 +589,22 @@ func (d *memoryDriver) DeleteMultiple(ctx context.Context, keys []string) error
 //   - interface{}: Giá trị từ cache hoặc từ callback
 //   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
 func (d *memoryDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	// Kiểm tra cache trước
-	value, found := d.Get(ctx, key)
-	if found {
-		return value, nil
-	}
-
-	// Không tìm thấy, gọi callback
-	value, err := callback()
-	if err != nil {
-		return nil, err
-	}
+	return d.RememberWithOptions(ctx, key, callback, RememberOptions{TTL: ttl})
+}
 
-	// Lưu kết quả vào cache
-	err = d.Set(ctx, key, value, ttl)
-	return value, err
+// RememberWithOptions lấy một giá trị từ cache hoặc thực thi callback, có chống cache stampede.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+//   - opts: TTL và các tùy chọn singleflight, khóa phân tán, XFetch, stale-while-revalidate
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *memoryDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	return rememberWithOptions(ctx, d, &d.flights, d.defaultExpiration, key, callback, opts)
 }
 
 // Stats trả về thông tin thống kê về cache.
@@ -320,15 +623,129 @@ func (d *memoryDriver) Stats(ctx context.Context) map[string]interface{} {
 
 	itemCount := len(d.items)
 	stats := map[string]interface{}{
-		"count":  itemCount,
-		"hits":   d.hits,
-		"misses": d.misses,
-		"type":   "memory",
+		"count":     itemCount,
+		"hits":      atomic.LoadInt64(&d.hits),
+		"misses":    atomic.LoadInt64(&d.misses),
+		"evictions": d.evictions,
+		"bytes":     d.bytes,
+		"policy":    d.policy,
+		"type":      "memory",
 	}
 
 	return stats
 }
 
+// TagVersions trả về version hiện tại của các tag, tạo version mới cho tag chưa có.
+//
+// Version của tag được lưu như một item không hết hạn tại key "__tag:" + tag, nên được tính
+// vào MaxItems/MaxBytes và bị loại bỏ theo cùng chính sách với các item khác. Tag bị loại bỏ
+// nhận version mới ở lần truy cập sau, tương đương với việc tag bị flush.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần lấy version
+//
+// Returns:
+//   - []string: Version của từng tag theo thứ tự của tags
+//   - error: Lỗi nếu không thể tạo version hoặc version vượt MaxBytes
+func (d *memoryDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	d.mu.Lock()
+	versions := make([]string, len(tags))
+	var evicted []*memoryEntry
+	var err error
+	for i, tag := range tags {
+		key := tagVersionKey(tag)
+		if entry, found := d.live(key); found {
+			d.touch(entry)
+			heap.Fix(&d.queue, entry.index)
+			versions[i], _ = entry.item.Value.(string)
+			continue
+		}
+
+		if versions[i], err = newTagVersion(); err != nil {
+			break
+		}
+		var removed []*memoryEntry
+		removed, err = d.store(key, versions[i], 0)
+		evicted = append(evicted, removed...)
+		if err != nil {
+			break
+		}
+	}
+	onEvict := d.onEvict
+	d.mu.Unlock()
+
+	notifyEvicted(onEvict, evicted)
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// FlushTags làm mất hiệu lực các entry gắn với tags bằng cách đổi version của từng tag.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần làm mất hiệu lực
+//
+// Returns:
+//   - error: Lỗi nếu không thể tạo version mới hoặc version vượt MaxBytes
+func (d *memoryDriver) FlushTags(ctx context.Context, tags []string) error {
+	d.mu.Lock()
+	var evicted []*memoryEntry
+	var err error
+	for _, tag := range tags {
+		var version string
+		if version, err = newTagVersion(); err != nil {
+			break
+		}
+		var removed []*memoryEntry
+		removed, err = d.store(tagVersionKey(tag), version, 0)
+		evicted = append(evicted, removed...)
+		if err != nil {
+			break
+		}
+	}
+	onEvict := d.onEvict
+	d.mu.Unlock()
+
+	notifyEvicted(onEvict, evicted)
+	return err
+}
+
+// WithSizer thay hàm ước lượng kích thước item.
+//
+// Params:
+//   - sizer: Hàm ước lượng kích thước, nil để tắt việc tính kích thước (chỉ khi MaxBytes = 0)
+//
+// Returns:
+//   - MemoryDriver: Chính driver để gọi nối tiếp
+func (d *memoryDriver) WithSizer(sizer Sizer) MemoryDriver {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if sizer == nil && d.maxBytes > 0 {
+		sizer = DefaultSizer
+	}
+	d.sizer = sizer
+	return d
+}
+
+// OnEvict đăng ký callback được gọi khi item bị loại bỏ do vượt giới hạn dung lượng.
+//
+// Params:
+//   - callback: Hàm nhận key và giá trị bị loại bỏ, nil để hủy đăng ký
+//
+// Returns:
+//   - MemoryDriver: Chính driver để gọi nối tiếp
+func (d *memoryDriver) OnEvict(callback EvictCallback) MemoryDriver {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onEvict = callback
+	return d
+}
+
 // Close giải phóng tài nguyên của driver.
 //
 // Phương thức này dừng goroutine janitor nếu đang chạy và giải phóng
@@ -366,14 +783,151 @@ func (d *memoryDriver) startJanitor() {
 // Phương thức này quét qua tất cả các item trong cache map,
 // kiểm tra thời gian hết hạn và xóa những item đã quá hạn.
 func (d *memoryDriver) deleteExpired() {
-	now := time.Now().UnixNano()
-
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	for k, v := range d.items {
-		if v.Expiration > 0 && now > v.Expiration {
-			delete(d.items, k)
+	d.removeExpired()
+}
+
+// removeExpired xóa các entry đã hết hạn khỏi map và hàng đợi loại bỏ. Phải được gọi khi giữ d.mu.
+func (d *memoryDriver) removeExpired() {
+	now := time.Now().UnixNano()
+	for _, entry := range d.items {
+		if entry.item.Expiration > 0 && now > entry.item.Expiration {
+			d.removeEntry(entry)
+		}
+	}
+}
+
+// expiration tính thời điểm hết hạn (UnixNano) từ ttl, 0 nếu không hết hạn.
+func (d *memoryDriver) expiration(ttl time.Duration) int64 {
+	if ttl == 0 {
+		if d.defaultExpiration > 0 {
+			return time.Now().Add(d.defaultExpiration).UnixNano()
 		}
+	} else if ttl > 0 {
+		return time.Now().Add(ttl).UnixNano()
 	}
+	return 0
 }
+
+// live trả về entry còn hạn của key, xóa entry nếu đã hết hạn. Phải được gọi khi giữ d.mu.
+func (d *memoryDriver) live(key string) (*memoryEntry, bool) {
+	entry, found := d.items[key]
+	if !found {
+		return nil, false
+	}
+	if entry.item.Expired() {
+		d.removeEntry(entry)
+		return nil, false
+	}
+	return entry, true
+}
+
+// store lưu giá trị cho key, loại bỏ các item khác nếu vượt giới hạn dung lượng.
+// Phải được gọi khi giữ d.mu.
+//
+// Returns:
+//   - []*memoryEntry: Các entry đã bị loại bỏ, dùng để gọi callback sau khi nhả khóa
+//   - error: ErrItemTooLarge nếu riêng item đã vượt MaxBytes
+func (d *memoryDriver) store(key string, value interface{}, exp int64) ([]*memoryEntry, error) {
+	var size int64
+	if d.sizer != nil {
+		size = d.sizer(key, value)
+	}
+	if d.maxBytes > 0 && size > d.maxBytes {
+		return nil, fmt.Errorf("%w: %q is %d bytes, limit is %d", ErrItemTooLarge, key, size, d.maxBytes)
+	}
+
+	// Tách item cũ ra để không tự loại bỏ chính key đang được ghi
+	entry, found := d.items[key]
+	if found {
+		d.removeEntry(entry)
+		d.touch(entry)
+	} else {
+		d.sequence++
+		entry = &memoryEntry{key: key, frequency: 1, sequence: d.sequence}
+	}
+
+	evicted := d.evict(size)
+
+	entry.item = Item{
+		Value:      value,
+		Expiration: exp,
+	}
+	entry.size = size
+	d.items[key] = entry
+	heap.Push(&d.queue, entry)
+	d.bytes += size
+	return evicted, nil
+}
+
+// notifyEvicted gọi callback cho các entry đã bị loại bỏ. Phải được gọi sau khi nhả d.mu.
+func notifyEvicted(onEvict EvictCallback, evicted []*memoryEntry) {
+	if onEvict == nil {
+		return
+	}
+	for _, victim := range evicted {
+		// Version của tag là dữ liệu nội bộ, không báo cho người dùng
+		if strings.HasPrefix(victim.key, tagVersionKeyPrefix) {
+			continue
+		}
+		onEvict(victim.key, victim.item.Value)
+	}
+}
+
+// touch cập nhật thông tin truy cập của entry theo chính sách loại bỏ.
+// Phải được gọi khi giữ d.mu; người gọi chịu trách nhiệm cập nhật lại vị trí trong heap.
+func (d *memoryDriver) touch(entry *memoryEntry) {
+	entry.frequency++
+	if d.policy != EvictionPolicyFIFO {
+		d.sequence++
+		entry.sequence = d.sequence
+	}
+}
+
+// removeEntry xóa entry khỏi map và hàng đợi loại bỏ. Phải được gọi khi giữ d.mu.
+func (d *memoryDriver) removeEntry(entry *memoryEntry) {
+	if entry.index >= 0 {
+		heap.Remove(&d.queue, entry.index)
+	}
+	delete(d.items, entry.key)
+	d.bytes -= entry.size
+}
+
+// evict loại bỏ item theo chính sách cho đến khi còn chỗ cho một item mới có kích thước size.
+// Các item đã hết hạn được xóa trước khi loại bỏ item còn hạn.
+// Phải được gọi khi giữ d.mu.
+//
+// Returns:
+//   - []*memoryEntry: Các entry đã bị loại bỏ, dùng để gọi callback sau khi nhả khóa
+func (d *memoryDriver) evict(size int64) []*memoryEntry {
+	var evicted []*memoryEntry
+	purged := false
+	for d.queue.Len() > 0 {
+		overItems := d.maxItems > 0 && len(d.items) >= d.maxItems
+		overBytes := d.maxBytes > 0 && d.bytes+size > d.maxBytes
+		if !overItems && !overBytes {
+			break
+		}
+
+		// Item đã hết hạn được xóa trước, không tính là bị loại bỏ và không gọi OnEvict
+		if !purged {
+			purged = true
+			d.removeExpired()
+			continue
+		}
+
+		victim := d.queue.entries[0]
+		d.removeEntry(victim)
+		d.evictions++
+		evicted = append(evicted, victim)
+	}
+	return evicted
+}
+
+var (
+	// ErrItemTooLarge được trả về khi riêng một item đã vượt giới hạn MaxBytes của memory driver
+	// hoặc MaxSize của file driver
+	ErrItemTooLarge = errors.New("cache: item exceeds driver size limit")
+)
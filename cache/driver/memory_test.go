@@ -3,6 +3,7 @@ package driver_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -298,6 +299,124 @@ func TestMemoryDriverIntegration(t *testing.T) {
 		_, found := quickDriver.Get(ctx, key)
 		assert.False(t, found)
 	})
+
+	t.Run("Tag Versions", func(t *testing.T) {
+		versions, err := memoryDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.Len(t, versions, 2)
+		assert.NotEqual(t, versions[0], versions[1])
+
+		// Version ổn định giữa các lần gọi và theo đúng thứ tự tag
+		again, err := memoryDriver.TagVersions(ctx, []string{"users", "tenant:7"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{versions[1], versions[0]}, again)
+
+		// FlushTags chỉ đổi version của tag được flush
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"tenant:7"}))
+		flushed, err := memoryDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, versions[0], flushed[0])
+		assert.Equal(t, versions[1], flushed[1])
+	})
+
+	t.Run("Atomic Operations", func(t *testing.T) {
+		// Increment khởi tạo key chưa tồn tại bằng delta
+		value, err := memoryDriver.Increment(ctx, "atomic:counter", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = memoryDriver.Increment(ctx, "atomic:counter", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), value)
+
+		value, err = memoryDriver.Decrement(ctx, "atomic:counter", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-2), value)
+
+		// Increment trên giá trị số đã ghi bằng Set
+		assert.NoError(t, memoryDriver.Set(ctx, "atomic:set", 40, time.Minute))
+		value, err = memoryDriver.Increment(ctx, "atomic:set", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), value)
+
+		assert.NoError(t, memoryDriver.Set(ctx, "atomic:text", "alice", time.Minute))
+		_, err = memoryDriver.Increment(ctx, "atomic:text", 1)
+		assert.ErrorIs(t, err, driver.ErrNotInteger)
+
+		// Add chỉ ghi khi key chưa tồn tại
+		added, err := memoryDriver.Add(ctx, "atomic:once", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		added, err = memoryDriver.Add(ctx, "atomic:once", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		result, found := memoryDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "first", result)
+
+		// CompareAndSwap chỉ ghi khi giá trị hiện tại khớp
+		swapped, err := memoryDriver.CompareAndSwap(ctx, "atomic:once", "other", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+		swapped, err = memoryDriver.CompareAndSwap(ctx, "atomic:once", "first", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		result, found = memoryDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "third", result)
+
+		swapped, err = memoryDriver.CompareAndSwap(ctx, "atomic:missing", nil, "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+
+		// Add và Increment coi key đã hết hạn như chưa tồn tại
+		assert.NoError(t, memoryDriver.Set(ctx, "atomic:expired", "old", 50*time.Millisecond))
+		assert.NoError(t, memoryDriver.Set(ctx, "atomic:expired_counter", 100, 50*time.Millisecond))
+		time.Sleep(100 * time.Millisecond)
+
+		added, err = memoryDriver.Add(ctx, "atomic:expired", "new", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		value, err = memoryDriver.Increment(ctx, "atomic:expired_counter", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), value)
+	})
+
+	t.Run("GetInto", func(t *testing.T) {
+		type profile struct {
+			Name string
+			Age  int
+		}
+		assert.NoError(t, memoryDriver.Set(ctx, "typed:profile", profile{Name: "An", Age: 30}, time.Minute))
+
+		var result profile
+		found, err := memoryDriver.GetInto(ctx, "typed:profile", &result)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "An", Age: 30}, result)
+
+		// Số được chuyển kiểu khi không mất giá trị
+		assert.NoError(t, memoryDriver.Set(ctx, "typed:number", 42, time.Minute))
+		var number int64
+		found, err = memoryDriver.GetInto(ctx, "typed:number", &number)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, int64(42), number)
+
+		var text string
+		found, err = memoryDriver.GetInto(ctx, "typed:profile", &text)
+		assert.True(t, found)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+
+		found, err = memoryDriver.GetInto(ctx, "typed:missing", &result)
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		_, err = memoryDriver.GetInto(ctx, "typed:profile", result)
+		assert.ErrorIs(t, err, driver.ErrInvalidDestination)
+	})
 }
 
 func TestMemoryDriverMocked(t *testing.T) {
@@ -402,6 +521,214 @@ func TestMemoryDriverTestSuite(t *testing.T) {
 	suite.Run(t, new(MemoryDriverTestSuite))
 }
 
+func TestMemoryDriverEviction(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func(t *testing.T, policy string, maxItems int) driver.MemoryDriver {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL:     300,
+			MaxItems:       maxItems,
+			EvictionPolicy: policy,
+		})
+		t.Cleanup(func() { memoryDriver.Close() })
+		return memoryDriver
+	}
+
+	t.Run("LRU evicts least recently used", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionPolicyLRU, 2)
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 1, 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "b", 2, 0))
+		_, found := memoryDriver.Get(ctx, "a")
+		assert.True(t, found)
+		assert.NoError(t, memoryDriver.Set(ctx, "c", 3, 0))
+
+		assert.True(t, memoryDriver.Has(ctx, "a"))
+		assert.False(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+	})
+
+	t.Run("LFU evicts least frequently used", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionPolicyLFU, 2)
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 1, 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "b", 2, 0))
+		for i := 0; i < 3; i++ {
+			memoryDriver.Get(ctx, "a")
+		}
+		memoryDriver.Get(ctx, "b")
+		assert.NoError(t, memoryDriver.Set(ctx, "c", 3, 0))
+
+		assert.True(t, memoryDriver.Has(ctx, "a"))
+		assert.False(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+	})
+
+	t.Run("FIFO evicts oldest inserted", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionPolicyFIFO, 2)
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 1, 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "b", 2, 0))
+		memoryDriver.Get(ctx, "a")
+		assert.NoError(t, memoryDriver.Set(ctx, "c", 3, 0))
+
+		assert.False(t, memoryDriver.Has(ctx, "a"))
+		assert.True(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+	})
+
+	t.Run("Tag versions count against MaxItems", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionPolicyLRU, 3)
+
+		var evicted []string
+		memoryDriver.OnEvict(func(key string, value interface{}) {
+			evicted = append(evicted, key)
+		})
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 1, 0))
+		for i := 0; i < 10; i++ {
+			_, err := memoryDriver.TagVersions(ctx, []string{fmt.Sprintf("tenant:%d", i)})
+			assert.NoError(t, err)
+		}
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, 3, stats["count"], "tag versions should be bounded by MaxItems")
+		assert.False(t, memoryDriver.Has(ctx, "a"))
+		assert.Equal(t, []string{"a"}, evicted, "evicted tag versions should not be reported to OnEvict")
+
+		// Tag bị loại bỏ nhận version mới, giống như bị flush
+		first, err := memoryDriver.TagVersions(ctx, []string{"tenant:9"})
+		assert.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			_, err := memoryDriver.TagVersions(ctx, []string{fmt.Sprintf("other:%d", i)})
+			assert.NoError(t, err)
+		}
+		again, err := memoryDriver.TagVersions(ctx, []string{"tenant:9"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, again)
+	})
+
+	t.Run("Overwrite does not evict", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionPolicyLRU, 2)
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 1, 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "b", 2, 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 10, 0))
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, 2, stats["count"])
+		assert.Equal(t, int64(0), stats["evictions"])
+	})
+
+	t.Run("Expired items are removed before evicting", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionPolicyLRU, 2)
+
+		var evicted []string
+		memoryDriver.OnEvict(func(key string, value interface{}) {
+			evicted = append(evicted, key)
+		})
+
+		assert.NoError(t, memoryDriver.Set(ctx, "old", 1, 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "short", 2, time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+		assert.NoError(t, memoryDriver.Set(ctx, "new", 3, 0))
+
+		assert.True(t, memoryDriver.Has(ctx, "old"), "live item should not be evicted while an expired one exists")
+		assert.True(t, memoryDriver.Has(ctx, "new"))
+		assert.Empty(t, evicted, "expired items should not be reported to OnEvict")
+		assert.Equal(t, int64(0), memoryDriver.Stats(ctx)["evictions"])
+	})
+
+	t.Run("OnEvict callback and stats", func(t *testing.T) {
+		memoryDriver := newDriver(t, "", 2)
+
+		var evicted []string
+		memoryDriver.OnEvict(func(key string, value interface{}) {
+			evicted = append(evicted, fmt.Sprintf("%s=%v", key, value))
+		})
+
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, memoryDriver.Set(ctx, fmt.Sprintf("key%d", i), i, 0))
+		}
+
+		assert.Equal(t, []string{"key0=0", "key1=1", "key2=2"}, evicted)
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, 2, stats["count"])
+		assert.Equal(t, int64(3), stats["evictions"])
+		assert.Equal(t, "lru", stats["policy"])
+	})
+
+	t.Run("MaxBytes with sizer", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL: 300,
+			MaxBytes:   10,
+		})
+		defer memoryDriver.Close()
+		memoryDriver.WithSizer(func(key string, value interface{}) int64 {
+			return int64(len(value.(string)))
+		})
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", "aaaa", 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "b", "bbbb", 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "c", "cccc", 0))
+
+		assert.False(t, memoryDriver.Has(ctx, "a"))
+		assert.True(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+		assert.Equal(t, int64(8), memoryDriver.Stats(ctx)["bytes"])
+
+		err := memoryDriver.Set(ctx, "d", "ddddddddddd", 0)
+		assert.ErrorIs(t, err, driver.ErrItemTooLarge)
+		assert.False(t, memoryDriver.Has(ctx, "d"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+	})
+
+	t.Run("Delete and Flush release bytes", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL: 300,
+			MaxBytes:   1024,
+		})
+		defer memoryDriver.Close()
+
+		assert.NoError(t, memoryDriver.Set(ctx, "a", "value", 0))
+		assert.Equal(t, driver.DefaultSizer("a", "value"), memoryDriver.Stats(ctx)["bytes"])
+
+		assert.NoError(t, memoryDriver.Delete(ctx, "a"))
+		assert.Equal(t, int64(0), memoryDriver.Stats(ctx)["bytes"])
+
+		assert.NoError(t, memoryDriver.Set(ctx, "b", map[string]int{"x": 1}, 0))
+		assert.NoError(t, memoryDriver.Flush(ctx))
+		assert.Equal(t, int64(0), memoryDriver.Stats(ctx)["bytes"])
+	})
+
+	t.Run("FlushPrefix removes only matching keys", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL: 300,
+			MaxBytes:   1024,
+		})
+		defer memoryDriver.Close()
+
+		assert.NoError(t, memoryDriver.Set(ctx, "users:1", "alice", 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "users:2", "bob", 0))
+		assert.NoError(t, memoryDriver.Set(ctx, "orders:1", "order", 0))
+
+		assert.NoError(t, memoryDriver.FlushPrefix(ctx, "users:"))
+
+		assert.False(t, memoryDriver.Has(ctx, "users:1"))
+		assert.False(t, memoryDriver.Has(ctx, "users:2"))
+		assert.True(t, memoryDriver.Has(ctx, "orders:1"))
+		assert.Equal(t, 1, memoryDriver.Stats(ctx)["count"])
+		assert.Equal(t, driver.DefaultSizer("orders:1", "order"), memoryDriver.Stats(ctx)["bytes"])
+	})
+}
+
+func TestDefaultSizer(t *testing.T) {
+	assert.Equal(t, int64(4), driver.DefaultSizer("ab", "cd"))
+	assert.Equal(t, int64(5), driver.DefaultSizer("ab", []byte("cde")))
+	assert.Equal(t, int64(9), driver.DefaultSizer("ab", map[string]int{"a": 1}))
+	assert.Equal(t, int64(2), driver.DefaultSizer("ab", nil))
+}
+
 func TestMemoryDriverConcurrency(t *testing.T) {
 	ctx := context.Background()
 	memoryConfig := config.DriverMemoryConfig{
@@ -449,6 +776,25 @@ func TestMemoryDriverConcurrency(t *testing.T) {
 		stats := memoryDriver.Stats(ctx)
 		assert.Greater(t, stats["count"], 0)
 	})
+
+	t.Run("Concurrent Increment", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					_, err := memoryDriver.Increment(ctx, "concurrent:counter", 1)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		value, err := memoryDriver.Increment(ctx, "concurrent:counter", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(200), value)
+	})
 }
 
 func BenchmarkMemoryDriver(b *testing.B) {
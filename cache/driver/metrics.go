@@ -0,0 +1,136 @@
+package driver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics là Observer tổng hợp số lần hit, miss, ghi, xóa, lỗi và độ trễ theo từng driver.
+//
+// Metrics an toàn khi dùng đồng thời và có thể gắn vào cache.Manager bằng AddObserver.
+type Metrics struct {
+	drivers sync.Map // Map tên driver tới *driverMetrics
+}
+
+// MetricsSnapshot là ảnh chụp các chỉ số của một driver tại một thời điểm.
+type MetricsSnapshot struct {
+	Hits         int64         // Số lần key được tìm thấy
+	Misses       int64         // Số lần key không tìm thấy
+	Writes       int64         // Số lần ghi giá trị
+	Deletes      int64         // Số lần xóa key hoặc làm trống cache
+	Errors       int64         // Số thao tác trả về lỗi
+	Operations   int64         // Tổng số sự kiện đã ghi nhận
+	TotalLatency time.Duration // Tổng thời gian thực thi của các sự kiện
+}
+
+// driverMetrics chứa các bộ đếm của một driver, cập nhật bằng sync/atomic.
+type driverMetrics struct {
+	hits         int64
+	misses       int64
+	writes       int64
+	deletes      int64
+	errors       int64
+	operations   int64
+	totalLatency int64
+}
+
+// NewMetrics tạo một Metrics observer mới.
+//
+// Returns:
+//   - *Metrics: Observer chưa ghi nhận sự kiện nào
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// OnHit ghi nhận một lần hit.
+func (m *Metrics) OnHit(_ context.Context, event Event) {
+	m.record(event, func(d *driverMetrics) *int64 { return &d.hits })
+}
+
+// OnMiss ghi nhận một lần miss.
+func (m *Metrics) OnMiss(_ context.Context, event Event) {
+	m.record(event, func(d *driverMetrics) *int64 { return &d.misses })
+}
+
+// OnWrite ghi nhận một lần ghi.
+func (m *Metrics) OnWrite(_ context.Context, event Event) {
+	m.record(event, func(d *driverMetrics) *int64 { return &d.writes })
+}
+
+// OnDelete ghi nhận một lần xóa.
+func (m *Metrics) OnDelete(_ context.Context, event Event) {
+	m.record(event, func(d *driverMetrics) *int64 { return &d.deletes })
+}
+
+// OnError ghi nhận một thao tác lỗi.
+func (m *Metrics) OnError(_ context.Context, event Event, _ error) {
+	m.record(event, func(d *driverMetrics) *int64 { return &d.errors })
+}
+
+// Snapshot trả về chỉ số hiện tại của từng driver.
+//
+// Returns:
+//   - map[string]MetricsSnapshot: Map với key là tên driver
+func (m *Metrics) Snapshot() map[string]MetricsSnapshot {
+	snapshots := make(map[string]MetricsSnapshot)
+	m.drivers.Range(func(name, value interface{}) bool {
+		d := value.(*driverMetrics)
+		snapshots[name.(string)] = MetricsSnapshot{
+			Hits:         atomic.LoadInt64(&d.hits),
+			Misses:       atomic.LoadInt64(&d.misses),
+			Writes:       atomic.LoadInt64(&d.writes),
+			Deletes:      atomic.LoadInt64(&d.deletes),
+			Errors:       atomic.LoadInt64(&d.errors),
+			Operations:   atomic.LoadInt64(&d.operations),
+			TotalLatency: time.Duration(atomic.LoadInt64(&d.totalLatency)),
+		}
+		return true
+	})
+	return snapshots
+}
+
+// Reset xóa mọi chỉ số đã ghi nhận.
+func (m *Metrics) Reset() {
+	m.drivers.Range(func(name, _ interface{}) bool {
+		m.drivers.Delete(name)
+		return true
+	})
+}
+
+// HitRatio trả về tỷ lệ hit trên tổng số hit và miss, 0 nếu chưa có lần đọc nào.
+func (s MetricsSnapshot) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// ErrorRate trả về tỷ lệ sự kiện lỗi trên tổng số sự kiện, 0 nếu chưa có sự kiện nào.
+func (s MetricsSnapshot) ErrorRate() float64 {
+	if s.Operations == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Operations)
+}
+
+// AverageLatency trả về độ trễ trung bình của các sự kiện, 0 nếu chưa có sự kiện nào.
+func (s MetricsSnapshot) AverageLatency() time.Duration {
+	if s.Operations == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Operations)
+}
+
+// record tăng bộ đếm được chọn bởi counter và cộng độ trễ của event cho driver của event.
+func (m *Metrics) record(event Event, counter func(*driverMetrics) *int64) {
+	value, ok := m.drivers.Load(event.Driver)
+	if !ok {
+		value, _ = m.drivers.LoadOrStore(event.Driver, &driverMetrics{})
+	}
+	d := value.(*driverMetrics)
+	atomic.AddInt64(counter(d), 1)
+	atomic.AddInt64(&d.operations, 1)
+	atomic.AddInt64(&d.totalLatency, int64(event.Duration))
+}
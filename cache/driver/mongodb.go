@@ -1,12 +1,19 @@
 package driver
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.fork.vn/providers/cache/config"
 	"go.fork.vn/providers/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -22,8 +29,18 @@ type MongoCacheItem struct {
 	CreatedAt  time.Time   `bson:"created_at"` // Thời điểm tạo cache item
 }
 
+// mongoEnvelope bọc giá trị để mã hóa thành BSON trước khi áp dụng transformer.
+type mongoEnvelope struct {
+	Value interface{} `bson:"v"`
+}
+
+// mongoTypeMismatchCode là mã lỗi TypeMismatch của MongoDB, trả về khi $inc trên giá trị không phải số.
+const mongoTypeMismatchCode = 14
+
 type MongoDBDriver interface {
 	Driver
+	Taggable
+	PrefixFlusher
 	// ensureIndexes tạo các index cần thiết cho MongoDB collection.
 	ensureIndexes(ctx context.Context) error
 }
@@ -35,10 +52,12 @@ type MongoDBDriver interface {
 // và cần tìm kiếm trong dữ liệu cache. MongoDB TTL index được sử dụng để tự động
 // xóa các document đã hết hạn.
 type mongoDBDriver struct {
-	mongodb    *mongodb.Manager // Service Provider mongoDB manager
-	config     config.DriverMongodbConfig
-	database   *mongo.Database   // MongoDB database để lưu trữ cache
-	collection *mongo.Collection // MongoDB collection để lưu trữ cache
+	mongodb     *mongodb.Manager // Service Provider mongoDB manager
+	config      config.DriverMongodbConfig
+	database    *mongo.Database   // MongoDB database để lưu trữ cache
+	collection  *mongo.Collection // MongoDB collection để lưu trữ cache
+	transformer Transformer       // Nén và mã hóa giá trị (nil nếu không dùng)
+	flights     flightGroup       // Gộp các lời gọi Remember đồng thời cho cùng key
 }
 
 // NewMongoDBDriver tạo một MongoDB driver mới với cấu hình mặc định.
@@ -52,13 +71,19 @@ type mongoDBDriver struct {
 //
 // Returns:
 //   - *MongoDBDriver: Driver đã được khởi tạo
-//   - error: Lỗi nếu không thể kết nối đến MongoDB hoặc tạo indices
+//   - error: Lỗi nếu cấu hình transform không hợp lệ, không thể kết nối đến MongoDB hoặc tạo indices
 func NewMongoDBDriver(cfg config.DriverMongodbConfig, manager mongodb.Manager) (MongoDBDriver, error) {
+	transformer, err := NewTransformer(cfg.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mongodb transform config: %w", err)
+	}
+
 	driver := &mongoDBDriver{
-		mongodb:    &manager,
-		config:     cfg,
-		database:   manager.DatabaseWithName(cfg.Database),
-		collection: manager.DatabaseWithName(cfg.Database).Collection(cfg.Collection),
+		mongodb:     &manager,
+		config:      cfg,
+		database:    manager.DatabaseWithName(cfg.Database),
+		collection:  manager.DatabaseWithName(cfg.Database).Collection(cfg.Collection),
+		transformer: transformer,
 	}
 
 	// Tạo indices cần thiết
@@ -136,12 +161,15 @@ func (d *mongoDBDriver) ensureIndexes(ctx context.Context) error {
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
 //   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 func (d *mongoDBDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
 	var cacheItem MongoCacheItem
-	err := d.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&cacheItem)
+	err := d.collection.FindOne(ctx, bson.M{"_id": d.prefixKey(key)}).Decode(&cacheItem)
 
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			d.config.Misses++
+			atomic.AddInt64(&d.config.Misses, 1)
 			return nil, false
 		}
 		return nil, false
@@ -150,13 +178,69 @@ func (d *mongoDBDriver) Get(ctx context.Context, key string) (interface{}, bool)
 	// Kiểm tra expiration (TTL index sẽ tự động xóa expired documents,
 	// nhưng chúng ta vẫn kiểm tra để đảm bảo tính nhất quán)
 	if cacheItem.Expiration > 0 && time.Now().UnixNano() > cacheItem.Expiration {
-		d.config.Misses++
+		atomic.AddInt64(&d.config.Misses, 1)
 		// TTL index sẽ tự động xóa, không cần xóa thủ công
 		return nil, false
 	}
 
-	d.config.Hits++
-	return cacheItem.Value, true
+	value, err := d.loadValue(cacheItem.Value)
+	if err != nil {
+		atomic.AddInt64(&d.config.Misses, 1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&d.config.Hits, 1)
+	return value, true
+}
+
+// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào dest.
+//
+// Trường value của document được đọc dưới dạng BSON thô rồi giải mã một lần vào kiểu của dest,
+// nên struct được trả về đúng kiểu thay vì bson.D.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy
+//   - dest: Con trỏ khác nil tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi nếu dest không hợp lệ, không thể đọc MongoDB hoặc không thể giải mã vào dest
+func (d *mongoDBDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	if _, err := destinationOf(dest); err != nil {
+		return false, err
+	}
+
+	var cacheItem struct {
+		Value      bson.RawValue `bson:"value"`
+		Expiration int64         `bson:"expiration"`
+	}
+	err := d.collection.FindOne(ctx, bson.M{"_id": d.prefixKey(key)}).Decode(&cacheItem)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			atomic.AddInt64(&d.config.Misses, 1)
+			return false, nil
+		}
+		return false, err
+	}
+
+	if cacheItem.Expiration > 0 && time.Now().UnixNano() > cacheItem.Expiration {
+		atomic.AddInt64(&d.config.Misses, 1)
+		return false, nil
+	}
+
+	atomic.AddInt64(&d.config.Hits, 1)
+	raw, err := d.loadRawValue(cacheItem.Value)
+	if err != nil {
+		return true, fmt.Errorf("could not decode cached value: %w", err)
+	}
+	if err := raw.Unmarshal(dest); err != nil {
+		return true, fmt.Errorf("could not decode cached value: %w", err)
+	}
+	return true, nil
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -174,22 +258,20 @@ func (d *mongoDBDriver) Get(ctx context.Context, key string) (interface{}, bool)
 // Returns:
 //   - error: Lỗi nếu có trong quá trình lưu trữ vào MongoDB
 func (d *mongoDBDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	var exp int64
-	now := time.Now()
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
 
-	if ttl == 0 {
-		if d.config.GetDefaultExpiration() > 0 {
-			exp = now.Add(d.config.GetDefaultExpiration()).UnixNano()
-		}
-	} else if ttl > 0 {
-		exp = now.Add(ttl).UnixNano()
+	now := time.Now()
+	stored, err := d.storeValue(value)
+	if err != nil {
+		return err
 	}
 
 	// Tạo cache item
 	cacheItem := MongoCacheItem{
-		Key:        key,
-		Value:      value,
-		Expiration: exp,
+		Key:        d.prefixKey(key),
+		Value:      stored,
+		Expiration: d.expiration(now, ttl),
 		CreatedAt:  now,
 	}
 
@@ -198,9 +280,9 @@ func (d *mongoDBDriver) Set(ctx context.Context, key string, value interface{},
 	opts.SetUpsert(true)
 
 	// Lưu vào MongoDB
-	_, err := d.collection.ReplaceOne(
+	_, err = d.collection.ReplaceOne(
 		ctx,
-		bson.M{"_id": key},
+		bson.M{"_id": d.prefixKey(key)},
 		cacheItem,
 		&opts,
 	)
@@ -220,6 +302,9 @@ func (d *mongoDBDriver) Set(ctx context.Context, key string, value interface{},
 // Returns:
 //   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
 func (d *mongoDBDriver) Has(ctx context.Context, key string) bool {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
 	_, exists := d.Get(ctx, key)
 	return exists
 }
@@ -236,14 +321,18 @@ func (d *mongoDBDriver) Has(ctx context.Context, key string) bool {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa
 func (d *mongoDBDriver) Delete(ctx context.Context, key string) error {
-	_, err := d.collection.DeleteOne(ctx, bson.M{"_id": key})
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	_, err := d.collection.DeleteOne(ctx, bson.M{"_id": d.prefixKey(key)})
 	return err
 }
 
 // Flush xóa tất cả các key khỏi cache.
 //
-// Phương thức này xóa tất cả documents trong MongoDB collection,
-// làm trống hoàn toàn bộ nhớ cache.
+// Phương thức này xóa tất cả documents có _id bắt đầu bằng prefix đã cấu hình
+// (toàn bộ collection nếu prefix rỗng), nên các ứng dụng dùng chung collection
+// với prefix khác nhau không xóa key của nhau.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -251,7 +340,26 @@ func (d *mongoDBDriver) Delete(ctx context.Context, key string) error {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa
 func (d *mongoDBDriver) Flush(ctx context.Context) error {
-	_, err := d.collection.DeleteMany(ctx, bson.M{})
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	_, err := d.collection.DeleteMany(ctx, d.prefixFilter(""))
+	return err
+}
+
+// FlushPrefix xóa tất cả các key bắt đầu bằng prefix khỏi cache.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của các key cần xóa (không gồm prefix của driver)
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa
+func (d *mongoDBDriver) FlushPrefix(ctx context.Context, prefix string) error {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	_, err := d.collection.DeleteMany(ctx, d.prefixFilter(prefix))
 	return err
 }
 
@@ -268,11 +376,18 @@ func (d *mongoDBDriver) Flush(ctx context.Context) error {
 //   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
 //   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
 func (d *mongoDBDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
 	results := make(map[string]interface{})
 	missed := make([]string, 0)
 
 	// Tạo filter cho nhiều key
-	filter := bson.M{"_id": bson.M{"$in": keys}}
+	prefixedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		prefixedKeys[i] = d.prefixKey(key)
+	}
+	filter := bson.M{"_id": bson.M{"$in": prefixedKeys}}
 
 	// Tìm tất cả các document khớp với filter
 	cursor, err := d.collection.Find(ctx, filter)
@@ -292,14 +407,21 @@ func (d *mongoDBDriver) GetMultiple(ctx context.Context, keys []string) (map[str
 			continue
 		}
 
+		key := strings.TrimPrefix(cacheItem.Key, d.config.Prefix)
+
 		// Kiểm tra expiration
 		if cacheItem.Expiration > 0 && now > cacheItem.Expiration {
-			missed = append(missed, cacheItem.Key)
+			missed = append(missed, key)
+			continue
+		}
+
+		value, err := d.loadValue(cacheItem.Value)
+		if err != nil {
 			continue
 		}
 
-		results[cacheItem.Key] = cacheItem.Value
-		found[cacheItem.Key] = true
+		results[key] = value
+		found[key] = true
 	}
 
 	// Thêm các key không tìm thấy vào danh sách missed
@@ -325,6 +447,9 @@ func (d *mongoDBDriver) GetMultiple(ctx context.Context, keys []string) (map[str
 // Returns:
 //   - error: Lỗi nếu có trong quá trình lưu trữ
 func (d *mongoDBDriver) SetMultiple(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
 	if ttl == 0 {
 		ttl = d.config.GetDefaultExpiration()
 	}
@@ -335,15 +460,19 @@ func (d *mongoDBDriver) SetMultiple(ctx context.Context, values map[string]inter
 	var operations []mongo.WriteModel
 
 	for key, value := range values {
+		stored, err := d.storeValue(value)
+		if err != nil {
+			return fmt.Errorf("could not store value for key '%s': %w", key, err)
+		}
 		cacheItem := MongoCacheItem{
-			Key:        key,
-			Value:      value,
+			Key:        d.prefixKey(key),
+			Value:      stored,
 			Expiration: exp,
 			CreatedAt:  now,
 		}
 
 		operation := mongo.NewReplaceOneModel().
-			SetFilter(bson.M{"_id": key}).
+			SetFilter(bson.M{"_id": d.prefixKey(key)}).
 			SetReplacement(cacheItem).
 			SetUpsert(true)
 
@@ -367,20 +496,26 @@ func (d *mongoDBDriver) SetMultiple(ctx context.Context, values map[string]inter
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa
 func (d *mongoDBDriver) DeleteMultiple(ctx context.Context, keys []string) error {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
 	if len(keys) == 0 {
 		return nil
 	}
 
 	// Xóa tất cả các document với key trong danh sách
-	_, err := d.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": keys}})
+	prefixedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		prefixedKeys[i] = d.prefixKey(key)
+	}
+	_, err := d.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": prefixedKeys}})
 	return err
 }
 
 // Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
 //
-// Phương thức này kiểm tra xem một key có tồn tại trong cache không, nếu có thì
-// trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
-// sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
+// Các lời gọi đồng thời cho cùng một key chỉ thực thi callback một lần, các lời gọi còn lại
+// chờ và nhận cùng kết quả. Tương đương RememberWithOptions với RememberOptions{TTL: ttl}.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -392,21 +527,22 @@ func (d *mongoDBDriver) DeleteMultiple(ctx context.Context, keys []string) error
 //   - interface{}: Giá trị từ cache hoặc từ callback
 //   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
 func (d *mongoDBDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	// Kiểm tra cache trước
-	value, found := d.Get(ctx, key)
-	if found {
-		return value, nil
-	}
-
-	// Không tìm thấy, gọi callback
-	value, err := callback()
-	if err != nil {
-		return nil, err
-	}
+	return d.RememberWithOptions(ctx, key, callback, RememberOptions{TTL: ttl})
+}
 
-	// Lưu kết quả vào cache
-	err = d.Set(ctx, key, value, ttl)
-	return value, err
+// RememberWithOptions lấy một giá trị từ cache hoặc thực thi callback, có chống cache stampede.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+//   - opts: TTL và các tùy chọn singleflight, khóa phân tán, XFetch, stale-while-revalidate
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *mongoDBDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	return rememberWithOptions(ctx, d, &d.flights, d.config.GetDefaultExpiration(), key, callback, opts)
 }
 
 // Stats trả về thông tin thống kê về cache.
@@ -420,8 +556,11 @@ func (d *mongoDBDriver) Remember(ctx context.Context, key string, ttl time.Durat
 // Returns:
 //   - map[string]interface{}: Map chứa các thông tin thống kê
 func (d *mongoDBDriver) Stats(ctx context.Context) map[string]interface{} {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
 	// Đếm số lượng document
-	count, err := d.collection.CountDocuments(ctx, bson.M{})
+	count, err := d.collection.CountDocuments(ctx, d.prefixFilter(""))
 	if err != nil {
 		count = -1
 	}
@@ -436,13 +575,493 @@ func (d *mongoDBDriver) Stats(ctx context.Context) map[string]interface{} {
 
 	return map[string]interface{}{
 		"count":  count,
-		"hits":   d.config.Hits,
-		"misses": d.config.Misses,
+		"hits":   atomic.LoadInt64(&d.config.Hits),
+		"misses": atomic.LoadInt64(&d.config.Misses),
 		"type":   "mongodb",
 		"stats":  stats,
 	}
 }
 
+// Increment tăng giá trị số nguyên của một key một cách nguyên tử.
+//
+// Document còn hạn được cập nhật bằng $inc. Nếu key chưa tồn tại hoặc đã hết hạn,
+// document được tạo (hoặc thay thế) bằng upsert chỉ khớp document đã hết hạn, và thao tác
+// được thử lại khi instance khác vừa tạo key trước. Khi có transformer, giá trị được đọc,
+// tăng và ghi lại chỉ khi giá trị đang lưu chưa bị thay đổi, thử lại nếu bị thay đổi đồng thời.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khi truy cập MongoDB
+func (d *mongoDBDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	if d.transformer != nil {
+		return d.incrementTransformed(ctx, key, delta)
+	}
+
+	for {
+		var cacheItem MongoCacheItem
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err := d.collection.FindOneAndUpdate(ctx, d.liveFilter(key), bson.M{"$inc": bson.M{"value": delta}}, opts).Decode(&cacheItem)
+		if err == nil {
+			value, ok := toInt64(cacheItem.Value)
+			if !ok {
+				return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+			}
+			return value, nil
+		}
+		if isMongoTypeMismatch(err) {
+			return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+		}
+		if err != mongo.ErrNoDocuments {
+			return 0, err
+		}
+
+		// Key chưa tồn tại hoặc đã hết hạn, khởi tạo bộ đếm bằng delta
+		created, err := d.createCounter(ctx, key, delta)
+		if err != nil {
+			return 0, err
+		}
+		if created {
+			return delta, nil
+		}
+		// Instance khác vừa tạo key, thử lại với $inc
+	}
+}
+
+// incrementTransformed tăng bộ đếm có giá trị đã biến đổi bởi transformer.
+func (d *mongoDBDriver) incrementTransformed(ctx context.Context, key string, delta int64) (int64, error) {
+	for {
+		var cacheItem struct {
+			Value bson.RawValue `bson:"value"`
+		}
+		err := d.collection.FindOne(ctx, d.liveFilter(key)).Decode(&cacheItem)
+		if err == mongo.ErrNoDocuments {
+			stored, err := d.storeValue(delta)
+			if err != nil {
+				return 0, err
+			}
+			created, err := d.createCounter(ctx, key, stored)
+			if err != nil {
+				return 0, err
+			}
+			if created {
+				return delta, nil
+			}
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		current, err := d.loadValue(cacheItem.Value)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+		}
+		value, ok := toInt64(current)
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+		}
+
+		result := value + delta
+		stored, err := d.storeValue(result)
+		if err != nil {
+			return 0, err
+		}
+		filter := d.liveFilter(key)
+		filter["value"] = cacheItem.Value
+		updated, err := d.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"value": stored}})
+		if err != nil {
+			return 0, err
+		}
+		if updated.MatchedCount == 1 {
+			return result, nil
+		}
+		// Giá trị vừa bị thay đổi đồng thời, đọc lại và thử lại
+	}
+}
+
+// createCounter tạo bộ đếm không hết hạn với giá trị stored nếu key chưa tồn tại hoặc đã hết hạn.
+//
+// Returns:
+//   - bool: false nếu instance khác vừa tạo key trước
+//   - error: Lỗi khi ghi MongoDB
+func (d *mongoDBDriver) createCounter(ctx context.Context, key string, stored interface{}) (bool, error) {
+	update := bson.M{"$set": bson.M{
+		"value":      stored,
+		"expiration": int64(0),
+		"created_at": time.Now(),
+	}}
+	_, err := d.collection.UpdateOne(ctx, d.expiredFilter(key), update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Decrement giảm giá trị số nguyên của một key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khi truy cập MongoDB
+func (d *mongoDBDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	return d.Increment(ctx, key, -delta)
+}
+
+// Add đặt một giá trị vào cache chỉ khi key chưa tồn tại hoặc đã hết hạn.
+//
+// Document được ghi bằng upsert chỉ khớp document đã hết hạn; nếu document còn hạn,
+// upsert vi phạm unique index của _id và Add trả về false.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+//   - error: Lỗi nếu có trong quá trình lưu trữ vào MongoDB
+func (d *mongoDBDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	stored, err := d.storeValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	cacheItem := MongoCacheItem{
+		Key:        d.prefixKey(key),
+		Value:      stored,
+		Expiration: d.expiration(now, ttl),
+		CreatedAt:  now,
+	}
+
+	_, err = d.collection.ReplaceOne(ctx, d.expiredFilter(key), cacheItem, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompareAndSwap thay giá trị của key bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+//
+// Giá trị được so sánh bằng phép so sánh của MongoDB trong filter của ReplaceOne,
+// nên phép so sánh và ghi là nguyên tử. Khi có transformer, giá trị đang lưu được đọc và
+// khôi phục để so sánh với oldValue, rồi chỉ được thay nếu chưa bị thay đổi kể từ lúc đọc.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần cập nhật
+//   - oldValue: Giá trị mong đợi hiện tại
+//   - newValue: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+//   - error: Lỗi nếu có trong quá trình lưu trữ vào MongoDB
+func (d *mongoDBDriver) CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	filter := d.liveFilter(key)
+	if d.transformer == nil {
+		filter["value"] = oldValue
+	} else {
+		current, matched, err := d.storedValueMatching(ctx, key, oldValue)
+		if err != nil || !matched {
+			return false, err
+		}
+		filter["value"] = current
+	}
+
+	stored, err := d.storeValue(newValue)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	cacheItem := MongoCacheItem{
+		Key:        d.prefixKey(key),
+		Value:      stored,
+		Expiration: d.expiration(now, ttl),
+		CreatedAt:  now,
+	}
+
+	result, err := d.collection.ReplaceOne(ctx, filter, cacheItem)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// compareAndDelete xóa key chỉ khi giá trị hiện tại bằng expected, so sánh trong filter của DeleteOne.
+func (d *mongoDBDriver) compareAndDelete(ctx context.Context, key string, expected interface{}) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	filter := d.liveFilter(key)
+	if d.transformer == nil {
+		filter["value"] = expected
+	} else {
+		current, matched, err := d.storedValueMatching(ctx, key, expected)
+		if err != nil || !matched {
+			return false, err
+		}
+		filter["value"] = current
+	}
+
+	result, err := d.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount == 1, nil
+}
+
+// storedValueMatching đọc giá trị đang lưu của key còn hạn và so sánh giá trị đã khôi phục với oldValue.
+//
+// Returns:
+//   - bson.RawValue: Giá trị đang lưu, dùng trong filter để chỉ ghi nếu chưa bị thay đổi
+//   - bool: true nếu key tồn tại và giá trị bằng oldValue
+//   - error: Lỗi khi đọc MongoDB hoặc mã hóa oldValue
+func (d *mongoDBDriver) storedValueMatching(ctx context.Context, key string, oldValue interface{}) (bson.RawValue, bool, error) {
+	var cacheItem struct {
+		Value bson.RawValue `bson:"value"`
+	}
+	err := d.collection.FindOne(ctx, d.liveFilter(key)).Decode(&cacheItem)
+	if err == mongo.ErrNoDocuments {
+		return bson.RawValue{}, false, nil
+	}
+	if err != nil {
+		return bson.RawValue{}, false, err
+	}
+
+	expected, err := bson.Marshal(mongoEnvelope{Value: oldValue})
+	if err != nil {
+		return bson.RawValue{}, false, fmt.Errorf("could not serialize value: %w", err)
+	}
+	if cacheItem.Value.Type != bson.TypeBinary {
+		return bson.RawValue{}, false, nil
+	}
+	_, data := cacheItem.Value.Binary()
+	current, err := d.transformer.Decode(data)
+	if err != nil || !bytes.Equal(current, expected) {
+		return bson.RawValue{}, false, nil
+	}
+	return cacheItem.Value, true, nil
+}
+
+// storeValue trả về giá trị lưu vào trường value của document.
+//
+// Không có transformer, giá trị được lưu nguyên dạng BSON. Có transformer, giá trị được bọc
+// trong mongoEnvelope, mã hóa thành BSON rồi biến đổi và lưu dạng binary.
+func (d *mongoDBDriver) storeValue(value interface{}) (interface{}, error) {
+	if d.transformer == nil {
+		return value, nil
+	}
+	data, err := bson.Marshal(mongoEnvelope{Value: value})
+	if err != nil {
+		return nil, fmt.Errorf("could not serialize value: %w", err)
+	}
+	return d.transformer.Encode(data)
+}
+
+// loadValue khôi phục giá trị đọc từ trường value của document.
+//
+// Giá trị không phải binary (ghi trước khi bật transformer, hoặc version của tag) được trả về nguyên dạng.
+func (d *mongoDBDriver) loadValue(value interface{}) (interface{}, error) {
+	binary, ok := value.(primitive.Binary)
+	if d.transformer == nil || !ok {
+		return value, nil
+	}
+	var envelope mongoEnvelope
+	if err := d.unwrap(binary.Data, &envelope); err != nil {
+		return nil, err
+	}
+	return envelope.Value, nil
+}
+
+// loadRawValue khôi phục giá trị BSON thô đọc từ trường value của document, tương tự loadValue.
+func (d *mongoDBDriver) loadRawValue(value bson.RawValue) (bson.RawValue, error) {
+	if d.transformer == nil || value.Type != bson.TypeBinary {
+		return value, nil
+	}
+	_, data := value.Binary()
+	var envelope struct {
+		Value bson.RawValue `bson:"v"`
+	}
+	if err := d.unwrap(data, &envelope); err != nil {
+		return bson.RawValue{}, err
+	}
+	return envelope.Value, nil
+}
+
+// unwrap khôi phục dữ liệu bằng transformer rồi giải mã BSON của envelope vào dest.
+func (d *mongoDBDriver) unwrap(data []byte, dest interface{}) error {
+	decoded, err := d.transformer.Decode(data)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(decoded, dest)
+}
+
+// prefixKey thêm prefix đã cấu hình vào key để tạo _id của document.
+func (d *mongoDBDriver) prefixKey(key string) string {
+	return d.config.Prefix + key
+}
+
+// prefixFilter trả về filter khớp các document có _id bắt đầu bằng prefix của driver và prefix.
+func (d *mongoDBDriver) prefixFilter(prefix string) bson.M {
+	full := d.config.Prefix + prefix
+	if full == "" {
+		return bson.M{}
+	}
+	return bson.M{"_id": bson.M{"$regex": "^" + regexp.QuoteMeta(full)}}
+}
+
+// expiration tính thời điểm hết hạn (UnixNano) từ ttl, 0 nếu không hết hạn.
+func (d *mongoDBDriver) expiration(now time.Time, ttl time.Duration) int64 {
+	if ttl == 0 {
+		if d.config.GetDefaultExpiration() > 0 {
+			return now.Add(d.config.GetDefaultExpiration()).UnixNano()
+		}
+	} else if ttl > 0 {
+		return now.Add(ttl).UnixNano()
+	}
+	return 0
+}
+
+// liveFilter trả về filter khớp document của key còn hạn.
+func (d *mongoDBDriver) liveFilter(key string) bson.M {
+	return bson.M{
+		"_id": d.prefixKey(key),
+		"$or": bson.A{
+			bson.M{"expiration": int64(0)},
+			bson.M{"expiration": bson.M{"$gt": time.Now().UnixNano()}},
+		},
+	}
+}
+
+// expiredFilter trả về filter khớp document của key đã hết hạn, dùng cho upsert
+// để chỉ tạo mới khi key chưa tồn tại hoặc thay thế document đã hết hạn.
+func (d *mongoDBDriver) expiredFilter(key string) bson.M {
+	return bson.M{
+		"_id":        d.prefixKey(key),
+		"expiration": bson.M{"$gt": int64(0), "$lte": time.Now().UnixNano()},
+	}
+}
+
+// isMongoTypeMismatch kiểm tra lỗi $inc trên giá trị không phải số của MongoDB.
+func isMongoTypeMismatch(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(mongoTypeMismatchCode)
+}
+
+// TagVersions trả về version hiện tại của các tag, tạo version mới cho tag chưa có.
+//
+// Version được lưu thành document có _id là "__tag:" + tag và expiration = 0 (không hết hạn)
+// trong cùng collection. Tag chưa có version được tạo bằng upsert với $setOnInsert nên
+// các instance dùng chung collection luôn nhận cùng một version.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần lấy version
+//
+// Returns:
+//   - []string: Version của từng tag theo thứ tự của tags
+//   - error: Lỗi nếu không thể đọc hoặc ghi MongoDB
+func (d *mongoDBDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	versions := make([]string, len(tags))
+	for i, tag := range tags {
+		version, err := newTagVersion()
+		if err != nil {
+			return nil, err
+		}
+
+		filter := bson.M{"_id": d.prefixKey(tagVersionKey(tag))}
+		update := bson.M{"$setOnInsert": bson.M{
+			"value":      version,
+			"expiration": int64(0),
+			"created_at": time.Now(),
+		}}
+		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+		var cacheItem MongoCacheItem
+		err = d.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&cacheItem)
+		if mongo.IsDuplicateKeyError(err) {
+			// Upsert đồng thời từ instance khác, document đã tồn tại nên thử lại sẽ chỉ đọc
+			err = d.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&cacheItem)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load version of tag '%s': %w", tag, err)
+		}
+
+		current, ok := cacheItem.Value.(string)
+		if !ok || current == "" {
+			return nil, fmt.Errorf("invalid version of tag '%s'", tag)
+		}
+		versions[i] = current
+	}
+	return versions, nil
+}
+
+// FlushTags làm mất hiệu lực các entry gắn với tags bằng cách ghi version mới cho từng tag.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần làm mất hiệu lực
+//
+// Returns:
+//   - error: Lỗi nếu không thể ghi MongoDB
+func (d *mongoDBDriver) FlushTags(ctx context.Context, tags []string) error {
+	ctx, cancel := operationContext(ctx, d.config.GetOperationTimeout())
+	defer cancel()
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var operations []mongo.WriteModel
+	for _, tag := range tags {
+		version, err := newTagVersion()
+		if err != nil {
+			return err
+		}
+
+		operation := mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": d.prefixKey(tagVersionKey(tag))}).
+			SetReplacement(MongoCacheItem{
+				Key:       d.prefixKey(tagVersionKey(tag)),
+				Value:     version,
+				CreatedAt: now,
+			}).
+			SetUpsert(true)
+		operations = append(operations, operation)
+	}
+
+	_, err := d.collection.BulkWrite(ctx, operations)
+	return err
+}
+
 // Close giải phóng tài nguyên của driver.
 //
 // Phương thức này đóng kết nối tới MongoDB và giải phóng
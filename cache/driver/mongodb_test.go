@@ -363,6 +363,78 @@ func TestMongoDriverIntegration(t *testing.T) {
 		_, found = mongoDriver.Get(ctx, key)
 		assert.False(t, found, "Document should be considered expired by our logic")
 	})
+
+	t.Run("Tag Versions", func(t *testing.T) {
+		versions, err := mongoDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.Len(t, versions, 2)
+		assert.NotEqual(t, versions[0], versions[1])
+
+		// Version ổn định giữa các lần gọi và theo đúng thứ tự tag
+		again, err := mongoDriver.TagVersions(ctx, []string{"users", "tenant:7"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{versions[1], versions[0]}, again)
+
+		// FlushTags chỉ đổi version của tag được flush
+		assert.NoError(t, mongoDriver.FlushTags(ctx, []string{"tenant:7"}))
+		flushed, err := mongoDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, versions[0], flushed[0])
+		assert.Equal(t, versions[1], flushed[1])
+	})
+
+	t.Run("Atomic Operations", func(t *testing.T) {
+		// Increment khởi tạo key chưa tồn tại bằng delta
+		value, err := mongoDriver.Increment(ctx, "atomic:counter", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = mongoDriver.Increment(ctx, "atomic:counter", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), value)
+
+		value, err = mongoDriver.Decrement(ctx, "atomic:counter", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-2), value)
+
+		// Increment trên giá trị số đã ghi bằng Set
+		assert.NoError(t, mongoDriver.Set(ctx, "atomic:set", 40, time.Minute))
+		value, err = mongoDriver.Increment(ctx, "atomic:set", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), value)
+
+		assert.NoError(t, mongoDriver.Set(ctx, "atomic:text", "alice", time.Minute))
+		_, err = mongoDriver.Increment(ctx, "atomic:text", 1)
+		assert.ErrorIs(t, err, driver.ErrNotInteger)
+
+		// Add chỉ ghi khi key chưa tồn tại
+		added, err := mongoDriver.Add(ctx, "atomic:once", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		added, err = mongoDriver.Add(ctx, "atomic:once", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		result, found := mongoDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "first", result)
+
+		// CompareAndSwap chỉ ghi khi giá trị hiện tại khớp
+		swapped, err := mongoDriver.CompareAndSwap(ctx, "atomic:once", "other", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+		swapped, err = mongoDriver.CompareAndSwap(ctx, "atomic:once", "first", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		result, found = mongoDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "third", result)
+
+		swapped, err = mongoDriver.CompareAndSwap(ctx, "atomic:missing", nil, "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+	})
 }
 
 func TestMongoDriverMocked(t *testing.T) {
@@ -486,6 +558,22 @@ func TestMongoDriverTestSuite(t *testing.T) {
 	t.Skip("Skipping test suite due to mock issues - use integration tests instead")
 }
 
+func TestNewMongoDBDriver_InvalidTransform(t *testing.T) {
+	mongoConfig := config.DriverMongodbConfig{
+		Enabled:    true,
+		Database:   "cache_test",
+		Collection: "cache_test_collection",
+		Transform: &config.TransformConfig{
+			Compression: &config.CompressionConfig{Enabled: true, Algorithm: "lz4"},
+		},
+	}
+
+	mongoDriver, err := driver.NewMongoDBDriver(mongoConfig, nil)
+
+	assert.Nil(t, mongoDriver)
+	assert.ErrorIs(t, err, driver.ErrUnsupportedCompression)
+}
+
 func TestMongoDriverConcurrency(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping MongoDB concurrency tests in short mode")
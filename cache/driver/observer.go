@@ -0,0 +1,367 @@
+package driver
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Các tên thao tác trong Event.Operation.
+const (
+	OperationGet                 = "get"
+	OperationGetInto             = "get_into"
+	OperationHas                 = "has"
+	OperationSet                 = "set"
+	OperationDelete              = "delete"
+	OperationFlush               = "flush"
+	OperationFlushPrefix         = "flush_prefix"
+	OperationFlushTags           = "flush_tags"
+	OperationGetMultiple         = "get_multiple"
+	OperationSetMultiple         = "set_multiple"
+	OperationDeleteMultiple      = "delete_multiple"
+	OperationRemember            = "remember"
+	OperationRememberWithOptions = "remember_with_options"
+	OperationIncrement           = "increment"
+	OperationDecrement           = "decrement"
+	OperationAdd                 = "add"
+	OperationCompareAndSwap      = "compare_and_swap"
+	OperationTagVersions         = "tag_versions"
+)
+
+// Event mô tả một thao tác trên driver được báo cho Observer.
+type Event struct {
+	// Driver là tên driver thực hiện thao tác
+	Driver string
+
+	// Operation là tên thao tác, một trong các hằng số Operation*
+	Operation string
+
+	// Key là key của thao tác (đã gồm namespace), rỗng với Flush và FlushTags, là tiền tố với FlushPrefix
+	Key string
+
+	// Duration là thời gian thực thi thao tác trên driver
+	Duration time.Duration
+}
+
+// Observer nhận sự kiện của các thao tác trên driver, dùng cho metrics, tracing và logging.
+//
+// Các phương thức được gọi đồng bộ trên goroutine thực hiện thao tác sau khi thao tác hoàn tất,
+// nên cài đặt phải an toàn khi gọi đồng thời và không nên chặn lâu.
+// Với thao tác nhiều key, mỗi key tạo một sự kiện với Duration là phần chia đều thời gian của cả thao tác,
+// nên tổng Duration của các sự kiện bằng thời gian thực thi (độ trễ không bị nhân theo số key).
+type Observer interface {
+	// OnHit được gọi khi key được tìm thấy (Get, GetInto, Has, GetMultiple, Remember không gọi callback).
+	OnHit(ctx context.Context, event Event)
+
+	// OnMiss được gọi khi key không tìm thấy hoặc đã hết hạn, kể cả khi Remember phải gọi callback.
+	OnMiss(ctx context.Context, event Event)
+
+	// OnWrite được gọi khi giá trị được ghi (Set, SetMultiple, Increment, Decrement, Add, CompareAndSwap
+	// và Remember khi callback được gọi). Add và CompareAndSwap không ghi thì không tạo sự kiện.
+	OnWrite(ctx context.Context, event Event)
+
+	// OnDelete được gọi khi key bị xóa (Delete, DeleteMultiple) hoặc cache bị làm trống (Flush, FlushPrefix, FlushTags).
+	OnDelete(ctx context.Context, event Event)
+
+	// OnError được gọi khi thao tác trả về lỗi.
+	//
+	// Get và Has không trả về lỗi nên lỗi kết nối của chúng được báo là miss.
+	OnError(ctx context.Context, event Event, err error)
+}
+
+// InstrumentedDriver là driver bọc một driver khác và báo mọi thao tác cho Observer.
+type InstrumentedDriver interface {
+	Driver
+	Taggable
+	PrefixFlusher
+
+	// Unwrap trả về driver được bọc.
+	Unwrap() Driver
+}
+
+// instrumentedDriver cài đặt InstrumentedDriver.
+//
+// TagVersions, FlushTags và FlushPrefix được chuyển tới driver được bọc nếu nó hỗ trợ,
+// nếu không trả về ErrTagsNotSupported hoặc ErrPrefixFlushNotSupported.
+type instrumentedDriver struct {
+	name     string   // Tên driver trong Event.Driver
+	driver   Driver   // Driver được bọc
+	observer Observer // Observer nhận sự kiện
+}
+
+// NewInstrumentedDriver bọc driver để báo mọi thao tác cho observer.
+//
+// Params:
+//   - name: Tên driver được ghi vào Event.Driver
+//   - driver: Driver cần bọc
+//   - observer: Observer nhận sự kiện
+//
+// Returns:
+//   - InstrumentedDriver: Driver đã được bọc
+func NewInstrumentedDriver(name string, driver Driver, observer Observer) InstrumentedDriver {
+	return &instrumentedDriver{name: name, driver: driver, observer: observer}
+}
+
+// Unwrap trả về driver được bọc.
+func (d *instrumentedDriver) Unwrap() Driver {
+	return d.driver
+}
+
+// Get lấy một giá trị từ driver được bọc và báo hit hoặc miss.
+func (d *instrumentedDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	start := time.Now()
+	value, found := d.driver.Get(ctx, key)
+	d.found(ctx, d.event(OperationGet, key, start), found)
+	return value, found
+}
+
+// GetInto lấy một giá trị từ driver được bọc vào dest và báo hit, miss hoặc lỗi.
+func (d *instrumentedDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	start := time.Now()
+	found, err := d.driver.GetInto(ctx, key, dest)
+	event := d.event(OperationGetInto, key, start)
+	if err != nil {
+		d.observer.OnError(ctx, event, err)
+	} else {
+		d.found(ctx, event, found)
+	}
+	return found, err
+}
+
+// Set ghi một giá trị vào driver được bọc và báo ghi hoặc lỗi.
+func (d *instrumentedDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	start := time.Now()
+	err := d.driver.Set(ctx, key, value, ttl)
+	d.written(ctx, d.event(OperationSet, key, start), true, err)
+	return err
+}
+
+// Has kiểm tra key trong driver được bọc và báo hit hoặc miss.
+func (d *instrumentedDriver) Has(ctx context.Context, key string) bool {
+	start := time.Now()
+	found := d.driver.Has(ctx, key)
+	d.found(ctx, d.event(OperationHas, key, start), found)
+	return found
+}
+
+// Delete xóa một key khỏi driver được bọc và báo xóa hoặc lỗi.
+func (d *instrumentedDriver) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := d.driver.Delete(ctx, key)
+	d.deleted(ctx, d.event(OperationDelete, key, start), err)
+	return err
+}
+
+// Flush làm trống driver được bọc và báo xóa hoặc lỗi.
+func (d *instrumentedDriver) Flush(ctx context.Context) error {
+	start := time.Now()
+	err := d.driver.Flush(ctx)
+	d.deleted(ctx, d.event(OperationFlush, "", start), err)
+	return err
+}
+
+// FlushPrefix xóa các key bắt đầu bằng prefix khỏi driver được bọc.
+func (d *instrumentedDriver) FlushPrefix(ctx context.Context, prefix string) error {
+	flusher, ok := d.driver.(PrefixFlusher)
+	if !ok {
+		return ErrPrefixFlushNotSupported
+	}
+	start := time.Now()
+	err := flusher.FlushPrefix(ctx, prefix)
+	d.deleted(ctx, d.event(OperationFlushPrefix, prefix, start), err)
+	return err
+}
+
+// GetMultiple lấy nhiều giá trị từ driver được bọc và báo hit, miss cho từng key.
+func (d *instrumentedDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	start := time.Now()
+	values, missed := d.driver.GetMultiple(ctx, keys)
+	duration := splitDuration(time.Since(start), len(values)+len(missed))
+	for key := range values {
+		d.observer.OnHit(ctx, Event{Driver: d.name, Operation: OperationGetMultiple, Key: key, Duration: duration})
+	}
+	for _, key := range missed {
+		d.observer.OnMiss(ctx, Event{Driver: d.name, Operation: OperationGetMultiple, Key: key, Duration: duration})
+	}
+	return values, missed
+}
+
+// SetMultiple ghi nhiều giá trị vào driver được bọc và báo ghi cho từng key hoặc lỗi.
+func (d *instrumentedDriver) SetMultiple(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	start := time.Now()
+	err := d.driver.SetMultiple(ctx, values, ttl)
+	if err != nil {
+		d.observer.OnError(ctx, d.event(OperationSetMultiple, "", start), err)
+		return err
+	}
+	duration := splitDuration(time.Since(start), len(values))
+	for key := range values {
+		d.observer.OnWrite(ctx, Event{Driver: d.name, Operation: OperationSetMultiple, Key: key, Duration: duration})
+	}
+	return nil
+}
+
+// DeleteMultiple xóa nhiều key khỏi driver được bọc và báo xóa cho từng key hoặc lỗi.
+func (d *instrumentedDriver) DeleteMultiple(ctx context.Context, keys []string) error {
+	start := time.Now()
+	err := d.driver.DeleteMultiple(ctx, keys)
+	if err != nil {
+		d.observer.OnError(ctx, d.event(OperationDeleteMultiple, "", start), err)
+		return err
+	}
+	duration := splitDuration(time.Since(start), len(keys))
+	for _, key := range keys {
+		d.observer.OnDelete(ctx, Event{Driver: d.name, Operation: OperationDeleteMultiple, Key: key, Duration: duration})
+	}
+	return nil
+}
+
+// Remember gọi Remember của driver được bọc và báo miss kèm ghi nếu callback được gọi, hit nếu không.
+func (d *instrumentedDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	start := time.Now()
+	called, wrapped := trackCallback(callback)
+	value, err := d.driver.Remember(ctx, key, ttl, wrapped)
+	d.remembered(ctx, d.event(OperationRemember, key, start), called.Load(), err)
+	return value, err
+}
+
+// RememberWithOptions gọi RememberWithOptions của driver được bọc và báo như Remember.
+func (d *instrumentedDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	start := time.Now()
+	called, wrapped := trackCallback(callback)
+	value, err := d.driver.RememberWithOptions(ctx, key, wrapped, opts)
+	d.remembered(ctx, d.event(OperationRememberWithOptions, key, start), called.Load(), err)
+	return value, err
+}
+
+// Increment tăng bộ đếm trong driver được bọc và báo ghi hoặc lỗi.
+func (d *instrumentedDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	start := time.Now()
+	value, err := d.driver.Increment(ctx, key, delta)
+	d.written(ctx, d.event(OperationIncrement, key, start), true, err)
+	return value, err
+}
+
+// Decrement giảm bộ đếm trong driver được bọc và báo ghi hoặc lỗi.
+func (d *instrumentedDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	start := time.Now()
+	value, err := d.driver.Decrement(ctx, key, delta)
+	d.written(ctx, d.event(OperationDecrement, key, start), true, err)
+	return value, err
+}
+
+// Add ghi giá trị vào driver được bọc nếu key chưa tồn tại và báo ghi khi giá trị được ghi.
+func (d *instrumentedDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	start := time.Now()
+	added, err := d.driver.Add(ctx, key, value, ttl)
+	d.written(ctx, d.event(OperationAdd, key, start), added, err)
+	return added, err
+}
+
+// CompareAndSwap thay giá trị trong driver được bọc và báo ghi khi giá trị được thay.
+func (d *instrumentedDriver) CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	start := time.Now()
+	swapped, err := d.driver.CompareAndSwap(ctx, key, oldValue, newValue, ttl)
+	d.written(ctx, d.event(OperationCompareAndSwap, key, start), swapped, err)
+	return swapped, err
+}
+
+// TagVersions trả về version của các tag từ driver được bọc, chỉ báo lỗi.
+func (d *instrumentedDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	taggable, ok := d.driver.(Taggable)
+	if !ok {
+		return nil, ErrTagsNotSupported
+	}
+	start := time.Now()
+	versions, err := taggable.TagVersions(ctx, tags)
+	if err != nil {
+		d.observer.OnError(ctx, d.event(OperationTagVersions, "", start), err)
+	}
+	return versions, err
+}
+
+// FlushTags làm mất hiệu lực các entry gắn tag trong driver được bọc và báo xóa hoặc lỗi.
+func (d *instrumentedDriver) FlushTags(ctx context.Context, tags []string) error {
+	taggable, ok := d.driver.(Taggable)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	start := time.Now()
+	err := taggable.FlushTags(ctx, tags)
+	d.deleted(ctx, d.event(OperationFlushTags, "", start), err)
+	return err
+}
+
+// Stats trả về thống kê của driver được bọc.
+func (d *instrumentedDriver) Stats(ctx context.Context) map[string]interface{} {
+	return d.driver.Stats(ctx)
+}
+
+// Close đóng driver được bọc.
+func (d *instrumentedDriver) Close() error {
+	return d.driver.Close()
+}
+
+// event tạo sự kiện cho thao tác bắt đầu tại start.
+func (d *instrumentedDriver) event(operation, key string, start time.Time) Event {
+	return Event{Driver: d.name, Operation: operation, Key: key, Duration: time.Since(start)}
+}
+
+// found báo hit hoặc miss.
+func (d *instrumentedDriver) found(ctx context.Context, event Event, found bool) {
+	if found {
+		d.observer.OnHit(ctx, event)
+	} else {
+		d.observer.OnMiss(ctx, event)
+	}
+}
+
+// written báo lỗi, hoặc ghi nếu giá trị đã được ghi.
+func (d *instrumentedDriver) written(ctx context.Context, event Event, written bool, err error) {
+	if err != nil {
+		d.observer.OnError(ctx, event, err)
+	} else if written {
+		d.observer.OnWrite(ctx, event)
+	}
+}
+
+// deleted báo lỗi hoặc xóa.
+func (d *instrumentedDriver) deleted(ctx context.Context, event Event, err error) {
+	if err != nil {
+		d.observer.OnError(ctx, event, err)
+	} else {
+		d.observer.OnDelete(ctx, event)
+	}
+}
+
+// remembered báo kết quả của Remember: hit nếu callback không được gọi, miss và ghi nếu được gọi.
+func (d *instrumentedDriver) remembered(ctx context.Context, event Event, called bool, err error) {
+	switch {
+	case !called && err == nil:
+		d.observer.OnHit(ctx, event)
+	case !called:
+		d.observer.OnError(ctx, event, err)
+	default:
+		d.observer.OnMiss(ctx, event)
+		d.written(ctx, event, true, err)
+	}
+}
+
+// splitDuration chia đều duration của một thao tác nhiều key cho n sự kiện.
+func splitDuration(duration time.Duration, n int) time.Duration {
+	if n <= 1 {
+		return duration
+	}
+	return duration / time.Duration(n)
+}
+
+// trackCallback bọc callback để biết callback có được gọi trong lời gọi Remember hay không.
+//
+// Callback có thể được gọi trên goroutine làm mới ở nền (StaleTTL), nên cờ là atomic.Bool.
+func trackCallback(callback func() (interface{}, error)) (*atomic.Bool, func() (interface{}, error)) {
+	called := new(atomic.Bool)
+	return called, func() (interface{}, error) {
+		called.Store(true)
+		return callback()
+	}
+}
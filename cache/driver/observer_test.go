@@ -0,0 +1,270 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
+	cacheMocks "go.fork.vn/providers/cache/mocks"
+)
+
+// recordedEvent là một sự kiện được recordingObserver ghi lại.
+type recordedEvent struct {
+	kind  string
+	event driver.Event
+	err   error
+}
+
+// recordingObserver ghi lại mọi sự kiện nhận được.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+func (o *recordingObserver) OnHit(_ context.Context, event driver.Event) {
+	o.record("hit", event, nil)
+}
+
+func (o *recordingObserver) OnMiss(_ context.Context, event driver.Event) {
+	o.record("miss", event, nil)
+}
+
+func (o *recordingObserver) OnWrite(_ context.Context, event driver.Event) {
+	o.record("write", event, nil)
+}
+
+func (o *recordingObserver) OnDelete(_ context.Context, event driver.Event) {
+	o.record("delete", event, nil)
+}
+
+func (o *recordingObserver) OnError(_ context.Context, event driver.Event, err error) {
+	o.record("error", event, err)
+}
+
+func (o *recordingObserver) record(kind string, event driver.Event, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, recordedEvent{kind: kind, event: event, err: err})
+}
+
+// kinds trả về loại của các sự kiện đã ghi và xóa danh sách sự kiện.
+func (o *recordingObserver) kinds() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	kinds := make([]string, len(o.events))
+	for i, e := range o.events {
+		kinds[i] = e.kind
+	}
+	o.events = nil
+	return kinds
+}
+
+func TestInstrumentedDriver(t *testing.T) {
+	ctx := context.Background()
+
+	newInstrumented := func(t *testing.T) (driver.InstrumentedDriver, *recordingObserver) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300, CleanupInterval: 60})
+		t.Cleanup(func() { memoryDriver.Close() })
+		observer := &recordingObserver{}
+		return driver.NewInstrumentedDriver("memory", memoryDriver, observer), observer
+	}
+
+	t.Run("Reports Reads And Writes", func(t *testing.T) {
+		d, observer := newInstrumented(t)
+
+		_, found := d.Get(ctx, "key")
+		assert.False(t, found)
+		assert.NoError(t, d.Set(ctx, "key", "value", time.Minute))
+		_, found = d.Get(ctx, "key")
+		assert.True(t, found)
+		assert.True(t, d.Has(ctx, "key"))
+		assert.NoError(t, d.Delete(ctx, "key"))
+
+		observer.mu.Lock()
+		first := observer.events[0].event
+		observer.mu.Unlock()
+		assert.Equal(t, "memory", first.Driver)
+		assert.Equal(t, driver.OperationGet, first.Operation)
+		assert.Equal(t, "key", first.Key)
+		assert.Equal(t, []string{"miss", "write", "hit", "hit", "delete"}, observer.kinds())
+	})
+
+	t.Run("Reports Each Key Of Multiple Operations", func(t *testing.T) {
+		d, observer := newInstrumented(t)
+
+		assert.NoError(t, d.SetMultiple(ctx, map[string]interface{}{"a": 1, "b": 2}, time.Minute))
+		assert.Equal(t, []string{"write", "write"}, observer.kinds())
+
+		d.GetMultiple(ctx, []string{"a", "missing"})
+		kinds := observer.kinds()
+		assert.ElementsMatch(t, []string{"hit", "miss"}, kinds)
+
+		assert.NoError(t, d.DeleteMultiple(ctx, []string{"a", "b"}))
+		assert.Equal(t, []string{"delete", "delete"}, observer.kinds())
+	})
+
+	t.Run("Splits Duration Of Multiple Operations", func(t *testing.T) {
+		mockDriver := cacheMocks.NewMockDriver(t)
+		mockDriver.On("GetMultiple", mock.Anything, []string{"a", "b", "c", "d"}).
+			Run(func(mock.Arguments) { time.Sleep(40 * time.Millisecond) }).
+			Return(map[string]interface{}{"a": 1, "b": 2}, []string{"c", "d"})
+		observer := &recordingObserver{}
+		d := driver.NewInstrumentedDriver("mock", mockDriver, observer)
+
+		start := time.Now()
+		d.GetMultiple(ctx, []string{"a", "b", "c", "d"})
+		elapsed := time.Since(start)
+
+		observer.mu.Lock()
+		defer observer.mu.Unlock()
+		assert.Len(t, observer.events, 4)
+		var total time.Duration
+		for _, e := range observer.events {
+			total += e.event.Duration
+		}
+		// Tổng độ trễ của các sự kiện bằng thời gian của một lần gọi, không nhân theo số key
+		assert.GreaterOrEqual(t, total, 40*time.Millisecond-4)
+		assert.LessOrEqual(t, total, elapsed)
+	})
+
+	t.Run("Reports Remember As Miss Then Hit", func(t *testing.T) {
+		d, observer := newInstrumented(t)
+		callback := func() (interface{}, error) { return "computed", nil }
+
+		_, err := d.Remember(ctx, "key", time.Minute, callback)
+		assert.NoError(t, err)
+		_, err = d.Remember(ctx, "key", time.Minute, callback)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"miss", "write", "hit"}, observer.kinds())
+	})
+
+	t.Run("Background Refresh Does Not Race With Reporting", func(t *testing.T) {
+		d, observer := newInstrumented(t)
+		opts := driver.RememberOptions{TTL: 20 * time.Millisecond, StaleTTL: time.Minute}
+		callback := func() (interface{}, error) { return "computed", nil }
+
+		_, err := d.RememberWithOptions(ctx, "key", callback, opts)
+		assert.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+
+		// Callback được gọi ở nền trong khi lời gọi trả về giá trị cũ và báo sự kiện
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := d.RememberWithOptions(ctx, "key", callback, opts)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.NotEmpty(t, observer.kinds())
+	})
+
+	t.Run("Reports Conditional Writes Only When Written", func(t *testing.T) {
+		d, observer := newInstrumented(t)
+
+		added, err := d.Add(ctx, "key", "v1", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		added, err = d.Add(ctx, "key", "v2", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+		swapped, err := d.CompareAndSwap(ctx, "key", "other", "v3", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+
+		assert.Equal(t, []string{"write"}, observer.kinds())
+	})
+
+	t.Run("Reports Errors", func(t *testing.T) {
+		d, observer := newInstrumented(t)
+		callbackErr := errors.New("callback failed")
+
+		assert.NoError(t, d.Set(ctx, "text", "not a number", time.Minute))
+		_, err := d.Increment(ctx, "text", 1)
+		assert.ErrorIs(t, err, driver.ErrNotInteger)
+		_, err = d.Remember(ctx, "key", time.Minute, func() (interface{}, error) { return nil, callbackErr })
+		assert.ErrorIs(t, err, callbackErr)
+
+		assert.Equal(t, []string{"write", "error", "miss", "error"}, observer.kinds())
+	})
+
+	t.Run("Unsupported Capabilities", func(t *testing.T) {
+		mockDriver := cacheMocks.NewMockDriver(t)
+		mockDriver.On("Flush", mock.Anything).Return(nil)
+		observer := &recordingObserver{}
+		d := driver.NewInstrumentedDriver("mock", mockDriver, observer)
+
+		assert.ErrorIs(t, d.FlushPrefix(ctx, "users:"), driver.ErrPrefixFlushNotSupported)
+		assert.ErrorIs(t, d.FlushTags(ctx, []string{"tag"}), driver.ErrTagsNotSupported)
+		_, err := d.TagVersions(ctx, []string{"tag"})
+		assert.ErrorIs(t, err, driver.ErrTagsNotSupported)
+		assert.NoError(t, d.Flush(ctx))
+
+		assert.Equal(t, []string{"delete"}, observer.kinds())
+		assert.Same(t, mockDriver, d.Unwrap())
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Aggregates Per Driver", func(t *testing.T) {
+		metrics := driver.NewMetrics()
+
+		metrics.OnHit(ctx, driver.Event{Driver: "redis", Duration: 2 * time.Millisecond})
+		metrics.OnHit(ctx, driver.Event{Driver: "redis", Duration: 4 * time.Millisecond})
+		metrics.OnMiss(ctx, driver.Event{Driver: "redis", Duration: 3 * time.Millisecond})
+		metrics.OnError(ctx, driver.Event{Driver: "redis", Duration: 3 * time.Millisecond}, errors.New("timeout"))
+		metrics.OnWrite(ctx, driver.Event{Driver: "memory"})
+		metrics.OnDelete(ctx, driver.Event{Driver: "memory"})
+
+		snapshot := metrics.Snapshot()
+		redis := snapshot["redis"]
+		assert.Equal(t, int64(2), redis.Hits)
+		assert.Equal(t, int64(1), redis.Misses)
+		assert.Equal(t, int64(1), redis.Errors)
+		assert.Equal(t, int64(4), redis.Operations)
+		assert.InDelta(t, 2.0/3.0, redis.HitRatio(), 0.0001)
+		assert.Equal(t, 0.25, redis.ErrorRate())
+		assert.Equal(t, 3*time.Millisecond, redis.AverageLatency())
+		assert.Equal(t, int64(1), snapshot["memory"].Writes)
+		assert.Equal(t, int64(1), snapshot["memory"].Deletes)
+		assert.Zero(t, snapshot["memory"].HitRatio())
+	})
+
+	t.Run("Concurrent Updates", func(t *testing.T) {
+		metrics := driver.NewMetrics()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					metrics.OnHit(ctx, driver.Event{Driver: "memory"})
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(5000), metrics.Snapshot()["memory"].Hits)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		metrics := driver.NewMetrics()
+		metrics.OnHit(ctx, driver.Event{Driver: "memory"})
+
+		metrics.Reset()
+
+		assert.Empty(t, metrics.Snapshot())
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -14,8 +16,35 @@ import (
 	redisManager "go.fork.vn/providers/redis"
 )
 
+// redisMaxTxRetries là số lần thử lại tối đa của transaction WATCH/MULTI khi key bị thay đổi đồng thời.
+const redisMaxTxRetries = 100
+
+// compareAndSwapScript ghi ARGV[2] vào KEYS[1] nếu giá trị hiện tại bằng ARGV[1],
+// với TTL ARGV[3] mili giây (0 là không hết hạn).
+var compareAndSwapScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// compareAndDeleteScript xóa KEYS[1] nếu giá trị hiện tại bằng ARGV[1].
+var compareAndDeleteScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+return redis.call("DEL", KEYS[1])
+`)
+
 type RedisDriver interface {
 	Driver
+	Taggable
+	PrefixFlusher
 	WithSerializer(serializer string) RedisDriver
 }
 
@@ -31,14 +60,19 @@ type redisDriver struct {
 	default_ttl  time.Duration                     // Thời gian sống mặc định cho các entry không chỉ định TTL
 	serializer   func(interface{}) ([]byte, error) // Hàm serialization để chuyển đổi giá trị thành dạng binary
 	deserializer func([]byte, interface{}) error   // Hàm deserialization để chuyển đổi từ binary
+	rawIntegers  bool                              // true nếu serializer lưu số nguyên dạng thập phân (json), cho phép dùng INCRBY
+	transformer  Transformer                       // Nén và mã hóa dữ liệu đã serialize (nil nếu không dùng)
+	timeout      time.Duration                     // Thời gian tối đa cho mỗi thao tác trên Redis (0 nếu không giới hạn)
 	hits         int64                             // Số lần cache hit
 	misses       int64                             // Số lần cache miss
+	flights      flightGroup                       // Gộp các lời gọi Remember đồng thời cho cùng key
 }
 
 // NewRedisDriver tạo một Redis driver mới với cấu hình mặc định.
 //
 // Phương thức này khởi tạo một RedisDriver mới với thông tin kết nối cơ bản.
-// Nó sử dụng cấu hình mặc định cho prefix là "cache:" và default_ttl là 5 phút.
+// Prefix được lấy từ cấu hình (mặc định "cache:" nếu để trống), nên các ứng dụng
+// dùng chung Redis với prefix khác nhau không đọc, ghi hay xóa key của nhau.
 //
 // Params:
 //   - host: Hostname hoặc IP của Redis server
@@ -48,7 +82,7 @@ type redisDriver struct {
 //
 // Returns:
 //   - *RedisDriver: Driver đã được khởi tạo
-//   - error: Lỗi nếu không thể kết nối tới Redis server
+//   - error: Lỗi nếu không thể kết nối tới Redis server hoặc cấu hình transform không hợp lệ
 func NewRedisDriver(config config.DriverRedisConfig, redis_manager redisManager.Manager) (RedisDriver, error) {
 	if !config.Enabled {
 		return nil, fmt.Errorf("redis driver is not enabled")
@@ -57,19 +91,31 @@ func NewRedisDriver(config config.DriverRedisConfig, redis_manager redisManager.
 	if err != nil {
 		return nil, fmt.Errorf("could not create Redis client: %w", err)
 	}
+	transformer, err := NewTransformer(config.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis transform config: %w", err)
+	}
+	prefix := config.Prefix
+	if prefix == "" {
+		prefix = "cache:" // Tiền tố mặc định
+	}
 	// Khởi tạo driver
 	driver := &redisDriver{
 		client:       client,
-		prefix:       "cache:", // Tiền tố mặc định
+		prefix:       prefix,
 		default_ttl:  time.Duration(config.DefaultTTL) * time.Second,
 		serializer:   json.Marshal,
 		deserializer: json.Unmarshal,
+		rawIntegers:  transformer == nil,
+		transformer:  transformer,
+		timeout:      config.GetOperationTimeout(),
 		hits:         0,
 		misses:       0,
 	}
 	switch config.Serializer {
 
 	case "gob":
+		driver.rawIntegers = false
 		driver.serializer = func(v interface{}) ([]byte, error) {
 			var buf bytes.Buffer
 			enc := gob.NewEncoder(&buf)
@@ -87,6 +133,7 @@ func NewRedisDriver(config config.DriverRedisConfig, redis_manager redisManager.
 			return nil
 		}
 	case "msgpack":
+		driver.rawIntegers = false
 		driver.serializer = func(v interface{}) ([]byte, error) {
 			data, err := msgpack.Marshal(v)
 			if err != nil {
@@ -121,8 +168,19 @@ func (d *redisDriver) prefixKey(key string) string {
 	return d.prefix + key
 }
 
+// redisGlobReplacer escape các ký tự đặc biệt của pattern trong SCAN/KEYS.
+var redisGlobReplacer = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// escapeGlob escape s để dùng làm phần so khớp nguyên văn trong pattern của Redis.
+func escapeGlob(s string) string {
+	return redisGlobReplacer.Replace(s)
+}
+
 // Get lấy một giá trị từ cache.
 func (d *redisDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	prefixedKey := d.prefixKey(key)
 
 	// Lấy giá trị từ Redis
@@ -130,7 +188,7 @@ func (d *redisDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 	if err != nil {
 		if err == redis.Nil {
 			// Key không tồn tại
-			d.misses++
+			atomic.AddInt64(&d.misses, 1)
 			return nil, false
 		}
 		// Lỗi khác
@@ -143,23 +201,60 @@ func (d *redisDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 	if d.deserializer != nil {
 		// Create a buffer to hold the decoded value
 		var decodedValue interface{}
-		if err := d.deserializer(data, &decodedValue); err != nil {
-			d.misses++
+		if err := d.decode(data, &decodedValue); err != nil {
+			atomic.AddInt64(&d.misses, 1)
 			return nil, false
 		}
 		value = decodedValue
 	} else {
 		// Fallback to JSON
 		if err := json.Unmarshal(data, &value); err != nil {
-			d.misses++
+			atomic.AddInt64(&d.misses, 1)
 			return nil, false
 		}
 	}
 
-	d.hits++
+	atomic.AddInt64(&d.hits, 1)
 	return value, true
 }
 
+// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào dest.
+//
+// Dữ liệu trong Redis được giải mã một lần bằng deserializer đã cấu hình vào kiểu của dest,
+// nên struct được trả về đúng kiểu thay vì map[string]interface{}.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy
+//   - dest: Con trỏ khác nil tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key, false nếu ngược lại
+//   - error: Lỗi nếu dest không hợp lệ, không thể đọc Redis hoặc không thể giải mã vào dest
+func (d *redisDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	if _, err := destinationOf(dest); err != nil {
+		return false, err
+	}
+
+	data, err := d.client.Get(ctx, d.prefixKey(key)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			atomic.AddInt64(&d.misses, 1)
+			return false, nil
+		}
+		return false, err
+	}
+
+	atomic.AddInt64(&d.hits, 1)
+	if err := d.decode(data, dest); err != nil {
+		return true, fmt.Errorf("could not decode cached value: %w", err)
+	}
+	return true, nil
+}
+
 // Set đặt một giá trị vào cache với TTL tùy chọn.
 //
 // Phương thức này mã hóa và lưu trữ một cặp key-value vào Redis với thời gian sống
@@ -174,10 +269,13 @@ func (d *redisDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
 func (d *redisDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	prefixedKey := d.prefixKey(key)
 
 	// Mã hóa dữ liệu
-	data, err := d.serializer(value)
+	data, err := d.encode(value)
 	if err != nil {
 		return fmt.Errorf("could not serialize value: %w", err)
 	}
@@ -203,6 +301,9 @@ func (d *redisDriver) Set(ctx context.Context, key string, value interface{}, tt
 // Returns:
 //   - bool: true nếu key tồn tại, false nếu ngược lại
 func (d *redisDriver) Has(ctx context.Context, key string) bool {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	prefixedKey := d.prefixKey(key)
 	exists, _ := d.client.Exists(ctx, prefixedKey).Result()
 	return exists > 0
@@ -219,6 +320,9 @@ func (d *redisDriver) Has(ctx context.Context, key string) bool {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa
 func (d *redisDriver) Delete(ctx context.Context, key string) error {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	prefixedKey := d.prefixKey(key)
 	return d.client.Del(ctx, prefixedKey).Err()
 }
@@ -228,41 +332,83 @@ func (d *redisDriver) Delete(ctx context.Context, key string) error {
 // Phương thức này quét và xóa tất cả các key có tiền tố đã cấu hình
 // trong Redis database được sử dụng. Phương pháp này an toàn hơn so với
 // FLUSHDB vì nó chỉ xóa các key thuộc về cache này.
+// Thời gian tối đa của thao tác được áp dụng cho từng lệnh SCAN và DEL,
+// nên việc xóa nhiều key không bị giới hạn bởi một timeout duy nhất.
 //
 // Params:
-//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - ctx: Context để kiểm soát thời gian thực thi của toàn bộ thao tác
 //
 // Returns:
 //   - error: Lỗi nếu có trong quá trình quét hoặc xóa
 func (d *redisDriver) Flush(ctx context.Context) error {
-	// Tìm tất cả các key có prefix
-	pattern := d.prefix + "*"
-	iter := d.client.Scan(ctx, 0, pattern, 0).Iterator()
+	return d.deleteMatching(ctx, escapeGlob(d.prefix)+"*")
+}
+
+// FlushPrefix xóa tất cả các key bắt đầu bằng prefix khỏi cache.
+//
+// Các key được tìm bằng SCAN với pattern gồm prefix của driver và prefix đã được escape,
+// nên các ký tự glob trong prefix được so khớp nguyên văn. Như Flush, thời gian tối đa
+// của thao tác được áp dụng cho từng lệnh SCAN và DEL.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của toàn bộ thao tác
+//   - prefix: Tiền tố của các key cần xóa (không gồm prefix của driver)
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình quét hoặc xóa
+func (d *redisDriver) FlushPrefix(ctx context.Context, prefix string) error {
+	return d.deleteMatching(ctx, escapeGlob(d.prefix+prefix)+"*")
+}
 
-	// Xóa từng key
-	var keys []string
-	for iter.Next(ctx) {
-		keys = append(keys, iter.Val())
+// redisScanCount là số key gợi ý cho mỗi lệnh SCAN của deleteMatching.
+const redisScanCount = 100
+
+// deleteMatching quét và xóa theo batch các key khớp với pattern.
+//
+// Mỗi lệnh SCAN và DEL có timeout riêng của driver, ctx giới hạn toàn bộ vòng lặp.
+func (d *redisDriver) deleteMatching(ctx context.Context, pattern string) error {
+	var cursor uint64
+	for {
+		keys, next, err := d.scan(ctx, cursor, pattern)
+		if err != nil {
+			return err
+		}
 
 		// Xóa theo batch để tối ưu hiệu suất
-		if len(keys) >= 100 {
-			if err := d.client.Del(ctx, keys...).Err(); err != nil {
+		if len(keys) > 0 {
+			if err := d.del(ctx, keys); err != nil {
 				return err
 			}
-			keys = []string{}
 		}
-	}
 
-	// Xóa batch cuối cùng
-	if len(keys) > 0 {
-		return d.client.Del(ctx, keys...).Err()
+		if next == 0 {
+			return nil
+		}
+		cursor = next
 	}
+}
+
+// scan thực hiện một lệnh SCAN với timeout của driver.
+func (d *redisDriver) scan(ctx context.Context, cursor uint64, pattern string) ([]string, uint64, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
 
-	return iter.Err()
+	return d.client.Scan(ctx, cursor, pattern, redisScanCount).Result()
+}
+
+// del thực hiện một lệnh DEL với timeout của driver.
+func (d *redisDriver) del(ctx context.Context, keys []string) error {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	return d.client.Del(ctx, keys...).Err()
 }
 
 // GetMultiple lấy nhiều giá trị từ cache
 func (d *redisDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	results := make(map[string]interface{})
 	missed := make([]string, 0)
 
@@ -296,7 +442,7 @@ func (d *redisDriver) GetMultiple(ctx context.Context, keys []string) (map[strin
 
 		// Use the same deserialization logic as Get method
 		if d.deserializer != nil {
-			if err := d.deserializer([]byte(data), &decoded); err != nil {
+			if err := d.decode([]byte(data), &decoded); err != nil {
 				missed = append(missed, keys[i])
 				continue
 			}
@@ -315,6 +461,9 @@ func (d *redisDriver) GetMultiple(ctx context.Context, keys []string) (map[strin
 
 // SetMultiple đặt nhiều giá trị vào cache
 func (d *redisDriver) SetMultiple(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	if ttl == 0 {
 		ttl = d.default_ttl
 	}
@@ -324,7 +473,7 @@ func (d *redisDriver) SetMultiple(ctx context.Context, values map[string]interfa
 
 	for key, value := range values {
 		// Mã hóa dữ liệu
-		data, err := d.serializer(value)
+		data, err := d.encode(value)
 		if err != nil {
 			return fmt.Errorf("could not serialize value for key '%s': %w", key, err)
 		}
@@ -340,6 +489,9 @@ func (d *redisDriver) SetMultiple(ctx context.Context, values map[string]interfa
 
 // DeleteMultiple xóa nhiều key khỏi cache
 func (d *redisDriver) DeleteMultiple(ctx context.Context, keys []string) error {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	if len(keys) == 0 {
 		return nil
 	}
@@ -354,29 +506,46 @@ func (d *redisDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 	return d.client.Del(ctx, prefixedKeys...).Err()
 }
 
-// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy
+// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Các lời gọi đồng thời cho cùng một key chỉ thực thi callback một lần, các lời gọi còn lại
+// chờ và nhận cùng kết quả. Tương đương RememberWithOptions với RememberOptions{TTL: ttl}.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
 func (d *redisDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	// Kiểm tra cache trước
-	value, found := d.Get(ctx, key)
-	if found {
-		return value, nil
-	}
-
-	// Không tìm thấy, gọi callback
-	value, err := callback()
-	if err != nil {
-		return nil, err
-	}
+	return d.RememberWithOptions(ctx, key, callback, RememberOptions{TTL: ttl})
+}
 
-	// Lưu kết quả vào cache
-	err = d.Set(ctx, key, value, ttl)
-	return value, err
+// RememberWithOptions lấy một giá trị từ cache hoặc thực thi callback, có chống cache stampede.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+//   - opts: TTL và các tùy chọn singleflight, khóa phân tán, XFetch, stale-while-revalidate
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *redisDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	return rememberWithOptions(ctx, d, &d.flights, d.default_ttl, key, callback, opts)
 }
 
 // Stats trả về thông tin thống kê về cache
 func (d *redisDriver) Stats(ctx context.Context) map[string]interface{} {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
 	// Đếm số lượng key với prefix
-	pattern := d.prefix + "*"
+	pattern := escapeGlob(d.prefix) + "*"
 	count, err := d.client.Keys(ctx, pattern).Result()
 	countVal := len(count)
 	if err != nil {
@@ -391,27 +560,347 @@ func (d *redisDriver) Stats(ctx context.Context) map[string]interface{} {
 
 	return map[string]interface{}{
 		"count":  countVal,
-		"hits":   d.hits,
-		"misses": d.misses,
+		"hits":   atomic.LoadInt64(&d.hits),
+		"misses": atomic.LoadInt64(&d.misses),
 		"type":   "redis",
 		"prefix": d.prefix,
 		"info":   info,
 	}
 }
 
+// Increment tăng giá trị số nguyên của một key một cách nguyên tử.
+//
+// Với serializer json, số nguyên được lưu dạng thập phân nên driver dùng trực tiếp INCRBY.
+// Với gob, msgpack hoặc khi có transformer, giá trị được đọc, giải mã và ghi lại trong transaction WATCH/MULTI
+// (thử lại khi key bị instance khác thay đổi giữa chừng), nên bộ đếm vẫn được lưu cùng
+// định dạng với Set và đọc được bằng Get.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khi truy cập Redis
+func (d *redisDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	if !d.rawIntegers {
+		return d.incrementTx(ctx, key, delta)
+	}
+
+	value, err := d.client.IncrBy(ctx, d.prefixKey(key), delta).Result()
+	if err != nil && strings.Contains(err.Error(), "not an integer") {
+		return 0, fmt.Errorf("%w: %q", ErrNotInteger, key)
+	}
+	return value, err
+}
+
+// incrementTx tăng bộ đếm được lưu bằng serializer không phải dạng thập phân
+// trong transaction WATCH/MULTI.
+func (d *redisDriver) incrementTx(ctx context.Context, key string, delta int64) (int64, error) {
+	prefixedKey := d.prefixKey(key)
+
+	var result int64
+	increment := func(tx *redis.Tx) error {
+		var current int64
+		ttl := time.Duration(0)
+
+		data, err := tx.Get(ctx, prefixedKey).Bytes()
+		switch {
+		case err == redis.Nil:
+		case err != nil:
+			return err
+		default:
+			var decoded interface{}
+			if err := d.decode(data, &decoded); err != nil {
+				return fmt.Errorf("%w: %q", ErrNotInteger, key)
+			}
+			value, ok := toInt64(decoded)
+			if !ok {
+				return fmt.Errorf("%w: %q", ErrNotInteger, key)
+			}
+			current, ttl = value, redis.KeepTTL
+		}
+
+		// Mã hóa dưới dạng interface để gob giải mã lại được vào interface{} trong Get
+		result = current + delta
+		var stored interface{} = result
+		encoded, err := d.encode(&stored)
+		if err != nil {
+			return fmt.Errorf("could not serialize value: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, prefixedKey, encoded, ttl)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < redisMaxTxRetries; i++ {
+		err := d.client.Watch(ctx, increment, prefixedKey)
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		return result, nil
+	}
+	return 0, fmt.Errorf("could not increment key '%s': %w", key, redis.TxFailedErr)
+}
+
+// Decrement giảm giá trị số nguyên của một key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotInteger nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khi truy cập Redis
+func (d *redisDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	return d.Increment(ctx, key, -delta)
+}
+
+// Add đặt một giá trị vào cache chỉ khi key chưa tồn tại, sử dụng SET NX của Redis.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+//   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
+func (d *redisDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	data, err := d.encode(value)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+	return d.client.SetNX(ctx, d.prefixKey(key), data, d.expiration(ttl)).Result()
+}
+
+// CompareAndSwap thay giá trị của key bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+//
+// oldValue được serialize rồi so sánh với dữ liệu đang lưu trong một Lua script,
+// nên phép so sánh và ghi là nguyên tử trên Redis. Khi có transformer, dữ liệu lưu thay đổi
+// giữa các lần ghi (nonce ngẫu nhiên của mã hóa), nên oldValue được so sánh với dữ liệu
+// đã khôi phục và script chỉ ghi nếu dữ liệu đang lưu chưa bị thay đổi kể từ lúc đọc.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa cần cập nhật
+//   - oldValue: Giá trị mong đợi hiện tại
+//   - newValue: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+//   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
+func (d *redisDriver) CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	oldData, err := d.storedData(ctx, key, oldValue)
+	if err != nil || oldData == nil {
+		return false, err
+	}
+	newData, err := d.encode(newValue)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	swapped, err := compareAndSwapScript.Run(ctx, d.client, []string{d.prefixKey(key)},
+		oldData, newData, d.expiration(ttl).Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return swapped == 1, nil
+}
+
+// compareAndDelete xóa key chỉ khi giá trị hiện tại bằng expected, so sánh trong một Lua script.
+func (d *redisDriver) compareAndDelete(ctx context.Context, key string, expected interface{}) (bool, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	data, err := d.storedData(ctx, key, expected)
+	if err != nil || data == nil {
+		return false, err
+	}
+	deleted, err := compareAndDeleteScript.Run(ctx, d.client, []string{d.prefixKey(key)}, data).Int()
+	if err != nil {
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
+// storedData trả về dữ liệu lưu trong Redis tương ứng với oldValue cho script CompareAndSwap.
+//
+// Không có transformer, đó chính là oldValue đã serialize. Có transformer, dữ liệu đang lưu
+// được đọc và trả về nếu giá trị khôi phục của nó bằng oldValue đã serialize, nil nếu không khớp.
+func (d *redisDriver) storedData(ctx context.Context, key string, oldValue interface{}) ([]byte, error) {
+	expected, err := d.serializer(oldValue)
+	if err != nil {
+		return nil, fmt.Errorf("could not serialize value: %w", err)
+	}
+	if d.transformer == nil {
+		return expected, nil
+	}
+
+	stored, err := d.client.Get(ctx, d.prefixKey(key)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	current, err := d.transformer.Decode(stored)
+	if err != nil || !bytes.Equal(current, expected) {
+		return nil, nil
+	}
+	return stored, nil
+}
+
+// encode serialize value rồi áp dụng transformer nếu có.
+func (d *redisDriver) encode(value interface{}) ([]byte, error) {
+	data, err := d.serializer(value)
+	if err != nil || d.transformer == nil {
+		return data, err
+	}
+	return d.transformer.Encode(data)
+}
+
+// decode khôi phục dữ liệu bằng transformer nếu có rồi deserialize vào dest.
+func (d *redisDriver) decode(data []byte, dest interface{}) error {
+	if d.transformer != nil {
+		var err error
+		if data, err = d.transformer.Decode(data); err != nil {
+			return err
+		}
+	}
+	return d.deserializer(data, dest)
+}
+
+// expiration chuyển ttl của API cache sang TTL của Redis (0 là không hết hạn).
+func (d *redisDriver) expiration(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		return d.default_ttl
+	}
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
 // Close giải phóng tài nguyên của driver
 func (d *redisDriver) Close() error {
 	return d.client.Close()
 }
 
+// TagVersions trả về version hiện tại của các tag, tạo version mới cho tag chưa có.
+//
+// Version được lưu dưới dạng chuỗi tại key prefix + "__tag:" + tag, không có TTL.
+// Tag chưa có version được tạo bằng SETNX nên các instance dùng chung Redis
+// luôn nhận cùng một version.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần lấy version
+//
+// Returns:
+//   - []string: Version của từng tag theo thứ tự của tags
+//   - error: Lỗi nếu không thể đọc hoặc ghi Redis
+func (d *redisDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	versions := make([]string, len(tags))
+	if len(tags) == 0 {
+		return versions, nil
+	}
+
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = d.prefixKey(tagVersionKey(tag))
+	}
+
+	values, err := d.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("could not load tag versions: %w", err)
+	}
+
+	for i, value := range values {
+		if version, ok := value.(string); ok && version != "" {
+			versions[i] = version
+			continue
+		}
+
+		version, err := newTagVersion()
+		if err != nil {
+			return nil, err
+		}
+		created, err := d.client.SetNX(ctx, keys[i], version, 0).Result()
+		if err != nil {
+			return nil, fmt.Errorf("could not create version of tag '%s': %w", tags[i], err)
+		}
+		if !created {
+			// Instance khác vừa tạo version trước, dùng version đó
+			if version, err = d.client.Get(ctx, keys[i]).Result(); err != nil {
+				return nil, fmt.Errorf("could not load version of tag '%s': %w", tags[i], err)
+			}
+		}
+		versions[i] = version
+	}
+	return versions, nil
+}
+
+// FlushTags làm mất hiệu lực các entry gắn với tags bằng cách ghi version mới cho từng tag.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần làm mất hiệu lực
+//
+// Returns:
+//   - error: Lỗi nếu không thể ghi Redis
+func (d *redisDriver) FlushTags(ctx context.Context, tags []string) error {
+	ctx, cancel := operationContext(ctx, d.timeout)
+	defer cancel()
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pipe := d.client.Pipeline()
+	for _, tag := range tags {
+		version, err := newTagVersion()
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, d.prefixKey(tagVersionKey(tag)), version, 0)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // WithSerializer thiết lập serializer theo tên
 func (d *redisDriver) WithSerializer(serializerName string) RedisDriver {
 	newDriver := &redisDriver{
 		client:      d.client,
 		prefix:      d.prefix,
 		default_ttl: d.default_ttl,
-		hits:        d.hits,
-		misses:      d.misses,
+		transformer: d.transformer,
+		timeout:     d.timeout,
+		hits:        atomic.LoadInt64(&d.hits),
+		misses:      atomic.LoadInt64(&d.misses),
 	}
 
 	switch serializerName {
@@ -449,6 +938,7 @@ func (d *redisDriver) WithSerializer(serializerName string) RedisDriver {
 	default: // json
 		newDriver.serializer = json.Marshal
 		newDriver.deserializer = json.Unmarshal
+		newDriver.rawIntegers = d.transformer == nil
 	}
 
 	return newDriver
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -93,6 +95,22 @@ func (suite *RedisDriverTestSuite) TestNewRedisDriver_ClientError() {
 	assert.Contains(suite.T(), err.Error(), "could not create Redis client")
 }
 
+func (suite *RedisDriverTestSuite) TestNewRedisDriver_InvalidTransform() {
+	// Arrange
+	transformConfig := suite.config
+	transformConfig.Transform = &config.TransformConfig{
+		Compression: &config.CompressionConfig{Enabled: true, Algorithm: "lz4"},
+	}
+	suite.mockManager.EXPECT().Client().Return(suite.mockClient, nil).Once()
+
+	// Act
+	redisDriver, err := driver.NewRedisDriver(transformConfig, suite.mockManager)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, driver.ErrUnsupportedCompression)
+	assert.Nil(suite.T(), redisDriver)
+}
+
 func (suite *RedisDriverTestSuite) TestNewRedisDriver_WithGobSerializer() {
 	// Arrange
 	gobConfig := suite.config
@@ -348,6 +366,29 @@ func TestRedisDriverIntegration(t *testing.T) {
 		assert.False(t, redisDriver.Has(ctx, "flush2"))
 	})
 
+	t.Run("Flush Many Keys With Operation Timeout", func(t *testing.T) {
+		// Timeout được áp dụng cho từng lệnh SCAN và DEL, không cho toàn bộ Flush
+		timeoutManager := redisMocks.NewMockManager(t)
+		timeoutManager.EXPECT().Client().Return(client, nil).Once()
+		timeoutConfig := config
+		timeoutConfig.Prefix = "flush:timeout:"
+		timeoutConfig.OperationTimeout = 200
+		timeoutDriver, err := driver.NewRedisDriver(timeoutConfig, timeoutManager)
+		assert.NoError(t, err)
+		defer timeoutDriver.Close()
+
+		items := make(map[string]interface{}, 2000)
+		for i := 0; i < 2000; i++ {
+			items[fmt.Sprintf("key:%d", i)] = i
+		}
+		assert.NoError(t, timeoutDriver.SetMultiple(ctx, items, 0))
+
+		assert.NoError(t, timeoutDriver.Flush(ctx))
+		keys, err := client.Keys(ctx, "flush:timeout:*").Result()
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
 	t.Run("TTL Expiration", func(t *testing.T) {
 		key := "test:ttl"
 		value := "test_value"
@@ -368,6 +409,167 @@ func TestRedisDriverIntegration(t *testing.T) {
 		_, found = redisDriver.Get(ctx, key)
 		assert.False(t, found)
 	})
+
+	t.Run("Tag Versions", func(t *testing.T) {
+		versions, err := redisDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.Len(t, versions, 2)
+		assert.NotEqual(t, versions[0], versions[1])
+
+		// Version ổn định giữa các lần gọi và theo đúng thứ tự tag
+		again, err := redisDriver.TagVersions(ctx, []string{"users", "tenant:7"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{versions[1], versions[0]}, again)
+
+		// FlushTags chỉ đổi version của tag được flush
+		assert.NoError(t, redisDriver.FlushTags(ctx, []string{"tenant:7"}))
+		flushed, err := redisDriver.TagVersions(ctx, []string{"tenant:7", "users"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, versions[0], flushed[0])
+		assert.Equal(t, versions[1], flushed[1])
+
+		// Version của tag không có TTL
+		ttl, err := client.TTL(ctx, "cache:__tag:users").Result()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(-1), ttl)
+	})
+
+	t.Run("Atomic Operations", func(t *testing.T) {
+		// Increment khởi tạo key chưa tồn tại bằng delta
+		value, err := redisDriver.Increment(ctx, "atomic:counter", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = redisDriver.Increment(ctx, "atomic:counter", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), value)
+
+		value, err = redisDriver.Decrement(ctx, "atomic:counter", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-2), value)
+
+		// Increment trên giá trị số đã ghi bằng Set
+		assert.NoError(t, redisDriver.Set(ctx, "atomic:set", 40, time.Minute))
+		value, err = redisDriver.Increment(ctx, "atomic:set", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), value)
+
+		assert.NoError(t, redisDriver.Set(ctx, "atomic:text", "alice", time.Minute))
+		_, err = redisDriver.Increment(ctx, "atomic:text", 1)
+		assert.ErrorIs(t, err, driver.ErrNotInteger)
+
+		// Add chỉ ghi khi key chưa tồn tại
+		added, err := redisDriver.Add(ctx, "atomic:once", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		added, err = redisDriver.Add(ctx, "atomic:once", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		result, found := redisDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "first", result)
+
+		// CompareAndSwap chỉ ghi khi giá trị hiện tại khớp
+		swapped, err := redisDriver.CompareAndSwap(ctx, "atomic:once", "other", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+		swapped, err = redisDriver.CompareAndSwap(ctx, "atomic:once", "first", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		result, found = redisDriver.Get(ctx, "atomic:once")
+		assert.True(t, found)
+		assert.Equal(t, "third", result)
+
+		swapped, err = redisDriver.CompareAndSwap(ctx, "atomic:missing", nil, "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+
+		// Increment đồng thời không mất cập nhật
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					_, err := redisDriver.Increment(ctx, "atomic:concurrent", 1)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		value, err = redisDriver.Increment(ctx, "atomic:concurrent", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), value)
+
+		// Serializer gob lưu bộ đếm cùng định dạng với Set
+		gobDriver := redisDriver.WithSerializer("gob")
+		value, err = gobDriver.Increment(ctx, "atomic:gob", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), value)
+		value, err = gobDriver.Increment(ctx, "atomic:gob", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), value)
+
+		result, found = gobDriver.Get(ctx, "atomic:gob")
+		assert.True(t, found)
+		assert.Equal(t, int64(7), result)
+	})
+
+	t.Run("Remember With Lock", func(t *testing.T) {
+		// Driver thứ hai có singleflight riêng, đóng vai một instance khác dùng chung Redis
+		otherDriver := redisDriver.WithSerializer("json")
+
+		var calls int32
+		callback := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(100 * time.Millisecond)
+			return "computed", nil
+		}
+		opts := driver.RememberOptions{TTL: time.Minute, Lock: true, LockWait: 2 * time.Second}
+
+		var wg sync.WaitGroup
+		for _, instance := range []driver.RedisDriver{redisDriver, otherDriver} {
+			wg.Add(1)
+			go func(instance driver.RedisDriver) {
+				defer wg.Done()
+				value, err := instance.RememberWithOptions(ctx, "remember:locked", callback, opts)
+				assert.NoError(t, err)
+				assert.Equal(t, "computed", value)
+			}(instance)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		exists, err := client.Exists(ctx, "cache:__lock:remember:locked").Result()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), exists, "lock should be released")
+	})
+
+	t.Run("GetInto", func(t *testing.T) {
+		type profile struct {
+			Name string `json:"name"`
+			Age  int    `json:"age"`
+		}
+		assert.NoError(t, redisDriver.Set(ctx, "typed:profile", profile{Name: "An", Age: 30}, time.Minute))
+
+		var result profile
+		found, err := redisDriver.GetInto(ctx, "typed:profile", &result)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "An", Age: 30}, result)
+
+		var number int
+		found, err = redisDriver.GetInto(ctx, "typed:profile", &number)
+		assert.True(t, found)
+		assert.Error(t, err)
+
+		found, err = redisDriver.GetInto(ctx, "typed:missing", &result)
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
 }
 
 func TestRedisDriverMocked(t *testing.T) {
@@ -1454,3 +1656,70 @@ func TestRedisDriverSerializerSwitching(t *testing.T) {
 		msgpackDriver.Delete(ctx, "msgpack_key")
 	})
 }
+
+func TestRedisDriverTransform(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Redis transform tests in short mode")
+	}
+
+	// Skip if Redis is not available
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15,
+	})
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available, skipping transform tests")
+	}
+	defer client.Close()
+
+	mockManager := redisMocks.NewMockManager(t)
+	mockManager.EXPECT().Client().Return(client, nil).Once()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 10,
+		Serializer: "json",
+		Prefix:     "transform:",
+		Transform: &config.TransformConfig{
+			Compression: &config.CompressionConfig{Enabled: true, Algorithm: driver.CompressionZstd, Threshold: 64},
+			Encryption:  &config.EncryptionConfig{Enabled: true, KeyID: "k1", Keys: map[string]string{"k1": testEncryptionKey}},
+		},
+	}, mockManager)
+	assert.NoError(t, err)
+	defer redisDriver.Close()
+	defer redisDriver.Flush(ctx)
+
+	t.Run("Stores Encrypted Values", func(t *testing.T) {
+		value := strings.Repeat("secret ", 100)
+		assert.NoError(t, redisDriver.Set(ctx, "pii", value, time.Minute))
+
+		raw, err := client.Get(ctx, "transform:pii").Bytes()
+		assert.NoError(t, err)
+		assert.NotContains(t, string(raw), "secret")
+
+		retrieved, found := redisDriver.Get(ctx, "pii")
+		assert.True(t, found)
+		assert.Equal(t, value, retrieved)
+	})
+
+	t.Run("Atomic Operations", func(t *testing.T) {
+		value, err := redisDriver.Increment(ctx, "counter", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+		value, err = redisDriver.Decrement(ctx, "counter", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), value)
+
+		assert.NoError(t, redisDriver.Set(ctx, "cas", "v1", time.Minute))
+		swapped, err := redisDriver.CompareAndSwap(ctx, "cas", "other", "v2", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+		swapped, err = redisDriver.CompareAndSwap(ctx, "cas", "v1", "v2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+		retrieved, _ := redisDriver.Get(ctx, "cas")
+		assert.Equal(t, "v2", retrieved)
+	})
+}
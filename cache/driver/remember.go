@@ -0,0 +1,434 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rememberMetaKeyPrefix là tiền tố của key lưu metadata (hạn logic, thời gian tính toán) của Remember.
+	rememberMetaKeyPrefix = "__remember:"
+
+	// rememberLockKeyPrefix là tiền tố của key dùng làm khóa phân tán của Remember.
+	rememberLockKeyPrefix = "__lock:"
+
+	// defaultRememberLockTTL là thời gian sống mặc định của khóa phân tán.
+	defaultRememberLockTTL = 30 * time.Second
+
+	// defaultRememberLockWait là thời gian chờ mặc định khi instance khác đang giữ khóa.
+	defaultRememberLockWait = 5 * time.Second
+
+	// rememberLockPollInterval là chu kỳ kiểm tra giá trị khi chờ instance giữ khóa.
+	rememberLockPollInterval = 50 * time.Millisecond
+)
+
+// compareAndDeleter là driver có thể xóa một key chỉ khi giá trị hiện tại bằng giá trị mong đợi
+// trong một thao tác nguyên tử, dùng để nhả khóa phân tán của Remember.
+type compareAndDeleter interface {
+	// compareAndDelete xóa key nếu giá trị hiện tại bằng expected.
+	//
+	// Returns:
+	//   - bool: true nếu key được xóa, false nếu key không tồn tại hoặc giá trị khác expected
+	//   - error: Lỗi nếu có trong quá trình truy cập dữ liệu
+	compareAndDelete(ctx context.Context, key string, expected interface{}) (bool, error)
+}
+
+// RememberOptions cấu hình cách Remember chống cache stampede.
+//
+// Mặc định (chỉ đặt TTL), các lời gọi đồng thời cho cùng một key trong một process chỉ
+// thực thi callback một lần (singleflight). Các tùy chọn còn lại bổ sung khóa phân tán giữa
+// các instance, tính lại sớm theo xác suất (XFetch) và trả về giá trị cũ trong khi làm mới nền
+// (stale-while-revalidate).
+//
+// Beta và StaleTTL chỉ có tác dụng khi TTL hiệu lực > 0. Khi bật, hạn logic của giá trị được lưu
+// ở một key metadata riêng, nên key dùng với các tùy chọn này chỉ nên được ghi qua Remember.
+type RememberOptions struct {
+	// TTL là thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	TTL time.Duration
+
+	// Lock bật khóa phân tán (dựa trên Add của driver) khi tính lại giá trị, để chỉ một instance
+	// dùng chung driver (Redis, MongoDB, thư mục file) thực thi callback
+	Lock bool
+
+	// LockTTL là thời gian sống của khóa phân tán (0 để dùng mặc định 30 giây)
+	LockTTL time.Duration
+
+	// LockWait là thời gian tối đa chờ instance giữ khóa ghi giá trị mới; hết thời gian chờ,
+	// callback được thực thi mà không có khóa (0 để dùng mặc định 5 giây)
+	LockWait time.Duration
+
+	// Beta là hệ số của thuật toán XFetch: giá trị được tính lại sớm với xác suất tăng dần khi
+	// gần hết hạn, tỷ lệ với thời gian thực thi callback. 1 là giá trị khuyến nghị, 0 để tắt
+	Beta float64
+
+	// StaleTTL là khoảng thời gian sau khi hết hạn mà giá trị cũ vẫn được trả về ngay,
+	// trong khi giá trị mới được tính lại ở nền (0 để tắt)
+	StaleTTL time.Duration
+}
+
+// rememberMeta là metadata của giá trị được ghi bởi Remember khi bật Beta hoặc StaleTTL.
+type rememberMeta struct {
+	expiry time.Time     // Hạn logic của giá trị
+	delta  time.Duration // Thời gian thực thi callback lần gần nhất
+}
+
+// rememberWithOptions cài đặt Remember có chống cache stampede trên các thao tác cơ bản của driver.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - d: Driver lưu trữ giá trị
+//   - flights: Nhóm singleflight của driver
+//   - defaultTTL: TTL mặc định của driver, dùng khi opts.TTL = 0
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+//   - opts: Tùy chọn chống cache stampede
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func rememberWithOptions(ctx context.Context, d Driver, flights *flightGroup, defaultTTL time.Duration, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	if callback == nil {
+		return nil, fmt.Errorf("callback function is required")
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	tracked := ttl > 0 && (opts.Beta > 0 || opts.StaleTTL > 0)
+
+	// load tính lại giá trị đã đọc với hạn logic seen (zero nếu chưa có giá trị).
+	load := func(seen time.Time) func(context.Context) (interface{}, error) {
+		return func(ctx context.Context) (interface{}, error) {
+			// Lần thực thi trước cho key có thể vừa kết thúc giữa lúc đọc cache và lúc trở thành leader
+			if value, found := refreshedRemembered(ctx, d, key, tracked, seen); found {
+				return value, nil
+			}
+			return loadRemembered(ctx, d, key, ttl, tracked, seen, callback, opts)
+		}
+	}
+
+	if !tracked {
+		if value, found := d.Get(ctx, key); found {
+			return value, nil
+		}
+		return flights.Do(ctx, key, load(time.Time{}))
+	}
+
+	metaKey := rememberMetaKeyPrefix + key
+	values, _ := d.GetMultiple(ctx, []string{key, metaKey})
+	value, found := values[key]
+	if !found {
+		return flights.Do(ctx, key, load(time.Time{}))
+	}
+
+	meta, ok := parseRememberMeta(values[metaKey])
+	if !ok {
+		// Giá trị không được ghi bởi Remember có metadata, dùng như giá trị thường
+		return value, nil
+	}
+
+	now := time.Now()
+	if now.Before(meta.expiry) {
+		if opts.Beta > 0 && xfetch(now, meta, opts.Beta) {
+			return flights.Do(ctx, key, load(meta.expiry))
+		}
+		return value, nil
+	}
+
+	if opts.StaleTTL > 0 {
+		// Trả về giá trị cũ, chỉ một goroutine làm mới ở nền
+		flights.Go(context.WithoutCancel(ctx), key, load(meta.expiry))
+		return value, nil
+	}
+	return flights.Do(ctx, key, load(meta.expiry))
+}
+
+// loadRemembered thực thi callback (có khóa phân tán nếu được bật) và lưu kết quả vào driver.
+func loadRemembered(ctx context.Context, d Driver, key string, ttl time.Duration, tracked bool, seen time.Time, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	if opts.Lock {
+		release, value, found, err := acquireRememberLock(ctx, d, key, tracked, seen, opts)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return value, nil
+		}
+		defer release()
+	}
+
+	start := time.Now()
+	value, err := callback()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tracked {
+		return value, d.Set(ctx, key, value, opts.TTL)
+	}
+
+	// Giá trị được giữ thêm StaleTTL sau hạn logic để phục vụ stale-while-revalidate
+	physicalTTL := ttl + opts.StaleTTL
+	meta := rememberMeta{expiry: time.Now().Add(ttl), delta: time.Since(start)}
+	if err := d.Set(ctx, key, value, physicalTTL); err != nil {
+		return value, err
+	}
+	return value, d.Set(ctx, rememberMetaKeyPrefix+key, meta.String(), physicalTTL)
+}
+
+// acquireRememberLock lấy khóa phân tán cho key hoặc chờ instance đang giữ khóa ghi giá trị mới.
+//
+// Returns:
+//   - func(): Hàm nhả khóa (nil nếu không giữ khóa)
+//   - interface{}: Giá trị mới do instance khác ghi trong lúc chờ
+//   - bool: true nếu đã có giá trị mới và không cần thực thi callback
+//   - error: Lỗi nếu không thể tạo khóa hoặc context bị hủy
+func acquireRememberLock(ctx context.Context, d Driver, key string, tracked bool, seen time.Time, opts RememberOptions) (func(), interface{}, bool, error) {
+	lockTTL := opts.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaultRememberLockTTL
+	}
+	lockWait := opts.LockWait
+	if lockWait <= 0 {
+		lockWait = defaultRememberLockWait
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	lockKey := rememberLockKeyPrefix + key
+	release := func() {
+		releaseRememberLock(context.WithoutCancel(ctx), d, lockKey, token)
+	}
+
+	deadline := time.Now().Add(lockWait)
+	for {
+		acquired, err := d.Add(ctx, lockKey, token, lockTTL)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("could not acquire remember lock: %w", err)
+		}
+		if acquired {
+			// Instance giữ khóa trước có thể vừa ghi giá trị mới rồi nhả khóa
+			if value, found := refreshedRemembered(ctx, d, key, tracked, seen); found {
+				release()
+				return nil, value, true, nil
+			}
+			return release, nil, false, nil
+		}
+
+		if value, found := freshRemembered(ctx, d, key, tracked); found {
+			return nil, value, true, nil
+		}
+		if !time.Now().Before(deadline) {
+			// Hết thời gian chờ, tính lại mà không giữ khóa để không chặn request
+			return func() {}, nil, false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, false, ctx.Err()
+		case <-time.After(rememberLockPollInterval):
+		}
+	}
+}
+
+// releaseRememberLock xóa khóa phân tán nếu nó vẫn là khóa của instance này.
+//
+// Khóa có thể đã hết hạn và bị instance khác lấy, nên việc so sánh token và xóa phải nguyên tử.
+// Driver không hỗ trợ compareAndDeleter (ví dụ L2 tùy chỉnh của tiered driver) được đọc rồi xóa.
+func releaseRememberLock(ctx context.Context, d Driver, lockKey, token string) {
+	if deleter, ok := d.(compareAndDeleter); ok {
+		_, _ = deleter.compareAndDelete(ctx, lockKey, token)
+		return
+	}
+	if current, found := d.Get(ctx, lockKey); found && current == token {
+		_ = d.Delete(ctx, lockKey)
+	}
+}
+
+// refreshedRemembered trả về giá trị của key nếu nó đã được tính lại sau lần đọc có hạn logic seen.
+//
+// Với seen zero (key chưa có giá trị khi đọc), mọi giá trị còn hạn đều được trả về, kể cả giá trị
+// không được ghi bởi Remember. Với key đã có giá trị, chỉ giá trị có hạn logic mới hơn seen
+// được trả về, để tính lại sớm (Beta) không bị bỏ qua vì giá trị cũ vẫn còn hạn.
+func refreshedRemembered(ctx context.Context, d Driver, key string, tracked bool, seen time.Time) (interface{}, bool) {
+	if !tracked || seen.IsZero() {
+		return freshRemembered(ctx, d, key, tracked)
+	}
+
+	values, _ := d.GetMultiple(ctx, []string{key, rememberMetaKeyPrefix + key})
+	value, found := values[key]
+	if !found {
+		return nil, false
+	}
+	meta, ok := parseRememberMeta(values[rememberMetaKeyPrefix+key])
+	if !ok || !meta.expiry.After(seen) || !time.Now().Before(meta.expiry) {
+		return nil, false
+	}
+	return value, true
+}
+
+// freshRemembered trả về giá trị của key nếu còn hạn (theo hạn logic nếu có metadata).
+func freshRemembered(ctx context.Context, d Driver, key string, tracked bool) (interface{}, bool) {
+	if !tracked {
+		return d.Get(ctx, key)
+	}
+
+	values, _ := d.GetMultiple(ctx, []string{key, rememberMetaKeyPrefix + key})
+	value, found := values[key]
+	if !found {
+		return nil, false
+	}
+	meta, ok := parseRememberMeta(values[rememberMetaKeyPrefix+key])
+	if ok && !time.Now().Before(meta.expiry) {
+		return nil, false
+	}
+	return value, true
+}
+
+// xfetch quyết định tính lại sớm theo thuật toán XFetch (Vattani và cộng sự, 2015):
+// tính lại khi now - delta * beta * ln(rand) >= expiry.
+func xfetch(now time.Time, meta rememberMeta, beta float64) bool {
+	gap := -float64(meta.delta) * beta * math.Log(1-rand.Float64())
+	return !now.Add(time.Duration(gap)).Before(meta.expiry)
+}
+
+// String mã hóa metadata thành chuỗi "expiry:delta" (nano giây) để lưu được với mọi serializer.
+func (m rememberMeta) String() string {
+	return strconv.FormatInt(m.expiry.UnixNano(), 10) + ":" + strconv.FormatInt(int64(m.delta), 10)
+}
+
+// parseRememberMeta giải mã metadata được ghi bởi rememberMeta.String.
+func parseRememberMeta(value interface{}) (rememberMeta, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return rememberMeta{}, false
+	}
+	expiry, delta, ok := strings.Cut(text, ":")
+	if !ok {
+		return rememberMeta{}, false
+	}
+	expiryNano, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return rememberMeta{}, false
+	}
+	deltaNano, err := strconv.ParseInt(delta, 10, 64)
+	if err != nil {
+		return rememberMeta{}, false
+	}
+	return rememberMeta{expiry: time.Unix(0, expiryNano), delta: time.Duration(deltaNano)}, true
+}
+
+// flightGroup gộp các lời gọi đồng thời cho cùng một key trong process thành một lần thực thi.
+//
+// Giá trị zero của flightGroup sẵn sàng để sử dụng.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight là một lần thực thi đang diễn ra của flightGroup.
+type flight struct {
+	done     chan struct{}
+	value    interface{}
+	err      error
+	canceled bool // true nếu context của leader bị hủy trong lúc thực thi
+}
+
+// Do thực thi fn cho key, hoặc chờ kết quả của lần thực thi đang diễn ra cho cùng key.
+//
+// Nếu context của leader bị hủy trong lúc thực thi, lời gọi đang chờ không nhận lỗi của leader
+// mà thực thi lại fn với context của chính nó. Panic của fn được ném lại trên goroutine của leader.
+//
+// Params:
+//   - ctx: Context của lời gọi; lời gọi chờ kết quả trả về ctx.Err() khi context bị hủy
+//   - key: Khóa gộp các lời gọi
+//   - fn: Hàm cần thực thi
+//
+// Returns:
+//   - interface{}: Kết quả của fn
+//   - error: Lỗi của fn hoặc của context
+func (g *flightGroup) Do(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	for {
+		f, leader := g.join(key)
+		if leader {
+			if r := g.run(ctx, key, f, fn); r != nil {
+				panic(r)
+			}
+			return f.value, f.err
+		}
+
+		select {
+		case <-f.done:
+			if f.canceled && ctx.Err() == nil {
+				continue
+			}
+			return f.value, f.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Go thực thi fn cho key ở nền nếu chưa có lần thực thi nào đang diễn ra cho key đó.
+//
+// Panic của fn được chuyển thành lỗi cho các lời gọi đang chờ và không làm dừng process.
+//
+// Params:
+//   - ctx: Context truyền cho fn
+//   - key: Khóa gộp các lời gọi
+//   - fn: Hàm cần thực thi, kết quả và lỗi được bỏ qua
+func (g *flightGroup) Go(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) {
+	f, leader := g.join(key)
+	if leader {
+		go g.run(ctx, key, f, fn)
+	}
+}
+
+// join trả về lần thực thi đang diễn ra cho key, hoặc tạo mới nếu chưa có.
+func (g *flightGroup) join(key string) (*flight, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if f, ok := g.flights[key]; ok {
+		return f, false
+	}
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f := &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	return f, true
+}
+
+// run thực thi fn và giải phóng các lời gọi đang chờ, kể cả khi fn panic.
+//
+// Returns:
+//   - interface{}: Giá trị panic của fn (nil nếu fn không panic), để caller quyết định ném lại
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fn func(context.Context) (interface{}, error)) (panicked interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = r
+			f.err = fmt.Errorf("remember callback panicked: %v", r)
+		}
+		f.canceled = ctx.Err() != nil && f.err != nil
+		g.finish(key, f)
+	}()
+	f.value, f.err = fn(ctx)
+	return nil
+}
+
+// finish đánh dấu lần thực thi hoàn tất và xóa nó khỏi nhóm.
+func (g *flightGroup) finish(key string, f *flight) {
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+	close(f.done)
+}
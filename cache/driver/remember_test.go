@@ -0,0 +1,332 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
+)
+
+func TestRememberStampedeProtection(t *testing.T) {
+	ctx := context.Background()
+
+	newMemoryDriver := func(t *testing.T) driver.MemoryDriver {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300, CleanupInterval: 60})
+		t.Cleanup(func() { memoryDriver.Close() })
+		return memoryDriver
+	}
+
+	t.Run("Singleflight", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+
+		var calls int32
+		callback := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			return "computed", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := memoryDriver.Remember(ctx, "hot:key", time.Minute, callback)
+				assert.NoError(t, err)
+				assert.Equal(t, "computed", value)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Shared Error", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+		callbackErr := errors.New("database unavailable")
+
+		_, err := memoryDriver.RememberWithOptions(ctx, "failing:key", func() (interface{}, error) {
+			return nil, callbackErr
+		}, driver.RememberOptions{TTL: time.Minute})
+		assert.Equal(t, callbackErr, err)
+		assert.False(t, memoryDriver.Has(ctx, "failing:key"))
+
+		// Lỗi không được cache, lần gọi sau thực thi lại callback
+		value, err := memoryDriver.RememberWithOptions(ctx, "failing:key", func() (interface{}, error) {
+			return "recovered", nil
+		}, driver.RememberOptions{TTL: time.Minute})
+		assert.NoError(t, err)
+		assert.Equal(t, "recovered", value)
+	})
+
+	t.Run("Stale While Revalidate", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+		opts := driver.RememberOptions{TTL: 100 * time.Millisecond, StaleTTL: time.Minute}
+
+		value, err := memoryDriver.RememberWithOptions(ctx, "report", func() (interface{}, error) {
+			return "v1", nil
+		}, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+
+		time.Sleep(150 * time.Millisecond)
+
+		// Giá trị cũ được trả về ngay, giá trị mới được tính ở nền
+		refreshed := make(chan struct{})
+		value, err = memoryDriver.RememberWithOptions(ctx, "report", func() (interface{}, error) {
+			defer close(refreshed)
+			return "v2", nil
+		}, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+
+		select {
+		case <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("background refresh did not run")
+		}
+		assert.Eventually(t, func() bool {
+			value, _ := memoryDriver.RememberWithOptions(ctx, "report", func() (interface{}, error) {
+				return "v3", nil
+			}, opts)
+			return value == "v2"
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Expired Without Stale Recomputes", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+		opts := driver.RememberOptions{TTL: 50 * time.Millisecond, Beta: 1}
+
+		_, err := memoryDriver.RememberWithOptions(ctx, "short", func() (interface{}, error) {
+			return "v1", nil
+		}, opts)
+		assert.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		value, err := memoryDriver.RememberWithOptions(ctx, "short", func() (interface{}, error) {
+			return "v2", nil
+		}, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", value)
+	})
+
+	t.Run("XFetch Early Recomputation", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+		slow := func(result string) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				time.Sleep(10 * time.Millisecond)
+				return result, nil
+			}
+		}
+
+		// Beta rất lớn khiến giá trị luôn được tính lại trước khi hết hạn
+		_, err := memoryDriver.RememberWithOptions(ctx, "eager", slow("v1"), driver.RememberOptions{TTL: time.Minute, Beta: 1e6})
+		assert.NoError(t, err)
+		value, err := memoryDriver.RememberWithOptions(ctx, "eager", slow("v2"), driver.RememberOptions{TTL: time.Minute, Beta: 1e6})
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", value)
+
+		// Beta nhỏ với TTL dài hầu như không tính lại sớm
+		_, err = memoryDriver.RememberWithOptions(ctx, "lazy", slow("v1"), driver.RememberOptions{TTL: time.Hour, Beta: 1})
+		assert.NoError(t, err)
+		value, err = memoryDriver.RememberWithOptions(ctx, "lazy", slow("v2"), driver.RememberOptions{TTL: time.Hour, Beta: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+	})
+
+	t.Run("Distributed Lock", func(t *testing.T) {
+		tempDir, err := os.MkdirTemp("", "cache_remember_lock_test_")
+		assert.NoError(t, err)
+		defer os.RemoveAll(tempDir)
+
+		// Hai driver dùng chung thư mục đóng vai hai instance của ứng dụng
+		fileConfig := config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, CleanupInterval: 60}
+		first, err := driver.NewFileDriver(fileConfig)
+		assert.NoError(t, err)
+		defer first.Close()
+		second, err := driver.NewFileDriver(fileConfig)
+		assert.NoError(t, err)
+		defer second.Close()
+
+		var calls int32
+		callback := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(100 * time.Millisecond)
+			return "computed", nil
+		}
+		opts := driver.RememberOptions{TTL: time.Minute, Lock: true, LockWait: 2 * time.Second}
+
+		var wg sync.WaitGroup
+		for i, instance := range []driver.Driver{first, second, first, second} {
+			wg.Add(1)
+			go func(i int, instance driver.Driver) {
+				defer wg.Done()
+				time.Sleep(time.Duration(i) * time.Millisecond)
+				value, err := instance.RememberWithOptions(ctx, "shared:key", callback, opts)
+				assert.NoError(t, err)
+				assert.Equal(t, "computed", value)
+			}(i, instance)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.False(t, first.Has(ctx, "__lock:shared:key"), "lock should be released")
+	})
+
+	t.Run("Lock Wait Timeout", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+
+		// Khóa bị giữ bởi instance khác không bao giờ ghi giá trị
+		added, err := memoryDriver.Add(ctx, "__lock:stuck", "other", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+
+		start := time.Now()
+		value, err := memoryDriver.RememberWithOptions(ctx, "stuck", func() (interface{}, error) {
+			return "computed", nil
+		}, driver.RememberOptions{TTL: time.Minute, Lock: true, LockWait: 100 * time.Millisecond})
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+		// Khóa của instance khác không bị xóa
+		lock, found := memoryDriver.Get(ctx, "__lock:stuck")
+		assert.True(t, found)
+		assert.Equal(t, "other", lock)
+	})
+
+	t.Run("Context Cancellation While Waiting", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		go memoryDriver.Remember(ctx, "blocked", time.Minute, func() (interface{}, error) {
+			close(started)
+			<-release
+			return "late", nil
+		})
+		<-started
+		defer close(release)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := memoryDriver.Remember(waitCtx, "blocked", time.Minute, func() (interface{}, error) {
+			return "unused", nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Leader Cancellation Does Not Fail Followers", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+
+		// Khóa bị giữ bởi instance khác nên leader chờ khóa cho tới khi context của nó hết hạn
+		added, err := memoryDriver.Add(ctx, "__lock:contended", "other", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		opts := driver.RememberOptions{TTL: time.Minute, Lock: true, LockWait: 300 * time.Millisecond}
+		callback := func() (interface{}, error) { return "computed", nil }
+
+		leaderCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := memoryDriver.RememberWithOptions(leaderCtx, "contended", callback, opts)
+			leaderErr <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		value, err := memoryDriver.RememberWithOptions(ctx, "contended", callback, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+		assert.ErrorIs(t, <-leaderErr, context.DeadlineExceeded)
+	})
+
+	t.Run("Callback Panic Is Raised On Caller", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+
+		assert.Panics(t, func() {
+			memoryDriver.Remember(ctx, "panicking", time.Minute, func() (interface{}, error) {
+				panic("boom")
+			})
+		})
+
+		// Flight của key đã được giải phóng
+		value, err := memoryDriver.Remember(ctx, "panicking", time.Minute, func() (interface{}, error) {
+			return "recovered", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "recovered", value)
+	})
+
+	t.Run("Background Refresh Panic Is Recovered", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+		opts := driver.RememberOptions{TTL: 50 * time.Millisecond, StaleTTL: time.Minute}
+
+		_, err := memoryDriver.RememberWithOptions(ctx, "report", func() (interface{}, error) {
+			return "v1", nil
+		}, opts)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		// Panic của lần làm mới ở nền không làm dừng process, giá trị cũ vẫn được phục vụ
+		refreshed := make(chan struct{})
+		value, err := memoryDriver.RememberWithOptions(ctx, "report", func() (interface{}, error) {
+			defer close(refreshed)
+			panic("boom")
+		}, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		<-refreshed
+
+		assert.Eventually(t, func() bool {
+			value, _ := memoryDriver.RememberWithOptions(ctx, "report", func() (interface{}, error) {
+				return "v2", nil
+			}, opts)
+			return value == "v2"
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Expired Lock Of Another Instance Is Kept", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+
+		value, err := memoryDriver.RememberWithOptions(ctx, "slow", func() (interface{}, error) {
+			// Khóa của lời gọi này hết hạn và bị instance khác lấy trong lúc callback chạy
+			time.Sleep(100 * time.Millisecond)
+			added, err := memoryDriver.Add(ctx, "__lock:slow", "other", time.Minute)
+			assert.NoError(t, err)
+			assert.True(t, added)
+			return "computed", nil
+		}, driver.RememberOptions{TTL: time.Minute, Lock: true, LockTTL: 50 * time.Millisecond})
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+
+		lock, found := memoryDriver.Get(ctx, "__lock:slow")
+		assert.True(t, found)
+		assert.Equal(t, "other", lock)
+	})
+
+	t.Run("XFetch With Lock Recomputes", func(t *testing.T) {
+		memoryDriver := newMemoryDriver(t)
+		opts := driver.RememberOptions{TTL: time.Minute, Beta: 1e6, Lock: true}
+		slow := func(result string) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				time.Sleep(10 * time.Millisecond)
+				return result, nil
+			}
+		}
+
+		_, err := memoryDriver.RememberWithOptions(ctx, "eager", slow("v1"), opts)
+		assert.NoError(t, err)
+		value, err := memoryDriver.RememberWithOptions(ctx, "eager", slow("v2"), opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", value)
+	})
+}
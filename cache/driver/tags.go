@@ -0,0 +1,65 @@
+package driver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// tagVersionKeyPrefix là tiền tố của các key lưu version của tag trong driver.
+const tagVersionKeyPrefix = "__tag:"
+
+// Taggable định nghĩa các thao tác quản lý version của tag, dùng cho cache có gắn tag.
+//
+// Mỗi tag có một version ngẫu nhiên. Key của các entry gắn tag được tạo từ version hiện tại
+// của các tag, nên khi version của một tag thay đổi, mọi entry gắn tag đó không còn truy cập
+// được nữa và sẽ tự hết hạn theo TTL (hoặc bị loại bỏ theo chính sách của driver).
+type Taggable interface {
+	// TagVersions trả về version hiện tại của các tag, theo đúng thứ tự của tags.
+	//
+	// Tag chưa có version sẽ được tạo version mới. Việc tạo version là nguyên tử trên driver
+	// nên các instance dùng chung driver luôn nhận cùng một version cho cùng một tag.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - tags: Danh sách tag cần lấy version
+	//
+	// Returns:
+	//   - []string: Version của từng tag
+	//   - error: Lỗi nếu không thể đọc hoặc tạo version
+	TagVersions(ctx context.Context, tags []string) ([]string, error)
+
+	// FlushTags làm mất hiệu lực tất cả các entry gắn với bất kỳ tag nào trong tags.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - tags: Danh sách tag cần làm mất hiệu lực
+	//
+	// Returns:
+	//   - error: Lỗi nếu không thể cập nhật version
+	FlushTags(ctx context.Context, tags []string) error
+}
+
+// tagVersionKey trả về key lưu version của tag trong driver.
+func tagVersionKey(tag string) string {
+	return tagVersionKeyPrefix + tag
+}
+
+// newTagVersion tạo một version ngẫu nhiên cho tag.
+func newTagVersion() (string, error) {
+	version, err := randomToken()
+	if err != nil {
+		return "", fmt.Errorf("could not generate tag version: %w", err)
+	}
+	return version, nil
+}
+
+// randomToken tạo một chuỗi hex ngẫu nhiên 16 ký tự.
+func randomToken() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
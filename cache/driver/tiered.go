@@ -0,0 +1,526 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultTieredL1TTL là thời gian sống tối đa mặc định của entry trong L1.
+	defaultTieredL1TTL = time.Minute
+
+	// tieredStripes là số nhóm key dùng để theo dõi việc ghi và invalidate L1.
+	tieredStripes = 64
+)
+
+// TieredOptions là các tùy chọn của tiered driver.
+type TieredOptions struct {
+	// L1TTL là thời gian sống tối đa của entry trong L1, giới hạn thời gian một instance
+	// có thể đọc giá trị cũ khi không có invalidation (0 để dùng mặc định 1 phút)
+	L1TTL time.Duration
+
+	// Invalidator phát và nhận thông báo invalidate L1 giữa các instance dùng chung L2
+	// (nil nếu chỉ có một instance)
+	Invalidator Invalidator
+}
+
+type TieredDriver interface {
+	Driver
+	Taggable
+	PrefixFlusher
+}
+
+// tieredDriver cài đặt cache driver hai tầng.
+//
+// tieredDriver đặt một driver nhanh trong process (L1, thường là memory) trước một driver
+// dùng chung (L2, thường là Redis hoặc MongoDB). Đọc được phục vụ từ L1 nếu có, nếu không thì
+// lấy từ L2 và nạp vào L1 với TTL ngắn. Ghi và xóa được thực hiện trên L2 trước, sau đó cập nhật L1
+// và phát thông báo để các instance khác xóa key khỏi L1 của chúng.
+type tieredDriver struct {
+	l1            Driver        // Tầng cache trong process
+	l2            Driver        // Tầng cache dùng chung, nguồn dữ liệu chính
+	l1TTL         time.Duration // Thời gian sống tối đa của entry trong L1
+	invalidator   Invalidator   // Phát và nhận thông báo invalidate (nil nếu tắt)
+	source        string        // Định danh instance trong thông báo invalidate
+	flights       flightGroup   // Gộp các lời gọi Remember đồng thời cho cùng key
+	l1Hits        int64         // Số lần đọc được phục vụ từ L1
+	l2Hits        int64         // Số lần đọc được phục vụ từ L2
+	misses        int64         // Số lần không tìm thấy ở cả hai tầng
+	invalidations int64         // Số thông báo invalidate nhận được từ instance khác
+
+	// l1Mu tuần tự hóa việc nạp L1 sau khi đọc L2 với việc ghi và invalidate L1 theo nhóm key;
+	// generations tăng mỗi khi một key trong nhóm được ghi hoặc invalidate, để lần nạp L1
+	// bắt đầu trước đó không ghi đè giá trị mới bằng giá trị cũ đọc từ L2
+	l1Mu        [tieredStripes]sync.Mutex
+	generations [tieredStripes]uint64
+}
+
+// NewTieredDriver tạo một tiered driver với L1 đặt trước L2.
+//
+// Nếu opts.Invalidator khác nil, driver đăng ký nhận thông báo invalidate ngay khi khởi tạo.
+//
+// Params:
+//   - l1: Driver tầng L1, thường là memory driver riêng của tiered driver
+//   - l2: Driver tầng L2 dùng chung giữa các instance, thường là Redis hoặc MongoDB
+//   - opts: Tùy chọn L1TTL và Invalidator
+//
+// Returns:
+//   - TieredDriver: Driver đã được khởi tạo
+//   - error: Lỗi nếu thiếu driver hoặc không thể đăng ký nhận thông báo invalidate
+func NewTieredDriver(l1, l2 Driver, opts TieredOptions) (TieredDriver, error) {
+	if l1 == nil || l2 == nil {
+		return nil, fmt.Errorf("tiered driver requires both L1 and L2 drivers")
+	}
+
+	source, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate tiered driver id: %w", err)
+	}
+
+	d := &tieredDriver{
+		l1:          l1,
+		l2:          l2,
+		l1TTL:       opts.L1TTL,
+		invalidator: opts.Invalidator,
+		source:      source,
+	}
+	if d.l1TTL <= 0 {
+		d.l1TTL = defaultTieredL1TTL
+	}
+
+	if d.invalidator != nil {
+		if err := d.invalidator.Subscribe(d.handleInvalidation); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Get lấy một giá trị từ L1, hoặc từ L2 và nạp vào L1 nếu L1 không có.
+//
+// Giá trị từ L2 không được nạp vào L1 nếu key bị ghi hoặc invalidate trong lúc đọc L2.
+func (d *tieredDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	if value, found := d.l1.Get(ctx, key); found {
+		atomic.AddInt64(&d.l1Hits, 1)
+		return value, true
+	}
+
+	generations := d.generationsOf([]string{key})
+	value, found := d.l2.Get(ctx, key)
+	if !found {
+		atomic.AddInt64(&d.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&d.l2Hits, 1)
+	d.fillL1(ctx, map[string]interface{}{key: value}, generations)
+	return value, true
+}
+
+// GetInto lấy một giá trị từ L1, hoặc giải mã từ L2 vào dest và nạp giá trị đã giải mã vào L1.
+//
+// Nếu giá trị trong L1 không gán được vào dest (ví dụ được nạp bởi Get dưới dạng map),
+// giá trị được đọc lại từ L2 để dest luôn nhận đúng kiểu.
+func (d *tieredDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	target, err := destinationOf(dest)
+	if err != nil {
+		return false, err
+	}
+
+	if found, err := d.l1.GetInto(ctx, key, dest); found && err == nil {
+		atomic.AddInt64(&d.l1Hits, 1)
+		return true, nil
+	}
+
+	generations := d.generationsOf([]string{key})
+	found, err := d.l2.GetInto(ctx, key, dest)
+	if !found {
+		atomic.AddInt64(&d.misses, 1)
+		return false, err
+	}
+
+	atomic.AddInt64(&d.l2Hits, 1)
+	if err != nil {
+		return true, err
+	}
+	d.fillL1(ctx, map[string]interface{}{key: target.Interface()}, generations)
+	return true, nil
+}
+
+// Set đặt một giá trị vào L2 và L1, sau đó phát thông báo invalidate cho các instance khác.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị trong L2 (0 để sử dụng mặc định của L2, -1 để không hết hạn);
+//     thời gian sống trong L1 không vượt quá L1TTL
+//
+// Returns:
+//   - error: Lỗi nếu không thể ghi L2 hoặc phát thông báo invalidate
+func (d *tieredDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := d.l2.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	d.updateL1([]string{key}, func() {
+		_ = d.l1.Set(ctx, key, value, d.l1Expiration(ttl))
+	})
+	return d.publish(ctx, InvalidationMessage{Keys: []string{key}})
+}
+
+// Has kiểm tra xem một key có tồn tại ở L1 hoặc L2 không.
+func (d *tieredDriver) Has(ctx context.Context, key string) bool {
+	return d.l1.Has(ctx, key) || d.l2.Has(ctx, key)
+}
+
+// Delete xóa một key khỏi L2 và L1, sau đó phát thông báo invalidate.
+func (d *tieredDriver) Delete(ctx context.Context, key string) error {
+	return d.invalidate(ctx, d.l2.Delete(ctx, key), key)
+}
+
+// Flush xóa tất cả các key khỏi L2 và L1, sau đó yêu cầu các instance khác xóa L1.
+func (d *tieredDriver) Flush(ctx context.Context) error {
+	if err := d.l2.Flush(ctx); err != nil {
+		return err
+	}
+	d.updateL1(nil, func() {
+		_ = d.l1.Flush(ctx)
+	})
+	return d.publish(ctx, InvalidationMessage{Flush: true})
+}
+
+// FlushPrefix xóa các key bắt đầu bằng prefix khỏi L2 và L1, sau đó yêu cầu các instance khác làm tương tự.
+//
+// L2 phải cài đặt PrefixFlusher, nếu không phương thức trả về ErrPrefixFlushNotSupported.
+func (d *tieredDriver) FlushPrefix(ctx context.Context, prefix string) error {
+	l2, ok := d.l2.(PrefixFlusher)
+	if !ok {
+		return ErrPrefixFlushNotSupported
+	}
+	if err := l2.FlushPrefix(ctx, prefix); err != nil {
+		return err
+	}
+	d.flushL1Prefix(ctx, prefix)
+	return d.publish(ctx, InvalidationMessage{Prefix: prefix})
+}
+
+// GetMultiple lấy nhiều giá trị, các key không có ở L1 được lấy từ L2 trong một lần gọi.
+func (d *tieredDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	results, missed := d.l1.GetMultiple(ctx, keys)
+	atomic.AddInt64(&d.l1Hits, int64(len(results)))
+	if len(missed) == 0 {
+		return results, missed
+	}
+
+	generations := d.generationsOf(missed)
+	values, stillMissed := d.l2.GetMultiple(ctx, missed)
+	atomic.AddInt64(&d.l2Hits, int64(len(values)))
+	atomic.AddInt64(&d.misses, int64(len(stillMissed)))
+	d.fillL1(ctx, values, generations)
+	for key, value := range values {
+		results[key] = value
+	}
+	return results, stillMissed
+}
+
+// SetMultiple đặt nhiều giá trị vào L2 và L1, sau đó phát thông báo invalidate.
+func (d *tieredDriver) SetMultiple(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	if err := d.l2.SetMultiple(ctx, values, ttl); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	d.updateL1(keys, func() {
+		_ = d.l1.SetMultiple(ctx, values, d.l1Expiration(ttl))
+	})
+	return d.publish(ctx, InvalidationMessage{Keys: keys})
+}
+
+// DeleteMultiple xóa nhiều key khỏi L2 và L1, sau đó phát thông báo invalidate.
+func (d *tieredDriver) DeleteMultiple(ctx context.Context, keys []string) error {
+	return d.invalidate(ctx, d.l2.DeleteMultiple(ctx, keys), keys...)
+}
+
+// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Các lời gọi đồng thời cho cùng một key chỉ thực thi callback một lần.
+// Tương đương RememberWithOptions với RememberOptions{TTL: ttl}.
+func (d *tieredDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return d.RememberWithOptions(ctx, key, callback, RememberOptions{TTL: ttl})
+}
+
+// RememberWithOptions lấy một giá trị từ cache hoặc thực thi callback, có chống cache stampede.
+//
+// Khóa phân tán được tạo trên L2. Vì TTL mặc định của L2 không được biết ở tầng này,
+// Beta và StaleTTL chỉ có tác dụng khi opts.TTL > 0.
+func (d *tieredDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts RememberOptions) (interface{}, error) {
+	return rememberWithOptions(ctx, d, &d.flights, 0, key, callback, opts)
+}
+
+// Increment tăng bộ đếm trên L2 một cách nguyên tử và xóa key khỏi L1.
+func (d *tieredDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	value, err := d.l2.Increment(ctx, key, delta)
+	return value, d.invalidate(ctx, err, key)
+}
+
+// Decrement giảm bộ đếm trên L2 một cách nguyên tử và xóa key khỏi L1.
+func (d *tieredDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	value, err := d.l2.Decrement(ctx, key, delta)
+	return value, d.invalidate(ctx, err, key)
+}
+
+// Add đặt một giá trị vào L2 chỉ khi key chưa tồn tại, L1 được invalidate nếu giá trị được ghi.
+func (d *tieredDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	added, err := d.l2.Add(ctx, key, value, ttl)
+	if err != nil || !added {
+		return added, err
+	}
+	return true, d.invalidate(ctx, nil, key)
+}
+
+// CompareAndSwap so sánh và ghi trên L2, L1 được invalidate nếu giá trị được thay.
+func (d *tieredDriver) CompareAndSwap(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	swapped, err := d.l2.CompareAndSwap(ctx, key, oldValue, newValue, ttl)
+	if err != nil || !swapped {
+		return swapped, err
+	}
+	return true, d.invalidate(ctx, nil, key)
+}
+
+// compareAndDelete xóa key trên L2 chỉ khi giá trị hiện tại bằng expected, L1 được invalidate nếu key bị xóa.
+//
+// L2 không hỗ trợ thao tác này được đọc rồi xóa như releaseRememberLock.
+func (d *tieredDriver) compareAndDelete(ctx context.Context, key string, expected interface{}) (bool, error) {
+	deleter, ok := d.l2.(compareAndDeleter)
+	if !ok {
+		current, found := d.l2.Get(ctx, key)
+		if !found || current != expected {
+			return false, nil
+		}
+		return true, d.Delete(ctx, key)
+	}
+
+	deleted, err := deleter.compareAndDelete(ctx, key, expected)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+	return true, d.invalidate(ctx, nil, key)
+}
+
+// TagVersions trả về version của các tag từ L2 để mọi instance dùng chung version.
+func (d *tieredDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	taggable, ok := d.l2.(Taggable)
+	if !ok {
+		return nil, ErrL2NotTaggable
+	}
+	return taggable.TagVersions(ctx, tags)
+}
+
+// FlushTags đổi version của các tag trên L2.
+//
+// Entry gắn tag trong L1 của mọi instance trở nên không truy cập được vì key của chúng
+// được tạo từ version cũ, nên không cần phát thông báo invalidate.
+func (d *tieredDriver) FlushTags(ctx context.Context, tags []string) error {
+	taggable, ok := d.l2.(Taggable)
+	if !ok {
+		return ErrL2NotTaggable
+	}
+	return taggable.FlushTags(ctx, tags)
+}
+
+// Stats trả về thông tin thống kê của cả hai tầng.
+func (d *tieredDriver) Stats(ctx context.Context) map[string]interface{} {
+	return map[string]interface{}{
+		"type":          "tiered",
+		"l1_hits":       atomic.LoadInt64(&d.l1Hits),
+		"l2_hits":       atomic.LoadInt64(&d.l2Hits),
+		"misses":        atomic.LoadInt64(&d.misses),
+		"invalidations": atomic.LoadInt64(&d.invalidations),
+		"l1_ttl":        d.l1TTL.String(),
+		"l1":            d.l1.Stats(ctx),
+		"l2":            d.l2.Stats(ctx),
+	}
+}
+
+// Close dừng nhận thông báo invalidate và đóng L1.
+//
+// L2 không bị đóng vì thường được đăng ký và đóng riêng trong Manager.
+func (d *tieredDriver) Close() error {
+	var errs []error
+	if d.invalidator != nil {
+		if err := d.invalidator.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := d.l1.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// invalidate xóa các key khỏi L1 và phát thông báo invalidate nếu thao tác trên L2 thành công.
+func (d *tieredDriver) invalidate(ctx context.Context, err error, keys ...string) error {
+	if err != nil {
+		return err
+	}
+	d.updateL1(keys, func() {
+		_ = d.l1.DeleteMultiple(ctx, keys)
+	})
+	return d.publish(ctx, InvalidationMessage{Keys: keys})
+}
+
+// publish phát thông báo invalidate với định danh của instance này.
+func (d *tieredDriver) publish(ctx context.Context, message InvalidationMessage) error {
+	if d.invalidator == nil {
+		return nil
+	}
+	message.Source = d.source
+	if err := d.invalidator.Publish(ctx, message); err != nil {
+		return fmt.Errorf("could not publish invalidation: %w", err)
+	}
+	return nil
+}
+
+// handleInvalidation xóa L1 theo thông báo của instance khác.
+func (d *tieredDriver) handleInvalidation(message InvalidationMessage) {
+	if message.Source == d.source {
+		return
+	}
+	atomic.AddInt64(&d.invalidations, 1)
+
+	ctx := context.Background()
+	if message.Flush {
+		d.updateL1(nil, func() {
+			_ = d.l1.Flush(ctx)
+		})
+		return
+	}
+	if message.Prefix != "" {
+		d.flushL1Prefix(ctx, message.Prefix)
+		return
+	}
+	d.updateL1(message.Keys, func() {
+		_ = d.l1.DeleteMultiple(ctx, message.Keys)
+	})
+}
+
+// flushL1Prefix xóa các key bắt đầu bằng prefix khỏi L1, hoặc toàn bộ L1 nếu L1 không hỗ trợ xóa theo tiền tố.
+func (d *tieredDriver) flushL1Prefix(ctx context.Context, prefix string) {
+	d.updateL1(nil, func() {
+		if l1, ok := d.l1.(PrefixFlusher); ok {
+			_ = l1.FlushPrefix(ctx, prefix)
+			return
+		}
+		_ = d.l1.Flush(ctx)
+	})
+}
+
+// generationsOf trả về generation hiện tại của nhóm chứa từng key, đọc trước khi lấy giá trị từ L2.
+func (d *tieredDriver) generationsOf(keys []string) map[string]uint64 {
+	generations := make(map[string]uint64, len(keys))
+	for _, key := range keys {
+		stripe := tieredStripe(key)
+		d.l1Mu[stripe].Lock()
+		generations[key] = d.generations[stripe]
+		d.l1Mu[stripe].Unlock()
+	}
+	return generations
+}
+
+// fillL1 nạp các giá trị đọc từ L2 vào L1, bỏ qua key có nhóm đã được ghi hoặc invalidate
+// kể từ khi generations được đọc.
+func (d *tieredDriver) fillL1(ctx context.Context, values map[string]interface{}, generations map[string]uint64) {
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	stripes := d.lockStripes(keys)
+	defer d.unlockStripes(stripes)
+
+	fresh := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if generation, ok := generations[key]; ok && d.generations[tieredStripe(key)] == generation {
+			fresh[key] = value
+		}
+	}
+	if len(fresh) > 0 {
+		_ = d.l1.SetMultiple(ctx, fresh, d.l1TTL)
+	}
+}
+
+// updateL1 chạy update (ghi hoặc xóa L1) trong khóa của các nhóm chứa keys và tăng generation
+// của các nhóm đó. keys nil nghĩa là mọi nhóm, dùng cho các thao tác flush.
+func (d *tieredDriver) updateL1(keys []string, update func()) {
+	stripes := d.lockStripes(keys)
+	defer d.unlockStripes(stripes)
+
+	for _, stripe := range stripes {
+		d.generations[stripe]++
+	}
+	update()
+}
+
+// lockStripes khóa các nhóm chứa keys (mọi nhóm nếu keys nil) theo thứ tự tăng dần để tránh deadlock.
+func (d *tieredDriver) lockStripes(keys []string) []int {
+	var stripes []int
+	if keys == nil {
+		stripes = make([]int, tieredStripes)
+		for stripe := range stripes {
+			stripes[stripe] = stripe
+		}
+	} else {
+		seen := make(map[int]bool, len(keys))
+		for _, key := range keys {
+			if stripe := tieredStripe(key); !seen[stripe] {
+				seen[stripe] = true
+				stripes = append(stripes, stripe)
+			}
+		}
+		sort.Ints(stripes)
+	}
+
+	for _, stripe := range stripes {
+		d.l1Mu[stripe].Lock()
+	}
+	return stripes
+}
+
+// unlockStripes mở khóa các nhóm đã khóa bằng lockStripes.
+func (d *tieredDriver) unlockStripes(stripes []int) {
+	for _, stripe := range stripes {
+		d.l1Mu[stripe].Unlock()
+	}
+}
+
+// tieredStripe trả về nhóm của key theo hash FNV-1a.
+func tieredStripe(key string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % tieredStripes)
+}
+
+// l1Expiration giới hạn ttl của L1 không vượt quá L1TTL.
+func (d *tieredDriver) l1Expiration(ttl time.Duration) time.Duration {
+	if ttl > 0 && ttl < d.l1TTL {
+		return ttl
+	}
+	return d.l1TTL
+}
+
+var (
+	// ErrL2NotTaggable được trả về khi driver L2 của tiered driver không cài đặt Taggable
+	ErrL2NotTaggable = errors.New("cache: tiered L2 driver does not support tags")
+)
@@ -0,0 +1,402 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
+	cacheMocks "go.fork.vn/providers/cache/mocks"
+)
+
+// busInvalidator là Invalidator trong process, chuyển thông báo tới mọi invalidator cùng bus.
+type busInvalidator struct {
+	bus     *invalidationBus
+	handler func(driver.InvalidationMessage)
+}
+
+type invalidationBus struct {
+	mu          sync.Mutex
+	subscribers []*busInvalidator
+}
+
+func (b *invalidationBus) invalidator() *busInvalidator {
+	return &busInvalidator{bus: b}
+}
+
+func (i *busInvalidator) Publish(ctx context.Context, message driver.InvalidationMessage) error {
+	i.bus.mu.Lock()
+	defer i.bus.mu.Unlock()
+	for _, subscriber := range i.bus.subscribers {
+		subscriber.handler(message)
+	}
+	return nil
+}
+
+func (i *busInvalidator) Subscribe(handler func(driver.InvalidationMessage)) error {
+	i.bus.mu.Lock()
+	defer i.bus.mu.Unlock()
+	i.handler = handler
+	i.bus.subscribers = append(i.bus.subscribers, i)
+	return nil
+}
+
+func (i *busInvalidator) Close() error {
+	return nil
+}
+
+func newTieredTestDriver(t *testing.T, l2 driver.Driver, opts driver.TieredOptions) driver.TieredDriver {
+	t.Helper()
+	l1 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 60})
+	tiered, err := driver.NewTieredDriver(l1, l2, opts)
+	assert.NoError(t, err)
+	t.Cleanup(func() { tiered.Close() })
+	return tiered
+}
+
+// pausingDriver giữ lời gọi Get sau khi đã đọc giá trị từ driver bên dưới cho tới khi resume được đóng,
+// để mô phỏng thao tác ghi xen giữa lúc tiered driver đọc L2 và nạp L1.
+type pausingDriver struct {
+	driver.Driver
+	read   chan struct{}
+	resume chan struct{}
+}
+
+func (p *pausingDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	value, found := p.Driver.Get(ctx, key)
+	p.read <- struct{}{}
+	<-p.resume
+	return value, found
+}
+
+func TestTieredDriver(t *testing.T) {
+	ctx := context.Background()
+
+	newL2 := func(t *testing.T) driver.MemoryDriver {
+		l2 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		t.Cleanup(func() { l2.Close() })
+		return l2
+	}
+
+	t.Run("Requires Both Tiers", func(t *testing.T) {
+		_, err := driver.NewTieredDriver(nil, newL2(t), driver.TieredOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Read Through L1", func(t *testing.T) {
+		l2 := newL2(t)
+		tiered := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Minute})
+
+		assert.NoError(t, l2.Set(ctx, "user:1", "alice", time.Minute))
+
+		// Lần đọc đầu lấy từ L2 và nạp vào L1
+		value, found := tiered.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+
+		// L1 tiếp tục phục vụ dù L2 đã đổi (trong giới hạn L1TTL, không có invalidation)
+		assert.NoError(t, l2.Set(ctx, "user:1", "changed", time.Minute))
+		value, found = tiered.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+
+		_, found = tiered.Get(ctx, "missing")
+		assert.False(t, found)
+
+		stats := tiered.Stats(ctx)
+		assert.Equal(t, "tiered", stats["type"])
+		assert.Equal(t, int64(1), stats["l1_hits"])
+		assert.Equal(t, int64(1), stats["l2_hits"])
+		assert.Equal(t, int64(1), stats["misses"])
+	})
+
+	t.Run("Short L1 TTL", func(t *testing.T) {
+		l2 := newL2(t)
+		tiered := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: 50 * time.Millisecond})
+
+		assert.NoError(t, tiered.Set(ctx, "config", "v1", time.Hour))
+		assert.NoError(t, l2.Set(ctx, "config", "v2", time.Hour))
+
+		time.Sleep(100 * time.Millisecond)
+
+		value, found := tiered.Get(ctx, "config")
+		assert.True(t, found)
+		assert.Equal(t, "v2", value, "L1 entry should expire after L1TTL")
+	})
+
+	t.Run("Writes Go Through Both Tiers", func(t *testing.T) {
+		l2 := newL2(t)
+		tiered := newTieredTestDriver(t, l2, driver.TieredOptions{})
+
+		assert.NoError(t, tiered.SetMultiple(ctx, map[string]interface{}{"a": 1, "b": 2}, time.Minute))
+		values, missed := l2.GetMultiple(ctx, []string{"a", "b"})
+		assert.Len(t, values, 2)
+		assert.Empty(t, missed)
+
+		values, missed = tiered.GetMultiple(ctx, []string{"a", "b", "c"})
+		assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, values)
+		assert.Equal(t, []string{"c"}, missed)
+
+		assert.NoError(t, tiered.Delete(ctx, "a"))
+		assert.False(t, tiered.Has(ctx, "a"))
+		assert.False(t, l2.Has(ctx, "a"))
+
+		assert.NoError(t, tiered.Flush(ctx))
+		assert.False(t, tiered.Has(ctx, "b"))
+	})
+
+	t.Run("Atomic Operations Use L2", func(t *testing.T) {
+		l2 := newL2(t)
+		tiered := newTieredTestDriver(t, l2, driver.TieredOptions{})
+
+		assert.NoError(t, tiered.Set(ctx, "counter", 1, time.Minute))
+		value, err := tiered.Increment(ctx, "counter", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		// L1 không giữ giá trị cũ của bộ đếm
+		current, found := tiered.Get(ctx, "counter")
+		assert.True(t, found)
+		assert.Equal(t, int64(5), current)
+
+		added, err := tiered.Add(ctx, "once", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		swapped, err := tiered.CompareAndSwap(ctx, "once", "first", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		current, found = tiered.Get(ctx, "once")
+		assert.True(t, found)
+		assert.Equal(t, "second", current)
+	})
+
+	t.Run("Tags Use L2 Versions", func(t *testing.T) {
+		l2 := newL2(t)
+		tiered := newTieredTestDriver(t, l2, driver.TieredOptions{})
+
+		versions, err := tiered.TagVersions(ctx, []string{"users"})
+		assert.NoError(t, err)
+		fromL2, err := l2.TagVersions(ctx, []string{"users"})
+		assert.NoError(t, err)
+		assert.Equal(t, fromL2, versions)
+
+		// L2 không hỗ trợ tag
+		notTaggable := newTieredTestDriver(t, cacheMocks.NewMockDriver(t), driver.TieredOptions{})
+		_, err = notTaggable.TagVersions(ctx, []string{"users"})
+		assert.True(t, errors.Is(err, driver.ErrL2NotTaggable))
+	})
+
+	t.Run("Cross Instance Invalidation", func(t *testing.T) {
+		l2 := newL2(t)
+		bus := &invalidationBus{}
+		first := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Hour, Invalidator: bus.invalidator()})
+		second := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Hour, Invalidator: bus.invalidator()})
+
+		assert.NoError(t, first.Set(ctx, "user:1", "alice", time.Hour))
+		value, found := second.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+
+		// Ghi ở instance thứ nhất xóa L1 của instance thứ hai
+		assert.NoError(t, first.Set(ctx, "user:1", "bob", time.Hour))
+		value, found = second.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "bob", value)
+
+		assert.NoError(t, first.Delete(ctx, "user:1"))
+		assert.False(t, second.Has(ctx, "user:1"))
+
+		assert.NoError(t, second.Set(ctx, "user:2", "carol", time.Hour))
+		_, found = first.Get(ctx, "user:2")
+		assert.True(t, found)
+		assert.NoError(t, second.Flush(ctx))
+		assert.False(t, first.Has(ctx, "user:2"))
+
+		assert.Equal(t, int64(3), second.Stats(ctx)["invalidations"])
+	})
+
+	t.Run("Concurrent Write During L2 Read", func(t *testing.T) {
+		newPaused := func(t *testing.T, l2 driver.Driver, invalidator driver.Invalidator) (driver.TieredDriver, driver.MemoryDriver, *pausingDriver) {
+			paused := &pausingDriver{Driver: l2, read: make(chan struct{}), resume: make(chan struct{})}
+			l1 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 60})
+			tiered, err := driver.NewTieredDriver(l1, paused, driver.TieredOptions{L1TTL: time.Hour, Invalidator: invalidator})
+			assert.NoError(t, err)
+			t.Cleanup(func() { tiered.Close() })
+			return tiered, l1, paused
+		}
+
+		// getDuring đọc key qua tiered driver và chạy write trong lúc lần đọc L2 đang dừng
+		getDuring := func(tiered driver.TieredDriver, paused *pausingDriver, write func()) {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				tiered.Get(ctx, "user:1")
+			}()
+			<-paused.read
+			write()
+			close(paused.resume)
+			<-done
+		}
+
+		t.Run("Local Set", func(t *testing.T) {
+			l2 := newL2(t)
+			assert.NoError(t, l2.Set(ctx, "user:1", "alice", time.Hour))
+			tiered, l1, paused := newPaused(t, l2, nil)
+
+			getDuring(tiered, paused, func() {
+				assert.NoError(t, tiered.Set(ctx, "user:1", "bob", time.Hour))
+			})
+
+			// Giá trị cũ đọc từ L2 không ghi đè giá trị mới trong L1
+			value, found := l1.Get(ctx, "user:1")
+			assert.True(t, found)
+			assert.Equal(t, "bob", value)
+		})
+
+		t.Run("Remote Invalidation", func(t *testing.T) {
+			l2 := newL2(t)
+			assert.NoError(t, l2.Set(ctx, "user:1", "alice", time.Hour))
+			bus := &invalidationBus{}
+			tiered, l1, paused := newPaused(t, l2, bus.invalidator())
+			other := newTieredTestDriver(t, l2, driver.TieredOptions{Invalidator: bus.invalidator()})
+
+			getDuring(tiered, paused, func() {
+				assert.NoError(t, other.Set(ctx, "user:1", "bob", time.Hour))
+			})
+
+			// Giá trị đã bị invalidate trong lúc đọc L2 không được nạp vào L1
+			_, found := l1.Get(ctx, "user:1")
+			assert.False(t, found)
+		})
+
+		t.Run("Concurrent Get And Set", func(t *testing.T) {
+			l2 := newL2(t)
+			l1 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 60})
+			tiered, err := driver.NewTieredDriver(l1, l2, driver.TieredOptions{L1TTL: time.Hour})
+			assert.NoError(t, err)
+			defer tiered.Close()
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 200; j++ {
+						tiered.Get(ctx, "counter")
+					}
+				}()
+				go func(i int) {
+					defer wg.Done()
+					for j := 0; j < 200; j++ {
+						assert.NoError(t, tiered.Set(ctx, "counter", i*1000+j, time.Hour))
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			// Sau khi mọi thao tác kết thúc, L1 không giữ giá trị khác L2
+			expected, _ := l2.Get(ctx, "counter")
+			if value, found := l1.Get(ctx, "counter"); found {
+				assert.Equal(t, expected, value)
+			}
+		})
+	})
+
+	t.Run("FlushPrefix", func(t *testing.T) {
+		l2 := newL2(t)
+		bus := &invalidationBus{}
+		first := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Hour, Invalidator: bus.invalidator()})
+		second := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Hour, Invalidator: bus.invalidator()})
+
+		assert.NoError(t, first.Set(ctx, "users:1", "alice", time.Hour))
+		assert.NoError(t, first.Set(ctx, "orders:1", "order-1", time.Hour))
+		assert.True(t, second.Has(ctx, "users:1"))
+		assert.True(t, second.Has(ctx, "orders:1"))
+
+		// Xóa theo tiền tố ở instance thứ nhất xóa cả L1 của instance thứ hai
+		assert.NoError(t, first.FlushPrefix(ctx, "users:"))
+		assert.False(t, first.Has(ctx, "users:1"))
+		assert.False(t, second.Has(ctx, "users:1"))
+		assert.False(t, l2.Has(ctx, "users:1"))
+		assert.True(t, second.Has(ctx, "orders:1"))
+	})
+
+	t.Run("FlushPrefix Requires L2 Support", func(t *testing.T) {
+		tiered := newTieredTestDriver(t, cacheMocks.NewMockDriver(t), driver.TieredOptions{L1TTL: time.Hour})
+
+		assert.ErrorIs(t, tiered.FlushPrefix(ctx, "users:"), driver.ErrPrefixFlushNotSupported)
+	})
+
+	t.Run("GetInto", func(t *testing.T) {
+		type profile struct {
+			Name string
+		}
+		l2 := newL2(t)
+		tiered := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Minute})
+
+		assert.NoError(t, l2.Set(ctx, "profile:1", profile{Name: "alice"}, time.Minute))
+
+		var result profile
+		found, err := tiered.GetInto(ctx, "profile:1", &result)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "alice"}, result)
+
+		// Lần đọc thứ hai được phục vụ từ L1
+		result = profile{}
+		found, err = tiered.GetInto(ctx, "profile:1", &result)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "alice"}, result)
+
+		found, err = tiered.GetInto(ctx, "missing", &result)
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		stats := tiered.Stats(ctx)
+		assert.Equal(t, int64(1), stats["l1_hits"])
+		assert.Equal(t, int64(1), stats["l2_hits"])
+		assert.Equal(t, int64(1), stats["misses"])
+	})
+}
+
+func TestTieredDriverRedisInvalidation(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15,
+	})
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available, skipping integration tests")
+	}
+	defer client.Close()
+
+	l2 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer l2.Close()
+
+	first := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Hour, Invalidator: driver.NewRedisInvalidator(client, "cache:test:invalidate")})
+	second := newTieredTestDriver(t, l2, driver.TieredOptions{L1TTL: time.Hour, Invalidator: driver.NewRedisInvalidator(client, "cache:test:invalidate")})
+
+	assert.NoError(t, first.Set(ctx, "user:1", "alice", time.Hour))
+	value, found := second.Get(ctx, "user:1")
+	assert.True(t, found)
+	assert.Equal(t, "alice", value)
+
+	assert.NoError(t, first.Set(ctx, "user:1", "bob", time.Hour))
+	assert.Eventually(t, func() bool {
+		value, _ := second.Get(ctx, "user:1")
+		return value == "bob"
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, first.Flush(ctx))
+	assert.Eventually(t, func() bool {
+		return !second.Has(ctx, "user:1")
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,141 @@
+package driver
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"go.fork.vn/providers/cache/config"
+)
+
+// Transformer biến đổi dữ liệu đã serialize trước khi driver lưu và khôi phục khi đọc,
+// dùng cho nén và mã hóa giá trị cache.
+//
+// Decode phải khôi phục đúng dữ liệu đã truyền vào Encode. Cài đặt phải an toàn khi gọi đồng thời.
+type Transformer interface {
+	// Encode biến đổi dữ liệu trước khi lưu.
+	//
+	// Params:
+	//   - data: Dữ liệu đã serialize
+	//
+	// Returns:
+	//   - []byte: Dữ liệu đã biến đổi
+	//   - error: Lỗi nếu không thể biến đổi
+	Encode(data []byte) ([]byte, error)
+
+	// Decode khôi phục dữ liệu đã được Encode.
+	//
+	// Params:
+	//   - data: Dữ liệu đọc từ bộ lưu trữ
+	//
+	// Returns:
+	//   - []byte: Dữ liệu ban đầu
+	//   - error: Lỗi nếu dữ liệu hỏng, không giải nén được hoặc không giải mã được
+	Decode(data []byte) ([]byte, error)
+}
+
+// transformerChain áp dụng các transformer theo thứ tự khi Encode và theo thứ tự ngược lại khi Decode.
+type transformerChain []Transformer
+
+// ChainTransformers ghép nhiều transformer thành một.
+//
+// Encode áp dụng các transformer theo thứ tự truyền vào, Decode theo thứ tự ngược lại,
+// nên ChainTransformers(compression, encryption) nén trước rồi mã hóa.
+//
+// Params:
+//   - transformers: Các transformer cần ghép, nil được bỏ qua
+//
+// Returns:
+//   - Transformer: Transformer ghép, nil nếu không có transformer nào
+func ChainTransformers(transformers ...Transformer) Transformer {
+	chain := make(transformerChain, 0, len(transformers))
+	for _, transformer := range transformers {
+		if transformer != nil {
+			chain = append(chain, transformer)
+		}
+	}
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	}
+	return chain
+}
+
+// Encode áp dụng Encode của từng transformer theo thứ tự.
+func (c transformerChain) Encode(data []byte) ([]byte, error) {
+	var err error
+	for _, transformer := range c {
+		if data, err = transformer.Encode(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// Decode áp dụng Decode của từng transformer theo thứ tự ngược lại.
+func (c transformerChain) Decode(data []byte) ([]byte, error) {
+	var err error
+	for i := len(c) - 1; i >= 0; i-- {
+		if data, err = c[i].Decode(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// NewTransformer tạo transformer từ cấu hình transform của một driver.
+//
+// Nén được áp dụng trước mã hóa. Khóa mã hóa trong cấu hình được mã hóa base64.
+//
+// Params:
+//   - cfg: Cấu hình transform của driver, nil nếu không dùng
+//
+// Returns:
+//   - Transformer: Transformer đã cấu hình, nil nếu không bật nén hay mã hóa
+//   - error: Lỗi nếu cấu hình không hợp lệ
+func NewTransformer(cfg *config.TransformConfig) (Transformer, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var transformers []Transformer
+	if c := cfg.Compression; c != nil && c.Enabled {
+		compression, err := NewCompressionTransformer(c.Algorithm, c.Threshold, c.Level)
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, compression)
+	}
+	if e := cfg.Encryption; e != nil && e.Enabled {
+		keys := make(map[string][]byte, len(e.Keys))
+		for id, encoded := range e.Keys {
+			key, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("invalid encryption key '%s': %w", id, err)
+			}
+			keys[id] = key
+		}
+		encryption, err := NewEncryptionTransformer(e.KeyID, keys)
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, encryption)
+	}
+	return ChainTransformers(transformers...), nil
+}
+
+var (
+	// ErrUnsupportedCompression được trả về khi thuật toán nén không được hỗ trợ
+	ErrUnsupportedCompression = errors.New("cache: unsupported compression algorithm")
+
+	// ErrDecompressedTooLarge được trả về khi dữ liệu giải nén vượt quá kích thước tối đa 64 MiB
+	ErrDecompressedTooLarge = errors.New("cache: decompressed value exceeds the size limit")
+
+	// ErrUnknownEncryptionKey được trả về khi không có khóa mã hóa với key ID được yêu cầu
+	ErrUnknownEncryptionKey = errors.New("cache: unknown encryption key id")
+
+	// ErrDecryptionFailed được trả về khi dữ liệu không được mã hóa hoặc không giải mã được
+	ErrDecryptionFailed = errors.New("cache: could not decrypt cached value")
+)
@@ -0,0 +1,291 @@
+package driver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
+)
+
+// testEncryptionKey là khóa AES-256 mã hóa base64 dùng trong test.
+var testEncryptionKey = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x42}, 32))
+
+func TestCompressionTransformer(t *testing.T) {
+	large := []byte(strings.Repeat(`{"name":"alice","role":"admin"}`, 100))
+
+	for _, algorithm := range []string{driver.CompressionGzip, driver.CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			transformer, err := driver.NewCompressionTransformer(algorithm, 256, 0)
+			assert.NoError(t, err)
+
+			encoded, err := transformer.Encode(large)
+			assert.NoError(t, err)
+			assert.Less(t, len(encoded), len(large))
+
+			decoded, err := transformer.Decode(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, large, decoded)
+		})
+	}
+
+	t.Run("Below Threshold", func(t *testing.T) {
+		transformer, err := driver.NewCompressionTransformer(driver.CompressionGzip, 256, 0)
+		assert.NoError(t, err)
+		small := []byte(`"short"`)
+
+		encoded, err := transformer.Encode(small)
+		assert.NoError(t, err)
+		assert.Equal(t, small, encoded)
+
+		decoded, err := transformer.Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, small, decoded)
+	})
+
+	t.Run("Data Starting With Frame Prefix", func(t *testing.T) {
+		transformer, err := driver.NewCompressionTransformer(driver.CompressionGzip, 256, 0)
+		assert.NoError(t, err)
+		data := []byte{0xFC, 'C', 'g', 1, 2, 3}
+
+		encoded, err := transformer.Encode(data)
+		assert.NoError(t, err)
+		decoded, err := transformer.Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	})
+
+	t.Run("Reads Data Of Other Algorithm", func(t *testing.T) {
+		gzipTransformer, err := driver.NewCompressionTransformer(driver.CompressionGzip, 0, 0)
+		assert.NoError(t, err)
+		zstdTransformer, err := driver.NewCompressionTransformer(driver.CompressionZstd, 0, 3)
+		assert.NoError(t, err)
+
+		encoded, err := gzipTransformer.Encode(large)
+		assert.NoError(t, err)
+		decoded, err := zstdTransformer.Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, large, decoded)
+	})
+
+	t.Run("Decompressed Size Limit", func(t *testing.T) {
+		// Dữ liệu lặp lại nén thành vài KB nhưng giải nén vượt giới hạn 64 MiB
+		bomb := make([]byte, 64<<20+1)
+		for _, algorithm := range []string{driver.CompressionGzip, driver.CompressionZstd} {
+			transformer, err := driver.NewCompressionTransformer(algorithm, 0, 0)
+			assert.NoError(t, err)
+
+			encoded, err := transformer.Encode(bomb)
+			assert.NoError(t, err)
+			assert.Less(t, len(encoded), 1<<20)
+
+			_, err = transformer.Decode(encoded)
+			assert.ErrorIs(t, err, driver.ErrDecompressedTooLarge, algorithm)
+		}
+	})
+
+	t.Run("Invalid Configuration", func(t *testing.T) {
+		_, err := driver.NewCompressionTransformer("lz4", 0, 0)
+		assert.ErrorIs(t, err, driver.ErrUnsupportedCompression)
+
+		_, err = driver.NewCompressionTransformer(driver.CompressionGzip, 0, 42)
+		assert.Error(t, err)
+	})
+}
+
+func TestEncryptionTransformer(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{0x01}, 32)
+	newKey := bytes.Repeat([]byte{0x02}, 16)
+	plaintext := []byte(`{"email":"alice@example.com"}`)
+
+	t.Run("Round Trip", func(t *testing.T) {
+		transformer, err := driver.NewEncryptionTransformer("v1", map[string][]byte{"v1": oldKey})
+		assert.NoError(t, err)
+
+		first, err := transformer.Encode(plaintext)
+		assert.NoError(t, err)
+		second, err := transformer.Encode(plaintext)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(first), "alice")
+		assert.NotEqual(t, first, second, "each encryption should use a fresh nonce")
+
+		decoded, err := transformer.Decode(first)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decoded)
+	})
+
+	t.Run("Key Rotation", func(t *testing.T) {
+		before, err := driver.NewEncryptionTransformer("v1", map[string][]byte{"v1": oldKey})
+		assert.NoError(t, err)
+		after, err := driver.NewEncryptionTransformer("v2", map[string][]byte{"v1": oldKey, "v2": newKey})
+		assert.NoError(t, err)
+		retired, err := driver.NewEncryptionTransformer("v2", map[string][]byte{"v2": newKey})
+		assert.NoError(t, err)
+
+		encrypted, err := before.Encode(plaintext)
+		assert.NoError(t, err)
+
+		decoded, err := after.Decode(encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decoded)
+
+		_, err = retired.Decode(encrypted)
+		assert.ErrorIs(t, err, driver.ErrUnknownEncryptionKey)
+	})
+
+	t.Run("Rejects Tampered And Plain Data", func(t *testing.T) {
+		transformer, err := driver.NewEncryptionTransformer("v1", map[string][]byte{"v1": oldKey})
+		assert.NoError(t, err)
+
+		encrypted, err := transformer.Encode(plaintext)
+		assert.NoError(t, err)
+		encrypted[len(encrypted)-1] ^= 0xFF
+
+		_, err = transformer.Decode(encrypted)
+		assert.ErrorIs(t, err, driver.ErrDecryptionFailed)
+		_, err = transformer.Decode(plaintext)
+		assert.ErrorIs(t, err, driver.ErrDecryptionFailed)
+	})
+
+	t.Run("Invalid Configuration", func(t *testing.T) {
+		_, err := driver.NewEncryptionTransformer("missing", map[string][]byte{"v1": oldKey})
+		assert.ErrorIs(t, err, driver.ErrUnknownEncryptionKey)
+
+		_, err = driver.NewEncryptionTransformer("v1", map[string][]byte{"v1": []byte("short")})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewTransformer(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		transformer, err := driver.NewTransformer(nil)
+		assert.NoError(t, err)
+		assert.Nil(t, transformer)
+
+		transformer, err = driver.NewTransformer(&config.TransformConfig{
+			Compression: &config.CompressionConfig{Enabled: false},
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, transformer)
+	})
+
+	t.Run("Compresses Then Encrypts", func(t *testing.T) {
+		transformer, err := driver.NewTransformer(&config.TransformConfig{
+			Compression: &config.CompressionConfig{Enabled: true, Algorithm: driver.CompressionZstd},
+			Encryption:  &config.EncryptionConfig{Enabled: true, KeyID: "k1", Keys: map[string]string{"k1": testEncryptionKey}},
+		})
+		assert.NoError(t, err)
+		data := []byte(strings.Repeat("compressible ", 1000))
+
+		encoded, err := transformer.Encode(data)
+		assert.NoError(t, err)
+		assert.Less(t, len(encoded), len(data)/10, "data should be compressed before encryption")
+
+		decoded, err := transformer.Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	})
+
+	t.Run("Invalid Key Encoding", func(t *testing.T) {
+		_, err := driver.NewTransformer(&config.TransformConfig{
+			Encryption: &config.EncryptionConfig{Enabled: true, KeyID: "k1", Keys: map[string]string{"k1": "not base64!"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestFileDriverTransform(t *testing.T) {
+	ctx := context.Background()
+
+	newFileDriver := func(t *testing.T, dir string, keyID string, keys map[string]string) driver.FileDriver {
+		fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{
+			Path:       dir,
+			DefaultTTL: 300,
+			Transform: &config.TransformConfig{
+				Compression: &config.CompressionConfig{Enabled: true, Algorithm: driver.CompressionGzip, Threshold: 128},
+				Encryption:  &config.EncryptionConfig{Enabled: true, KeyID: keyID, Keys: keys},
+			},
+		})
+		assert.NoError(t, err)
+		t.Cleanup(func() { fileDriver.Close() })
+		return fileDriver
+	}
+
+	t.Run("Stores Encrypted Files", func(t *testing.T) {
+		dir := t.TempDir()
+		fileDriver := newFileDriver(t, dir, "k1", map[string]string{"k1": testEncryptionKey})
+		value := strings.Repeat("alice@example.com ", 50)
+
+		assert.NoError(t, fileDriver.Set(ctx, "user:1", value, time.Minute))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*"))
+		assert.NoError(t, err)
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(content), "alice")
+			assert.NotContains(t, string(content), "user:1")
+		}
+
+		retrieved, found := fileDriver.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, value, retrieved)
+
+		var typed string
+		found, err = fileDriver.GetInto(ctx, "user:1", &typed)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, value, typed)
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		fileDriver := newFileDriver(t, t.TempDir(), "k1", map[string]string{"k1": testEncryptionKey})
+
+		counter, err := fileDriver.Increment(ctx, "counter", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), counter)
+
+		assert.NoError(t, fileDriver.Set(ctx, "cas", "v1", time.Minute))
+		swapped, err := fileDriver.CompareAndSwap(ctx, "cas", "v1", "v2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		assert.NoError(t, fileDriver.Set(ctx, "users:1", "alice", time.Minute))
+		assert.NoError(t, fileDriver.FlushPrefix(ctx, "users:"))
+		assert.False(t, fileDriver.Has(ctx, "users:1"))
+		assert.True(t, fileDriver.Has(ctx, "cas"))
+	})
+
+	t.Run("Key Rotation", func(t *testing.T) {
+		dir := t.TempDir()
+		newKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x07}, 32))
+		before := newFileDriver(t, dir, "k1", map[string]string{"k1": testEncryptionKey})
+		assert.NoError(t, before.Set(ctx, "key", "value", time.Minute))
+
+		after := newFileDriver(t, dir, "k2", map[string]string{"k1": testEncryptionKey, "k2": newKey})
+		retrieved, found := after.Get(ctx, "key")
+		assert.True(t, found)
+		assert.Equal(t, "value", retrieved)
+
+		retired := newFileDriver(t, dir, "k2", map[string]string{"k2": newKey})
+		_, found = retired.Get(ctx, "key")
+		assert.False(t, found)
+	})
+
+	t.Run("Invalid Configuration", func(t *testing.T) {
+		_, err := driver.NewFileDriver(config.DriverFileConfig{
+			Path: t.TempDir(),
+			Transform: &config.TransformConfig{
+				Encryption: &config.EncryptionConfig{Enabled: true, KeyID: "missing"},
+			},
+		})
+		assert.ErrorIs(t, err, driver.ErrUnknownEncryptionKey)
+	})
+}
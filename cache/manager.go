@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,26 @@ type Manager interface {
 	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 	Get(key string) (interface{}, bool)
 
+	// GetContext lấy một giá trị từ cache mặc định, dùng ctx cho thao tác trên driver.
+	//
+	// Deadline và cancellation của ctx được truyền tới driver, nên request đã bị hủy hoặc hết hạn
+	// không tiếp tục chờ Redis hoặc MongoDB. Các phương thức không có hậu tố Context dùng context.Background().
+	GetContext(ctx context.Context, key string) (interface{}, bool)
+
+	// GetInto lấy một giá trị từ cache mặc định và giải mã trực tiếp vào dest.
+	//
+	// Params:
+	//   - key: Cache key cần tìm
+	//   - dest: Con trỏ khác nil tới biến nhận giá trị
+	//
+	// Returns:
+	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+	//   - error: Lỗi nếu dest không hợp lệ, giá trị không khớp kiểu của dest, hoặc driver mặc định không được cấu hình
+	GetInto(key string, dest interface{}) (bool, error)
+
+	// GetIntoContext lấy một giá trị từ cache mặc định và giải mã vào dest, dùng ctx cho thao tác trên driver.
+	GetIntoContext(ctx context.Context, key string, dest interface{}) (bool, error)
+
 	// Set đặt một giá trị vào cache với TTL tùy chọn.
 	//
 	// Phương thức này lưu trữ một cặp key-value vào cache mặc định với thời gian sống
@@ -42,6 +63,9 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
 	Set(key string, value interface{}, ttl time.Duration) error
 
+	// SetContext đặt một giá trị vào cache mặc định, dùng ctx cho thao tác trên driver.
+	SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
 	// Has kiểm tra xem một key có tồn tại trong cache không.
 	//
 	// Phương thức này xác định liệu một key có tồn tại trong cache mặc định và chưa hết hạn hay không.
@@ -53,6 +77,9 @@ type Manager interface {
 	//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
 	Has(key string) bool
 
+	// HasContext kiểm tra xem một key có tồn tại trong cache mặc định không, dùng ctx cho thao tác trên driver.
+	HasContext(ctx context.Context, key string) bool
+
 	// Delete xóa một key khỏi cache.
 	//
 	// Phương thức này xóa key và giá trị tương ứng khỏi cache mặc định nếu tồn tại.
@@ -64,14 +91,22 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 	Delete(key string) error
 
+	// DeleteContext xóa một key khỏi cache mặc định, dùng ctx cho thao tác trên driver.
+	DeleteContext(ctx context.Context, key string) error
+
 	// Flush xóa tất cả các key khỏi cache.
 	//
 	// Phương thức này xóa tất cả dữ liệu trong cache mặc định, làm trống hoàn toàn bộ nhớ cache.
+	// Với manager tạo bởi Namespace, chỉ các key thuộc namespace bị xóa; driver mặc định phải
+	// cài đặt driver.PrefixFlusher, nếu không Flush trả về driver.ErrPrefixFlushNotSupported.
 	//
 	// Returns:
 	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 	Flush() error
 
+	// FlushContext xóa tất cả các key khỏi cache mặc định, dùng ctx cho thao tác trên driver.
+	FlushContext(ctx context.Context) error
+
 	// GetMultiple lấy nhiều giá trị từ cache.
 	//
 	// Phương thức này lấy các giá trị tương ứng với nhiều key từ cache mặc định trong một lần gọi.
@@ -84,6 +119,9 @@ type Manager interface {
 	//   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
 	GetMultiple(keys []string) (map[string]interface{}, []string)
 
+	// GetMultipleContext lấy nhiều giá trị từ cache mặc định, dùng ctx cho thao tác trên driver.
+	GetMultipleContext(ctx context.Context, keys []string) (map[string]interface{}, []string)
+
 	// SetMultiple đặt nhiều giá trị vào cache.
 	//
 	// Phương thức này lưu trữ nhiều cặp key-value vào cache mặc định trong một lần gọi
@@ -97,6 +135,9 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
 	SetMultiple(values map[string]interface{}, ttl time.Duration) error
 
+	// SetMultipleContext đặt nhiều giá trị vào cache mặc định, dùng ctx cho thao tác trên driver.
+	SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error
+
 	// DeleteMultiple xóa nhiều key khỏi cache.
 	//
 	// Phương thức này xóa nhiều key và giá trị tương ứng khỏi cache mặc định trong một lần gọi.
@@ -108,6 +149,9 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 	DeleteMultiple(keys []string) error
 
+	// DeleteMultipleContext xóa nhiều key khỏi cache mặc định, dùng ctx cho thao tác trên driver.
+	DeleteMultipleContext(ctx context.Context, keys []string) error
+
 	// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
 	//
 	// Phương thức này kiểm tra xem một key có tồn tại trong cache mặc định không, nếu có thì
@@ -124,6 +168,124 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
 	Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
 
+	// RememberContext lấy một giá trị từ cache mặc định hoặc thực thi callback nếu không tìm thấy, dùng ctx cho thao tác trên driver.
+	RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// RememberWithOptions lấy một giá trị từ cache mặc định hoặc thực thi callback, có chống cache stampede.
+	//
+	// Params:
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+	//   - opts: TTL và các tùy chọn singleflight, khóa phân tán, XFetch, stale-while-revalidate
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+	RememberWithOptions(key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error)
+
+	// RememberWithOptionsContext lấy một giá trị từ cache mặc định hoặc thực thi callback, có chống cache stampede, dùng ctx cho thao tác trên driver.
+	RememberWithOptionsContext(ctx context.Context, key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error)
+
+	// Increment tăng giá trị số nguyên của một key trong cache mặc định một cách nguyên tử.
+	//
+	// Nếu key chưa tồn tại hoặc đã hết hạn, giá trị được khởi tạo bằng delta và không hết hạn.
+	// Nếu key đã tồn tại, thời gian hết hạn hiện tại được giữ nguyên.
+	//
+	// Params:
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi tăng
+	//   - error: driver.ErrNotInteger nếu giá trị hiện tại không phải số nguyên, lỗi khác trong quá trình thực hiện, hoặc driver mặc định không được cấu hình
+	Increment(key string, delta int64) (int64, error)
+
+	// IncrementContext tăng bộ đếm trong cache mặc định một cách nguyên tử, dùng ctx cho thao tác trên driver.
+	IncrementContext(ctx context.Context, key string, delta int64) (int64, error)
+
+	// Decrement giảm giá trị số nguyên của một key trong cache mặc định một cách nguyên tử.
+	//
+	// Params:
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần trừ đi
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi giảm
+	//   - error: driver.ErrNotInteger nếu giá trị hiện tại không phải số nguyên, lỗi khác trong quá trình thực hiện, hoặc driver mặc định không được cấu hình
+	Decrement(key string, delta int64) (int64, error)
+
+	// DecrementContext giảm bộ đếm trong cache mặc định một cách nguyên tử, dùng ctx cho thao tác trên driver.
+	DecrementContext(ctx context.Context, key string, delta int64) (int64, error)
+
+	// Add đặt một giá trị vào cache mặc định chỉ khi key chưa tồn tại (hoặc đã hết hạn).
+	//
+	// Params:
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+	Add(key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// AddContext đặt một giá trị vào cache mặc định chỉ khi key chưa tồn tại, dùng ctx cho thao tác trên driver.
+	AddContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// CompareAndSwap thay giá trị của key trong cache mặc định bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+	//
+	// Params:
+	//   - key: Cache key cần cập nhật
+	//   - oldValue: Giá trị mong đợi hiện tại
+	//   - newValue: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+	CompareAndSwap(key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error)
+
+	// CompareAndSwapContext thay giá trị của key trong cache mặc định chỉ khi giá trị hiện tại bằng oldValue, dùng ctx cho thao tác trên driver.
+	CompareAndSwapContext(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error)
+
+	// Tags trả về view của cache gắn với các tag được chỉ định.
+	//
+	// Các entry ghi qua view có thể được làm mất hiệu lực theo nhóm bằng Flush của view
+	// mà không ảnh hưởng tới các entry khác. Driver mặc định phải cài đặt driver.Taggable
+	// (memory, file, redis và mongodb đều hỗ trợ), nếu không các thao tác trả về ErrTagsNotSupported.
+	//
+	// Params:
+	//   - names: Danh sách tag, trùng lặp sẽ được loại bỏ và thứ tự không quan trọng
+	//
+	// Returns:
+	//   - TaggedCache: View để đọc, ghi và làm mất hiệu lực các entry gắn tag
+	Tags(names ...string) TaggedCache
+
+	// Namespace trả về manager có phạm vi giới hạn trong namespace name.
+	//
+	// Mọi key đọc, ghi qua manager trả về được thêm tiền tố name + ":" trước khi tới driver,
+	// nên các namespace khác nhau không đọc hay ghi đè key của nhau. Flush của manager này
+	// chỉ xóa các key của namespace. Manager trả về dùng chung các driver với manager gốc;
+	// namespace có thể lồng nhau (Namespace("a").Namespace("b") dùng tiền tố "a:b:").
+	//
+	// Params:
+	//   - name: Tên namespace, rỗng để trả về chính manager hiện tại
+	//
+	// Returns:
+	//   - Manager: Manager có phạm vi giới hạn trong namespace
+	Namespace(name string) Manager
+
+	// AddObserver gắn observer nhận sự kiện của mọi thao tác qua manager.
+	//
+	// Observer nhận OnHit, OnMiss, OnWrite, OnDelete và OnError kèm tên driver, key và thời gian
+	// thực thi, dùng để đo tỷ lệ hit, độ trễ và tỷ lệ lỗi theo từng driver (xem driver.Metrics).
+	// Observer dùng chung giữa manager gốc và các namespace. Thao tác gọi trực tiếp trên driver
+	// lấy từ Driver không được báo cho observer.
+	//
+	// Params:
+	//   - observer: Observer cần gắn
+	AddObserver(observer driver.Observer)
+
 	// AddDriver thêm một driver vào manager.
 	//
 	// Phương thức này đăng ký một driver mới với manager theo tên xác định.
@@ -178,9 +340,18 @@ type Manager interface {
 // manager quản lý nhiều driver cache thông qua một map driver và cung cấp
 // cơ chế để thực hiện các thao tác cache qua driver mặc định. Nó đảm bảo thread-safety
 // thông qua RWMutex và cung cấp các phương thức tiện ích để tương tác với nhiều driver.
+// Các manager tạo bởi Namespace dùng chung driverRegistry với manager gốc.
 type manager struct {
+	*driverRegistry
+	namespace string // Tiền tố thêm vào mọi key ("" với manager gốc)
+}
+
+// driverRegistry chứa các driver và observer đã đăng ký, dùng chung giữa manager gốc và các namespace.
+type driverRegistry struct {
 	drivers       map[string]driver.Driver // Map chứa tất cả các driver đã đăng ký
 	defaultDriver string                   // Tên của driver mặc định
+	observers     observerGroup            // Các observer đã gắn
+	instrumented  map[string]driver.Driver // Driver đã bọc bởi observers, theo tên driver
 	mu            sync.RWMutex             // Mutex cho các thao tác thread-safe
 }
 
@@ -192,8 +363,45 @@ type manager struct {
 //   - Manager: Đối tượng Manager mới được khởi tạo
 func NewManager() Manager {
 	return &manager{
-		drivers: make(map[string]driver.Driver),
+		driverRegistry: &driverRegistry{
+			drivers:      make(map[string]driver.Driver),
+			instrumented: make(map[string]driver.Driver),
+		},
+	}
+}
+
+// Namespace trả về manager có phạm vi giới hạn trong namespace name.
+//
+// Params:
+//   - name: Tên namespace, rỗng để trả về chính manager hiện tại
+//
+// Returns:
+//   - Manager: Manager dùng chung driver với m, thêm tiền tố name + ":" vào mọi key
+func (m *manager) Namespace(name string) Manager {
+	if name == "" {
+		return m
+	}
+	return &manager{
+		driverRegistry: m.driverRegistry,
+		namespace:      m.namespace + name + ":",
+	}
+}
+
+// key thêm tiền tố của namespace vào key.
+func (m *manager) key(key string) string {
+	return m.namespace + key
+}
+
+// keys thêm tiền tố của namespace vào danh sách key.
+func (m *manager) keys(keys []string) []string {
+	if m.namespace == "" {
+		return keys
 	}
+	namespaced := make([]string, len(keys))
+	for i, key := range keys {
+		namespaced[i] = m.key(key)
+	}
+	return namespaced
 }
 
 // Get lấy một giá trị từ cache.
@@ -207,11 +415,38 @@ func NewManager() Manager {
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
 //   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 func (m *manager) Get(key string) (interface{}, bool) {
-	driver, err := m.DefaultDriver()
+	return m.GetContext(context.Background(), key)
+}
+
+// GetContext lấy một giá trị từ cache mặc định, dùng ctx cho thao tác trên driver.
+func (m *manager) GetContext(ctx context.Context, key string) (interface{}, bool) {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return nil, false
 	}
-	return driver.Get(context.Background(), key)
+	return driver.Get(ctx, m.key(key))
+}
+
+// GetInto lấy một giá trị từ cache mặc định và giải mã trực tiếp vào dest.
+//
+// Params:
+//   - key: Cache key cần tìm
+//   - dest: Con trỏ khác nil tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi nếu dest không hợp lệ, giá trị không khớp kiểu của dest, hoặc driver mặc định không được cấu hình
+func (m *manager) GetInto(key string, dest interface{}) (bool, error) {
+	return m.GetIntoContext(context.Background(), key, dest)
+}
+
+// GetIntoContext lấy một giá trị từ cache mặc định và giải mã vào dest, dùng ctx cho thao tác trên driver.
+func (m *manager) GetIntoContext(ctx context.Context, key string, dest interface{}) (bool, error) {
+	driver, err := m.activeDriver()
+	if err != nil {
+		return false, err
+	}
+	return driver.GetInto(ctx, m.key(key), dest)
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -226,11 +461,16 @@ func (m *manager) Get(key string) (interface{}, bool) {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
 func (m *manager) Set(key string, value interface{}, ttl time.Duration) error {
-	driver, err := m.DefaultDriver()
+	return m.SetContext(context.Background(), key, value, ttl)
+}
+
+// SetContext đặt một giá trị vào cache mặc định, dùng ctx cho thao tác trên driver.
+func (m *manager) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return err
 	}
-	return driver.Set(context.Background(), key, value, ttl)
+	return driver.Set(ctx, m.key(key), value, ttl)
 }
 
 // Has kiểm tra xem một key có tồn tại trong cache không.
@@ -243,11 +483,16 @@ func (m *manager) Set(key string, value interface{}, ttl time.Duration) error {
 // Returns:
 //   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
 func (m *manager) Has(key string) bool {
-	driver, err := m.DefaultDriver()
+	return m.HasContext(context.Background(), key)
+}
+
+// HasContext kiểm tra xem một key có tồn tại trong cache mặc định không, dùng ctx cho thao tác trên driver.
+func (m *manager) HasContext(ctx context.Context, key string) bool {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return false
 	}
-	return driver.Has(context.Background(), key)
+	return driver.Has(ctx, m.key(key))
 }
 
 // Delete xóa một key khỏi cache.
@@ -260,25 +505,45 @@ func (m *manager) Has(key string) bool {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 func (m *manager) Delete(key string) error {
-	driver, err := m.DefaultDriver()
+	return m.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext xóa một key khỏi cache mặc định, dùng ctx cho thao tác trên driver.
+func (m *manager) DeleteContext(ctx context.Context, key string) error {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return err
 	}
-	return driver.Delete(context.Background(), key)
+	return driver.Delete(ctx, m.key(key))
 }
 
 // Flush xóa tất cả các key khỏi cache.
 //
 // Phương thức này xóa tất cả dữ liệu trong cache mặc định, làm trống hoàn toàn bộ nhớ cache.
+// Với manager tạo bởi Namespace, chỉ các key thuộc namespace bị xóa.
 //
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 func (m *manager) Flush() error {
-	driver, err := m.DefaultDriver()
+	return m.FlushContext(context.Background())
+}
+
+// FlushContext xóa tất cả các key khỏi cache mặc định, dùng ctx cho thao tác trên driver.
+//
+// Với manager tạo bởi Namespace, chỉ các key của namespace bị xóa qua driver.PrefixFlusher.
+func (m *manager) FlushContext(ctx context.Context) error {
+	d, err := m.activeDriver()
 	if err != nil {
 		return err
 	}
-	return driver.Flush(context.Background())
+	if m.namespace == "" {
+		return d.Flush(ctx)
+	}
+	flusher, ok := d.(driver.PrefixFlusher)
+	if !ok {
+		return driver.ErrPrefixFlushNotSupported
+	}
+	return flusher.FlushPrefix(ctx, m.namespace)
 }
 
 // GetMultiple lấy nhiều giá trị từ cache.
@@ -292,11 +557,28 @@ func (m *manager) Flush() error {
 //   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
 //   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
 func (m *manager) GetMultiple(keys []string) (map[string]interface{}, []string) {
-	driver, err := m.DefaultDriver()
+	return m.GetMultipleContext(context.Background(), keys)
+}
+
+// GetMultipleContext lấy nhiều giá trị từ cache mặc định, dùng ctx cho thao tác trên driver.
+func (m *manager) GetMultipleContext(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return make(map[string]interface{}), keys
 	}
-	return driver.GetMultiple(context.Background(), keys)
+	if m.namespace == "" {
+		return driver.GetMultiple(ctx, keys)
+	}
+
+	found, missed := driver.GetMultiple(ctx, m.keys(keys))
+	results := make(map[string]interface{}, len(found))
+	for key, value := range found {
+		results[strings.TrimPrefix(key, m.namespace)] = value
+	}
+	for i, key := range missed {
+		missed[i] = strings.TrimPrefix(key, m.namespace)
+	}
+	return results, missed
 }
 
 // SetMultiple đặt nhiều giá trị vào cache.
@@ -311,11 +593,23 @@ func (m *manager) GetMultiple(keys []string) (map[string]interface{}, []string)
 // Returns:
 //   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
 func (m *manager) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
-	driver, err := m.DefaultDriver()
+	return m.SetMultipleContext(context.Background(), values, ttl)
+}
+
+// SetMultipleContext đặt nhiều giá trị vào cache mặc định, dùng ctx cho thao tác trên driver.
+func (m *manager) SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return err
 	}
-	return driver.SetMultiple(context.Background(), values, ttl)
+	if m.namespace != "" {
+		namespaced := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			namespaced[m.key(key)] = value
+		}
+		values = namespaced
+	}
+	return driver.SetMultiple(ctx, values, ttl)
 }
 
 // DeleteMultiple xóa nhiều key khỏi cache.
@@ -328,11 +622,16 @@ func (m *manager) SetMultiple(values map[string]interface{}, ttl time.Duration)
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 func (m *manager) DeleteMultiple(keys []string) error {
-	driver, err := m.DefaultDriver()
+	return m.DeleteMultipleContext(context.Background(), keys)
+}
+
+// DeleteMultipleContext xóa nhiều key khỏi cache mặc định, dùng ctx cho thao tác trên driver.
+func (m *manager) DeleteMultipleContext(ctx context.Context, keys []string) error {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return err
 	}
-	return driver.DeleteMultiple(context.Background(), keys)
+	return driver.DeleteMultiple(ctx, m.keys(keys))
 }
 
 // Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
@@ -350,11 +649,137 @@ func (m *manager) DeleteMultiple(keys []string) error {
 //   - interface{}: Giá trị từ cache hoặc từ callback
 //   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
 func (m *manager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	driver, err := m.DefaultDriver()
+	return m.RememberContext(context.Background(), key, ttl, callback)
+}
+
+// RememberContext lấy một giá trị từ cache mặc định hoặc thực thi callback nếu không tìm thấy, dùng ctx cho thao tác trên driver.
+func (m *manager) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	driver, err := m.activeDriver()
+	if err != nil {
+		return nil, err
+	}
+	return driver.Remember(ctx, m.key(key), ttl, callback)
+}
+
+// RememberWithOptions lấy một giá trị từ cache mặc định hoặc thực thi callback, có chống cache stampede.
+//
+// Các lời gọi đồng thời cho cùng một key chỉ thực thi callback một lần. opts có thể bật
+// khóa phân tán giữa các instance (Lock), tính lại sớm theo xác suất (Beta) và trả về
+// giá trị cũ trong khi làm mới ở nền (StaleTTL).
+//
+// Params:
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - callback: Hàm được gọi để lấy dữ liệu khi cần tính lại
+//   - opts: TTL và các tùy chọn chống cache stampede
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (m *manager) RememberWithOptions(key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error) {
+	return m.RememberWithOptionsContext(context.Background(), key, callback, opts)
+}
+
+// RememberWithOptionsContext lấy một giá trị từ cache mặc định hoặc thực thi callback, có chống cache stampede, dùng ctx cho thao tác trên driver.
+func (m *manager) RememberWithOptionsContext(ctx context.Context, key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error) {
+	driver, err := m.activeDriver()
 	if err != nil {
 		return nil, err
 	}
-	return driver.Remember(context.Background(), key, ttl, callback)
+	return driver.RememberWithOptions(ctx, m.key(key), callback, opts)
+}
+
+// Increment tăng giá trị số nguyên của một key trong cache mặc định một cách nguyên tử.
+//
+// Nếu key chưa tồn tại hoặc đã hết hạn, giá trị được khởi tạo bằng delta và không hết hạn.
+// Nếu key đã tồn tại, thời gian hết hạn hiện tại được giữ nguyên.
+//
+// Params:
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: driver.ErrNotInteger nếu giá trị hiện tại không phải số nguyên, lỗi khác trong quá trình thực hiện, hoặc driver mặc định không được cấu hình
+func (m *manager) Increment(key string, delta int64) (int64, error) {
+	return m.IncrementContext(context.Background(), key, delta)
+}
+
+// IncrementContext tăng bộ đếm trong cache mặc định một cách nguyên tử, dùng ctx cho thao tác trên driver.
+func (m *manager) IncrementContext(ctx context.Context, key string, delta int64) (int64, error) {
+	driver, err := m.activeDriver()
+	if err != nil {
+		return 0, err
+	}
+	return driver.Increment(ctx, m.key(key), delta)
+}
+
+// Decrement giảm giá trị số nguyên của một key trong cache mặc định một cách nguyên tử.
+//
+// Params:
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: driver.ErrNotInteger nếu giá trị hiện tại không phải số nguyên, lỗi khác trong quá trình thực hiện, hoặc driver mặc định không được cấu hình
+func (m *manager) Decrement(key string, delta int64) (int64, error) {
+	return m.DecrementContext(context.Background(), key, delta)
+}
+
+// DecrementContext giảm bộ đếm trong cache mặc định một cách nguyên tử, dùng ctx cho thao tác trên driver.
+func (m *manager) DecrementContext(ctx context.Context, key string, delta int64) (int64, error) {
+	driver, err := m.activeDriver()
+	if err != nil {
+		return 0, err
+	}
+	return driver.Decrement(ctx, m.key(key), delta)
+}
+
+// Add đặt một giá trị vào cache mặc định chỉ khi key chưa tồn tại (hoặc đã hết hạn).
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key đã tồn tại
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (m *manager) Add(key string, value interface{}, ttl time.Duration) (bool, error) {
+	return m.AddContext(context.Background(), key, value, ttl)
+}
+
+// AddContext đặt một giá trị vào cache mặc định chỉ khi key chưa tồn tại, dùng ctx cho thao tác trên driver.
+func (m *manager) AddContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	driver, err := m.activeDriver()
+	if err != nil {
+		return false, err
+	}
+	return driver.Add(ctx, m.key(key), value, ttl)
+}
+
+// CompareAndSwap thay giá trị của key trong cache mặc định bằng newValue chỉ khi giá trị hiện tại bằng oldValue.
+//
+// Params:
+//   - key: Cache key cần cập nhật
+//   - oldValue: Giá trị mong đợi hiện tại
+//   - newValue: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu giá trị hiện tại khác oldValue hoặc key không tồn tại
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (m *manager) CompareAndSwap(key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	return m.CompareAndSwapContext(context.Background(), key, oldValue, newValue, ttl)
+}
+
+// CompareAndSwapContext thay giá trị của key trong cache mặc định chỉ khi giá trị hiện tại bằng oldValue, dùng ctx cho thao tác trên driver.
+func (m *manager) CompareAndSwapContext(ctx context.Context, key string, oldValue, newValue interface{}, ttl time.Duration) (bool, error) {
+	driver, err := m.activeDriver()
+	if err != nil {
+		return false, err
+	}
+	return driver.CompareAndSwap(ctx, m.key(key), oldValue, newValue, ttl)
 }
 
 // AddDriver thêm một driver vào manager.
@@ -370,6 +795,9 @@ func (m *manager) AddDriver(name string, driver driver.Driver) {
 	defer m.mu.Unlock()
 
 	m.drivers[name] = driver
+	if len(m.observers) > 0 {
+		m.instrumented[name] = m.instrument(name, driver)
+	}
 
 	// Đặt driver đầu tiên được thêm làm mặc định nếu chưa có driver mặc định
 	if m.defaultDriver == "" {
@@ -377,6 +805,20 @@ func (m *manager) AddDriver(name string, driver driver.Driver) {
 	}
 }
 
+// AddObserver gắn observer nhận sự kiện của mọi thao tác qua manager.
+//
+// Params:
+//   - observer: Observer cần gắn
+func (m *manager) AddObserver(observer driver.Observer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.observers = append(m.observers[:len(m.observers):len(m.observers)], observer)
+	for name, d := range m.drivers {
+		m.instrumented[name] = m.instrument(name, d)
+	}
+}
+
 // SetDefaultDriver đặt driver mặc định.
 //
 // Phương thức này thiết lập driver mặc định được sử dụng cho các thao tác cache.
@@ -473,3 +915,31 @@ func (m *manager) DefaultDriver() (driver.Driver, error) {
 
 	return nil, fmt.Errorf("default cache driver '%s' not found", m.defaultDriver)
 }
+
+// activeDriver trả về driver mặc định dùng cho các thao tác của manager.
+//
+// Khi có observer, driver trả về là bản bọc bởi driver.NewInstrumentedDriver; bản bọc luôn
+// cài đặt driver.Taggable và driver.PrefixFlusher nhưng trả về driver.ErrTagsNotSupported hoặc
+// driver.ErrPrefixFlushNotSupported nếu driver gốc không hỗ trợ.
+func (m *manager) activeDriver() (driver.Driver, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.defaultDriver == "" {
+		return nil, fmt.Errorf("no default cache driver set")
+	}
+
+	if driver, ok := m.instrumented[m.defaultDriver]; ok {
+		return driver, nil
+	}
+	if driver, ok := m.drivers[m.defaultDriver]; ok {
+		return driver, nil
+	}
+
+	return nil, fmt.Errorf("default cache driver '%s' not found", m.defaultDriver)
+}
+
+// instrument bọc d để báo thao tác cho các observer hiện tại. Caller phải giữ m.mu.
+func (m *driverRegistry) instrument(name string, d driver.Driver) driver.Driver {
+	return driver.NewInstrumentedDriver(name, d, m.observers)
+}
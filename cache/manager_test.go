@@ -1,4 +1,4 @@
-package cache
+package cache_test
 
 import (
 	"context"
@@ -8,12 +8,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.fork.vn/providers/cache"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
 	"go.fork.vn/providers/cache/mocks"
 )
 
 // TestNewManager tests the NewManager constructor
 func TestNewManager(t *testing.T) {
-	manager := NewManager()
+	manager := cache.NewManager()
 	assert.NotNil(t, manager)
 
 	// Test that newly created manager has no default driver
@@ -28,7 +31,7 @@ func TestManagerGet(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Get(context.Background(), "test-key").Return("test-value", true)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -45,7 +48,7 @@ func TestManagerGet(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Get(context.Background(), "nonexistent-key").Return(nil, false)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -59,7 +62,7 @@ func TestManagerGet(t *testing.T) {
 
 	t.Run("returns not found when no default driver is set", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		value, found := manager.Get("any-key")
@@ -71,7 +74,7 @@ func TestManagerGet(t *testing.T) {
 
 	t.Run("returns not found when default driver doesn't exist", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.SetDefaultDriver("nonexistent")
 
 		// Act
@@ -83,6 +86,39 @@ func TestManagerGet(t *testing.T) {
 	})
 }
 
+// TestManagerGetInto tests the GetInto method with various scenarios
+func TestManagerGetInto(t *testing.T) {
+	t.Run("delegates to default driver", func(t *testing.T) {
+		// Arrange
+		var dest string
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().GetInto(context.Background(), "test-key", &dest).Return(true, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		found, err := manager.GetInto("test-key", &dest)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		// Arrange
+		var dest string
+		manager := cache.NewManager()
+
+		// Act
+		found, err := manager.GetInto("any-key", &dest)
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, found)
+	})
+}
+
 // TestManagerSet tests the Set method with various scenarios
 func TestManagerSet(t *testing.T) {
 	t.Run("sets value successfully when default driver is configured", func(t *testing.T) {
@@ -90,7 +126,7 @@ func TestManagerSet(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Set(context.Background(), "test-key", "test-value", 5*time.Minute).Return(nil)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -107,7 +143,7 @@ func TestManagerSet(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Set(context.Background(), "test-key", "test-value", 5*time.Minute).Return(expectedError)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -121,7 +157,7 @@ func TestManagerSet(t *testing.T) {
 
 	t.Run("returns error when no default driver is set", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		err := manager.Set("test-key", "test-value", 5*time.Minute)
@@ -133,7 +169,7 @@ func TestManagerSet(t *testing.T) {
 
 	t.Run("returns error when default driver doesn't exist", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.SetDefaultDriver("nonexistent")
 
 		// Act
@@ -152,7 +188,7 @@ func TestManagerHas(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Has(context.Background(), "existing-key").Return(true)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -168,7 +204,7 @@ func TestManagerHas(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Has(context.Background(), "nonexistent-key").Return(false)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -181,7 +217,7 @@ func TestManagerHas(t *testing.T) {
 
 	t.Run("returns false when no default driver is set", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		exists := manager.Has("any-key")
@@ -192,7 +228,7 @@ func TestManagerHas(t *testing.T) {
 
 	t.Run("returns false when default driver doesn't exist", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.SetDefaultDriver("nonexistent")
 
 		// Act
@@ -210,7 +246,7 @@ func TestManagerDelete(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Delete(context.Background(), "test-key").Return(nil)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -227,7 +263,7 @@ func TestManagerDelete(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Delete(context.Background(), "test-key").Return(expectedError)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -241,7 +277,7 @@ func TestManagerDelete(t *testing.T) {
 
 	t.Run("returns error when no default driver is set", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		err := manager.Delete("test-key")
@@ -259,7 +295,7 @@ func TestManagerFlush(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Flush(context.Background()).Return(nil)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -276,7 +312,7 @@ func TestManagerFlush(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Flush(context.Background()).Return(expectedError)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -290,7 +326,7 @@ func TestManagerFlush(t *testing.T) {
 
 	t.Run("returns error when no default driver is set", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		err := manager.Flush()
@@ -315,7 +351,7 @@ func TestManagerGetMultiple(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().GetMultiple(context.Background(), keys).Return(expectedFound, expectedMissing)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -330,7 +366,7 @@ func TestManagerGetMultiple(t *testing.T) {
 	t.Run("returns empty map and all keys as missing when no default driver is set", func(t *testing.T) {
 		// Arrange
 		keys := []string{"key1", "key2", "key3"}
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		found, missing := manager.GetMultiple(keys)
@@ -343,7 +379,7 @@ func TestManagerGetMultiple(t *testing.T) {
 	t.Run("returns empty map and all keys as missing when default driver doesn't exist", func(t *testing.T) {
 		// Arrange
 		keys := []string{"key1", "key2", "key3"}
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.SetDefaultDriver("nonexistent")
 
 		// Act
@@ -368,7 +404,7 @@ func TestManagerSetMultiple(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().SetMultiple(context.Background(), values, ttl).Return(nil)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -390,7 +426,7 @@ func TestManagerSetMultiple(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().SetMultiple(context.Background(), values, ttl).Return(expectedError)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -407,7 +443,7 @@ func TestManagerSetMultiple(t *testing.T) {
 		values := map[string]interface{}{
 			"key1": "value1",
 		}
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		err := manager.SetMultiple(values, 10*time.Minute)
@@ -427,7 +463,7 @@ func TestManagerDeleteMultiple(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().DeleteMultiple(context.Background(), keys).Return(nil)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -446,7 +482,7 @@ func TestManagerDeleteMultiple(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().DeleteMultiple(context.Background(), keys).Return(expectedError)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -461,7 +497,7 @@ func TestManagerDeleteMultiple(t *testing.T) {
 	t.Run("returns error when no default driver is set", func(t *testing.T) {
 		// Arrange
 		keys := []string{"key1", "key2"}
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		err := manager.DeleteMultiple(keys)
@@ -483,7 +519,7 @@ func TestManagerRemember(t *testing.T) {
 			return "new-value", nil
 		}
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -504,7 +540,7 @@ func TestManagerRemember(t *testing.T) {
 			return "callback-value", nil
 		}
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -526,7 +562,7 @@ func TestManagerRemember(t *testing.T) {
 			return nil, expectedError
 		}
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("mock", mockDriver)
 		manager.SetDefaultDriver("mock")
 
@@ -544,7 +580,7 @@ func TestManagerRemember(t *testing.T) {
 		callback := func() (interface{}, error) {
 			return "value", nil
 		}
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		value, err := manager.Remember("key", 10*time.Minute, callback)
@@ -556,12 +592,496 @@ func TestManagerRemember(t *testing.T) {
 	})
 }
 
+// TestManagerRememberWithOptions tests the RememberWithOptions method with various scenarios
+func TestManagerRememberWithOptions(t *testing.T) {
+	t.Run("passes options to default driver", func(t *testing.T) {
+		// Arrange
+		opts := driver.RememberOptions{TTL: 10 * time.Minute, Lock: true, Beta: 1, StaleTTL: time.Minute}
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().RememberWithOptions(context.Background(), "report", mock.AnythingOfType("func() (interface {}, error)"), opts).Return("computed", nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		value, err := manager.RememberWithOptions("report", func() (interface{}, error) {
+			return "computed", nil
+		}, opts)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		value, err := manager.RememberWithOptions("report", func() (interface{}, error) {
+			return "computed", nil
+		}, driver.RememberOptions{TTL: time.Minute})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+// TestManagerIncrement tests the Increment and Decrement methods with various scenarios
+func TestManagerIncrement(t *testing.T) {
+	t.Run("increments counter through default driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Increment(context.Background(), "counter", int64(5)).Return(int64(7), nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		value, err := manager.Increment("counter", 5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), value)
+	})
+
+	t.Run("decrements counter through default driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Decrement(context.Background(), "counter", int64(2)).Return(int64(5), nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		value, err := manager.Decrement("counter", 2)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+	})
+
+	t.Run("returns driver error for non-integer value", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Increment(context.Background(), "name", int64(1)).Return(int64(0), driver.ErrNotInteger)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		_, err := manager.Increment("name", 1)
+
+		// Assert
+		assert.ErrorIs(t, err, driver.ErrNotInteger)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		_, incErr := manager.Increment("counter", 1)
+		_, decErr := manager.Decrement("counter", 1)
+
+		// Assert
+		assert.Error(t, incErr)
+		assert.Contains(t, incErr.Error(), "no default cache driver set")
+		assert.Error(t, decErr)
+	})
+}
+
+// TestManagerAdd tests the Add method with various scenarios
+func TestManagerAdd(t *testing.T) {
+	t.Run("returns driver result", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Add(context.Background(), "lock", "owner", time.Minute).Return(true, nil).Once()
+		mockDriver.EXPECT().Add(context.Background(), "lock", "other", time.Minute).Return(false, nil).Once()
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		first, firstErr := manager.Add("lock", "owner", time.Minute)
+		second, secondErr := manager.Add("lock", "other", time.Minute)
+
+		// Assert
+		assert.NoError(t, firstErr)
+		assert.True(t, first)
+		assert.NoError(t, secondErr)
+		assert.False(t, second)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		added, err := manager.Add("lock", "owner", time.Minute)
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, added)
+	})
+}
+
+// TestManagerCompareAndSwap tests the CompareAndSwap method with various scenarios
+func TestManagerCompareAndSwap(t *testing.T) {
+	t.Run("returns driver result", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().CompareAndSwap(context.Background(), "version", 1, 2, time.Duration(0)).Return(true, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		swapped, err := manager.CompareAndSwap("version", 1, 2, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+	})
+
+	t.Run("returns error from driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		expectedErr := errors.New("connection lost")
+		mockDriver.EXPECT().CompareAndSwap(context.Background(), "version", 1, 2, time.Minute).Return(false, expectedErr)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		swapped, err := manager.CompareAndSwap("version", 1, 2, time.Minute)
+
+		// Assert
+		assert.Equal(t, expectedErr, err)
+		assert.False(t, swapped)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		swapped, err := manager.CompareAndSwap("version", 1, 2, 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, swapped)
+	})
+}
+
+// TestManagerContextMethods tests that context variants pass the caller's context to the driver
+func TestManagerContextMethods(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	t.Run("passes context to driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Get(ctx, "key").Return("value", true)
+		mockDriver.EXPECT().Set(ctx, "key", "value", time.Minute).Return(nil)
+		mockDriver.EXPECT().Delete(ctx, "key").Return(nil)
+		mockDriver.EXPECT().Increment(ctx, "counter", int64(2)).Return(int64(2), nil)
+		mockDriver.EXPECT().Remember(ctx, "remember", time.Minute, mock.AnythingOfType("func() (interface {}, error)")).Return("cached", nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act & Assert
+		value, found := manager.GetContext(ctx, "key")
+		assert.True(t, found)
+		assert.Equal(t, "value", value)
+		assert.NoError(t, manager.SetContext(ctx, "key", "value", time.Minute))
+		assert.NoError(t, manager.DeleteContext(ctx, "key"))
+
+		counter, err := manager.IncrementContext(ctx, "counter", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), counter)
+
+		remembered, err := manager.RememberContext(ctx, "remember", time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "cached", remembered)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act & Assert
+		assert.Error(t, manager.SetContext(ctx, "key", "value", time.Minute))
+		assert.Error(t, manager.FlushContext(ctx))
+		_, err := manager.AddContext(ctx, "key", "value", time.Minute)
+		assert.Error(t, err)
+	})
+}
+
+// TestManagerNamespace tests managers scoped to a key namespace
+func TestManagerNamespace(t *testing.T) {
+	newDrivers := map[string]func(t *testing.T) driver.Driver{
+		"memory": func(t *testing.T) driver.Driver {
+			d := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+			t.Cleanup(func() { d.Close() })
+			return d
+		},
+		"file": func(t *testing.T) driver.Driver {
+			d, err := driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), DefaultTTL: 300, Prefix: "app:"})
+			assert.NoError(t, err)
+			t.Cleanup(func() { d.Close() })
+			return d
+		},
+	}
+
+	for name, newDriver := range newDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Run("isolates keys between namespaces", func(t *testing.T) {
+				// Arrange
+				manager := cache.NewManager()
+				manager.AddDriver(name, newDriver(t))
+				users := manager.Namespace("users")
+				orders := manager.Namespace("orders")
+
+				// Act
+				assert.NoError(t, users.Set("1", "alice", time.Minute))
+				assert.NoError(t, orders.Set("1", "order-1", time.Minute))
+
+				// Assert
+				value, found := users.Get("1")
+				assert.True(t, found)
+				assert.Equal(t, "alice", value)
+				value, found = orders.Get("1")
+				assert.True(t, found)
+				assert.Equal(t, "order-1", value)
+				value, found = manager.Get("users:1")
+				assert.True(t, found, "namespaced key should be stored with the namespace prefix")
+				assert.Equal(t, "alice", value)
+				assert.False(t, manager.Has("1"))
+			})
+
+			t.Run("flush only removes keys of the namespace", func(t *testing.T) {
+				// Arrange
+				manager := cache.NewManager()
+				manager.AddDriver(name, newDriver(t))
+				users := manager.Namespace("users")
+				assert.NoError(t, manager.Set("plain", "kept", time.Minute))
+				assert.NoError(t, users.Set("1", "alice", time.Minute))
+				assert.NoError(t, manager.Namespace("orders").Set("1", "order-1", time.Minute))
+
+				// Act
+				assert.NoError(t, users.Flush())
+
+				// Assert
+				assert.False(t, users.Has("1"))
+				assert.True(t, manager.Namespace("orders").Has("1"))
+				assert.True(t, manager.Has("plain"))
+			})
+
+			t.Run("multiple operations strip the namespace from results", func(t *testing.T) {
+				// Arrange
+				manager := cache.NewManager()
+				manager.AddDriver(name, newDriver(t))
+				users := manager.Namespace("users")
+				assert.NoError(t, users.SetMultiple(map[string]interface{}{"1": "alice", "2": "bob"}, time.Minute))
+
+				// Act
+				values, missed := users.GetMultiple([]string{"1", "2", "3"})
+
+				// Assert
+				assert.Equal(t, map[string]interface{}{"1": "alice", "2": "bob"}, values)
+				assert.Equal(t, []string{"3"}, missed)
+				assert.NoError(t, users.DeleteMultiple([]string{"1", "2"}))
+				assert.False(t, manager.Has("users:1"))
+			})
+		})
+	}
+
+	t.Run("nested namespaces combine prefixes", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("memory", newDrivers["memory"](t))
+
+		// Act
+		assert.NoError(t, manager.Namespace("tenant:7").Namespace("users").Set("1", "alice", time.Minute))
+
+		// Assert
+		value, found := manager.Get("tenant:7:users:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+		assert.Same(t, manager, manager.Namespace(""))
+	})
+
+	t.Run("tags are scoped to the namespace", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("memory", newDrivers["memory"](t))
+		first := manager.Namespace("first")
+		second := manager.Namespace("second")
+		assert.NoError(t, first.Tags("users").Set("1", "alice", time.Minute))
+		assert.NoError(t, second.Tags("users").Set("1", "bob", time.Minute))
+
+		// Act
+		assert.NoError(t, first.Tags("users").Flush())
+
+		// Assert
+		assert.False(t, first.Tags("users").Has("1"))
+		value, found := second.Tags("users").Get("1")
+		assert.True(t, found)
+		assert.Equal(t, "bob", value)
+	})
+
+	t.Run("flush returns error when driver cannot flush by prefix", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		err := manager.Namespace("users").Flush()
+
+		// Assert
+		assert.ErrorIs(t, err, driver.ErrPrefixFlushNotSupported)
+	})
+
+	t.Run("shares drivers with the parent manager", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		users := manager.Namespace("users")
+
+		// Act
+		manager.AddDriver("memory", newDrivers["memory"](t))
+
+		// Assert
+		assert.NoError(t, users.Set("1", "alice", time.Minute))
+		_, err := users.Driver("memory")
+		assert.NoError(t, err)
+	})
+}
+
+// TestManagerObservers tests reporting of cache events to observers attached with AddObserver
+func TestManagerObservers(t *testing.T) {
+	newMemoryDriver := func(t *testing.T) driver.Driver {
+		d := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		t.Cleanup(func() { d.Close() })
+		return d
+	}
+
+	t.Run("reports operations to metrics per driver", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("memory", newMemoryDriver(t))
+		metrics := driver.NewMetrics()
+		manager.AddObserver(metrics)
+
+		// Act
+		assert.NoError(t, manager.Set("key", "value", time.Minute))
+		manager.Get("key")
+		manager.Get("missing")
+		assert.NoError(t, manager.Delete("key"))
+
+		// Assert
+		snapshot := metrics.Snapshot()["memory"]
+		assert.Equal(t, int64(1), snapshot.Hits)
+		assert.Equal(t, int64(1), snapshot.Misses)
+		assert.Equal(t, int64(1), snapshot.Writes)
+		assert.Equal(t, int64(1), snapshot.Deletes)
+		assert.Equal(t, 0.5, snapshot.HitRatio())
+	})
+
+	t.Run("instruments drivers added after the observer", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		metrics := driver.NewMetrics()
+		manager.AddObserver(metrics)
+		manager.AddDriver("memory", newMemoryDriver(t))
+
+		// Act
+		manager.Has("key")
+
+		// Assert
+		assert.Equal(t, int64(1), metrics.Snapshot()["memory"].Misses)
+	})
+
+	t.Run("notifies every observer", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("memory", newMemoryDriver(t))
+		first := driver.NewMetrics()
+		second := driver.NewMetrics()
+		manager.AddObserver(first)
+		manager.AddObserver(second)
+
+		// Act
+		assert.NoError(t, manager.Set("key", "value", time.Minute))
+
+		// Assert
+		assert.Equal(t, int64(1), first.Snapshot()["memory"].Writes)
+		assert.Equal(t, int64(1), second.Snapshot()["memory"].Writes)
+	})
+
+	t.Run("observes namespaces and tags", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("memory", newMemoryDriver(t))
+		metrics := driver.NewMetrics()
+		manager.AddObserver(metrics)
+		tagged := manager.Namespace("users").Tags("admins")
+
+		// Act
+		assert.NoError(t, tagged.Set("1", "alice", time.Minute))
+		assert.NoError(t, tagged.Flush())
+
+		// Assert
+		snapshot := metrics.Snapshot()["memory"]
+		assert.Equal(t, int64(1), snapshot.Writes)
+		assert.Equal(t, int64(1), snapshot.Deletes)
+	})
+
+	t.Run("reports driver errors", func(t *testing.T) {
+		// Arrange
+		mockDriver := mocks.NewMockDriver(t)
+		mockDriver.On("Set", mock.Anything, "key", "value", time.Minute).Return(errors.New("connection refused"))
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+		metrics := driver.NewMetrics()
+		manager.AddObserver(metrics)
+
+		// Act
+		err := manager.Set("key", "value", time.Minute)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, int64(1), metrics.Snapshot()["mock"].Errors)
+		assert.Equal(t, 1.0, metrics.Snapshot()["mock"].ErrorRate())
+	})
+
+	t.Run("keeps returning unsupported capability errors", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mocks.NewMockDriver(t))
+		manager.AddObserver(driver.NewMetrics())
+
+		// Act
+		namespaceErr := manager.Namespace("users").Flush()
+		tagsErr := manager.Tags("users").Flush()
+
+		// Assert
+		assert.ErrorIs(t, namespaceErr, driver.ErrPrefixFlushNotSupported)
+		assert.ErrorIs(t, tagsErr, cache.ErrTagsNotSupported)
+	})
+}
+
 // TestManagerAddDriver tests the AddDriver method with various scenarios
 func TestManagerAddDriver(t *testing.T) {
 	t.Run("adds driver successfully", func(t *testing.T) {
 		// Arrange
 		mockDriver := mocks.NewMockDriver(t)
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		manager.AddDriver("test-driver", mockDriver)
@@ -577,7 +1097,7 @@ func TestManagerAddDriver(t *testing.T) {
 		mockDriver := mocks.NewMockDriver(t)
 		mockDriver.EXPECT().Get(context.Background(), "test-key").Return("test-value", true)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		manager.AddDriver("first-driver", mockDriver)
@@ -592,7 +1112,7 @@ func TestManagerAddDriver(t *testing.T) {
 		// Arrange
 		oldDriver := mocks.NewMockDriver(t)
 		newDriver := mocks.NewMockDriver(t)
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		manager.AddDriver("same-name", oldDriver)
@@ -613,7 +1133,7 @@ func TestManagerSetDefaultDriver(t *testing.T) {
 		driver2 := mocks.NewMockDriver(t)
 		driver2.EXPECT().Get(context.Background(), "test-key").Return("value-from-driver2", true)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("driver1", driver1)
 		manager.AddDriver("driver2", driver2)
 
@@ -628,7 +1148,7 @@ func TestManagerSetDefaultDriver(t *testing.T) {
 
 	t.Run("setting non-existent driver as default doesn't cause immediate error", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act - this should not panic or error immediately
 		manager.SetDefaultDriver("non-existent")
@@ -644,7 +1164,7 @@ func TestManagerDriver(t *testing.T) {
 	t.Run("returns driver when it exists", func(t *testing.T) {
 		// Arrange
 		mockDriver := mocks.NewMockDriver(t)
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("test-driver", mockDriver)
 
 		// Act
@@ -657,7 +1177,7 @@ func TestManagerDriver(t *testing.T) {
 
 	t.Run("returns error when driver doesn't exist", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		driver, err := manager.Driver("non-existent")
@@ -688,7 +1208,7 @@ func TestManagerStats(t *testing.T) {
 		mockDriver2 := mocks.NewMockDriver(t)
 		mockDriver2.EXPECT().Stats(context.Background()).Return(driver2Stats)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("driver1", mockDriver1)
 		manager.AddDriver("driver2", mockDriver2)
 
@@ -703,7 +1223,7 @@ func TestManagerStats(t *testing.T) {
 
 	t.Run("returns empty map when no drivers are registered", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		stats := manager.Stats()
@@ -723,7 +1243,7 @@ func TestManagerClose(t *testing.T) {
 		mockDriver2 := mocks.NewMockDriver(t)
 		mockDriver2.EXPECT().Close().Return(nil)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("driver1", mockDriver1)
 		manager.AddDriver("driver2", mockDriver2)
 
@@ -744,7 +1264,7 @@ func TestManagerClose(t *testing.T) {
 		mockDriver2 := mocks.NewMockDriver(t)
 		mockDriver2.EXPECT().Close().Return(expectedError)
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("driver1", mockDriver1)
 		manager.AddDriver("driver2", mockDriver2)
 
@@ -758,7 +1278,7 @@ func TestManagerClose(t *testing.T) {
 
 	t.Run("succeeds when no drivers are registered", func(t *testing.T) {
 		// Arrange
-		manager := NewManager()
+		manager := cache.NewManager()
 
 		// Act
 		err := manager.Close()
@@ -777,7 +1297,7 @@ func TestManagerConcurrency(t *testing.T) {
 		mockDriver.EXPECT().Set(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		mockDriver.EXPECT().Has(mock.Anything, mock.Anything).Return(true).Maybe()
 
-		manager := NewManager()
+		manager := cache.NewManager()
 		manager.AddDriver("concurrent-driver", mockDriver)
 		manager.SetDefaultDriver("concurrent-driver")
 
@@ -4,6 +4,7 @@ package mocks
 import (
 	context "context"
 	mock "github.com/stretchr/testify/mock"
+	driver "go.fork.vn/providers/cache/driver"
 	time "time"
 )
 
@@ -20,6 +21,65 @@ func (_m *MockDriver) EXPECT() *MockDriver_Expecter {
 	return &MockDriver_Expecter{mock: &_m.Mock}
 }
 
+// Add provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDriver_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockDriver_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockDriver_Expecter) Add(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockDriver_Add_Call {
+	return &MockDriver_Add_Call{Call: _e.mock.On("Add", ctx, key, value, ttl)}
+}
+
+func (_c *MockDriver_Add_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockDriver_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockDriver_Add_Call) Return(_a0 bool, _a1 error) *MockDriver_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDriver_Add_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockDriver_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockDriver) Close() error {
 	ret := _m.Called()
@@ -65,6 +125,124 @@ func (_c *MockDriver_Close_Call) RunAndReturn(run func() error) *MockDriver_Clos
 	return _c
 }
 
+// CompareAndSwap provides a mock function with given fields: ctx, key, oldValue, newValue, ttl
+func (_m *MockDriver) CompareAndSwap(ctx context.Context, key string, oldValue interface{}, newValue interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, oldValue, newValue, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndSwap")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, oldValue, newValue, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, oldValue, newValue, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, oldValue, newValue, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDriver_CompareAndSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwap'
+type MockDriver_CompareAndSwap_Call struct {
+	*mock.Call
+}
+
+// CompareAndSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - oldValue interface{}
+//   - newValue interface{}
+//   - ttl time.Duration
+func (_e *MockDriver_Expecter) CompareAndSwap(ctx interface{}, key interface{}, oldValue interface{}, newValue interface{}, ttl interface{}) *MockDriver_CompareAndSwap_Call {
+	return &MockDriver_CompareAndSwap_Call{Call: _e.mock.On("CompareAndSwap", ctx, key, oldValue, newValue, ttl)}
+}
+
+func (_c *MockDriver_CompareAndSwap_Call) Run(run func(ctx context.Context, key string, oldValue interface{}, newValue interface{}, ttl time.Duration)) *MockDriver_CompareAndSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(interface{}), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockDriver_CompareAndSwap_Call) Return(_a0 bool, _a1 error) *MockDriver_CompareAndSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDriver_CompareAndSwap_Call) RunAndReturn(run func(context.Context, string, interface{}, interface{}, time.Duration) (bool, error)) *MockDriver_CompareAndSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decrement provides a mock function with given fields: ctx, key, delta
+func (_m *MockDriver) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	ret := _m.Called(ctx, key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrement")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, key, delta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, key, delta)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDriver_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockDriver_Decrement_Call struct {
+	*mock.Call
+}
+
+// Decrement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+func (_e *MockDriver_Expecter) Decrement(ctx interface{}, key interface{}, delta interface{}) *MockDriver_Decrement_Call {
+	return &MockDriver_Decrement_Call{Call: _e.mock.On("Decrement", ctx, key, delta)}
+}
+
+func (_c *MockDriver_Decrement_Call) Run(run func(ctx context.Context, key string, delta int64)) *MockDriver_Decrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDriver_Decrement_Call) Return(_a0 int64, _a1 error) *MockDriver_Decrement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDriver_Decrement_Call) RunAndReturn(run func(context.Context, string, int64) (int64, error)) *MockDriver_Decrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, key
 func (_m *MockDriver) Delete(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -264,6 +442,64 @@ func (_c *MockDriver_Get_Call) RunAndReturn(run func(context.Context, string) (i
 	return _c
 }
 
+// GetInto provides a mock function with given fields: ctx, key, dest
+func (_m *MockDriver) GetInto(ctx context.Context, key string, dest interface{}) (bool, error) {
+	ret := _m.Called(ctx, key, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInto")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (bool, error)); ok {
+		return rf(ctx, key, dest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
+		r0 = rf(ctx, key, dest)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, key, dest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDriver_GetInto_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInto'
+type MockDriver_GetInto_Call struct {
+	*mock.Call
+}
+
+// GetInto is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - dest interface{}
+func (_e *MockDriver_Expecter) GetInto(ctx interface{}, key interface{}, dest interface{}) *MockDriver_GetInto_Call {
+	return &MockDriver_GetInto_Call{Call: _e.mock.On("GetInto", ctx, key, dest)}
+}
+
+func (_c *MockDriver_GetInto_Call) Run(run func(ctx context.Context, key string, dest interface{})) *MockDriver_GetInto_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockDriver_GetInto_Call) Return(_a0 bool, _a1 error) *MockDriver_GetInto_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDriver_GetInto_Call) RunAndReturn(run func(context.Context, string, interface{}) (bool, error)) *MockDriver_GetInto_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMultiple provides a mock function with given fields: ctx, keys
 func (_m *MockDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
 	ret := _m.Called(ctx, keys)
@@ -372,6 +608,64 @@ func (_c *MockDriver_Has_Call) RunAndReturn(run func(context.Context, string) bo
 	return _c
 }
 
+// Increment provides a mock function with given fields: ctx, key, delta
+func (_m *MockDriver) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	ret := _m.Called(ctx, key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, key, delta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, key, delta)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDriver_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockDriver_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+func (_e *MockDriver_Expecter) Increment(ctx interface{}, key interface{}, delta interface{}) *MockDriver_Increment_Call {
+	return &MockDriver_Increment_Call{Call: _e.mock.On("Increment", ctx, key, delta)}
+}
+
+func (_c *MockDriver_Increment_Call) Run(run func(ctx context.Context, key string, delta int64)) *MockDriver_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDriver_Increment_Call) Return(_a0 int64, _a1 error) *MockDriver_Increment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDriver_Increment_Call) RunAndReturn(run func(context.Context, string, int64) (int64, error)) *MockDriver_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remember provides a mock function with given fields: ctx, key, ttl, callback
 func (_m *MockDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
 	ret := _m.Called(ctx, key, ttl, callback)
@@ -433,6 +727,67 @@ func (_c *MockDriver_Remember_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
+// RememberWithOptions provides a mock function with given fields: ctx, key, callback, opts
+func (_m *MockDriver) RememberWithOptions(ctx context.Context, key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error) {
+	ret := _m.Called(ctx, key, callback, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RememberWithOptions")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func() (interface{}, error), driver.RememberOptions) (interface{}, error)); ok {
+		return rf(ctx, key, callback, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, func() (interface{}, error), driver.RememberOptions) interface{}); ok {
+		r0 = rf(ctx, key, callback, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, func() (interface{}, error), driver.RememberOptions) error); ok {
+		r1 = rf(ctx, key, callback, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDriver_RememberWithOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberWithOptions'
+type MockDriver_RememberWithOptions_Call struct {
+	*mock.Call
+}
+
+// RememberWithOptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - callback func()(interface{} , error)
+//   - opts driver.RememberOptions
+func (_e *MockDriver_Expecter) RememberWithOptions(ctx interface{}, key interface{}, callback interface{}, opts interface{}) *MockDriver_RememberWithOptions_Call {
+	return &MockDriver_RememberWithOptions_Call{Call: _e.mock.On("RememberWithOptions", ctx, key, callback, opts)}
+}
+
+func (_c *MockDriver_RememberWithOptions_Call) Run(run func(ctx context.Context, key string, callback func() (interface{}, error), opts driver.RememberOptions)) *MockDriver_RememberWithOptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func() (interface{}, error)), args[3].(driver.RememberOptions))
+	})
+	return _c
+}
+
+func (_c *MockDriver_RememberWithOptions_Call) Return(_a0 interface{}, _a1 error) *MockDriver_RememberWithOptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDriver_RememberWithOptions_Call) RunAndReturn(run func(context.Context, string, func() (interface{}, error), driver.RememberOptions) (interface{}, error)) *MockDriver_RememberWithOptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function with given fields: ctx, key, value, ttl
 func (_m *MockDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, value, ttl)
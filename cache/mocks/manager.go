@@ -2,6 +2,8 @@
 package mocks
 
 import (
+	context "context"
+	cache "go.fork.vn/providers/cache"
 	driver "go.fork.vn/providers/cache/driver"
 	mock "github.com/stretchr/testify/mock"
 	time "time"
@@ -20,6 +22,123 @@ func (_m *MockManager) EXPECT() *MockManager_Expecter {
 	return &MockManager_Expecter{mock: &_m.Mock}
 }
 
+// Add provides a mock function with given fields: key, value, ttl
+func (_m *MockManager) Add(key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) bool); ok {
+		r0 = rf(key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, interface{}, time.Duration) error); ok {
+		r1 = rf(key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockManager_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Add(key interface{}, value interface{}, ttl interface{}) *MockManager_Add_Call {
+	return &MockManager_Add_Call{Call: _e.mock.On("Add", key, value, ttl)}
+}
+
+func (_c *MockManager_Add_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockManager_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Add_Call) Return(_a0 bool, _a1 error) *MockManager_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Add_Call) RunAndReturn(run func(string, interface{}, time.Duration) (bool, error)) *MockManager_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockManager) AddContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_AddContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContext'
+type MockManager_AddContext_Call struct {
+	*mock.Call
+}
+
+// AddContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) AddContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockManager_AddContext_Call {
+	return &MockManager_AddContext_Call{Call: _e.mock.On("AddContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockManager_AddContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockManager_AddContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_AddContext_Call) Return(_a0 bool, _a1 error) *MockManager_AddContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_AddContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockManager_AddContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddDriver provides a mock function with given fields: name, _a1
 func (_m *MockManager) AddDriver(name string, _a1 driver.Driver) {
 	_m.Called(name, _a1)
@@ -54,6 +173,39 @@ func (_c *MockManager_AddDriver_Call) RunAndReturn(run func(string, driver.Drive
 	return _c
 }
 
+// AddObserver provides a mock function with given fields: observer
+func (_m *MockManager) AddObserver(observer driver.Observer) {
+	_m.Called(observer)
+}
+
+// MockManager_AddObserver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddObserver'
+type MockManager_AddObserver_Call struct {
+	*mock.Call
+}
+
+// AddObserver is a helper method to define mock.On call
+//   - observer driver.Observer
+func (_e *MockManager_Expecter) AddObserver(observer interface{}) *MockManager_AddObserver_Call {
+	return &MockManager_AddObserver_Call{Call: _e.mock.On("AddObserver", observer)}
+}
+
+func (_c *MockManager_AddObserver_Call) Run(run func(observer driver.Observer)) *MockManager_AddObserver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(driver.Observer))
+	})
+	return _c
+}
+
+func (_c *MockManager_AddObserver_Call) Return() *MockManager_AddObserver_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManager_AddObserver_Call) RunAndReturn(run func(driver.Observer)) *MockManager_AddObserver_Call {
+	_c.Run(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockManager) Close() error {
 	ret := _m.Called()
@@ -99,121 +251,146 @@ func (_c *MockManager_Close_Call) RunAndReturn(run func() error) *MockManager_Cl
 	return _c
 }
 
-// Delete provides a mock function with given fields: key
-func (_m *MockManager) Delete(key string) error {
-	ret := _m.Called(key)
+// CompareAndSwap provides a mock function with given fields: key, oldValue, newValue, ttl
+func (_m *MockManager) CompareAndSwap(key string, oldValue interface{}, newValue interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(key, oldValue, newValue, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for CompareAndSwap")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(key)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, interface{}, time.Duration) (bool, error)); ok {
+		return rf(key, oldValue, newValue, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, interface{}, interface{}, time.Duration) bool); ok {
+		r0 = rf(key, oldValue, newValue, ttl)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, interface{}, interface{}, time.Duration) error); ok {
+		r1 = rf(key, oldValue, newValue, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockManager_Delete_Call struct {
+// MockManager_CompareAndSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwap'
+type MockManager_CompareAndSwap_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// CompareAndSwap is a helper method to define mock.On call
 //   - key string
-func (_e *MockManager_Expecter) Delete(key interface{}) *MockManager_Delete_Call {
-	return &MockManager_Delete_Call{Call: _e.mock.On("Delete", key)}
+//   - oldValue interface{}
+//   - newValue interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) CompareAndSwap(key interface{}, oldValue interface{}, newValue interface{}, ttl interface{}) *MockManager_CompareAndSwap_Call {
+	return &MockManager_CompareAndSwap_Call{Call: _e.mock.On("CompareAndSwap", key, oldValue, newValue, ttl)}
 }
 
-func (_c *MockManager_Delete_Call) Run(run func(key string)) *MockManager_Delete_Call {
+func (_c *MockManager_CompareAndSwap_Call) Run(run func(key string, oldValue interface{}, newValue interface{}, ttl time.Duration)) *MockManager_CompareAndSwap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(interface{}), args[2].(interface{}), args[3].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_Delete_Call) Return(_a0 error) *MockManager_Delete_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_CompareAndSwap_Call) Return(_a0 bool, _a1 error) *MockManager_CompareAndSwap_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Delete_Call) RunAndReturn(run func(string) error) *MockManager_Delete_Call {
+func (_c *MockManager_CompareAndSwap_Call) RunAndReturn(run func(string, interface{}, interface{}, time.Duration) (bool, error)) *MockManager_CompareAndSwap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteMultiple provides a mock function with given fields: keys
-func (_m *MockManager) DeleteMultiple(keys []string) error {
-	ret := _m.Called(keys)
+// CompareAndSwapContext provides a mock function with given fields: ctx, key, oldValue, newValue, ttl
+func (_m *MockManager) CompareAndSwapContext(ctx context.Context, key string, oldValue interface{}, newValue interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, oldValue, newValue, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteMultiple")
+		panic("no return value specified for CompareAndSwapContext")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(keys)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, oldValue, newValue, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, oldValue, newValue, ttl)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, oldValue, newValue, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_DeleteMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMultiple'
-type MockManager_DeleteMultiple_Call struct {
+// MockManager_CompareAndSwapContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwapContext'
+type MockManager_CompareAndSwapContext_Call struct {
 	*mock.Call
 }
 
-// DeleteMultiple is a helper method to define mock.On call
-//   - keys []string
-func (_e *MockManager_Expecter) DeleteMultiple(keys interface{}) *MockManager_DeleteMultiple_Call {
-	return &MockManager_DeleteMultiple_Call{Call: _e.mock.On("DeleteMultiple", keys)}
+// CompareAndSwapContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - oldValue interface{}
+//   - newValue interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) CompareAndSwapContext(ctx interface{}, key interface{}, oldValue interface{}, newValue interface{}, ttl interface{}) *MockManager_CompareAndSwapContext_Call {
+	return &MockManager_CompareAndSwapContext_Call{Call: _e.mock.On("CompareAndSwapContext", ctx, key, oldValue, newValue, ttl)}
 }
 
-func (_c *MockManager_DeleteMultiple_Call) Run(run func(keys []string)) *MockManager_DeleteMultiple_Call {
+func (_c *MockManager_CompareAndSwapContext_Call) Run(run func(ctx context.Context, key string, oldValue interface{}, newValue interface{}, ttl time.Duration)) *MockManager_CompareAndSwapContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(interface{}), args[4].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_DeleteMultiple_Call) Return(_a0 error) *MockManager_DeleteMultiple_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_CompareAndSwapContext_Call) Return(_a0 bool, _a1 error) *MockManager_CompareAndSwapContext_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_DeleteMultiple_Call) RunAndReturn(run func([]string) error) *MockManager_DeleteMultiple_Call {
+func (_c *MockManager_CompareAndSwapContext_Call) RunAndReturn(run func(context.Context, string, interface{}, interface{}, time.Duration) (bool, error)) *MockManager_CompareAndSwapContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Driver provides a mock function with given fields: name
-func (_m *MockManager) Driver(name string) (driver.Driver, error) {
-	ret := _m.Called(name)
+// Decrement provides a mock function with given fields: key, delta
+func (_m *MockManager) Decrement(key string, delta int64) (int64, error) {
+	ret := _m.Called(key, delta)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Driver")
+		panic("no return value specified for Decrement")
 	}
 
-	var r0 driver.Driver
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (driver.Driver, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(string, int64) (int64, error)); ok {
+		return rf(key, delta)
 	}
-	if rf, ok := ret.Get(0).(func(string) driver.Driver); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(string, int64) int64); ok {
+		r0 = rf(key, delta)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(driver.Driver)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(key, delta)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -221,266 +398,1241 @@ func (_m *MockManager) Driver(name string) (driver.Driver, error) {
 	return r0, r1
 }
 
-// MockManager_Driver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Driver'
-type MockManager_Driver_Call struct {
+// MockManager_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockManager_Decrement_Call struct {
 	*mock.Call
 }
 
-// Driver is a helper method to define mock.On call
-//   - name string
-func (_e *MockManager_Expecter) Driver(name interface{}) *MockManager_Driver_Call {
-	return &MockManager_Driver_Call{Call: _e.mock.On("Driver", name)}
+// Decrement is a helper method to define mock.On call
+//   - key string
+//   - delta int64
+func (_e *MockManager_Expecter) Decrement(key interface{}, delta interface{}) *MockManager_Decrement_Call {
+	return &MockManager_Decrement_Call{Call: _e.mock.On("Decrement", key, delta)}
+}
+
+func (_c *MockManager_Decrement_Call) Run(run func(key string, delta int64)) *MockManager_Decrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockManager_Decrement_Call) Return(_a0 int64, _a1 error) *MockManager_Decrement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Decrement_Call) RunAndReturn(run func(string, int64) (int64, error)) *MockManager_Decrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DecrementContext provides a mock function with given fields: ctx, key, delta
+func (_m *MockManager) DecrementContext(ctx context.Context, key string, delta int64) (int64, error) {
+	ret := _m.Called(ctx, key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrementContext")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, key, delta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, key, delta)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_DecrementContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecrementContext'
+type MockManager_DecrementContext_Call struct {
+	*mock.Call
+}
+
+// DecrementContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+func (_e *MockManager_Expecter) DecrementContext(ctx interface{}, key interface{}, delta interface{}) *MockManager_DecrementContext_Call {
+	return &MockManager_DecrementContext_Call{Call: _e.mock.On("DecrementContext", ctx, key, delta)}
+}
+
+func (_c *MockManager_DecrementContext_Call) Run(run func(ctx context.Context, key string, delta int64)) *MockManager_DecrementContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockManager_DecrementContext_Call) Return(_a0 int64, _a1 error) *MockManager_DecrementContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_DecrementContext_Call) RunAndReturn(run func(context.Context, string, int64) (int64, error)) *MockManager_DecrementContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: key
+func (_m *MockManager) Delete(key string) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockManager_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) Delete(key interface{}) *MockManager_Delete_Call {
+	return &MockManager_Delete_Call{Call: _e.mock.On("Delete", key)}
+}
+
+func (_c *MockManager_Delete_Call) Run(run func(key string)) *MockManager_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Delete_Call) Return(_a0 error) *MockManager_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Delete_Call) RunAndReturn(run func(string) error) *MockManager_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) DeleteContext(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_DeleteContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContext'
+type MockManager_DeleteContext_Call struct {
+	*mock.Call
+}
+
+// DeleteContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) DeleteContext(ctx interface{}, key interface{}) *MockManager_DeleteContext_Call {
+	return &MockManager_DeleteContext_Call{Call: _e.mock.On("DeleteContext", ctx, key)}
+}
+
+func (_c *MockManager_DeleteContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_DeleteContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_DeleteContext_Call) Return(_a0 error) *MockManager_DeleteContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_DeleteContext_Call) RunAndReturn(run func(context.Context, string) error) *MockManager_DeleteContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMultiple provides a mock function with given fields: keys
+func (_m *MockManager) DeleteMultiple(keys []string) error {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMultiple")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_DeleteMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMultiple'
+type MockManager_DeleteMultiple_Call struct {
+	*mock.Call
+}
+
+// DeleteMultiple is a helper method to define mock.On call
+//   - keys []string
+func (_e *MockManager_Expecter) DeleteMultiple(keys interface{}) *MockManager_DeleteMultiple_Call {
+	return &MockManager_DeleteMultiple_Call{Call: _e.mock.On("DeleteMultiple", keys)}
+}
+
+func (_c *MockManager_DeleteMultiple_Call) Run(run func(keys []string)) *MockManager_DeleteMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_DeleteMultiple_Call) Return(_a0 error) *MockManager_DeleteMultiple_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_DeleteMultiple_Call) RunAndReturn(run func([]string) error) *MockManager_DeleteMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMultipleContext provides a mock function with given fields: ctx, keys
+func (_m *MockManager) DeleteMultipleContext(ctx context.Context, keys []string) error {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMultipleContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_DeleteMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMultipleContext'
+type MockManager_DeleteMultipleContext_Call struct {
+	*mock.Call
+}
+
+// DeleteMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockManager_Expecter) DeleteMultipleContext(ctx interface{}, keys interface{}) *MockManager_DeleteMultipleContext_Call {
+	return &MockManager_DeleteMultipleContext_Call{Call: _e.mock.On("DeleteMultipleContext", ctx, keys)}
+}
+
+func (_c *MockManager_DeleteMultipleContext_Call) Run(run func(ctx context.Context, keys []string)) *MockManager_DeleteMultipleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_DeleteMultipleContext_Call) Return(_a0 error) *MockManager_DeleteMultipleContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_DeleteMultipleContext_Call) RunAndReturn(run func(context.Context, []string) error) *MockManager_DeleteMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Driver provides a mock function with given fields: name
+func (_m *MockManager) Driver(name string) (driver.Driver, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Driver")
+	}
+
+	var r0 driver.Driver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (driver.Driver, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) driver.Driver); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(driver.Driver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Driver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Driver'
+type MockManager_Driver_Call struct {
+	*mock.Call
+}
+
+// Driver is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Driver(name interface{}) *MockManager_Driver_Call {
+	return &MockManager_Driver_Call{Call: _e.mock.On("Driver", name)}
+}
+
+func (_c *MockManager_Driver_Call) Run(run func(name string)) *MockManager_Driver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Driver_Call) Return(_a0 driver.Driver, _a1 error) *MockManager_Driver_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Driver_Call) RunAndReturn(run func(string) (driver.Driver, error)) *MockManager_Driver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Flush provides a mock function with no fields
+func (_m *MockManager) Flush() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockManager_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Flush() *MockManager_Flush_Call {
+	return &MockManager_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *MockManager_Flush_Call) Run(run func()) *MockManager_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Flush_Call) Return(_a0 error) *MockManager_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Flush_Call) RunAndReturn(run func() error) *MockManager_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushContext provides a mock function with given fields: ctx
+func (_m *MockManager) FlushContext(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_FlushContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushContext'
+type MockManager_FlushContext_Call struct {
+	*mock.Call
+}
+
+// FlushContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockManager_Expecter) FlushContext(ctx interface{}) *MockManager_FlushContext_Call {
+	return &MockManager_FlushContext_Call{Call: _e.mock.On("FlushContext", ctx)}
+}
+
+func (_c *MockManager_FlushContext_Call) Run(run func(ctx context.Context)) *MockManager_FlushContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockManager_FlushContext_Call) Return(_a0 error) *MockManager_FlushContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_FlushContext_Call) RunAndReturn(run func(context.Context) error) *MockManager_FlushContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: key
+func (_m *MockManager) Get(key string) (interface{}, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (interface{}, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockManager_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) Get(key interface{}) *MockManager_Get_Call {
+	return &MockManager_Get_Call{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *MockManager_Get_Call) Run(run func(key string)) *MockManager_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Get_Call) Return(_a0 interface{}, _a1 bool) *MockManager_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Get_Call) RunAndReturn(run func(string) (interface{}, bool)) *MockManager_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) GetContext(ctx context.Context, key string) (interface{}, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContext")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContext'
+type MockManager_GetContext_Call struct {
+	*mock.Call
+}
+
+// GetContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) GetContext(ctx interface{}, key interface{}) *MockManager_GetContext_Call {
+	return &MockManager_GetContext_Call{Call: _e.mock.On("GetContext", ctx, key)}
+}
+
+func (_c *MockManager_GetContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_GetContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetContext_Call) Return(_a0 interface{}, _a1 bool) *MockManager_GetContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetContext_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockManager_GetContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInto provides a mock function with given fields: key, dest
+func (_m *MockManager) GetInto(key string, dest interface{}) (bool, error) {
+	ret := _m.Called(key, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInto")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, interface{}) (bool, error)); ok {
+		return rf(key, dest)
+	}
+	if rf, ok := ret.Get(0).(func(string, interface{}) bool); ok {
+		r0 = rf(key, dest)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, interface{}) error); ok {
+		r1 = rf(key, dest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetInto_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInto'
+type MockManager_GetInto_Call struct {
+	*mock.Call
+}
+
+// GetInto is a helper method to define mock.On call
+//   - key string
+//   - dest interface{}
+func (_e *MockManager_Expecter) GetInto(key interface{}, dest interface{}) *MockManager_GetInto_Call {
+	return &MockManager_GetInto_Call{Call: _e.mock.On("GetInto", key, dest)}
+}
+
+func (_c *MockManager_GetInto_Call) Run(run func(key string, dest interface{})) *MockManager_GetInto_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetInto_Call) Return(_a0 bool, _a1 error) *MockManager_GetInto_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetInto_Call) RunAndReturn(run func(string, interface{}) (bool, error)) *MockManager_GetInto_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIntoContext provides a mock function with given fields: ctx, key, dest
+func (_m *MockManager) GetIntoContext(ctx context.Context, key string, dest interface{}) (bool, error) {
+	ret := _m.Called(ctx, key, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIntoContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (bool, error)); ok {
+		return rf(ctx, key, dest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
+		r0 = rf(ctx, key, dest)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, key, dest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetIntoContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIntoContext'
+type MockManager_GetIntoContext_Call struct {
+	*mock.Call
+}
+
+// GetIntoContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - dest interface{}
+func (_e *MockManager_Expecter) GetIntoContext(ctx interface{}, key interface{}, dest interface{}) *MockManager_GetIntoContext_Call {
+	return &MockManager_GetIntoContext_Call{Call: _e.mock.On("GetIntoContext", ctx, key, dest)}
+}
+
+func (_c *MockManager_GetIntoContext_Call) Run(run func(ctx context.Context, key string, dest interface{})) *MockManager_GetIntoContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetIntoContext_Call) Return(_a0 bool, _a1 error) *MockManager_GetIntoContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetIntoContext_Call) RunAndReturn(run func(context.Context, string, interface{}) (bool, error)) *MockManager_GetIntoContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultiple provides a mock function with given fields: keys
+func (_m *MockManager) GetMultiple(keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultiple")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func([]string) (map[string]interface{}, []string)); ok {
+		return rf(keys)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string]interface{}); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) []string); ok {
+		r1 = rf(keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
+type MockManager_GetMultiple_Call struct {
+	*mock.Call
+}
+
+// GetMultiple is a helper method to define mock.On call
+//   - keys []string
+func (_e *MockManager_Expecter) GetMultiple(keys interface{}) *MockManager_GetMultiple_Call {
+	return &MockManager_GetMultiple_Call{Call: _e.mock.On("GetMultiple", keys)}
+}
+
+func (_c *MockManager_GetMultiple_Call) Run(run func(keys []string)) *MockManager_GetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockManager_GetMultiple_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetMultiple_Call) RunAndReturn(run func([]string) (map[string]interface{}, []string)) *MockManager_GetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultipleContext provides a mock function with given fields: ctx, keys
+func (_m *MockManager) GetMultipleContext(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultipleContext")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultipleContext'
+type MockManager_GetMultipleContext_Call struct {
+	*mock.Call
+}
+
+// GetMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockManager_Expecter) GetMultipleContext(ctx interface{}, keys interface{}) *MockManager_GetMultipleContext_Call {
+	return &MockManager_GetMultipleContext_Call{Call: _e.mock.On("GetMultipleContext", ctx, keys)}
+}
+
+func (_c *MockManager_GetMultipleContext_Call) Run(run func(ctx context.Context, keys []string)) *MockManager_GetMultipleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetMultipleContext_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockManager_GetMultipleContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetMultipleContext_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockManager_GetMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function with given fields: key
+func (_m *MockManager) Has(key string) bool {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockManager_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockManager_Has_Call struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) Has(key interface{}) *MockManager_Has_Call {
+	return &MockManager_Has_Call{Call: _e.mock.On("Has", key)}
+}
+
+func (_c *MockManager_Has_Call) Run(run func(key string)) *MockManager_Has_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Has_Call) Return(_a0 bool) *MockManager_Has_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Has_Call) RunAndReturn(run func(string) bool) *MockManager_Has_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) HasContext(ctx context.Context, key string) bool {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasContext")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockManager_HasContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasContext'
+type MockManager_HasContext_Call struct {
+	*mock.Call
+}
+
+// HasContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) HasContext(ctx interface{}, key interface{}) *MockManager_HasContext_Call {
+	return &MockManager_HasContext_Call{Call: _e.mock.On("HasContext", ctx, key)}
+}
+
+func (_c *MockManager_HasContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_HasContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_HasContext_Call) Return(_a0 bool) *MockManager_HasContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_HasContext_Call) RunAndReturn(run func(context.Context, string) bool) *MockManager_HasContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Increment provides a mock function with given fields: key, delta
+func (_m *MockManager) Increment(key string, delta int64) (int64, error) {
+	ret := _m.Called(key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) (int64, error)); ok {
+		return rf(key, delta)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) int64); ok {
+		r0 = rf(key, delta)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockManager_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - key string
+//   - delta int64
+func (_e *MockManager_Expecter) Increment(key interface{}, delta interface{}) *MockManager_Increment_Call {
+	return &MockManager_Increment_Call{Call: _e.mock.On("Increment", key, delta)}
+}
+
+func (_c *MockManager_Increment_Call) Run(run func(key string, delta int64)) *MockManager_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockManager_Increment_Call) Return(_a0 int64, _a1 error) *MockManager_Increment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Increment_Call) RunAndReturn(run func(string, int64) (int64, error)) *MockManager_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementContext provides a mock function with given fields: ctx, key, delta
+func (_m *MockManager) IncrementContext(ctx context.Context, key string, delta int64) (int64, error) {
+	ret := _m.Called(ctx, key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementContext")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, key, delta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, key, delta)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_IncrementContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementContext'
+type MockManager_IncrementContext_Call struct {
+	*mock.Call
 }
 
-func (_c *MockManager_Driver_Call) Run(run func(name string)) *MockManager_Driver_Call {
+// IncrementContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+func (_e *MockManager_Expecter) IncrementContext(ctx interface{}, key interface{}, delta interface{}) *MockManager_IncrementContext_Call {
+	return &MockManager_IncrementContext_Call{Call: _e.mock.On("IncrementContext", ctx, key, delta)}
+}
+
+func (_c *MockManager_IncrementContext_Call) Run(run func(ctx context.Context, key string, delta int64)) *MockManager_IncrementContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
 	})
 	return _c
 }
 
-func (_c *MockManager_Driver_Call) Return(_a0 driver.Driver, _a1 error) *MockManager_Driver_Call {
+func (_c *MockManager_IncrementContext_Call) Return(_a0 int64, _a1 error) *MockManager_IncrementContext_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Driver_Call) RunAndReturn(run func(string) (driver.Driver, error)) *MockManager_Driver_Call {
+func (_c *MockManager_IncrementContext_Call) RunAndReturn(run func(context.Context, string, int64) (int64, error)) *MockManager_IncrementContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Flush provides a mock function with no fields
-func (_m *MockManager) Flush() error {
-	ret := _m.Called()
+// Namespace provides a mock function with given fields: name
+func (_m *MockManager) Namespace(name string) cache.Manager {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Flush")
+		panic("no return value specified for Namespace")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	var r0 cache.Manager
+	if rf, ok := ret.Get(0).(func(string) cache.Manager); ok {
+		r0 = rf(name)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.Manager)
+		}
 	}
 
 	return r0
 }
 
-// MockManager_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
-type MockManager_Flush_Call struct {
+// MockManager_Namespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Namespace'
+type MockManager_Namespace_Call struct {
 	*mock.Call
 }
 
-// Flush is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Flush() *MockManager_Flush_Call {
-	return &MockManager_Flush_Call{Call: _e.mock.On("Flush")}
+// Namespace is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Namespace(name interface{}) *MockManager_Namespace_Call {
+	return &MockManager_Namespace_Call{Call: _e.mock.On("Namespace", name)}
 }
 
-func (_c *MockManager_Flush_Call) Run(run func()) *MockManager_Flush_Call {
+func (_c *MockManager_Namespace_Call) Run(run func(name string)) *MockManager_Namespace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockManager_Flush_Call) Return(_a0 error) *MockManager_Flush_Call {
+func (_c *MockManager_Namespace_Call) Return(_a0 cache.Manager) *MockManager_Namespace_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Flush_Call) RunAndReturn(run func() error) *MockManager_Flush_Call {
+func (_c *MockManager_Namespace_Call) RunAndReturn(run func(string) cache.Manager) *MockManager_Namespace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function with given fields: key
-func (_m *MockManager) Get(key string) (interface{}, bool) {
-	ret := _m.Called(key)
+// Remember provides a mock function with given fields: key, ttl, callback
+func (_m *MockManager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(key, ttl, callback)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for Remember")
 	}
 
 	var r0 interface{}
-	var r1 bool
-	if rf, ok := ret.Get(0).(func(string) (interface{}, bool)); ok {
-		return rf(key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(key, ttl, callback)
 	}
-	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(key, ttl, callback)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) bool); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func(string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(key, ttl, callback)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockManager_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockManager_Get_Call struct {
+// MockManager_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockManager_Remember_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// Remember is a helper method to define mock.On call
 //   - key string
-func (_e *MockManager_Expecter) Get(key interface{}) *MockManager_Get_Call {
-	return &MockManager_Get_Call{Call: _e.mock.On("Get", key)}
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockManager_Expecter) Remember(key interface{}, ttl interface{}, callback interface{}) *MockManager_Remember_Call {
+	return &MockManager_Remember_Call{Call: _e.mock.On("Remember", key, ttl, callback)}
 }
 
-func (_c *MockManager_Get_Call) Run(run func(key string)) *MockManager_Get_Call {
+func (_c *MockManager_Remember_Call) Run(run func(key string, ttl time.Duration, callback func() (interface{}, error))) *MockManager_Remember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(time.Duration), args[2].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockManager_Get_Call) Return(_a0 interface{}, _a1 bool) *MockManager_Get_Call {
+func (_c *MockManager_Remember_Call) Return(_a0 interface{}, _a1 error) *MockManager_Remember_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Get_Call) RunAndReturn(run func(string) (interface{}, bool)) *MockManager_Get_Call {
+func (_c *MockManager_Remember_Call) RunAndReturn(run func(string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_Remember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMultiple provides a mock function with given fields: keys
-func (_m *MockManager) GetMultiple(keys []string) (map[string]interface{}, []string) {
-	ret := _m.Called(keys)
+// RememberContext provides a mock function with given fields: ctx, key, ttl, callback
+func (_m *MockManager) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, callback)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMultiple")
+		panic("no return value specified for RememberContext")
 	}
 
-	var r0 map[string]interface{}
-	var r1 []string
-	if rf, ok := ret.Get(0).(func([]string) (map[string]interface{}, []string)); ok {
-		return rf(keys)
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, callback)
 	}
-	if rf, ok := ret.Get(0).(func([]string) map[string]interface{}); ok {
-		r0 = rf(keys)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, callback)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
+			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]string) []string); ok {
-		r1 = rf(keys)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, callback)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]string)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockManager_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
-type MockManager_GetMultiple_Call struct {
+// MockManager_RememberContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberContext'
+type MockManager_RememberContext_Call struct {
 	*mock.Call
 }
 
-// GetMultiple is a helper method to define mock.On call
-//   - keys []string
-func (_e *MockManager_Expecter) GetMultiple(keys interface{}) *MockManager_GetMultiple_Call {
-	return &MockManager_GetMultiple_Call{Call: _e.mock.On("GetMultiple", keys)}
+// RememberContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockManager_Expecter) RememberContext(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockManager_RememberContext_Call {
+	return &MockManager_RememberContext_Call{Call: _e.mock.On("RememberContext", ctx, key, ttl, callback)}
 }
 
-func (_c *MockManager_GetMultiple_Call) Run(run func(keys []string)) *MockManager_GetMultiple_Call {
+func (_c *MockManager_RememberContext_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockManager_RememberContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockManager_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockManager_GetMultiple_Call {
+func (_c *MockManager_RememberContext_Call) Return(_a0 interface{}, _a1 error) *MockManager_RememberContext_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_GetMultiple_Call) RunAndReturn(run func([]string) (map[string]interface{}, []string)) *MockManager_GetMultiple_Call {
+func (_c *MockManager_RememberContext_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_RememberContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Has provides a mock function with given fields: key
-func (_m *MockManager) Has(key string) bool {
-	ret := _m.Called(key)
+// RememberWithOptions provides a mock function with given fields: key, callback, opts
+func (_m *MockManager) RememberWithOptions(key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error) {
+	ret := _m.Called(key, callback, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Has")
+		panic("no return value specified for RememberWithOptions")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(key)
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, func() (interface{}, error), driver.RememberOptions) (interface{}, error)); ok {
+		return rf(key, callback, opts)
+	}
+	if rf, ok := ret.Get(0).(func(string, func() (interface{}, error), driver.RememberOptions) interface{}); ok {
+		r0 = rf(key, callback, opts)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, func() (interface{}, error), driver.RememberOptions) error); ok {
+		r1 = rf(key, callback, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
-type MockManager_Has_Call struct {
+// MockManager_RememberWithOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberWithOptions'
+type MockManager_RememberWithOptions_Call struct {
 	*mock.Call
 }
 
-// Has is a helper method to define mock.On call
+// RememberWithOptions is a helper method to define mock.On call
 //   - key string
-func (_e *MockManager_Expecter) Has(key interface{}) *MockManager_Has_Call {
-	return &MockManager_Has_Call{Call: _e.mock.On("Has", key)}
+//   - callback func()(interface{} , error)
+//   - opts driver.RememberOptions
+func (_e *MockManager_Expecter) RememberWithOptions(key interface{}, callback interface{}, opts interface{}) *MockManager_RememberWithOptions_Call {
+	return &MockManager_RememberWithOptions_Call{Call: _e.mock.On("RememberWithOptions", key, callback, opts)}
 }
 
-func (_c *MockManager_Has_Call) Run(run func(key string)) *MockManager_Has_Call {
+func (_c *MockManager_RememberWithOptions_Call) Run(run func(key string, callback func() (interface{}, error), opts driver.RememberOptions)) *MockManager_RememberWithOptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(func() (interface{}, error)), args[2].(driver.RememberOptions))
 	})
 	return _c
 }
 
-func (_c *MockManager_Has_Call) Return(_a0 bool) *MockManager_Has_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_RememberWithOptions_Call) Return(_a0 interface{}, _a1 error) *MockManager_RememberWithOptions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Has_Call) RunAndReturn(run func(string) bool) *MockManager_Has_Call {
+func (_c *MockManager_RememberWithOptions_Call) RunAndReturn(run func(string, func() (interface{}, error), driver.RememberOptions) (interface{}, error)) *MockManager_RememberWithOptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remember provides a mock function with given fields: key, ttl, callback
-func (_m *MockManager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	ret := _m.Called(key, ttl, callback)
+// RememberWithOptionsContext provides a mock function with given fields: ctx, key, callback, opts
+func (_m *MockManager) RememberWithOptionsContext(ctx context.Context, key string, callback func() (interface{}, error), opts driver.RememberOptions) (interface{}, error) {
+	ret := _m.Called(ctx, key, callback, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Remember")
+		panic("no return value specified for RememberWithOptionsContext")
 	}
 
 	var r0 interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
-		return rf(key, ttl, callback)
+	if rf, ok := ret.Get(0).(func(context.Context, string, func() (interface{}, error), driver.RememberOptions) (interface{}, error)); ok {
+		return rf(ctx, key, callback, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) interface{}); ok {
-		r0 = rf(key, ttl, callback)
+	if rf, ok := ret.Get(0).(func(context.Context, string, func() (interface{}, error), driver.RememberOptions) interface{}); ok {
+		r0 = rf(ctx, key, callback, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Duration, func() (interface{}, error)) error); ok {
-		r1 = rf(key, ttl, callback)
+	if rf, ok := ret.Get(1).(func(context.Context, string, func() (interface{}, error), driver.RememberOptions) error); ok {
+		r1 = rf(ctx, key, callback, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -488,32 +1640,33 @@ func (_m *MockManager) Remember(key string, ttl time.Duration, callback func() (
 	return r0, r1
 }
 
-// MockManager_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
-type MockManager_Remember_Call struct {
+// MockManager_RememberWithOptionsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberWithOptionsContext'
+type MockManager_RememberWithOptionsContext_Call struct {
 	*mock.Call
 }
 
-// Remember is a helper method to define mock.On call
+// RememberWithOptionsContext is a helper method to define mock.On call
+//   - ctx context.Context
 //   - key string
-//   - ttl time.Duration
 //   - callback func()(interface{} , error)
-func (_e *MockManager_Expecter) Remember(key interface{}, ttl interface{}, callback interface{}) *MockManager_Remember_Call {
-	return &MockManager_Remember_Call{Call: _e.mock.On("Remember", key, ttl, callback)}
+//   - opts driver.RememberOptions
+func (_e *MockManager_Expecter) RememberWithOptionsContext(ctx interface{}, key interface{}, callback interface{}, opts interface{}) *MockManager_RememberWithOptionsContext_Call {
+	return &MockManager_RememberWithOptionsContext_Call{Call: _e.mock.On("RememberWithOptionsContext", ctx, key, callback, opts)}
 }
 
-func (_c *MockManager_Remember_Call) Run(run func(key string, ttl time.Duration, callback func() (interface{}, error))) *MockManager_Remember_Call {
+func (_c *MockManager_RememberWithOptionsContext_Call) Run(run func(ctx context.Context, key string, callback func() (interface{}, error), opts driver.RememberOptions)) *MockManager_RememberWithOptionsContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Duration), args[2].(func() (interface{}, error)))
+		run(args[0].(context.Context), args[1].(string), args[2].(func() (interface{}, error)), args[3].(driver.RememberOptions))
 	})
 	return _c
 }
 
-func (_c *MockManager_Remember_Call) Return(_a0 interface{}, _a1 error) *MockManager_Remember_Call {
+func (_c *MockManager_RememberWithOptionsContext_Call) Return(_a0 interface{}, _a1 error) *MockManager_RememberWithOptionsContext_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Remember_Call) RunAndReturn(run func(string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_Remember_Call {
+func (_c *MockManager_RememberWithOptionsContext_Call) RunAndReturn(run func(context.Context, string, func() (interface{}, error), driver.RememberOptions) (interface{}, error)) *MockManager_RememberWithOptionsContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -566,6 +1719,55 @@ func (_c *MockManager_Set_Call) RunAndReturn(run func(string, interface{}, time.
 	return _c
 }
 
+// SetContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockManager) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_SetContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetContext'
+type MockManager_SetContext_Call struct {
+	*mock.Call
+}
+
+// SetContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) SetContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockManager_SetContext_Call {
+	return &MockManager_SetContext_Call{Call: _e.mock.On("SetContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockManager_SetContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockManager_SetContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_SetContext_Call) Return(_a0 error) *MockManager_SetContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SetContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *MockManager_SetContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetDefaultDriver provides a mock function with given fields: name
 func (_m *MockManager) SetDefaultDriver(name string) {
 	_m.Called(name)
@@ -646,6 +1848,54 @@ func (_c *MockManager_SetMultiple_Call) RunAndReturn(run func(map[string]interfa
 	return _c
 }
 
+// SetMultipleContext provides a mock function with given fields: ctx, values, ttl
+func (_m *MockManager) SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMultipleContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_SetMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMultipleContext'
+type MockManager_SetMultipleContext_Call struct {
+	*mock.Call
+}
+
+// SetMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) SetMultipleContext(ctx interface{}, values interface{}, ttl interface{}) *MockManager_SetMultipleContext_Call {
+	return &MockManager_SetMultipleContext_Call{Call: _e.mock.On("SetMultipleContext", ctx, values, ttl)}
+}
+
+func (_c *MockManager_SetMultipleContext_Call) Run(run func(ctx context.Context, values map[string]interface{}, ttl time.Duration)) *MockManager_SetMultipleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_SetMultipleContext_Call) Return(_a0 error) *MockManager_SetMultipleContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SetMultipleContext_Call) RunAndReturn(run func(context.Context, map[string]interface{}, time.Duration) error) *MockManager_SetMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stats provides a mock function with no fields
 func (_m *MockManager) Stats() map[string]map[string]interface{} {
 	ret := _m.Called()
@@ -693,6 +1943,67 @@ func (_c *MockManager_Stats_Call) RunAndReturn(run func() map[string]map[string]
 	return _c
 }
 
+// Tags provides a mock function with given fields: names
+func (_m *MockManager) Tags(names ...string) cache.TaggedCache {
+	_va := make([]interface{}, len(names))
+	for _i := range names {
+		_va[_i] = names[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tags")
+	}
+
+	var r0 cache.TaggedCache
+	if rf, ok := ret.Get(0).(func(...string) cache.TaggedCache); ok {
+		r0 = rf(names...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.TaggedCache)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Tags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tags'
+type MockManager_Tags_Call struct {
+	*mock.Call
+}
+
+// Tags is a helper method to define mock.On call
+//   - names ...string
+func (_e *MockManager_Expecter) Tags(names ...interface{}) *MockManager_Tags_Call {
+	return &MockManager_Tags_Call{Call: _e.mock.On("Tags",
+		append([]interface{}{}, names...)...)}
+}
+
+func (_c *MockManager_Tags_Call) Run(run func(names ...string)) *MockManager_Tags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_Tags_Call) Return(_a0 cache.TaggedCache) *MockManager_Tags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Tags_Call) RunAndReturn(run func(...string) cache.TaggedCache) *MockManager_Tags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockManager creates a new instance of MockManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockManager(t interface {
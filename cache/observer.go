@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+
+	"go.fork.vn/providers/cache/driver"
+)
+
+// observerGroup chuyển mỗi sự kiện tới tất cả các observer theo thứ tự được gắn.
+type observerGroup []driver.Observer
+
+// OnHit chuyển sự kiện hit tới các observer.
+func (g observerGroup) OnHit(ctx context.Context, event driver.Event) {
+	for _, observer := range g {
+		observer.OnHit(ctx, event)
+	}
+}
+
+// OnMiss chuyển sự kiện miss tới các observer.
+func (g observerGroup) OnMiss(ctx context.Context, event driver.Event) {
+	for _, observer := range g {
+		observer.OnMiss(ctx, event)
+	}
+}
+
+// OnWrite chuyển sự kiện ghi tới các observer.
+func (g observerGroup) OnWrite(ctx context.Context, event driver.Event) {
+	for _, observer := range g {
+		observer.OnWrite(ctx, event)
+	}
+}
+
+// OnDelete chuyển sự kiện xóa tới các observer.
+func (g observerGroup) OnDelete(ctx context.Context, event driver.Event) {
+	for _, observer := range g {
+		observer.OnDelete(ctx, event)
+	}
+}
+
+// OnError chuyển sự kiện lỗi tới các observer.
+func (g observerGroup) OnError(ctx context.Context, event driver.Event, err error) {
+	for _, observer := range g {
+		observer.OnError(ctx, event, err)
+	}
+}
@@ -74,6 +74,8 @@ func (p *serviceProvider) Register(app interface{}) {
 		if err := configManager.UnmarshalKey("cache", &cfg); err != nil {
 			panic("Cache config unmarshal error: " + err.Error())
 		}
+		// Prefix chung được áp dụng cho các driver chưa cấu hình prefix riêng
+		cfg.ApplyPrefix()
 		manager := NewManager()
 		// Đăng ký cache service
 		c.Instance("cache", manager)
@@ -132,6 +134,41 @@ func (p *serviceProvider) Register(app interface{}) {
 			c.Instance("cache.mongodb", mongodbDriver)
 			p.providers = append(p.providers, "cache.mongodb")
 		}
+
+		if cfg.Drivers.Tiered != nil && cfg.Drivers.Tiered.Enabled {
+			// L2 là một driver đã được đăng ký ở trên
+			l2, err := manager.Driver(cfg.Drivers.Tiered.L2)
+			if err != nil {
+				panic("Failed to create Tiered driver: " + err.Error())
+			}
+
+			opts := driver.TieredOptions{L1TTL: cfg.Drivers.Tiered.GetL1TTL()}
+			if channel := cfg.GetInvalidationChannel(); channel != "" {
+				if redisManager == nil {
+					redisManager = c.MustMake("redis").(redis.Manager)
+				}
+				client, err := redisManager.Client()
+				if err != nil {
+					panic("Failed to create Tiered driver invalidator: " + err.Error())
+				}
+				opts.Invalidator = driver.NewRedisInvalidator(client, channel)
+			}
+
+			// L1 là memory driver riêng của tiered driver
+			l1 := driver.NewMemoryDriver(config.DriverMemoryConfig{
+				DefaultTTL:      cfg.Drivers.Tiered.L1TTL,
+				CleanupInterval: cfg.Drivers.Tiered.L1TTL,
+				MaxItems:        cfg.Drivers.Tiered.L1MaxItems,
+			})
+			tieredDriver, err := driver.NewTieredDriver(l1, l2, opts)
+			if err != nil {
+				l1.Close()
+				panic("Failed to create Tiered driver: " + err.Error())
+			}
+			manager.AddDriver("tiered", tieredDriver)
+			c.Instance("cache.tiered", tieredDriver)
+			p.providers = append(p.providers, "cache.tiered")
+		}
 	}
 }
 
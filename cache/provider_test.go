@@ -148,6 +148,51 @@ func TestServiceProviderRegister(t *testing.T) {
 		// requires proper MongoDB client setup that's beyond the scope of this unit test
 		t.Skip("MongoDB driver test requires integration testing with real MongoDB client setup")
 	})
+
+	t.Run("registers with tiered driver configuration", func(t *testing.T) {
+		// Arrange
+		container := di.New()
+		mockApp := &dimocks.Application{}
+		mockConfigManager := configmocks.NewMockManager(t)
+
+		mockApp.On("Container").Return(container)
+		container.Instance("config", mockConfigManager)
+
+		cacheConfig := config.Config{
+			DefaultDriver: "tiered",
+			Drivers: config.DriversConfig{
+				Memory: &config.DriverMemoryConfig{
+					Enabled:         true,
+					DefaultTTL:      3600,
+					CleanupInterval: 600,
+				},
+				Tiered: &config.DriverTieredConfig{
+					Enabled:    true,
+					L2:         "memory",
+					L1TTL:      30,
+					L1MaxItems: 100,
+				},
+			},
+		}
+
+		mockConfigManager.EXPECT().UnmarshalKey("cache", mock.AnythingOfType("*config.Config")).RunAndReturn(func(key string, cfg interface{}) error {
+			if c, ok := cfg.(*config.Config); ok {
+				*c = cacheConfig
+			}
+			return nil
+		})
+
+		provider := NewServiceProvider()
+
+		// Act
+		provider.Register(mockApp)
+
+		// Assert
+		assert.True(t, container.Bound("cache.tiered"), "Expected 'cache.tiered' to be bound in container")
+		cacheManager := container.MustMake("cache").(*manager)
+		_, err := cacheManager.Driver("tiered")
+		assert.NoError(t, err)
+	})
 }
 
 func TestServiceProviderBoot(t *testing.T) {
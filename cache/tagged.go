@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.fork.vn/providers/cache/driver"
+)
+
+// TaggedCache là view của cache mà mọi entry đều gắn với một tập tag.
+//
+// Key của entry được tạo từ version hiện tại của các tag, nên Flush chỉ cần đổi version
+// của các tag để làm mất hiệu lực toàn bộ entry gắn tag đó mà không ảnh hưởng tới các
+// entry khác. Entry cũ không còn truy cập được và sẽ tự hết hạn theo TTL.
+//
+// Entry chỉ truy cập được qua đúng tập tag đã dùng khi ghi (thứ tự tag không quan trọng).
+type TaggedCache interface {
+	// Get lấy một giá trị gắn tag từ cache.
+	//
+	// Params:
+	//   - key: Cache key cần tìm
+	//
+	// Returns:
+	//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+	Get(key string) (interface{}, bool)
+
+	// GetContext lấy một giá trị gắn tag, dùng ctx cho các thao tác trên driver (kể cả đọc version của tag).
+	// Các phương thức không có hậu tố Context dùng context.Background().
+	GetContext(ctx context.Context, key string) (interface{}, bool)
+
+	// Set đặt một giá trị gắn tag vào cache với TTL tùy chọn.
+	//
+	// Params:
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver không hỗ trợ tag
+	Set(key string, value interface{}, ttl time.Duration) error
+
+	// SetContext đặt một giá trị gắn tag, dùng ctx cho các thao tác trên driver.
+	SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// Has kiểm tra xem một key gắn tag có tồn tại trong cache không.
+	//
+	// Params:
+	//   - key: Cache key cần kiểm tra
+	//
+	// Returns:
+	//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
+	Has(key string) bool
+
+	// HasContext kiểm tra một key gắn tag, dùng ctx cho các thao tác trên driver.
+	HasContext(ctx context.Context, key string) bool
+
+	// Delete xóa một key gắn tag khỏi cache.
+	//
+	// Params:
+	//   - key: Cache key cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver không hỗ trợ tag
+	Delete(key string) error
+
+	// DeleteContext xóa một key gắn tag, dùng ctx cho các thao tác trên driver.
+	DeleteContext(ctx context.Context, key string) error
+
+	// Remember lấy một giá trị gắn tag từ cache hoặc thực thi callback nếu không tìm thấy.
+	//
+	// Params:
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+	//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver không hỗ trợ tag
+	Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// RememberContext lấy một giá trị gắn tag hoặc thực thi callback nếu không tìm thấy,
+	// dùng ctx cho các thao tác trên driver.
+	RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// Flush làm mất hiệu lực mọi entry gắn với bất kỳ tag nào của view này.
+	//
+	// Entry gắn với tag "tenant:7" bị làm mất hiệu lực dù được ghi qua Tags("tenant:7")
+	// hay Tags("tenant:7", "users").
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình cập nhật version hoặc driver không hỗ trợ tag
+	Flush() error
+
+	// FlushContext làm mất hiệu lực mọi entry gắn với các tag của view, dùng ctx cho thao tác trên driver.
+	FlushContext(ctx context.Context) error
+}
+
+// taggedCache là implementation của TaggedCache trên driver mặc định của manager.
+type taggedCache struct {
+	manager *manager
+	tags    []string
+}
+
+// Tags trả về view của cache gắn với các tag được chỉ định.
+//
+// Params:
+//   - names: Danh sách tag, trùng lặp sẽ được loại bỏ và thứ tự không quan trọng
+//
+// Returns:
+//   - TaggedCache: View để đọc, ghi và làm mất hiệu lực các entry gắn tag
+func (m *manager) Tags(names ...string) TaggedCache {
+	tags := normalizeTags(names)
+	// Tag thuộc về namespace, nên cùng tên tag ở hai namespace không làm mất hiệu lực lẫn nhau
+	for i, tag := range tags {
+		tags[i] = m.key(tag)
+	}
+	return &taggedCache{manager: m, tags: tags}
+}
+
+// Get lấy một giá trị gắn tag từ cache.
+func (c *taggedCache) Get(key string) (interface{}, bool) {
+	return c.GetContext(context.Background(), key)
+}
+
+// GetContext lấy một giá trị gắn tag, dùng ctx cho các thao tác trên driver.
+func (c *taggedCache) GetContext(ctx context.Context, key string) (interface{}, bool) {
+	d, taggedKey, err := c.resolve(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+	return d.Get(ctx, taggedKey)
+}
+
+// Set đặt một giá trị gắn tag vào cache với TTL tùy chọn.
+func (c *taggedCache) Set(key string, value interface{}, ttl time.Duration) error {
+	return c.SetContext(context.Background(), key, value, ttl)
+}
+
+// SetContext đặt một giá trị gắn tag, dùng ctx cho các thao tác trên driver.
+func (c *taggedCache) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	d, taggedKey, err := c.resolve(ctx, key)
+	if err != nil {
+		return err
+	}
+	return d.Set(ctx, taggedKey, value, ttl)
+}
+
+// Has kiểm tra xem một key gắn tag có tồn tại trong cache không.
+func (c *taggedCache) Has(key string) bool {
+	return c.HasContext(context.Background(), key)
+}
+
+// HasContext kiểm tra một key gắn tag, dùng ctx cho các thao tác trên driver.
+func (c *taggedCache) HasContext(ctx context.Context, key string) bool {
+	d, taggedKey, err := c.resolve(ctx, key)
+	if err != nil {
+		return false
+	}
+	return d.Has(ctx, taggedKey)
+}
+
+// Delete xóa một key gắn tag khỏi cache.
+func (c *taggedCache) Delete(key string) error {
+	return c.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext xóa một key gắn tag, dùng ctx cho các thao tác trên driver.
+func (c *taggedCache) DeleteContext(ctx context.Context, key string) error {
+	d, taggedKey, err := c.resolve(ctx, key)
+	if err != nil {
+		return err
+	}
+	return d.Delete(ctx, taggedKey)
+}
+
+// Remember lấy một giá trị gắn tag từ cache hoặc thực thi callback nếu không tìm thấy.
+func (c *taggedCache) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return c.RememberContext(context.Background(), key, ttl, callback)
+}
+
+// RememberContext lấy một giá trị gắn tag hoặc thực thi callback nếu không tìm thấy,
+// dùng ctx cho các thao tác trên driver.
+func (c *taggedCache) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	d, taggedKey, err := c.resolve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return d.Remember(ctx, taggedKey, ttl, callback)
+}
+
+// Flush làm mất hiệu lực mọi entry gắn với bất kỳ tag nào của view này.
+func (c *taggedCache) Flush() error {
+	return c.FlushContext(context.Background())
+}
+
+// FlushContext làm mất hiệu lực mọi entry gắn với các tag của view, dùng ctx cho thao tác trên driver.
+func (c *taggedCache) FlushContext(ctx context.Context) error {
+	d, err := c.manager.activeDriver()
+	if err != nil {
+		return err
+	}
+	taggable, ok := d.(driver.Taggable)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	return taggable.FlushTags(ctx, c.tags)
+}
+
+// resolve lấy driver mặc định và tạo key gắn tag từ version hiện tại của các tag.
+func (c *taggedCache) resolve(ctx context.Context, key string) (driver.Driver, string, error) {
+	d, err := c.manager.activeDriver()
+	if err != nil {
+		return nil, "", err
+	}
+	taggable, ok := d.(driver.Taggable)
+	if !ok {
+		return nil, "", ErrTagsNotSupported
+	}
+
+	versions, err := taggable.TagVersions(ctx, c.tags)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not resolve tags: %w", err)
+	}
+
+	h := sha1.New()
+	for i, tag := range c.tags {
+		h.Write([]byte(tag + "=" + versions[i] + "|"))
+	}
+	return d, c.manager.key("tagged:" + hex.EncodeToString(h.Sum(nil)) + ":" + key), nil
+}
+
+// normalizeTags loại bỏ tag trùng lặp và sắp xếp để thứ tự tag không ảnh hưởng tới key.
+func normalizeTags(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			tags = append(tags, name)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+var (
+	// ErrTagsNotSupported được trả về khi driver mặc định không cài đặt driver.Taggable
+	ErrTagsNotSupported = driver.ErrTagsNotSupported
+)
@@ -0,0 +1,164 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.fork.vn/providers/cache"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
+	"go.fork.vn/providers/cache/mocks"
+)
+
+func newTaggedTestManager(t *testing.T) cache.Manager {
+	t.Helper()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	t.Cleanup(func() { memoryDriver.Close() })
+
+	manager := cache.NewManager()
+	manager.AddDriver("memory", memoryDriver)
+	return manager
+}
+
+// taggedTestKey là khóa context dùng để kiểm tra context được truyền tới driver.
+type taggedTestKey struct{}
+
+// contextRecordingDriver ghi lại context của các thao tác tag và ghi trên memory driver.
+type contextRecordingDriver struct {
+	driver.MemoryDriver
+	calls    []string
+	contexts []context.Context
+}
+
+func (d *contextRecordingDriver) record(call string, ctx context.Context) {
+	d.calls = append(d.calls, call)
+	d.contexts = append(d.contexts, ctx)
+}
+
+func (d *contextRecordingDriver) TagVersions(ctx context.Context, tags []string) ([]string, error) {
+	d.record("TagVersions", ctx)
+	return d.MemoryDriver.TagVersions(ctx, tags)
+}
+
+func (d *contextRecordingDriver) FlushTags(ctx context.Context, tags []string) error {
+	d.record("FlushTags", ctx)
+	return d.MemoryDriver.FlushTags(ctx, tags)
+}
+
+func (d *contextRecordingDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	d.record("Set", ctx)
+	return d.MemoryDriver.Set(ctx, key, value, ttl)
+}
+
+// TestTaggedCache tests reading and writing entries through tagged views
+func TestTaggedCache(t *testing.T) {
+	t.Run("set and get through the same tags", func(t *testing.T) {
+		manager := newTaggedTestManager(t)
+
+		err := manager.Tags("tenant:7", "users").Set("user:1", "alice", time.Minute)
+		assert.NoError(t, err)
+
+		value, found := manager.Tags("tenant:7", "users").Get("user:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+		assert.True(t, manager.Tags("users", "tenant:7", "users").Has("user:1"), "tag order and duplicates should not matter")
+
+		_, found = manager.Get("user:1")
+		assert.False(t, found, "tagged entry should not be visible without tags")
+		_, found = manager.Tags("tenant:7").Get("user:1")
+		assert.False(t, found, "tagged entry should only be visible with the same tag set")
+	})
+
+	t.Run("flush invalidates entries sharing any tag", func(t *testing.T) {
+		manager := newTaggedTestManager(t)
+
+		assert.NoError(t, manager.Set("plain", "kept", time.Minute))
+		assert.NoError(t, manager.Tags("tenant:7", "users").Set("user:1", "alice", time.Minute))
+		assert.NoError(t, manager.Tags("tenant:7").Set("settings", "dark", time.Minute))
+		assert.NoError(t, manager.Tags("tenant:8").Set("settings", "light", time.Minute))
+
+		assert.NoError(t, manager.Tags("tenant:7").Flush())
+
+		assert.False(t, manager.Tags("tenant:7", "users").Has("user:1"))
+		assert.False(t, manager.Tags("tenant:7").Has("settings"))
+
+		value, found := manager.Tags("tenant:8").Get("settings")
+		assert.True(t, found)
+		assert.Equal(t, "light", value)
+		value, found = manager.Get("plain")
+		assert.True(t, found)
+		assert.Equal(t, "kept", value)
+
+		// Ghi lại sau khi flush sử dụng version mới của tag
+		assert.NoError(t, manager.Tags("tenant:7").Set("settings", "blue", time.Minute))
+		value, found = manager.Tags("tenant:7").Get("settings")
+		assert.True(t, found)
+		assert.Equal(t, "blue", value)
+	})
+
+	t.Run("remember and delete", func(t *testing.T) {
+		manager := newTaggedTestManager(t)
+		tagged := manager.Tags("reports")
+
+		calls := 0
+		callback := func() (interface{}, error) {
+			calls++
+			return "computed", nil
+		}
+
+		for i := 0; i < 2; i++ {
+			value, err := tagged.Remember("daily", time.Minute, callback)
+			assert.NoError(t, err)
+			assert.Equal(t, "computed", value)
+		}
+		assert.Equal(t, 1, calls)
+
+		assert.NoError(t, tagged.Delete("daily"))
+		assert.False(t, tagged.Has("daily"))
+	})
+
+	t.Run("returns error when driver does not support tags", func(t *testing.T) {
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mocks.NewMockDriver(t))
+
+		err := manager.Tags("users").Set("user:1", "alice", time.Minute)
+		assert.True(t, errors.Is(err, cache.ErrTagsNotSupported))
+		assert.True(t, errors.Is(manager.Tags("users").Flush(), cache.ErrTagsNotSupported))
+
+		_, found := manager.Tags("users").Get("user:1")
+		assert.False(t, found)
+	})
+
+	t.Run("context variants pass the caller context to the driver", func(t *testing.T) {
+		recording := &contextRecordingDriver{MemoryDriver: driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})}
+		t.Cleanup(func() { recording.Close() })
+		manager := cache.NewManager()
+		manager.AddDriver("memory", recording)
+
+		ctx := context.WithValue(context.Background(), taggedTestKey{}, "request-1")
+		tagged := manager.Tags("users")
+		assert.NoError(t, tagged.SetContext(ctx, "user:1", "alice", time.Minute))
+		value, found := tagged.GetContext(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "alice", value)
+		assert.NoError(t, tagged.FlushContext(ctx))
+		assert.False(t, tagged.HasContext(ctx, "user:1"))
+
+		assert.Equal(t, []string{"TagVersions", "Set", "TagVersions", "FlushTags", "TagVersions"}, recording.calls)
+		for _, got := range recording.contexts {
+			assert.Equal(t, "request-1", got.Value(taggedTestKey{}))
+		}
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		manager := cache.NewManager()
+
+		err := manager.Tags("users").Set("user:1", "alice", time.Minute)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no default cache driver set")
+		assert.Error(t, manager.Tags("users").Flush())
+	})
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Get lấy một giá trị từ cache mặc định của manager dưới dạng kiểu T.
+//
+// Giá trị được giải mã một lần bởi driver vào T (qua Manager.GetInto), nên struct lưu trong
+// Redis, MongoDB hoặc file được trả về đúng kiểu thay vì map[string]interface{}.
+//
+// Params:
+//   - m: Manager chứa driver mặc định
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - T: Giá trị trong cache (giá trị zero nếu không tìm thấy hoặc có lỗi)
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi nếu giá trị không khớp kiểu T hoặc driver mặc định không được cấu hình
+func Get[T any](m Manager, key string) (T, bool, error) {
+	return GetContext[T](context.Background(), m, key)
+}
+
+// GetContext lấy một giá trị kiểu T từ cache mặc định của manager, dùng ctx cho thao tác trên driver.
+func GetContext[T any](ctx context.Context, m Manager, key string) (T, bool, error) {
+	var value T
+	found, err := m.GetIntoContext(ctx, key, &value)
+	if err != nil || !found {
+		var zero T
+		return zero, found, err
+	}
+	return value, true, nil
+}
+
+// Remember lấy một giá trị kiểu T từ cache mặc định hoặc thực thi callback nếu không tìm thấy.
+//
+// Khi tính lại, callback được gọi qua Manager.Remember nên vẫn được chống cache stampede.
+// Nếu lời gọi đồng thời khác đã ghi giá trị trước, giá trị được đọc lại bằng GetInto
+// để luôn trả về đúng kiểu T.
+//
+// Params:
+//   - m: Manager chứa driver mặc định
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - T: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi từ callback, lỗi giải mã, hoặc driver mặc định không được cấu hình
+func Remember[T any](m Manager, key string, ttl time.Duration, callback func() (T, error)) (T, error) {
+	return RememberContext(context.Background(), m, key, ttl, callback)
+}
+
+// RememberContext lấy một giá trị kiểu T từ cache mặc định hoặc thực thi callback nếu không tìm thấy,
+// dùng ctx cho các thao tác trên driver.
+func RememberContext[T any](ctx context.Context, m Manager, key string, ttl time.Duration, callback func() (T, error)) (T, error) {
+	var zero T
+	if value, found, err := GetContext[T](ctx, m, key); found && err == nil {
+		return value, nil
+	}
+
+	result, err := m.RememberContext(ctx, key, ttl, func() (interface{}, error) {
+		return callback()
+	})
+	if err != nil {
+		return zero, err
+	}
+	if value, ok := result.(T); ok {
+		return value, nil
+	}
+
+	value, _, err := GetContext[T](ctx, m, key)
+	if err != nil {
+		return zero, err
+	}
+	return value, nil
+}
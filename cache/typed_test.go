@@ -0,0 +1,93 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.fork.vn/providers/cache"
+	"go.fork.vn/providers/cache/config"
+	"go.fork.vn/providers/cache/driver"
+)
+
+type typedProfile struct {
+	Name  string
+	Roles []string
+}
+
+func newTypedTestManager(t *testing.T) cache.Manager {
+	t.Helper()
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{
+		Path:       t.TempDir(),
+		DefaultTTL: 300,
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { fileDriver.Close() })
+
+	manager := cache.NewManager()
+	manager.AddDriver("file", fileDriver)
+	return manager
+}
+
+// TestGetTyped tests reading cached values as a concrete type
+func TestGetTyped(t *testing.T) {
+	t.Run("decodes struct into the requested type", func(t *testing.T) {
+		manager := newTypedTestManager(t)
+		profile := typedProfile{Name: "alice", Roles: []string{"admin"}}
+		assert.NoError(t, manager.Set("profile:1", profile, time.Minute))
+
+		value, found, err := cache.Get[typedProfile](manager, "profile:1")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile, value)
+	})
+
+	t.Run("returns zero value when key is missing", func(t *testing.T) {
+		manager := newTypedTestManager(t)
+
+		value, found, err := cache.Get[typedProfile](manager, "missing")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, typedProfile{}, value)
+	})
+
+	t.Run("returns error when no default driver is set", func(t *testing.T) {
+		_, found, err := cache.Get[string](cache.NewManager(), "key")
+		assert.Error(t, err)
+		assert.False(t, found)
+	})
+}
+
+// TestRememberTyped tests the typed Remember helper
+func TestRememberTyped(t *testing.T) {
+	t.Run("stores callback result and reads it back as the same type", func(t *testing.T) {
+		manager := newTypedTestManager(t)
+		calls := 0
+		callback := func() (typedProfile, error) {
+			calls++
+			return typedProfile{Name: "bob", Roles: []string{"user"}}, nil
+		}
+
+		value, err := cache.Remember(manager, "profile:2", time.Minute, callback)
+		assert.NoError(t, err)
+		assert.Equal(t, typedProfile{Name: "bob", Roles: []string{"user"}}, value)
+
+		value, err = cache.Remember(manager, "profile:2", time.Minute, callback)
+		assert.NoError(t, err)
+		assert.Equal(t, typedProfile{Name: "bob", Roles: []string{"user"}}, value)
+		assert.Equal(t, 1, calls, "callback should only run on cache miss")
+	})
+
+	t.Run("returns callback error", func(t *testing.T) {
+		manager := newTypedTestManager(t)
+		expectedErr := errors.New("load failed")
+
+		value, err := cache.Remember(manager, "profile:3", time.Minute, func() (typedProfile, error) {
+			return typedProfile{}, expectedErr
+		})
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Equal(t, typedProfile{}, value)
+		assert.False(t, manager.Has("profile:3"))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-fork/providers/queue/adapter"
@@ -28,12 +29,23 @@ type Client interface {
 
 	// Close đóng kết nối của client.
 	Close() error
+
+	// RegisterEventListeners đăng ký các listener nhận sự kiện vòng đời của tác vụ.
+	RegisterEventListeners(listeners ...EventListener)
+
+	// SetEventDispatcher thay thế dispatcher dùng để phát sự kiện.
+	// Các listener đã đăng ký qua RegisterEventListeners được chuyển sang dispatcher mới,
+	// và dispatcher cũ được đóng.
+	SetEventDispatcher(dispatcher EventDispatcher)
 }
 
 // client triển khai interface Client.
 type client struct {
 	queue       adapter.QueueAdapter
 	defaultOpts *TaskOptions
+	mu          sync.RWMutex
+	events      EventDispatcher
+	listeners   []EventListener // Listener đăng ký qua RegisterEventListeners
 }
 
 // NewClient tạo một Client mới với Redis.
@@ -42,6 +54,7 @@ func NewClient(redisClient *redis.Client) Client {
 	return &client{
 		queue:       queue,
 		defaultOpts: GetDefaultOptions(),
+		events:      NewSyncDispatcher(),
 	}
 }
 
@@ -50,6 +63,7 @@ func NewClientWithAdapter(adapter adapter.QueueAdapter) Client {
 	return &client{
 		queue:       adapter,
 		defaultOpts: GetDefaultOptions(),
+		events:      NewSyncDispatcher(),
 	}
 }
 
@@ -68,6 +82,7 @@ func NewClientWithUniversalClient(redisClient redis.UniversalClient) Client {
 	return &client{
 		queue:       queue,
 		defaultOpts: GetDefaultOptions(),
+		events:      NewSyncDispatcher(),
 	}
 }
 
@@ -77,6 +92,7 @@ func NewMemoryClient() Client {
 	return &client{
 		queue:       queue,
 		defaultOpts: GetDefaultOptions(),
+		events:      NewSyncDispatcher(),
 	}
 }
 
@@ -136,6 +152,12 @@ func (c *client) EnqueueContext(ctx context.Context, taskName string, payload in
 		processTime = options.ProcessAt
 	}
 
+	enqueued := newTaskEvent(EventEnqueued, task)
+	c.mu.RLock()
+	events := c.events
+	c.mu.RUnlock()
+	events.Dispatch(enqueued)
+
 	return &TaskInfo{
 		ID:        task.ID,
 		Name:      task.Name,
@@ -160,10 +182,37 @@ func (c *client) EnqueueAt(taskName string, processAt time.Time, payload interfa
 	return c.EnqueueContext(context.Background(), taskName, payload, opts...)
 }
 
-// Close đóng kết nối của client.
+// Close đóng kết nối của client và event dispatcher.
 func (c *client) Close() error {
-	// Không có gì để đóng với adapter hiện tại
-	return nil
+	// Adapter hiện tại không cần đóng, chỉ đóng dispatcher để xử lý nốt sự kiện còn trong buffer
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.events.Close()
+}
+
+// RegisterEventListeners đăng ký các listener nhận sự kiện vòng đời của tác vụ.
+func (c *client) RegisterEventListeners(listeners ...EventListener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, listeners...)
+	c.events.AddListeners(listeners...)
+}
+
+// SetEventDispatcher thay thế dispatcher dùng để phát sự kiện.
+//
+// Các listener đã đăng ký qua RegisterEventListeners được chuyển sang dispatcher mới,
+// và dispatcher cũ được đóng.
+func (c *client) SetEventDispatcher(dispatcher EventDispatcher) {
+	if dispatcher == nil {
+		return
+	}
+	c.mu.Lock()
+	dispatcher.AddListeners(c.listeners...)
+	previous := c.events
+	c.events = dispatcher
+	c.mu.Unlock()
+
+	previous.Close()
 }
 
 // scheduledTask đại diện cho một tác vụ đã được lên lịch.
@@ -10,6 +10,9 @@ type Config struct {
 
 	// Client chứa cấu hình cho queue client.
 	Client ClientConfig `mapstructure:"client"`
+
+	// Events chứa cấu hình cho việc phát sự kiện vòng đời của tác vụ.
+	Events EventsConfig `mapstructure:"events"`
 }
 
 // AdapterConfig chứa cấu hình cho các adapter.
@@ -86,6 +89,17 @@ type ClientDefaultOptions struct {
 	Timeout int `mapstructure:"timeout"`
 }
 
+// EventsConfig chứa cấu hình cho event dispatcher.
+type EventsConfig struct {
+	// Async xác định có gọi listener bất đồng bộ qua buffer có giới hạn hay không.
+	// Nếu false, listener được gọi đồng bộ trên goroutine của worker.
+	Async bool `mapstructure:"async"`
+
+	// BufferSize là kích thước buffer của dispatcher bất đồng bộ.
+	// Sự kiện bị bỏ qua khi buffer đầy.
+	BufferSize int `mapstructure:"bufferSize"`
+}
+
 // DefaultConfig trả về cấu hình mặc định cho queue.
 func DefaultConfig() Config {
 	return Config{
@@ -116,5 +130,9 @@ func DefaultConfig() Config {
 				Timeout:  30,
 			},
 		},
+		Events: EventsConfig{
+			Async:      false,
+			BufferSize: 1024,
+		},
 	}
 }
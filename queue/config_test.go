@@ -31,4 +31,8 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, "default", config.Client.DefaultOptions.Queue)
 	assert.Equal(t, 3, config.Client.DefaultOptions.MaxRetry)
 	assert.Equal(t, 30, config.Client.DefaultOptions.Timeout)
+
+	// Test Events config
+	assert.False(t, config.Events.Async)
+	assert.Equal(t, 1024, config.Events.BufferSize)
 }
@@ -0,0 +1,343 @@
+package queue
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType xác định loại sự kiện trong vòng đời của một tác vụ.
+type EventType string
+
+const (
+	// EventEnqueued được phát ra khi tác vụ được đưa vào hàng đợi.
+	EventEnqueued EventType = "enqueued"
+
+	// EventStarted được phát ra khi worker bắt đầu xử lý tác vụ.
+	EventStarted EventType = "started"
+
+	// EventSucceeded được phát ra khi handler xử lý tác vụ thành công.
+	EventSucceeded EventType = "succeeded"
+
+	// EventFailed được phát ra mỗi khi handler trả về lỗi.
+	EventFailed EventType = "failed"
+
+	// EventRetried được phát ra khi tác vụ lỗi được đưa vào retry queue.
+	EventRetried EventType = "retried"
+
+	// EventDeadLettered được phát ra khi tác vụ được chuyển vào dead letter queue.
+	EventDeadLettered EventType = "dead_lettered"
+
+	// EventScheduledPromoted được phát ra khi tác vụ đã lên lịch đến hạn
+	// và được chuyển sang pending queue.
+	EventScheduledPromoted EventType = "scheduled_promoted"
+)
+
+// Event mô tả một chuyển trạng thái trong vòng đời của tác vụ.
+type Event struct {
+	// Type là loại sự kiện
+	Type EventType
+
+	// TaskID là định danh của tác vụ
+	TaskID string
+
+	// TaskName là tên của loại tác vụ
+	TaskName string
+
+	// Queue là tên hàng đợi chứa tác vụ
+	Queue string
+
+	// RetryCount là số lần tác vụ đã được thử lại tại thời điểm phát sự kiện
+	RetryCount int
+
+	// MaxRetry là số lần thử lại tối đa của tác vụ
+	MaxRetry int
+
+	// WorkerID là định danh của worker xử lý tác vụ (-1 nếu không áp dụng)
+	WorkerID int
+
+	// Duration là thời gian xử lý của handler (chỉ có với succeeded/failed)
+	Duration time.Duration
+
+	// RetryDelay là thời gian chờ trước lần thử lại tiếp theo (chỉ có với retried)
+	RetryDelay time.Duration
+
+	// Err là lỗi gây ra sự kiện (chỉ có với failed/dead_lettered)
+	Err error
+
+	// Time là thời điểm sự kiện xảy ra
+	Time time.Time
+}
+
+// newTaskEvent tạo một Event từ thông tin của tác vụ.
+func newTaskEvent(eventType EventType, task *Task) Event {
+	return Event{
+		Type:       eventType,
+		TaskID:     task.ID,
+		TaskName:   task.Name,
+		Queue:      task.Queue,
+		RetryCount: task.RetryCount,
+		MaxRetry:   task.MaxRetry,
+		WorkerID:   -1,
+		Time:       time.Now(),
+	}
+}
+
+// EventListener là interface nhận các sự kiện vòng đời của tác vụ.
+//
+// HandleEvent được gọi trên goroutine của dispatcher, vì vậy listener
+// không nên thực hiện các thao tác chặn lâu khi dùng dispatcher đồng bộ.
+type EventListener interface {
+	// HandleEvent xử lý một sự kiện.
+	HandleEvent(event Event)
+}
+
+// EventListenerFunc cho phép sử dụng một hàm thông thường làm EventListener.
+type EventListenerFunc func(event Event)
+
+// HandleEvent gọi hàm f với sự kiện được cung cấp.
+func (f EventListenerFunc) HandleEvent(event Event) {
+	f(event)
+}
+
+// EventDispatcher phân phối sự kiện đến các listener đã đăng ký.
+type EventDispatcher interface {
+	// AddListeners đăng ký thêm các listener.
+	AddListeners(listeners ...EventListener)
+
+	// Dispatch gửi một sự kiện đến tất cả listener.
+	Dispatch(event Event)
+
+	// Close dừng dispatcher và giải phóng tài nguyên.
+	Close() error
+}
+
+// listenerSet lưu danh sách listener an toàn cho truy cập đồng thời.
+type listenerSet struct {
+	mu        sync.RWMutex
+	listeners []EventListener
+}
+
+// add thêm các listener vào danh sách.
+func (s *listenerSet) add(listeners ...EventListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, listener := range listeners {
+		if listener != nil {
+			s.listeners = append(s.listeners, listener)
+		}
+	}
+}
+
+// notify gọi tất cả listener với sự kiện, bỏ qua panic từ listener.
+func (s *listenerSet) notify(event Event) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+
+	for _, listener := range listeners {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Queue event listener panicked on %s event: %v", event.Type, r)
+				}
+			}()
+			listener.HandleEvent(event)
+		}()
+	}
+}
+
+// syncDispatcher gọi listener đồng bộ trên goroutine phát sự kiện.
+type syncDispatcher struct {
+	listenerSet
+}
+
+// NewSyncDispatcher tạo một dispatcher gọi listener đồng bộ.
+func NewSyncDispatcher(listeners ...EventListener) EventDispatcher {
+	d := &syncDispatcher{}
+	d.add(listeners...)
+	return d
+}
+
+// AddListeners đăng ký thêm các listener.
+func (d *syncDispatcher) AddListeners(listeners ...EventListener) {
+	d.add(listeners...)
+}
+
+// Dispatch gửi sự kiện đến tất cả listener ngay lập tức.
+func (d *syncDispatcher) Dispatch(event Event) {
+	d.notify(event)
+}
+
+// Close không làm gì với dispatcher đồng bộ.
+func (d *syncDispatcher) Close() error {
+	return nil
+}
+
+// AsyncDispatcher gửi sự kiện qua một buffer có giới hạn và gọi listener
+// trên một goroutine riêng. Khi buffer đầy, sự kiện mới bị bỏ qua để không
+// làm chậm worker xử lý tác vụ.
+type AsyncDispatcher struct {
+	listenerSet
+	events    chan Event
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+	closed    atomic.Bool
+	dropped   atomic.Uint64
+}
+
+// NewAsyncDispatcher tạo một dispatcher bất đồng bộ với kích thước buffer cho trước.
+// Nếu bufferSize <= 0, giá trị mặc định 1024 được sử dụng.
+func NewAsyncDispatcher(bufferSize int, listeners ...EventListener) *AsyncDispatcher {
+	if bufferSize <= 0 {
+		bufferSize = 1024
+	}
+
+	d := &AsyncDispatcher{
+		events:  make(chan Event, bufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	d.add(listeners...)
+
+	go d.loop()
+
+	return d
+}
+
+// AddListeners đăng ký thêm các listener.
+func (d *AsyncDispatcher) AddListeners(listeners ...EventListener) {
+	d.add(listeners...)
+}
+
+// Dispatch đưa sự kiện vào buffer. Sự kiện bị bỏ qua nếu buffer đầy
+// hoặc dispatcher đã đóng.
+func (d *AsyncDispatcher) Dispatch(event Event) {
+	if d.closed.Load() {
+		d.dropped.Add(1)
+		return
+	}
+
+	select {
+	case d.events <- event:
+	default:
+		d.dropped.Add(1)
+	}
+}
+
+// Dropped trả về số sự kiện bị bỏ qua do buffer đầy hoặc dispatcher đã đóng.
+func (d *AsyncDispatcher) Dropped() uint64 {
+	return d.dropped.Load()
+}
+
+// Close dừng nhận sự kiện mới và chờ các sự kiện còn trong buffer được xử lý.
+func (d *AsyncDispatcher) Close() error {
+	d.closeOnce.Do(func() {
+		d.closed.Store(true)
+		close(d.done)
+	})
+	<-d.stopped
+	return nil
+}
+
+// loop đọc sự kiện từ buffer và gọi listener cho đến khi dispatcher đóng.
+func (d *AsyncDispatcher) loop() {
+	defer close(d.stopped)
+
+	for {
+		select {
+		case event := <-d.events:
+			d.notify(event)
+		case <-d.done:
+			// Xử lý nốt các sự kiện còn lại trong buffer
+			for {
+				select {
+				case event := <-d.events:
+					d.notify(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Logger là interface tối thiểu để ghi log, tương thích với log.Manager
+// của log provider.
+type Logger interface {
+	// Debug ghi một thông điệp ở cấp độ debug.
+	Debug(message string, args ...interface{})
+
+	// Info ghi một thông điệp ở cấp độ info.
+	Info(message string, args ...interface{})
+
+	// Warning ghi một thông điệp ở cấp độ warning.
+	Warning(message string, args ...interface{})
+
+	// Error ghi một thông điệp ở cấp độ error.
+	Error(message string, args ...interface{})
+}
+
+// stdLogger triển khai Logger bằng package log chuẩn.
+type stdLogger struct{}
+
+// NewStdLogger trả về Logger ghi ra package log chuẩn của Go.
+func NewStdLogger() Logger {
+	return stdLogger{}
+}
+
+// Debug ghi một thông điệp ở cấp độ debug.
+func (stdLogger) Debug(message string, args ...interface{}) {
+	log.Printf(message, args...)
+}
+
+// Info ghi một thông điệp ở cấp độ info.
+func (stdLogger) Info(message string, args ...interface{}) {
+	log.Printf(message, args...)
+}
+
+// Warning ghi một thông điệp ở cấp độ warning.
+func (stdLogger) Warning(message string, args ...interface{}) {
+	log.Printf(message, args...)
+}
+
+// Error ghi một thông điệp ở cấp độ error.
+func (stdLogger) Error(message string, args ...interface{}) {
+	log.Printf(message, args...)
+}
+
+// logListener chuyển các sự kiện vòng đời của tác vụ thành log.
+type logListener struct {
+	logger Logger
+}
+
+// NewLogListener tạo một EventListener ghi mọi sự kiện ra logger được cung cấp.
+// Có thể truyền log.Manager từ log provider trực tiếp làm logger.
+func NewLogListener(logger Logger) EventListener {
+	if logger == nil {
+		logger = NewStdLogger()
+	}
+	return &logListener{logger: logger}
+}
+
+// HandleEvent ghi sự kiện ra logger với cấp độ phù hợp.
+func (l *logListener) HandleEvent(event Event) {
+	switch event.Type {
+	case EventEnqueued:
+		l.logger.Debug("Task %s (type: %s) enqueued to queue %s", event.TaskID, event.TaskName, event.Queue)
+	case EventStarted:
+		l.logger.Debug("Worker %d processing task: %s (type: %s)", event.WorkerID, event.TaskID, event.TaskName)
+	case EventSucceeded:
+		l.logger.Info("Worker %d completed task %s successfully (took %v)", event.WorkerID, event.TaskID, event.Duration)
+	case EventFailed:
+		l.logger.Warning("Task %s failed (attempt %d/%d): %v (took %v)", event.TaskID, event.RetryCount+1, event.MaxRetry, event.Err, event.Duration)
+	case EventRetried:
+		l.logger.Info("Task %s scheduled for retry %d in %v", event.TaskID, event.RetryCount, event.RetryDelay)
+	case EventDeadLettered:
+		l.logger.Error("Task %s moved to dead letter queue %s:dead: %v", event.TaskID, event.Queue, event.Err)
+	case EventScheduledPromoted:
+		l.logger.Debug("Moved scheduled task %s to pending queue %s:pending", event.TaskID, event.Queue)
+	}
+}
@@ -0,0 +1,275 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-fork/providers/queue/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingListener lưu lại các sự kiện nhận được để kiểm tra
+type recordingListener struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (l *recordingListener) HandleEvent(event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *recordingListener) types() []EventType {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	types := make([]EventType, 0, len(l.events))
+	for _, event := range l.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// recordingLogger lưu lại các thông điệp log theo cấp độ
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages map[string][]string
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{messages: make(map[string][]string)}
+}
+
+func (l *recordingLogger) record(level, message string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages[level] = append(l.messages[level], fmt.Sprintf(message, args...))
+}
+
+func (l *recordingLogger) Debug(message string, args ...interface{}) {
+	l.record("debug", message, args...)
+}
+
+func (l *recordingLogger) Info(message string, args ...interface{}) {
+	l.record("info", message, args...)
+}
+
+func (l *recordingLogger) Warning(message string, args ...interface{}) {
+	l.record("warning", message, args...)
+}
+
+func (l *recordingLogger) Error(message string, args ...interface{}) {
+	l.record("error", message, args...)
+}
+
+// TestSyncDispatcher tests that listeners are invoked synchronously in order
+func TestSyncDispatcher(t *testing.T) {
+	listener := &recordingListener{}
+	var calls []string
+
+	dispatcher := NewSyncDispatcher(listener)
+	dispatcher.AddListeners(EventListenerFunc(func(event Event) {
+		calls = append(calls, event.TaskID)
+	}))
+
+	dispatcher.Dispatch(Event{Type: EventEnqueued, TaskID: "task-1"})
+
+	assert.Equal(t, []EventType{EventEnqueued}, listener.types())
+	assert.Equal(t, []string{"task-1"}, calls)
+	assert.NoError(t, dispatcher.Close())
+}
+
+// TestSyncDispatcherRecoversListenerPanic tests that a panicking listener does not stop others
+func TestSyncDispatcherRecoversListenerPanic(t *testing.T) {
+	listener := &recordingListener{}
+
+	dispatcher := NewSyncDispatcher(
+		EventListenerFunc(func(event Event) { panic("boom") }),
+		listener,
+	)
+
+	assert.NotPanics(t, func() {
+		dispatcher.Dispatch(Event{Type: EventFailed})
+	})
+	assert.Equal(t, []EventType{EventFailed}, listener.types())
+}
+
+// TestAsyncDispatcher tests that events are delivered asynchronously and drained on Close
+func TestAsyncDispatcher(t *testing.T) {
+	listener := &recordingListener{}
+	dispatcher := NewAsyncDispatcher(10, listener)
+
+	dispatcher.Dispatch(Event{Type: EventStarted})
+	dispatcher.Dispatch(Event{Type: EventSucceeded})
+
+	// Close chờ các sự kiện còn trong buffer được xử lý
+	require.NoError(t, dispatcher.Close())
+	assert.Equal(t, []EventType{EventStarted, EventSucceeded}, listener.types())
+
+	// Sự kiện sau khi đóng bị bỏ qua
+	dispatcher.Dispatch(Event{Type: EventFailed})
+	assert.Equal(t, uint64(1), dispatcher.Dropped())
+}
+
+// TestAsyncDispatcherDropsWhenFull tests that a full buffer drops events instead of blocking
+func TestAsyncDispatcherDropsWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan struct{}, 1)
+
+	dispatcher := NewAsyncDispatcher(1, EventListenerFunc(func(event Event) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+		<-release
+	}))
+
+	// Sự kiện đầu tiên chặn listener, sự kiện thứ hai lấp đầy buffer
+	dispatcher.Dispatch(Event{Type: EventStarted})
+	<-received
+	dispatcher.Dispatch(Event{Type: EventStarted})
+
+	// Buffer đã đầy nên sự kiện này bị bỏ qua
+	dispatcher.Dispatch(Event{Type: EventStarted})
+	assert.Equal(t, uint64(1), dispatcher.Dropped())
+
+	close(release)
+	require.NoError(t, dispatcher.Close())
+}
+
+// TestLogListener tests that events are forwarded to the logger with matching levels
+func TestLogListener(t *testing.T) {
+	logger := newRecordingLogger()
+	listener := NewLogListener(logger)
+
+	listener.HandleEvent(Event{Type: EventSucceeded, TaskID: "t1", WorkerID: 1})
+	listener.HandleEvent(Event{Type: EventFailed, TaskID: "t2", MaxRetry: 3, Err: errors.New("boom")})
+	listener.HandleEvent(Event{Type: EventDeadLettered, TaskID: "t3", Queue: "emails", Err: errors.New("boom")})
+
+	assert.Len(t, logger.messages["info"], 1)
+	assert.Len(t, logger.messages["warning"], 1)
+	require.Len(t, logger.messages["error"], 1)
+	assert.Contains(t, logger.messages["error"][0], "emails:dead")
+}
+
+// TestClientEmitsEnqueuedEvent tests that the client emits an enqueued event
+func TestClientEmitsEnqueuedEvent(t *testing.T) {
+	client := NewMemoryClient()
+	listener := &recordingListener{}
+	client.RegisterEventListeners(listener)
+
+	info, err := client.Enqueue("email:send", map[string]string{"to": "user@example.com"}, WithQueue("emails"))
+	require.NoError(t, err)
+
+	require.Len(t, listener.events, 1)
+	event := listener.events[0]
+	assert.Equal(t, EventEnqueued, event.Type)
+	assert.Equal(t, info.ID, event.TaskID)
+	assert.Equal(t, "email:send", event.TaskName)
+	assert.Equal(t, "emails", event.Queue)
+}
+
+// TestServerEmitsLifecycleEvents tests started/succeeded/failed/retried/dead-lettered events
+func TestServerEmitsLifecycleEvents(t *testing.T) {
+	memoryAdapter := adapter.NewMemoryQueue("test:")
+	logger := newRecordingLogger()
+
+	server := NewServerWithAdapter(memoryAdapter, ServerOptions{
+		Concurrency:  1,
+		DefaultQueue: "default",
+		Logger:       logger,
+	}).(*queueServer)
+
+	listener := &recordingListener{}
+	server.RegisterEventListeners(listener)
+
+	server.RegisterHandler("ok", func(ctx context.Context, task *Task) error { return nil })
+	server.RegisterHandler("fail", func(ctx context.Context, task *Task) error { return errors.New("boom") })
+
+	server.processTask(0, &Task{ID: "t1", Name: "ok", Queue: "default", MaxRetry: 1})
+	assert.Equal(t, []EventType{EventStarted, EventSucceeded}, listener.types())
+
+	listener.events = nil
+	server.processTask(0, &Task{ID: "t2", Name: "fail", Queue: "default", MaxRetry: 2})
+	assert.Equal(t, []EventType{EventStarted, EventFailed, EventRetried}, listener.types())
+
+	listener.events = nil
+	server.processTask(0, &Task{ID: "t3", Name: "fail", Queue: "default", MaxRetry: 1})
+	assert.Equal(t, []EventType{EventStarted, EventFailed, EventDeadLettered}, listener.types())
+	assert.EqualError(t, listener.events[2].Err, "boom")
+
+	// Listener mặc định ghi sự kiện ra logger của server
+	assert.NotEmpty(t, logger.messages["error"])
+}
+
+// TestSetEventDispatcherKeepsListeners tests that replacing the dispatcher keeps registered listeners and closes the old one
+func TestSetEventDispatcherKeepsListeners(t *testing.T) {
+	client := NewMemoryClient()
+	listener := &recordingListener{}
+	client.RegisterEventListeners(listener)
+
+	previous := NewAsyncDispatcher(10)
+	client.SetEventDispatcher(previous)
+	client.SetEventDispatcher(NewSyncDispatcher())
+
+	_, err := client.Enqueue("email:send", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []EventType{EventEnqueued}, listener.types())
+	previous.Dispatch(Event{Type: EventEnqueued})
+	assert.Equal(t, uint64(1), previous.Dropped(), "replaced dispatcher should be closed")
+}
+
+// TestServerStopClosesEventDispatcher tests that stopping the server drains the async dispatcher
+func TestServerStopClosesEventDispatcher(t *testing.T) {
+	server := NewServerWithAdapter(adapter.NewMemoryQueue("test:"), ServerOptions{
+		Concurrency:     1,
+		DefaultQueue:    "default",
+		ShutdownTimeout: time.Second,
+		Logger:          newRecordingLogger(),
+	})
+	listener := &recordingListener{}
+	server.RegisterEventListeners(listener)
+	dispatcher := NewAsyncDispatcher(10)
+	server.SetEventDispatcher(dispatcher)
+
+	require.NoError(t, server.Start())
+	dispatcher.Dispatch(Event{Type: EventStarted})
+	require.NoError(t, server.Stop())
+
+	assert.Equal(t, []EventType{EventStarted}, listener.types())
+	dispatcher.Dispatch(Event{Type: EventStarted})
+	assert.Equal(t, uint64(1), dispatcher.Dropped())
+}
+
+// TestServerEmitsScheduledPromotedEvent tests that due scheduled tasks emit a promoted event
+func TestServerEmitsScheduledPromotedEvent(t *testing.T) {
+	memoryAdapter := adapter.NewMemoryQueue("test:")
+
+	server := NewServerWithAdapter(memoryAdapter, ServerOptions{
+		Concurrency:  1,
+		DefaultQueue: "default",
+		Logger:       newRecordingLogger(),
+	}).(*queueServer)
+
+	listener := &recordingListener{}
+	server.SetEventDispatcher(NewSyncDispatcher(listener))
+
+	err := memoryAdapter.Enqueue(context.Background(), "default:scheduled", &scheduledTask{
+		TaskID:    "t1",
+		ProcessAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	server.processDelayedTasks()
+
+	require.Len(t, listener.events, 1)
+	assert.Equal(t, EventScheduledPromoted, listener.events[0].Type)
+	assert.Equal(t, "t1", listener.events[0].TaskID)
+	assert.Equal(t, "default", listener.events[0].Queue)
+}
@@ -47,6 +47,7 @@ type manager struct {
 	redisClient redisClient.UniversalClient
 	memoryQueue adapter.QueueAdapter
 	redisQueue  adapter.QueueAdapter
+	logger      Logger
 }
 
 // NewManager tạo một manager mới với cấu hình mặc định.
@@ -171,6 +172,7 @@ func (m *manager) Client() Client {
 		} else {
 			m.client = NewClientWithAdapter(m.Adapter(m.config.Adapter.Default))
 		}
+		m.client.SetEventDispatcher(m.newEventDispatcher())
 	}
 	return m.client
 }
@@ -187,6 +189,7 @@ func (m *manager) Server() Server {
 			ShutdownTimeout: time.Duration(m.config.Server.ShutdownTimeout) * time.Second,
 			LogLevel:        m.config.Server.LogLevel,
 			RetryLimit:      m.config.Server.RetryLimit,
+			Logger:          m.getLogger(),
 		}
 
 		if m.config.Adapter.Default == "redis" {
@@ -194,6 +197,7 @@ func (m *manager) Server() Server {
 		} else {
 			m.server = NewServerWithAdapter(m.Adapter(m.config.Adapter.Default), serverOpts)
 		}
+		m.server.SetEventDispatcher(m.newEventDispatcher())
 	}
 	return m.server
 }
@@ -211,3 +215,35 @@ func (m *manager) Scheduler() scheduler.Manager {
 func (m *manager) SetScheduler(sched scheduler.Manager) {
 	m.scheduler = sched
 }
+
+// newEventDispatcher tạo event dispatcher theo cấu hình Events.
+//
+// Dispatcher luôn có sẵn một listener ghi sự kiện ra logger của manager.
+func (m *manager) newEventDispatcher() EventDispatcher {
+	listener := NewLogListener(m.getLogger())
+	if m.config.Events.Async {
+		return NewAsyncDispatcher(m.config.Events.BufferSize, listener)
+	}
+	return NewSyncDispatcher(listener)
+}
+
+// getLogger trả về logger của queue.
+//
+// Nếu container có dịch vụ "log" tương thích với Logger (như log.Manager),
+// dịch vụ đó được sử dụng; ngược lại dùng package log chuẩn.
+func (m *manager) getLogger() Logger {
+	if m.logger == nil {
+		if m.container != nil {
+			if logService, err := m.container.Make("log"); err == nil {
+				if logger, ok := logService.(Logger); ok {
+					m.logger = logger
+				}
+			}
+		}
+
+		if m.logger == nil {
+			m.logger = NewStdLogger()
+		}
+	}
+	return m.logger
+}
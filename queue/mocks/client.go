@@ -368,6 +368,85 @@ func (_c *MockClient_EnqueueIn_Call) RunAndReturn(run func(string, time.Duration
 	return _c
 }
 
+// RegisterEventListeners provides a mock function with given fields: listeners
+func (_m *MockClient) RegisterEventListeners(listeners ...queue.EventListener) {
+	_va := make([]interface{}, len(listeners))
+	for _i := range listeners {
+		_va[_i] = listeners[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// MockClient_RegisterEventListeners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterEventListeners'
+type MockClient_RegisterEventListeners_Call struct {
+	*mock.Call
+}
+
+// RegisterEventListeners is a helper method to define mock.On call
+//   - listeners ...queue.EventListener
+func (_e *MockClient_Expecter) RegisterEventListeners(listeners ...interface{}) *MockClient_RegisterEventListeners_Call {
+	return &MockClient_RegisterEventListeners_Call{Call: _e.mock.On("RegisterEventListeners",
+		append([]interface{}{}, listeners...)...)}
+}
+
+func (_c *MockClient_RegisterEventListeners_Call) Run(run func(listeners ...queue.EventListener)) *MockClient_RegisterEventListeners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]queue.EventListener, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(queue.EventListener)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockClient_RegisterEventListeners_Call) Return() *MockClient_RegisterEventListeners_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockClient_RegisterEventListeners_Call) RunAndReturn(run func(...queue.EventListener)) *MockClient_RegisterEventListeners_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetEventDispatcher provides a mock function with given fields: dispatcher
+func (_m *MockClient) SetEventDispatcher(dispatcher queue.EventDispatcher) {
+	_m.Called(dispatcher)
+}
+
+// MockClient_SetEventDispatcher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventDispatcher'
+type MockClient_SetEventDispatcher_Call struct {
+	*mock.Call
+}
+
+// SetEventDispatcher is a helper method to define mock.On call
+//   - dispatcher queue.EventDispatcher
+func (_e *MockClient_Expecter) SetEventDispatcher(dispatcher interface{}) *MockClient_SetEventDispatcher_Call {
+	return &MockClient_SetEventDispatcher_Call{Call: _e.mock.On("SetEventDispatcher", dispatcher)}
+}
+
+func (_c *MockClient_SetEventDispatcher_Call) Run(run func(dispatcher queue.EventDispatcher)) *MockClient_SetEventDispatcher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(queue.EventDispatcher))
+	})
+	return _c
+}
+
+func (_c *MockClient_SetEventDispatcher_Call) Return() *MockClient_SetEventDispatcher_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockClient_SetEventDispatcher_Call) RunAndReturn(run func(queue.EventDispatcher)) *MockClient_SetEventDispatcher_Call {
+	_c.Run(run)
+	return _c
+}
+
 // NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockClient(t interface {
@@ -69,6 +69,52 @@ func (_c *MockServer_GetScheduler_Call) RunAndReturn(run func() scheduler.Manage
 	return _c
 }
 
+// RegisterEventListeners provides a mock function with given fields: listeners
+func (_m *MockServer) RegisterEventListeners(listeners ...queue.EventListener) {
+	_va := make([]interface{}, len(listeners))
+	for _i := range listeners {
+		_va[_i] = listeners[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// MockServer_RegisterEventListeners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterEventListeners'
+type MockServer_RegisterEventListeners_Call struct {
+	*mock.Call
+}
+
+// RegisterEventListeners is a helper method to define mock.On call
+//   - listeners ...queue.EventListener
+func (_e *MockServer_Expecter) RegisterEventListeners(listeners ...interface{}) *MockServer_RegisterEventListeners_Call {
+	return &MockServer_RegisterEventListeners_Call{Call: _e.mock.On("RegisterEventListeners",
+		append([]interface{}{}, listeners...)...)}
+}
+
+func (_c *MockServer_RegisterEventListeners_Call) Run(run func(listeners ...queue.EventListener)) *MockServer_RegisterEventListeners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]queue.EventListener, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(queue.EventListener)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockServer_RegisterEventListeners_Call) Return() *MockServer_RegisterEventListeners_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockServer_RegisterEventListeners_Call) RunAndReturn(run func(...queue.EventListener)) *MockServer_RegisterEventListeners_Call {
+	_c.Run(run)
+	return _c
+}
+
 // RegisterHandler provides a mock function with given fields: taskName, handler
 func (_m *MockServer) RegisterHandler(taskName string, handler queue.HandlerFunc) {
 	_m.Called(taskName, handler)
@@ -136,6 +182,39 @@ func (_c *MockServer_RegisterHandlers_Call) RunAndReturn(run func(map[string]que
 	return _c
 }
 
+// SetEventDispatcher provides a mock function with given fields: dispatcher
+func (_m *MockServer) SetEventDispatcher(dispatcher queue.EventDispatcher) {
+	_m.Called(dispatcher)
+}
+
+// MockServer_SetEventDispatcher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEventDispatcher'
+type MockServer_SetEventDispatcher_Call struct {
+	*mock.Call
+}
+
+// SetEventDispatcher is a helper method to define mock.On call
+//   - dispatcher queue.EventDispatcher
+func (_e *MockServer_Expecter) SetEventDispatcher(dispatcher interface{}) *MockServer_SetEventDispatcher_Call {
+	return &MockServer_SetEventDispatcher_Call{Call: _e.mock.On("SetEventDispatcher", dispatcher)}
+}
+
+func (_c *MockServer_SetEventDispatcher_Call) Run(run func(dispatcher queue.EventDispatcher)) *MockServer_SetEventDispatcher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(queue.EventDispatcher))
+	})
+	return _c
+}
+
+func (_c *MockServer_SetEventDispatcher_Call) Return() *MockServer_SetEventDispatcher_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockServer_SetEventDispatcher_Call) RunAndReturn(run func(queue.EventDispatcher)) *MockServer_SetEventDispatcher_Call {
+	_c.Run(run)
+	return _c
+}
+
 // SetScheduler provides a mock function with given fields: _a0
 func (_m *MockServer) SetScheduler(_a0 scheduler.Manager) {
 	_m.Called(_a0)
@@ -3,7 +3,6 @@ package queue
 import (
 	"context"
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
@@ -40,6 +39,15 @@ type ServerOptions struct {
 
 	// RetryLimit xác định số lần thử lại tối đa cho tác vụ bị lỗi.
 	RetryLimit int
+
+	// Logger là logger dùng cho các thông điệp vận hành của server và
+	// listener mặc định. Nếu nil, package log chuẩn được sử dụng.
+	Logger Logger
+
+	// Clock là đồng hồ dùng để xác định delayed task đã đến hạn và thời điểm retry.
+	// Nếu nil, đồng hồ hệ thống được sử dụng. Trong kiểm thử, dùng cùng fake clock
+	// của package scheduler/testing cho server và scheduler (scheduler.WithClock).
+	Clock scheduler.Clock
 }
 
 // Server là interface cho việc xử lý tác vụ từ hàng đợi.
@@ -61,6 +69,14 @@ type Server interface {
 
 	// GetScheduler trả về scheduler hiện tại.
 	GetScheduler() scheduler.Manager
+
+	// RegisterEventListeners đăng ký các listener nhận sự kiện vòng đời của tác vụ.
+	RegisterEventListeners(listeners ...EventListener)
+
+	// SetEventDispatcher thay thế dispatcher dùng để phát sự kiện.
+	// Các listener đã đăng ký qua RegisterEventListeners được chuyển sang dispatcher mới,
+	// và dispatcher cũ được đóng.
+	SetEventDispatcher(dispatcher EventDispatcher)
 }
 
 // queueServer triển khai interface Server.
@@ -75,6 +91,10 @@ type queueServer struct {
 	mu              sync.Mutex
 	options         ServerOptions
 	queues          []string
+	logger          Logger
+	eventsMu        sync.RWMutex
+	events          EventDispatcher
+	listeners       []EventListener // Listener đăng ký qua RegisterEventListeners
 }
 
 // NewServer tạo một Server mới.
@@ -102,16 +122,7 @@ func NewServer(redisClient redis.UniversalClient, opts ServerOptions) Server {
 		queues = append(queues, defaultQueue)
 	}
 
-	return &queueServer{
-		queue:           queue,
-		handlers:        sync.Map{},
-		started:         false,
-		stopCh:          make(chan struct{}),
-		workerDoneCh:    make(chan struct{}),
-		schedulerDoneCh: make(chan struct{}),
-		options:         opts,
-		queues:          queues,
-	}
+	return newQueueServer(queue, opts, queues)
 }
 
 // NewServerWithAdapter tạo một Server mới với adapter QueueAdapter được cung cấp.
@@ -128,8 +139,18 @@ func NewServerWithAdapter(adapter adapter.QueueAdapter, opts ServerOptions) Serv
 		queues = append(queues, defaultQueue)
 	}
 
+	return newQueueServer(adapter, opts, queues)
+}
+
+// newQueueServer khởi tạo queueServer với logger và dispatcher mặc định.
+func newQueueServer(queue adapter.QueueAdapter, opts ServerOptions, queues []string) *queueServer {
+	logger := opts.Logger
+	if logger == nil {
+		logger = NewStdLogger()
+	}
+
 	return &queueServer{
-		queue:           adapter,
+		queue:           queue,
 		handlers:        sync.Map{},
 		started:         false,
 		stopCh:          make(chan struct{}),
@@ -137,6 +158,8 @@ func NewServerWithAdapter(adapter adapter.QueueAdapter, opts ServerOptions) Serv
 		schedulerDoneCh: make(chan struct{}),
 		options:         opts,
 		queues:          queues,
+		logger:          logger,
+		events:          NewSyncDispatcher(NewLogListener(logger)),
 	}
 }
 
@@ -162,7 +185,7 @@ func (s *queueServer) Start() error {
 	}
 
 	s.started = true
-	log.Println("Starting queue worker server...")
+	s.logger.Info("Starting queue worker server...")
 
 	// Khởi động workers để xử lý immediate tasks
 	s.startWorkers()
@@ -172,7 +195,7 @@ func (s *queueServer) Start() error {
 		s.setupDelayedTaskScheduler()
 	}
 
-	log.Printf("Queue worker server started with %d workers", s.options.Concurrency)
+	s.logger.Info("Queue worker server started with %d workers", s.options.Concurrency)
 	return nil
 }
 
@@ -185,7 +208,7 @@ func (s *queueServer) Stop() error {
 		return fmt.Errorf("server not started")
 	}
 
-	log.Println("Stopping queue worker server...")
+	s.logger.Info("Stopping queue worker server...")
 
 	// Gửi tín hiệu stop cho tất cả workers (chỉ close nếu chưa closed)
 	select {
@@ -207,9 +230,9 @@ func (s *queueServer) Stop() error {
 
 	select {
 	case <-done:
-		log.Println("All workers stopped gracefully")
+		s.logger.Info("All workers stopped gracefully")
 	case <-time.After(s.options.ShutdownTimeout):
-		log.Println("Shutdown timeout reached, forcing stop")
+		s.logger.Warning("Shutdown timeout reached, forcing stop")
 	}
 
 	// Dừng scheduler nếu có
@@ -217,8 +240,13 @@ func (s *queueServer) Stop() error {
 		s.scheduler.Stop()
 	}
 
+	// Đóng dispatcher sau khi workers dừng để xử lý nốt các sự kiện còn trong buffer
+	s.eventsMu.RLock()
+	s.events.Close()
+	s.eventsMu.RUnlock()
+
 	s.started = false
-	log.Println("Queue worker server stopped")
+	s.logger.Info("Queue worker server stopped")
 	return nil
 }
 
@@ -236,6 +264,39 @@ func (s *queueServer) GetScheduler() scheduler.Manager {
 	return s.scheduler
 }
 
+// RegisterEventListeners đăng ký các listener nhận sự kiện vòng đời của tác vụ.
+func (s *queueServer) RegisterEventListeners(listeners ...EventListener) {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+	s.listeners = append(s.listeners, listeners...)
+	s.events.AddListeners(listeners...)
+}
+
+// SetEventDispatcher thay thế dispatcher dùng để phát sự kiện.
+//
+// Các listener đã đăng ký qua RegisterEventListeners được chuyển sang dispatcher mới,
+// và dispatcher cũ được đóng.
+func (s *queueServer) SetEventDispatcher(dispatcher EventDispatcher) {
+	if dispatcher == nil {
+		return
+	}
+	s.eventsMu.Lock()
+	dispatcher.AddListeners(s.listeners...)
+	previous := s.events
+	s.events = dispatcher
+	s.eventsMu.Unlock()
+
+	previous.Close()
+}
+
+// emit phát một sự kiện qua dispatcher hiện tại.
+func (s *queueServer) emit(event Event) {
+	s.eventsMu.RLock()
+	events := s.events
+	s.eventsMu.RUnlock()
+	events.Dispatch(event)
+}
+
 // startWorkers khởi động các worker để xử lý immediate tasks
 func (s *queueServer) startWorkers() {
 	// Khởi tạo worker done channel
@@ -253,7 +314,7 @@ func (s *queueServer) setupDelayedTaskScheduler() {
 	}
 
 	// Thiết lập task định kỳ để kiểm tra delayed tasks (mỗi 30 giây)
-	s.scheduler.Every(uint64(30)).Seconds().Do(func() {
+	s.scheduler.Every(30).Seconds().Do(func() {
 		if s.started {
 			s.processDelayedTasks()
 		}
@@ -269,18 +330,18 @@ func (s *queueServer) setupDelayedTaskScheduler() {
 func (s *queueServer) workerLoop(workerID int) {
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("Worker %d recovered from panic: %v", workerID, r)
+			s.logger.Error("Worker %d recovered from panic: %v", workerID, r)
 		}
 		// Thông báo worker đã dừng
 		s.workerDoneCh <- struct{}{}
 	}()
 
-	log.Printf("Worker %d started", workerID)
+	s.logger.Debug("Worker %d started", workerID)
 
 	for {
 		select {
 		case <-s.stopCh:
-			log.Printf("Worker %d stopping", workerID)
+			s.logger.Debug("Worker %d stopping", workerID)
 			return
 		default:
 			// Thử lấy task từ queue
@@ -304,12 +365,11 @@ func (s *queueServer) fetchTask() *Task {
 		// Client enqueues to {queueName}:pending, so we need to dequeue from there
 		pendingQueueName := fmt.Sprintf("%s:pending", queueName)
 		if err := s.queue.Dequeue(ctx, pendingQueueName, &task); err == nil {
-			log.Printf("Successfully dequeued task %s from queue %s", task.ID, pendingQueueName)
 			return &task
 		} else {
 			// Log the error for debugging, but only if it's not "queue is empty"
 			if err.Error() != "queue is empty: "+pendingQueueName {
-				log.Printf("Error dequeuing from queue %s: %v", pendingQueueName, err)
+				s.logger.Error("Error dequeuing from queue %s: %v", pendingQueueName, err)
 			}
 		}
 	}
@@ -319,12 +379,13 @@ func (s *queueServer) fetchTask() *Task {
 
 // processTask xử lý một task
 func (s *queueServer) processTask(workerID int, task *Task) {
-	log.Printf("Worker %d processing task: %s (type: %s)", workerID, task.ID, task.Name)
+	started := newTaskEvent(EventStarted, task)
+	started.WorkerID = workerID
+	s.emit(started)
 
 	// Tìm handler cho task
 	handlerInterface, exists := s.handlers.Load(task.Name)
 	if !exists {
-		log.Printf("No handler found for task type: %s", task.Name)
 		// Move to dead letter queue since we can't process this task
 		s.moveToDeadLetterQueue(task, fmt.Errorf("no handler found for task type: %s", task.Name))
 		return
@@ -332,7 +393,7 @@ func (s *queueServer) processTask(workerID int, task *Task) {
 
 	handler, ok := handlerInterface.(HandlerFunc)
 	if !ok {
-		log.Printf("Invalid handler type for task: %s", task.Name)
+		s.logger.Error("Invalid handler type for task: %s", task.Name)
 		return
 	}
 
@@ -345,10 +406,17 @@ func (s *queueServer) processTask(workerID int, task *Task) {
 	duration := time.Since(start)
 
 	if err != nil {
-		log.Printf("Worker %d failed to process task %s: %v (took %v)", workerID, task.ID, err, duration)
+		failed := newTaskEvent(EventFailed, task)
+		failed.WorkerID = workerID
+		failed.Duration = duration
+		failed.Err = err
+		s.emit(failed)
 		s.handleFailedTask(task, err)
 	} else {
-		log.Printf("Worker %d completed task %s successfully (took %v)", workerID, task.ID, duration)
+		succeeded := newTaskEvent(EventSucceeded, task)
+		succeeded.WorkerID = workerID
+		succeeded.Duration = duration
+		s.emit(succeeded)
 	}
 }
 
@@ -370,7 +438,8 @@ func (s *queueServer) processDelayedTasks() {
 			}
 
 			// Kiểm tra xem task đã đến hạn chưa
-			if time.Now().Before(scheduledTask.ProcessAt) {
+			now := s.now()
+			if now.Before(scheduledTask.ProcessAt) {
 				// Task chưa đến hạn, đưa lại vào scheduled queue
 				s.queue.Enqueue(ctx, scheduledQueueName, &scheduledTask)
 				break
@@ -379,21 +448,31 @@ func (s *queueServer) processDelayedTasks() {
 			// Task đã đến hạn, tạo task thực sự và đưa vào pending queue
 			task := &Task{
 				ID:         scheduledTask.TaskID,
-				CreatedAt:  time.Now(),
-				ProcessAt:  time.Now(),
+				CreatedAt:  now,
+				ProcessAt:  now,
 				RetryCount: 0,
 			}
 
 			// Đưa task vào pending queue để worker xử lý
 			if err := s.queue.Enqueue(ctx, pendingQueueName, task); err != nil {
-				log.Printf("Failed to move scheduled task %s to pending queue: %v", scheduledTask.TaskID, err)
+				s.logger.Error("Failed to move scheduled task %s to pending queue: %v", scheduledTask.TaskID, err)
 			} else {
-				log.Printf("Moved scheduled task %s to pending queue %s", scheduledTask.TaskID, pendingQueueName)
+				promoted := newTaskEvent(EventScheduledPromoted, task)
+				promoted.Queue = queueName
+				s.emit(promoted)
 			}
 		}
 	}
 }
 
+// now trả về thời điểm hiện tại theo Clock của server.
+func (s *queueServer) now() time.Time {
+	if s.options.Clock == nil {
+		return time.Now()
+	}
+	return s.options.Clock.Now()
+}
+
 // handleFailedTask xử lý task bị lỗi
 func (s *queueServer) handleFailedTask(task *Task, err error) {
 	ctx := context.Background()
@@ -401,26 +480,25 @@ func (s *queueServer) handleFailedTask(task *Task, err error) {
 	// Tăng retry count
 	task.RetryCount++
 
-	log.Printf("Task %s failed (attempt %d/%d): %v", task.ID, task.RetryCount, task.MaxRetry, err)
-
 	// Kiểm tra xem có thể retry không
 	if task.RetryCount < task.MaxRetry {
 		// Tính toán thời gian delay cho retry (exponential backoff)
 		retryDelay := time.Duration(task.RetryCount*task.RetryCount) * time.Minute
-		task.ProcessAt = time.Now().Add(retryDelay)
+		task.ProcessAt = s.now().Add(retryDelay)
 
 		// Đưa task vào retry queue để xử lý lại sau
 		retryQueueName := fmt.Sprintf("%s:retry", task.Queue)
 		if enqueueErr := s.queue.Enqueue(ctx, retryQueueName, task); enqueueErr != nil {
-			log.Printf("Failed to enqueue task %s for retry: %v", task.ID, enqueueErr)
+			s.logger.Error("Failed to enqueue task %s for retry: %v", task.ID, enqueueErr)
 			// Nếu không thể enqueue để retry, đưa vào dead letter queue
 			s.moveToDeadLetterQueue(task, enqueueErr)
 		} else {
-			log.Printf("Task %s scheduled for retry %d in %v", task.ID, task.RetryCount, retryDelay)
+			retried := newTaskEvent(EventRetried, task)
+			retried.RetryDelay = retryDelay
+			s.emit(retried)
 		}
 	} else {
 		// Đã vượt quá số lần retry, đưa vào dead letter queue
-		log.Printf("Task %s exceeded max retry limit (%d), moving to dead letter queue", task.ID, task.MaxRetry)
 		s.moveToDeadLetterQueue(task, err)
 	}
 }
@@ -433,14 +511,16 @@ func (s *queueServer) moveToDeadLetterQueue(task *Task, reason error) {
 	deadLetterTask := &DeadLetterTask{
 		Task:     *task,
 		Reason:   reason.Error(),
-		FailedAt: time.Now(),
+		FailedAt: s.now(),
 	}
 
 	deadLetterQueueName := fmt.Sprintf("%s:dead", task.Queue)
 	if err := s.queue.Enqueue(ctx, deadLetterQueueName, deadLetterTask); err != nil {
-		log.Printf("Failed to move task %s to dead letter queue: %v", task.ID, err)
+		s.logger.Error("Failed to move task %s to dead letter queue: %v", task.ID, err)
 	} else {
-		log.Printf("Task %s moved to dead letter queue %s", task.ID, deadLetterQueueName)
+		deadLettered := newTaskEvent(EventDeadLettered, task)
+		deadLettered.Err = reason
+		s.emit(deadLettered)
 	}
 }
 
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/go-fork/providers/queue/adapter"
+	"github.com/go-fork/providers/scheduler"
 	"github.com/go-fork/providers/scheduler/mocks"
+	schedulertesting "github.com/go-fork/providers/scheduler/testing"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -266,7 +268,7 @@ func TestSchedulerMock(t *testing.T) {
 	schedulerMock.EXPECT().StartAsync().Maybe()
 
 	// Expect scheduler setup for delayed task processing
-	schedulerMock.EXPECT().Every(mock.AnythingOfType("uint64")).Return(schedulerMock).Maybe()
+	schedulerMock.EXPECT().Every(mock.AnythingOfType("int")).Return(schedulerMock).Maybe()
 	schedulerMock.EXPECT().Seconds().Return(schedulerMock).Maybe()
 	schedulerMock.EXPECT().Do(mock.AnythingOfType("func()")).Return(nil, nil).Maybe()
 
@@ -330,7 +332,7 @@ func TestServerSchedulerDelayedTasks(t *testing.T) {
 	mockScheduler.EXPECT().StartAsync()
 
 	// Expect scheduler setup delayed task processing
-	mockScheduler.EXPECT().Every(mock.AnythingOfType("uint64")).Return(mockScheduler)
+	mockScheduler.EXPECT().Every(mock.AnythingOfType("int")).Return(mockScheduler)
 	mockScheduler.EXPECT().Seconds().Return(mockScheduler)
 	mockScheduler.EXPECT().Do(mock.AnythingOfType("func()")).Return(nil, nil)
 
@@ -349,6 +351,56 @@ func TestServerSchedulerDelayedTasks(t *testing.T) {
 	mockScheduler.AssertExpectations(t)
 }
 
+// TestServerDelayedTaskPromoterWithFakeClock tests delayed task promotion driven by a fake clock
+func TestServerDelayedTaskPromoterWithFakeClock(t *testing.T) {
+	ctx := context.Background()
+	memoryAdapter := adapter.NewMemoryQueue("test:")
+	clock := schedulertesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	server := NewServerWithAdapter(memoryAdapter, ServerOptions{
+		Concurrency:  0,
+		DefaultQueue: "default",
+		Clock:        clock,
+	})
+
+	var promoted []Event
+	server.SetEventDispatcher(NewSyncDispatcher(EventListenerFunc(func(event Event) {
+		if event.Type == EventScheduledPromoted {
+			promoted = append(promoted, event)
+		}
+	})))
+
+	sched, err := scheduler.NewSchedulerWithConfig(scheduler.DefaultConfig(), scheduler.WithClock(clock))
+	require.NoError(t, err)
+	server.SetScheduler(sched)
+
+	// Task đến hạn sau 45 giây theo fake clock
+	require.NoError(t, memoryAdapter.Enqueue(ctx, "default:scheduled", &scheduledTask{
+		TaskID:    "delayed-1",
+		ProcessAt: clock.Now().Add(45 * time.Second),
+	}))
+
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	// Chờ lần chạy ngay khi khởi động của promoter hoàn tất
+	require.Eventually(t, func() bool {
+		jobs := sched.GetScheduler().Jobs()
+		return len(jobs) == 1 && jobs[0].FinishedRunCount() == 1
+	}, time.Second, time.Millisecond)
+
+	clock.Advance(30 * time.Second)
+	assert.Empty(t, promoted, "Task should not be promoted before it is due")
+
+	clock.Advance(30 * time.Second)
+	require.Len(t, promoted, 1, "Task should be promoted once due")
+	assert.Equal(t, "delayed-1", promoted[0].TaskID)
+
+	size, err := memoryAdapter.Size(ctx, "default:pending")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), size)
+}
+
 // TestServerWithoutScheduler tests server operation without scheduler
 func TestServerWithoutScheduler(t *testing.T) {
 	memoryAdapter := adapter.NewMemoryQueue("test:")
@@ -391,7 +443,7 @@ func TestServerSchedulerLifecycle(t *testing.T) {
 	// Expect scheduler lifecycle calls
 	mockScheduler.EXPECT().IsRunning().Return(false).Once()
 	mockScheduler.EXPECT().StartAsync().Once()
-	mockScheduler.EXPECT().Every(mock.AnythingOfType("uint64")).Return(mockScheduler).Once()
+	mockScheduler.EXPECT().Every(mock.AnythingOfType("int")).Return(mockScheduler).Once()
 	mockScheduler.EXPECT().Seconds().Return(mockScheduler).Once()
 	mockScheduler.EXPECT().Do(mock.AnythingOfType("func()")).Return(nil, nil).Once()
 
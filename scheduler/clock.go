@@ -0,0 +1,332 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// Clock cung cấp thời gian cho scheduler, cho phép thay thế đồng hồ hệ thống
+// bằng fake clock (xem package scheduler/testing) để kiểm thử không cần sleep.
+type Clock interface {
+	// Now trả về thời điểm hiện tại.
+	Now() time.Time
+
+	// Sleep chờ trong khoảng thời gian d.
+	Sleep(d time.Duration)
+
+	// AfterFunc gọi f trong goroutine của Clock sau khoảng thời gian d.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer là timer được tạo bởi Clock.AfterFunc.
+type Timer interface {
+	// Stop hủy timer, trả về false nếu timer đã được kích hoạt hoặc đã bị hủy.
+	Stop() bool
+}
+
+// clockDispatchTimeout là thời gian tối đa timer của Clock chờ job được kích hoạt chạy xong.
+var clockDispatchTimeout = 5 * time.Second
+
+// realClock là Clock sử dụng đồng hồ hệ thống.
+type realClock struct{}
+
+// RealClock trả về Clock sử dụng đồng hồ hệ thống.
+func RealClock() Clock {
+	return realClock{}
+}
+
+// Now trả về thời điểm hiện tại của hệ thống.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep chờ trong khoảng thời gian d.
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// AfterFunc gọi f sau khoảng thời gian d.
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// gocronTime chuyển Clock thành gocron.TimeWrapper.
+type gocronTime struct {
+	clock Clock
+}
+
+// Now trả về thời điểm hiện tại theo múi giờ của scheduler.
+func (t gocronTime) Now(location *time.Location) time.Time {
+	return t.clock.Now().In(location)
+}
+
+// Unix trả về thời điểm tương ứng với Unix time.
+func (t gocronTime) Unix(sec int64, nsec int64) time.Time {
+	return time.Unix(sec, nsec)
+}
+
+// Sleep chờ trong khoảng thời gian d.
+func (t gocronTime) Sleep(d time.Duration) {
+	t.clock.Sleep(d)
+}
+
+// setClock thay đồng hồ của scheduler bằng clock.
+func (m *manager) setClock(clock Clock) {
+	m.clock = clock
+	m.Scheduler.CustomTime(gocronTime{clock: clock})
+	m.Scheduler.CustomTimer(m.afterFunc)
+}
+
+// now trả về thời điểm hiện tại theo Clock của scheduler.
+func (m *manager) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock.Now()
+}
+
+// afterFunc chuyển timer của gocron sang Clock.
+//
+// Callback chỉ trả về khi job được timer kích hoạt đã chạy xong hoặc đang chờ
+// một timer khác của Clock (tối đa clockDispatchTimeout), nên fake clock kích
+// hoạt job một cách đồng bộ.
+func (m *manager) afterFunc(d time.Duration, f func()) *time.Timer {
+	// gocron cần *time.Timer để hủy timer khi xóa job hoặc Stop(): dùng timer
+	// không bao giờ hết hạn làm đại diện và kiểm tra nó khi Clock kích hoạt
+	placeholder := time.NewTimer(math.MaxInt64)
+	m.clock.AfterFunc(d, func() {
+		if !placeholder.Stop() {
+			return
+		}
+		dispatch := m.queueDispatch()
+		f()
+		m.awaitDispatch(dispatch)
+	})
+	return placeholder
+}
+
+// clockDispatch là một lần timer của Clock kích hoạt job. Timer chỉ trả về sau
+// khi dispatch kết thúc.
+type clockDispatch struct {
+	done chan struct{}
+	once sync.Once
+}
+
+// finish kết thúc dispatch. Gọi trên dispatch nil không có tác dụng.
+func (d *clockDispatch) finish() {
+	if d == nil {
+		return
+	}
+	d.once.Do(func() { close(d.done) })
+}
+
+// clockRun giữ dispatch của một lần chạy job. Khi job chờ theo Clock (jitter,
+// spread, retry), dispatch hiện tại kết thúc và timer chờ tạo dispatch mới.
+type clockRun struct {
+	dispatch *clockDispatch
+}
+
+// finish kết thúc dispatch mà lần chạy đang giữ.
+func (r *clockRun) finish() {
+	if r != nil {
+		r.dispatch.finish()
+	}
+}
+
+// clockRunKey là khóa lưu *clockRun trong context của lần chạy job.
+type clockRunKey struct{}
+
+// withClockRun gắn run vào context của lần chạy job.
+func withClockRun(ctx context.Context, run *clockRun) context.Context {
+	if run == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, clockRunKey{}, run)
+}
+
+// clockRunFrom trả về *clockRun của lần chạy job, nil nếu job không được Clock kích hoạt.
+func clockRunFrom(ctx context.Context) *clockRun {
+	run, _ := ctx.Value(clockRunKey{}).(*clockRun)
+	return run
+}
+
+// queueDispatch tạo dispatch cho timer vừa kích hoạt, chờ job được gocron chạy nhận.
+func (m *manager) queueDispatch() *clockDispatch {
+	dispatch := &clockDispatch{done: make(chan struct{})}
+	m.dispatchMu.Lock()
+	defer m.dispatchMu.Unlock()
+	m.dispatches = append(m.dispatches, dispatch)
+	return dispatch
+}
+
+// claimDispatch nhận dispatch đang chờ sớm nhất, nil nếu không có.
+func (m *manager) claimDispatch() *clockDispatch {
+	m.dispatchMu.Lock()
+	defer m.dispatchMu.Unlock()
+	if len(m.dispatches) == 0 {
+		return nil
+	}
+	dispatch := m.dispatches[0]
+	m.dispatches = m.dispatches[1:]
+	return dispatch
+}
+
+// requeueDispatch đưa dispatch trở lại đầu hàng chờ để lần chạy job nhận lại.
+func (m *manager) requeueDispatch(dispatch *clockDispatch) {
+	if dispatch == nil {
+		return
+	}
+	m.dispatchMu.Lock()
+	defer m.dispatchMu.Unlock()
+	m.dispatches = append([]*clockDispatch{dispatch}, m.dispatches...)
+}
+
+// releaseDispatches kết thúc các dispatch chưa được job nào nhận, dùng khi scheduler dừng.
+func (m *manager) releaseDispatches() {
+	m.dispatchMu.Lock()
+	pending := m.dispatches
+	m.dispatches = nil
+	m.dispatchMu.Unlock()
+
+	for _, dispatch := range pending {
+		dispatch.finish()
+	}
+}
+
+// awaitDispatch chờ dispatch kết thúc trong tối đa clockDispatchTimeout.
+// Hết thời gian chờ, dispatch bị bỏ và lỗi ErrClockDispatchTimeout được báo qua reportClockError.
+func (m *manager) awaitDispatch(dispatch *clockDispatch) {
+	timer := time.NewTimer(clockDispatchTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-dispatch.done:
+		return
+	case <-timer.C:
+	}
+
+	m.dispatchMu.Lock()
+	for i, pending := range m.dispatches {
+		if pending == dispatch {
+			m.dispatches = append(m.dispatches[:i], m.dispatches[i+1:]...)
+			break
+		}
+	}
+	m.dispatchMu.Unlock()
+
+	m.reportClockError(fmt.Errorf("%w: job did not finish within %v", ErrClockDispatchTimeout, clockDispatchTimeout))
+}
+
+// reportClockError báo lỗi cho Clock nếu Clock triển khai DispatchErrorHandler, ngược lại ghi log.
+func (m *manager) reportClockError(err error) {
+	if handler, ok := m.clock.(DispatchErrorHandler); ok {
+		handler.HandleDispatchError(err)
+		return
+	}
+	log.Printf("scheduler: %v", err)
+}
+
+// sleep chờ delay theo Clock của scheduler, trả về false nếu ctx bị hủy trong lúc chờ.
+//
+// Với Clock được thiết lập qua WithClock, dispatch mà lần chạy job đang giữ kết
+// thúc sau khi timer chờ được đăng ký, và timer chờ khi kích hoạt tạo dispatch
+// mới cho phần còn lại của lần chạy.
+func (m *manager) sleep(ctx context.Context, delay time.Duration) bool {
+	if m.clock == nil {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	woken := make(chan *clockDispatch, 1)
+	timer := m.clock.AfterFunc(delay, func() {
+		dispatch := &clockDispatch{done: make(chan struct{})}
+		woken <- dispatch
+		m.awaitDispatch(dispatch)
+	})
+
+	run := clockRunFrom(ctx)
+	run.finish()
+
+	select {
+	case dispatch := <-woken:
+		if run == nil {
+			// Không có lần chạy nào giữ dispatch: không bắt Clock chờ
+			dispatch.finish()
+		} else {
+			run.dispatch = dispatch
+		}
+		return true
+	case <-ctx.Done():
+		if !timer.Stop() {
+			// Timer chờ đã được kích hoạt và đang chờ dispatch của nó
+			(<-woken).finish()
+		}
+		return false
+	}
+}
+
+// dispatchLocker bọc distributed locker của gocron: job được Clock kích hoạt
+// nhưng không lấy được khóa sẽ không chạy, nên dispatch của nó kết thúc ngay.
+type dispatchLocker struct {
+	gocron.Locker
+	m *manager
+}
+
+// Lock lấy khóa của job có tên key. Job có jitter hoặc spread chờ độ trễ trước
+// khi lấy khóa (xem lockAfterDelay).
+func (l dispatchLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	if control := l.m.controlByName(key); control != nil && control.window > 0 {
+		return l.m.lockAfterDelay(ctx, l.Locker, key, control)
+	}
+
+	lock, err := l.Locker.Lock(ctx, key)
+	if err != nil || lock == nil {
+		l.m.claimDispatch().finish()
+	}
+	return lock, err
+}
+
+// dispatchElector bọc leader elector của gocron: job được Clock kích hoạt trên
+// instance không phải leader sẽ không chạy, nên dispatch của nó kết thúc ngay.
+type dispatchElector struct {
+	gocron.Elector
+	m *manager
+}
+
+// IsLeader trả về nil nếu instance hiện tại là leader.
+func (e dispatchElector) IsLeader(ctx context.Context) error {
+	err := e.Elector.IsLeader(ctx)
+	if err != nil {
+		e.m.claimDispatch().finish()
+	}
+	return err
+}
+
+// DispatchErrorHandler có thể được Clock triển khai để nhận lỗi khi job được
+// timer của Clock kích hoạt không chạy xong trong thời gian chờ. Với Clock không
+// triển khai interface này, lỗi được ghi ra log.
+type DispatchErrorHandler interface {
+	// HandleDispatchError nhận lỗi của một lần kích hoạt, được gọi trong goroutine của Clock.
+	HandleDispatchError(err error)
+}
+
+// Error constants cho Clock
+var (
+	// ErrClockDispatchTimeout được báo khi job được timer của Clock kích hoạt không
+	// chạy xong (hoặc không bắt đầu chờ timer khác) trong clockDispatchTimeout.
+	ErrClockDispatchTimeout = errors.New("scheduler: clock dispatch timed out")
+)
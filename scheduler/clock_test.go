@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock là Clock chỉ kích hoạt timer khi gọi fire, ghi nhận lỗi dispatch.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []func()
+	errs   []error
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Sleep(time.Duration) {}
+
+func (c *manualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timers = append(c.timers, f)
+	return time.NewTimer(time.Hour)
+}
+
+func (c *manualClock) HandleDispatchError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+// fire kích hoạt timer được tạo sớm nhất và trả về khi callback của nó trả về.
+func (c *manualClock) fire() {
+	c.mu.Lock()
+	f := c.timers[0]
+	c.timers = c.timers[1:]
+	c.mu.Unlock()
+	f()
+}
+
+func (c *manualClock) errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]error(nil), c.errs...)
+}
+
+func TestClockDispatchWaitsOnlyForItsRun(t *testing.T) {
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := &manager{clock: clock}
+
+	dispatch := m.queueDispatch()
+	returned := make(chan struct{})
+	go func() {
+		m.awaitDispatch(dispatch)
+		close(returned)
+	}()
+
+	// Lần chạy không do timer kích hoạt (RunNow) không kết thúc dispatch đang chờ
+	(&clockRun{}).finish()
+	select {
+	case <-returned:
+		t.Fatal("Timer returned before its run finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	run := &clockRun{dispatch: m.claimDispatch()}
+	if run.dispatch != dispatch {
+		t.Fatal("Expected the run to claim the pending dispatch")
+	}
+	run.finish()
+	<-returned
+
+	if errs := clock.errors(); len(errs) != 0 {
+		t.Errorf("Expected no dispatch errors, got %v", errs)
+	}
+}
+
+func TestClockDispatchTimeoutReported(t *testing.T) {
+	defer func(timeout time.Duration) { clockDispatchTimeout = timeout }(clockDispatchTimeout)
+	clockDispatchTimeout = 20 * time.Millisecond
+
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := &manager{clock: clock}
+
+	m.awaitDispatch(m.queueDispatch())
+
+	errs := clock.errors()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrClockDispatchTimeout) {
+		t.Fatalf("Expected ErrClockDispatchTimeout to be reported, got %v", errs)
+	}
+	if dispatch := m.claimDispatch(); dispatch != nil {
+		t.Error("Timed out dispatch should no longer be pending")
+	}
+}
+
+func TestClockSleepHandsOverDispatch(t *testing.T) {
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := &manager{clock: clock}
+
+	first := m.queueDispatch()
+	run := &clockRun{dispatch: m.claimDispatch()}
+	ctx := withClockRun(m.runContext(), run)
+
+	woke := make(chan bool)
+	go func() { woke <- m.sleep(ctx, time.Minute) }()
+
+	// Dispatch đầu kết thúc khi job bắt đầu chờ timer
+	m.awaitDispatch(first)
+
+	fired := make(chan struct{})
+	go func() {
+		clock.fire()
+		close(fired)
+	}()
+	if !<-woke {
+		t.Fatal("Expected sleep to return true when its timer fires")
+	}
+	if run.dispatch == first {
+		t.Fatal("Expected the run to hold the dispatch of the sleep timer")
+	}
+
+	select {
+	case <-fired:
+		t.Fatal("Sleep timer returned before the run finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	run.finish()
+	<-fired
+
+	if errs := clock.errors(); len(errs) != 0 {
+		t.Errorf("Expected no dispatch errors, got %v", errs)
+	}
+}
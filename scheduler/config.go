@@ -5,17 +5,56 @@ import "time"
 // Config là cấu trúc cấu hình chính cho scheduler provider.
 //
 // Config định nghĩa các tùy chọn cấu hình cho scheduler manager và distributed locking.
-// Nó hỗ trợ Redis distributed locking khi chạy trên nhiều instance.
+// Nó hỗ trợ distributed locking với Redis hoặc MongoDB khi chạy trên nhiều instance.
 type Config struct {
 	// AutoStart xác định có tự động khởi động scheduler khi ứng dụng boot không
 	// ServiceProvider sẽ tự động gọi scheduler.StartAsync() trong Boot() method nếu true
 	AutoStart bool `mapstructure:"auto_start" yaml:"auto_start"`
 
+	// Timezone là múi giờ IANA của scheduler (ví dụ "Asia/Ho_Chi_Minh").
+	// Để trống hoặc "Local" để dùng múi giờ của máy chủ.
+	Timezone string `mapstructure:"timezone" yaml:"timezone"`
+
+	// SingletonMode bật chế độ singleton mặc định cho tất cả job:
+	// một job không chạy lần mới khi lần chạy trước chưa hoàn thành.
+	// Không dùng được cùng distributed locking hoặc leader election vì gocron
+	// không khóa job ở chế độ singleton.
+	SingletonMode bool `mapstructure:"singleton_mode" yaml:"singleton_mode"`
+
+	// Concurrency chứa cấu hình giới hạn số job chạy đồng thời
+	Concurrency ConcurrencyConfig `mapstructure:"concurrency" yaml:"concurrency"`
+
 	// DistributedLock chứa cấu hình cho distributed locking
 	DistributedLock DistributedLockConfig `mapstructure:"distributed_lock" yaml:"distributed_lock"`
 
-	// Options chứa cấu hình RedisLockerOptions cho distributed locking
+	// Options chứa cấu hình RedisLockerOptions cho distributed locking,
+	// áp dụng cho tất cả các driver
 	Options RedisLockerOptions `mapstructure:"options" yaml:"options"`
+
+	// LeaderElection chứa cấu hình cho chế độ leader election
+	LeaderElection LeaderElectionConfig `mapstructure:"leader_election" yaml:"leader_election"`
+
+	// History chứa cấu hình lưu lịch sử chạy job
+	History HistoryConfig `mapstructure:"history" yaml:"history"`
+
+	// Misfire chứa cấu hình lưu thời điểm chạy gần nhất cho chính sách chạy bù
+	Misfire MisfireConfig `mapstructure:"misfire" yaml:"misfire"`
+
+	// JobStore chứa cấu hình lưu các job chạy một lần tạo bởi ScheduleOnce
+	JobStore JobStoreConfig `mapstructure:"job_store" yaml:"job_store"`
+
+	// Jobs chứa danh sách job khai báo, được nạp trong Boot() và áp dụng lại khi config thay đổi
+	Jobs []JobConfig `mapstructure:"jobs" yaml:"jobs"`
+}
+
+// ConcurrencyConfig chứa cấu hình giới hạn số job chạy đồng thời.
+type ConcurrencyConfig struct {
+	// MaxJobs là số job tối đa được chạy đồng thời, 0 là không giới hạn
+	MaxJobs int `mapstructure:"max_jobs" yaml:"max_jobs"`
+
+	// Mode xác định cách xử lý job khi đã đạt giới hạn:
+	// "reschedule" bỏ qua lần chạy và chờ lịch tiếp theo, "wait" xếp hàng chờ đến lượt
+	Mode string `mapstructure:"mode" yaml:"mode"`
 }
 
 // DistributedLockConfig chứa cấu hình cho distributed locking.
@@ -23,6 +62,166 @@ type DistributedLockConfig struct {
 	// Enabled xác định có bật distributed locking không
 	// Chỉ cần thiết khi chạy scheduler trên nhiều instance trong môi trường phân tán
 	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Driver xác định backend cho distributed locking: "redis", "mongodb" hoặc "memory".
+	// "memory" chỉ khóa trong cùng một process, phù hợp cho kiểm thử và triển khai một instance.
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// Collection là tên collection lưu khóa khi sử dụng driver "mongodb"
+	Collection string `mapstructure:"collection" yaml:"collection"`
+}
+
+// LeaderElectionConfig chứa cấu hình cho chế độ leader election.
+//
+// Khác với distributed locking (khóa từng lần chạy job), leader election chỉ cho
+// phép một instance duy nhất (leader) thực thi tất cả các job.
+type LeaderElectionConfig struct {
+	// Enabled xác định có bật leader election không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Driver xác định backend lưu lease của leader: "redis" hoặc "mongodb"
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// Key là khóa dùng chung giữa các instance tranh cử
+	Key string `mapstructure:"key" yaml:"key"`
+
+	// Collection là tên collection lưu lease khi sử dụng driver "mongodb"
+	Collection string `mapstructure:"collection" yaml:"collection"`
+
+	// LeaseDuration là thời hạn lease của leader (giây)
+	LeaseDuration int `mapstructure:"lease_duration" yaml:"lease_duration"`
+
+	// RenewInterval là chu kỳ gia hạn lease và tranh cử (giây), phải nhỏ hơn LeaseDuration
+	RenewInterval int `mapstructure:"renew_interval" yaml:"renew_interval"`
+}
+
+// DefaultLeaderElectionConfig trả về cấu hình mặc định cho leader election.
+func DefaultLeaderElectionConfig() LeaderElectionConfig {
+	return LeaderElectionConfig{
+		Enabled:       false,
+		Driver:        "redis",
+		Key:           "scheduler_leader",
+		Collection:    "scheduler_leaders",
+		LeaseDuration: 15, // 15 seconds
+		RenewInterval: 5,  // 5 seconds
+	}
+}
+
+// ToElectorOptions chuyển đổi cấu hình leader election thành ElectorOptions.
+func (cfg LeaderElectionConfig) ToElectorOptions() ElectorOptions {
+	return ElectorOptions{
+		Key:           cfg.Key,
+		LeaseDuration: time.Duration(cfg.LeaseDuration) * time.Second,
+		RenewInterval: time.Duration(cfg.RenewInterval) * time.Second,
+	}
+}
+
+// HistoryConfig chứa cấu hình lưu lịch sử chạy job.
+type HistoryConfig struct {
+	// Enabled xác định có ghi lại lịch sử chạy job không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Driver xác định backend lưu lịch sử: "memory", "redis" hoặc "mongodb"
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// Node là định danh của instance ghi vào lịch sử, để trống để dùng hostname
+	Node string `mapstructure:"node" yaml:"node"`
+
+	// MaxRuns là số lần chạy gần nhất được lưu cho mỗi job
+	MaxRuns int `mapstructure:"max_runs" yaml:"max_runs"`
+
+	// KeyPrefix là tiền tố key trong Redis khi sử dụng driver "redis"
+	KeyPrefix string `mapstructure:"key_prefix" yaml:"key_prefix"`
+
+	// Collection là tên collection khi sử dụng driver "mongodb"
+	Collection string `mapstructure:"collection" yaml:"collection"`
+}
+
+// DefaultHistoryConfig trả về cấu hình mặc định cho lịch sử chạy job.
+func DefaultHistoryConfig() HistoryConfig {
+	return HistoryConfig{
+		Enabled:    false,
+		Driver:     "memory",
+		MaxRuns:    100,
+		KeyPrefix:  "scheduler_history:",
+		Collection: "scheduler_runs",
+	}
+}
+
+// ToHistoryOptions chuyển đổi cấu hình lịch sử thành HistoryOptions.
+func (cfg HistoryConfig) ToHistoryOptions() HistoryOptions {
+	return HistoryOptions{
+		KeyPrefix: cfg.KeyPrefix,
+		MaxRuns:   cfg.MaxRuns,
+	}
+}
+
+// MisfireConfig chứa cấu hình lưu thời điểm chạy gần nhất của các job có
+// chính sách chạy bù, dùng để phát hiện lần chạy bị lỡ khi scheduler khởi động.
+type MisfireConfig struct {
+	// Enabled xác định có lưu thời điểm chạy và chạy bù khi khởi động không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Driver xác định backend lưu thời điểm chạy: "redis", "mongodb" hoặc "memory".
+	// "memory" không giữ dữ liệu qua các lần khởi động, chỉ phù hợp cho kiểm thử.
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// KeyPrefix là tiền tố key trong Redis khi sử dụng driver "redis"
+	KeyPrefix string `mapstructure:"key_prefix" yaml:"key_prefix"`
+
+	// Collection là tên collection khi sử dụng driver "mongodb"
+	Collection string `mapstructure:"collection" yaml:"collection"`
+}
+
+// DefaultMisfireConfig trả về cấu hình mặc định cho chạy bù.
+func DefaultMisfireConfig() MisfireConfig {
+	return MisfireConfig{
+		Enabled:    false,
+		Driver:     "redis",
+		KeyPrefix:  "scheduler_last_run:",
+		Collection: "scheduler_last_runs",
+	}
+}
+
+// ToLastRunOptions chuyển đổi cấu hình chạy bù thành LastRunOptions.
+func (cfg MisfireConfig) ToLastRunOptions() LastRunOptions {
+	return LastRunOptions{
+		KeyPrefix: cfg.KeyPrefix,
+	}
+}
+
+// JobStoreConfig chứa cấu hình lưu các job chạy một lần, để job không bị mất
+// khi scheduler khởi động lại.
+type JobStoreConfig struct {
+	// Enabled xác định có lưu job chạy một lần và nạp lại khi khởi động không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Driver xác định backend lưu job: "redis", "mongodb" hoặc "memory".
+	// "memory" không giữ dữ liệu qua các lần khởi động, chỉ phù hợp cho kiểm thử.
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// KeyPrefix là tiền tố key trong Redis khi sử dụng driver "redis"
+	KeyPrefix string `mapstructure:"key_prefix" yaml:"key_prefix"`
+
+	// Collection là tên collection khi sử dụng driver "mongodb"
+	Collection string `mapstructure:"collection" yaml:"collection"`
+}
+
+// DefaultJobStoreConfig trả về cấu hình mặc định cho job store.
+func DefaultJobStoreConfig() JobStoreConfig {
+	return JobStoreConfig{
+		Enabled:    false,
+		Driver:     "redis",
+		KeyPrefix:  "scheduler_once:",
+		Collection: "scheduler_once_jobs",
+	}
+}
+
+// ToJobStoreOptions chuyển đổi cấu hình job store thành JobStoreOptions.
+func (cfg JobStoreConfig) ToJobStoreOptions() JobStoreOptions {
+	return JobStoreOptions{
+		KeyPrefix: cfg.KeyPrefix,
+	}
 }
 
 // RedisLockerOptions chứa các tùy chọn cấu hình cho Redis Locker.
@@ -43,11 +242,23 @@ type RedisLockerOptions struct {
 // DefaultConfig trả về cấu hình mặc định cho scheduler.
 func DefaultConfig() Config {
 	return Config{
-		AutoStart: true,
+		AutoStart:     true,
+		Timezone:      "",
+		SingletonMode: false,
+		Concurrency: ConcurrencyConfig{
+			MaxJobs: 0,
+			Mode:    "reschedule",
+		},
 		DistributedLock: DistributedLockConfig{
-			Enabled: false,
+			Enabled:    false,
+			Driver:     "redis",
+			Collection: "scheduler_locks",
 		},
-		Options: DefaultRedisLockerOptions(),
+		Options:        DefaultRedisLockerOptions(),
+		LeaderElection: DefaultLeaderElectionConfig(),
+		History:        DefaultHistoryConfig(),
+		Misfire:        DefaultMisfireConfig(),
+		JobStore:       DefaultJobStoreConfig(),
 	}
 }
 
@@ -0,0 +1,292 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// JobInfo mô tả trạng thái hiện tại của một job trong scheduler.
+type JobInfo struct {
+	// Name là tên của job
+	Name string `json:"name"`
+
+	// Tags là các tag của job
+	Tags []string `json:"tags"`
+
+	// Schedule mô tả lịch chạy của job, ví dụ "cron: 0 * * * *" hoặc "every 5m0s"
+	Schedule string `json:"schedule"`
+
+	// NextRun là thời điểm chạy kế tiếp theo lịch
+	NextRun time.Time `json:"next_run"`
+
+	// LastRun là thời điểm bắt đầu lần chạy gần nhất trên instance này, rỗng nếu chưa chạy
+	LastRun time.Time `json:"last_run"`
+
+	// RunCount là số lần job đã chạy trên instance này, kể cả các lần chạy bằng RunNow
+	RunCount int `json:"run_count"`
+
+	// Running cho biết job có đang chạy không
+	Running bool `json:"running"`
+
+	// Paused cho biết job có đang bị tạm dừng không
+	Paused bool `json:"paused"`
+}
+
+// jobControl lưu trạng thái điều khiển của một job được tạo qua Manager.
+type jobControl struct {
+	schedule string
+	run      func() error
+
+	// delay trả về độ trễ trước một lần chạy, window là độ trễ tối đa (jitter + spread)
+	delay  func() time.Duration
+	window time.Duration
+
+	mu       sync.Mutex
+	paused   bool
+	running  int
+	runCount int
+	lastRun  time.Time
+
+	// lockDelayed đếm số lần chạy đã chờ độ trễ trước khi lấy khóa phân tán
+	lockDelayed int
+}
+
+// isPaused cho biết job có đang bị tạm dừng không.
+func (c *jobControl) isPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// setPaused tạm dừng hoặc tiếp tục job.
+func (c *jobControl) setPaused(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = paused
+}
+
+// start ghi nhận job bắt đầu chạy.
+func (c *jobControl) start(startedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running++
+	c.runCount++
+	c.lastRun = startedAt
+}
+
+// finish ghi nhận job đã chạy xong.
+func (c *jobControl) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running--
+}
+
+// addLockDelayed ghi nhận một lần chạy đã chờ độ trễ trước khi lấy khóa phân tán.
+func (c *jobControl) addLockDelayed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lockDelayed++
+}
+
+// takeLockDelayed cho biết lần chạy đã chờ độ trễ trước khi lấy khóa phân tán chưa.
+func (c *jobControl) takeLockDelayed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lockDelayed == 0 {
+		return false
+	}
+	c.lockDelayed--
+	return true
+}
+
+// controlledJob là job của scheduler cùng trạng thái điều khiển của nó.
+type controlledJob struct {
+	job     *gocron.Job
+	control *jobControl
+}
+
+// trackControl đăng ký trạng thái điều khiển cho job.
+func (m *manager) trackControl(job *gocron.Job, control *jobControl) {
+	m.controlMu.Lock()
+	defer m.controlMu.Unlock()
+	if m.controls == nil {
+		m.controls = make(map[*gocron.Job]*jobControl)
+	}
+	m.controls[job] = control
+}
+
+// controlByName trả về trạng thái điều khiển của job có tên name, nil nếu không có.
+// Chỉ xét các job còn trong scheduler, nên job đã bị xóa hoặc thay thế (ví dụ khi
+// ApplyJobs tạo lại job cùng tên) không trả về trạng thái cũ.
+func (m *manager) controlByName(name string) *jobControl {
+	for _, controlled := range m.controlledJobs() {
+		if controlled.control != nil && controlled.job.GetName() == name {
+			return controlled.control
+		}
+	}
+	return nil
+}
+
+// controlledJobs trả về các job hiện có trong scheduler cùng trạng thái điều khiển.
+// Job được thêm trực tiếp vào gocron không qua Manager có control nil.
+func (m *manager) controlledJobs() []controlledJob {
+	jobs := m.Scheduler.Jobs()
+	current := make(map[*gocron.Job]bool, len(jobs))
+
+	m.controlMu.Lock()
+	defer m.controlMu.Unlock()
+
+	result := make([]controlledJob, 0, len(jobs))
+	for _, job := range jobs {
+		current[job] = true
+		result = append(result, controlledJob{job: job, control: m.controls[job]})
+	}
+	// Bỏ trạng thái của các job đã bị xóa khỏi scheduler
+	for job := range m.controls {
+		if !current[job] {
+			delete(m.controls, job)
+		}
+	}
+	return result
+}
+
+// jobsMatching trả về các job có trạng thái điều khiển thỏa điều kiện match.
+func (m *manager) jobsMatching(match func(job *gocron.Job) bool) []controlledJob {
+	var result []controlledJob
+	for _, controlled := range m.controlledJobs() {
+		if controlled.control != nil && match(controlled.job) {
+			result = append(result, controlled)
+		}
+	}
+	return result
+}
+
+// jobsByName trả về các job có tên name, hoặc ErrJobNotFound nếu không có.
+func (m *manager) jobsByName(name string) ([]controlledJob, error) {
+	jobs := m.jobsMatching(func(job *gocron.Job) bool {
+		return job.GetName() == name
+	})
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return jobs, nil
+}
+
+// jobsByTag trả về các job có tag, hoặc ErrJobNotFound nếu không có.
+func (m *manager) jobsByTag(tag string) ([]controlledJob, error) {
+	jobs := m.jobsMatching(func(job *gocron.Job) bool {
+		for _, jobTag := range job.Tags() {
+			if jobTag == tag {
+				return true
+			}
+		}
+		return false
+	})
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("%w: tag %s", ErrJobNotFound, tag)
+	}
+	return jobs, nil
+}
+
+// Jobs trả về trạng thái của tất cả các job, sắp xếp theo tên.
+func (m *manager) Jobs() []JobInfo {
+	controlled := m.controlledJobs()
+	infos := make([]JobInfo, 0, len(controlled))
+	for _, item := range controlled {
+		info := JobInfo{
+			Name:    item.job.GetName(),
+			Tags:    item.job.Tags(),
+			NextRun: item.job.NextRun(),
+		}
+		if item.control != nil {
+			item.control.mu.Lock()
+			info.Schedule = item.control.schedule
+			info.LastRun = item.control.lastRun
+			info.RunCount = item.control.runCount
+			info.Running = item.control.running > 0
+			info.Paused = item.control.paused
+			item.control.mu.Unlock()
+		} else {
+			info.RunCount = item.job.FinishedRunCount()
+			info.Running = item.job.IsRunning()
+		}
+		infos = append(infos, info)
+	}
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// PauseJob tạm dừng các job có tên name.
+func (m *manager) PauseJob(name string) error {
+	jobs, err := m.jobsByName(name)
+	if err != nil {
+		return err
+	}
+	setPaused(jobs, true)
+	return nil
+}
+
+// ResumeJob tiếp tục các job có tên name.
+func (m *manager) ResumeJob(name string) error {
+	jobs, err := m.jobsByName(name)
+	if err != nil {
+		return err
+	}
+	setPaused(jobs, false)
+	return nil
+}
+
+// PauseByTag tạm dừng các job có tag.
+func (m *manager) PauseByTag(tag string) error {
+	jobs, err := m.jobsByTag(tag)
+	if err != nil {
+		return err
+	}
+	setPaused(jobs, true)
+	return nil
+}
+
+// ResumeByTag tiếp tục các job có tag.
+func (m *manager) ResumeByTag(tag string) error {
+	jobs, err := m.jobsByTag(tag)
+	if err != nil {
+		return err
+	}
+	setPaused(jobs, false)
+	return nil
+}
+
+// setPaused tạm dừng hoặc tiếp tục các job.
+func setPaused(jobs []controlledJob, paused bool) {
+	for _, job := range jobs {
+		job.control.setPaused(paused)
+	}
+}
+
+// RunNow chạy ngay các job có tên name trong goroutine riêng.
+func (m *manager) RunNow(name string) error {
+	jobs, err := m.jobsByName(name)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		go func(run func() error) {
+			_ = run()
+		}(job.control.run)
+	}
+	return nil
+}
+
+// Error constants cho điều khiển job
+var (
+	// ErrJobNotFound được trả về khi không tìm thấy job theo tên hoặc tag.
+	ErrJobNotFound = errors.New("scheduler: job not found")
+)
@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerJobs(t *testing.T) {
+	sched := NewScheduler()
+
+	if _, err := sched.Cron("0 * * * *").Name("hourly").Tag("reports").Do(func() {}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := sched.Every(5).Minutes().Name("cleanup").Do(func() {}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	jobs := sched.Jobs()
+	if len(jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(jobs))
+	}
+	if jobs[0].Name != "cleanup" || jobs[0].Schedule != "every 5m0s" {
+		t.Errorf("Unexpected first job: %+v", jobs[0])
+	}
+	if jobs[1].Name != "hourly" || jobs[1].Schedule != "cron: 0 * * * *" || len(jobs[1].Tags) != 1 {
+		t.Errorf("Unexpected second job: %+v", jobs[1])
+	}
+	if !jobs[1].LastRun.IsZero() || jobs[1].RunCount != 0 || jobs[1].Running || jobs[1].Paused {
+		t.Errorf("Expected job that has not run, got %+v", jobs[1])
+	}
+}
+
+func TestSchedulerPauseResumeJob(t *testing.T) {
+	sched := NewScheduler()
+
+	var runs atomic.Int32
+	if _, err := sched.Every("20ms").Name("tick").Do(func() { runs.Add(1) }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if err := sched.PauseJob("tick"); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Errorf("Expected paused job not to run, got %d runs", got)
+	}
+	if jobs := sched.Jobs(); !jobs[0].Paused {
+		t.Error("Expected job reported as paused")
+	}
+
+	if err := sched.ResumeJob("tick"); err != nil {
+		t.Fatalf("Failed to resume job: %v", err)
+	}
+	waitFor(t, func() bool { return runs.Load() > 0 }, "resumed job did not run")
+
+	info := sched.Jobs()[0]
+	if info.Paused || info.RunCount == 0 || info.LastRun.IsZero() {
+		t.Errorf("Unexpected job info after resume: %+v", info)
+	}
+}
+
+func TestSchedulerPauseByTag(t *testing.T) {
+	sched := NewScheduler()
+
+	for _, name := range []string{"a", "b"} {
+		if _, err := sched.Every(1).Hours().Name(name).Tag("batch").Do(func() {}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+	}
+	if _, err := sched.Every(1).Hours().Name("c").Do(func() {}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	if err := sched.PauseByTag("batch"); err != nil {
+		t.Fatalf("Failed to pause by tag: %v", err)
+	}
+	for _, job := range sched.Jobs() {
+		if job.Paused != (job.Name != "c") {
+			t.Errorf("Unexpected paused state for %s: %v", job.Name, job.Paused)
+		}
+	}
+
+	if err := sched.ResumeByTag("batch"); err != nil {
+		t.Fatalf("Failed to resume by tag: %v", err)
+	}
+	for _, job := range sched.Jobs() {
+		if job.Paused {
+			t.Errorf("Expected %s resumed", job.Name)
+		}
+	}
+
+	if err := sched.PauseByTag("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+	if err := sched.PauseJob("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestSchedulerRunNow(t *testing.T) {
+	sched := NewScheduler()
+
+	var runs atomic.Int32
+	if _, err := sched.Cron("0 0 1 1 *").Name("yearly").Do(func() { runs.Add(1) }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	// RunNow chạy cả job đang tạm dừng và không cần scheduler đang chạy
+	if err := sched.PauseJob("yearly"); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+
+	if err := sched.RunNow("yearly"); err != nil {
+		t.Fatalf("Failed to run job: %v", err)
+	}
+	waitFor(t, func() bool { return runs.Load() == 1 }, "job did not run")
+	waitFor(t, func() bool { return sched.Jobs()[0].RunCount == 1 }, "run was not counted")
+
+	if err := sched.RunNow("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	sched := NewScheduler()
+	if _, err := sched.Every(1).Hours().Name("pkg/report").Tag("reports").Do(func() {}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	handler := NewHTTPHandler(sched)
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodPost, "/jobs/pkg%2Freport/pause", http.StatusOK},
+		{http.MethodPost, "/jobs/missing/pause", http.StatusNotFound},
+		{http.MethodPost, "/tags/reports/resume", http.StatusOK},
+		{http.MethodPost, "/jobs/pkg%2Freport/run", http.StatusOK},
+		{http.MethodGet, "/jobs/pkg%2Freport/run", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.status, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	var jobs []JobInfo
+	if err := json.NewDecoder(rec.Body).Decode(&jobs); err != nil {
+		t.Fatalf("Failed to decode jobs: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "pkg/report" || jobs[0].Schedule != "every 1h0m0s" {
+		t.Errorf("Unexpected jobs response: %+v", jobs)
+	}
+}
@@ -2,13 +2,23 @@
 // dựa trên thư viện gocron.
 //
 // Tính năng nổi bật:
-//   - Configuration-driven: Hỗ trợ cấu hình qua file config với struct Config và RedisLockerOptions
+//   - Configuration-driven: Hỗ trợ cấu hình qua file config với struct Config (múi giờ, singleton, giới hạn đồng thời, locker), áp dụng giống nhau qua provider hoặc NewSchedulerWithConfig
 //   - Auto-start: Tự động khởi động scheduler khi ứng dụng boot (có thể tắt qua config)
 //   - Wrap toàn bộ tính năng của thư viện gocron - một thư viện lập lịch và chạy task hiệu quả
 //   - Hỗ trợ nhiều loại lịch trình: theo khoảng thời gian, theo thời điểm cụ thể, biểu thức cron
 //   - Hỗ trợ chế độ singleton để tránh chạy song song cùng một task
-//   - Hỗ trợ distributed locking với Redis cho môi trường phân tán (tự động cấu hình qua config)
+//   - Job nhận context (DoWithContext) với timeout, retry backoff và chuyển panic thành lỗi
+//   - Workflow (DoWorkflow): DAG các bước phụ thuộc nhau, bước độc lập chạy song song, trạng thái từng bước lưu trong lịch sử
+//   - Hỗ trợ distributed locking với Redis, MongoDB hoặc bộ nhớ trong (tự động cấu hình qua config)
+//   - Hỗ trợ leader election với Redis hoặc MongoDB: chỉ instance leader thực thi job
 //   - Hỗ trợ tag để nhóm và quản lý các task
+//   - Xem trạng thái job (Jobs), tạm dừng/tiếp tục theo tên hoặc tag, chạy ngay (RunNow) và HTTP handler JSON (NewHTTPHandler)
+//   - Khai báo job trong config (scheduler.jobs) với handler đăng ký theo tên qua RegisterJobHandler
+//   - Lưu lịch sử chạy job (memory, Redis, MongoDB) với LastRun, Runs và thống kê tỷ lệ thành công
+//   - Chạy bù lần chạy bị lỡ khi khởi động theo chính sách Misfire (skip, run_once, run_all)
+//   - Jitter và Spread để dàn các job cùng lịch trên nhiều service, tránh dồn tải vào cùng một thời điểm
+//   - Job chạy một lần bền vững (ScheduleOnce) lưu trong Redis hoặc MongoDB, nạp lại khi khởi động và có thể hủy theo tên
+//   - Thay đồng hồ qua WithClock; package scheduler/testing cung cấp FakeClock để kiểm thử không cần sleep
 //   - Tích hợp với DI container thông qua ServiceProvider
 //   - API fluent cho trải nghiệm lập trình dễ dàng
 //
@@ -18,7 +28,7 @@
 //   - ServiceProvider giúp tích hợp dễ dàng vào ứng dụng thông qua DI container
 //   - Hỗ trợ dual config system: RedisLockerOptions (int) cho file config và RedisLockerOptionsTime (time.Duration) cho internal use
 //   - Tự động khởi động scheduler khi ứng dụng boot (có thể tắt thông qua config auto_start: false)
-//   - Tự động thiết lập distributed locking khi được enable trong config và có redis provider
+//   - Tự động thiết lập distributed locking theo driver (redis, mongodb, memory) khi được enable trong config
 //   - Hỗ trợ tự động gia hạn khóa cho distributed locking trong môi trường phân tán
 //
 // Ví dụ sử dụng với configuration-driven approach:
@@ -0,0 +1,259 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// LeaderElector là một gocron.Elector dựa trên lease có thể gia hạn.
+//
+// Khi được gắn vào scheduler qua Manager.WithLeaderElection, chỉ instance đang
+// giữ lease mới thực thi job; các instance khác chờ đến khi lease hết hạn
+// (leader dừng hoặc mất kết nối) và một instance mới được bầu.
+type LeaderElector interface {
+	gocron.Elector
+
+	// Start bắt đầu vòng lặp tranh cử và gia hạn lease trong một goroutine riêng.
+	// Gọi Start nhiều lần không có tác dụng khi vòng lặp đang chạy.
+	Start()
+
+	// Stop dừng vòng lặp tranh cử và từ bỏ lease nếu đang là leader,
+	// để instance khác có thể lên làm leader ngay lập tức.
+	Stop() error
+
+	// Leading trả về true nếu instance hiện tại đang giữ lease còn hiệu lực.
+	Leading() bool
+
+	// OnLeadershipChange đăng ký callback được gọi mỗi khi instance hiện tại
+	// trở thành leader (true) hoặc mất vai trò leader (false).
+	OnLeadershipChange(callback func(isLeader bool))
+}
+
+// ElectorOptions chứa các tùy chọn cho leader elector.
+type ElectorOptions struct {
+	// Key là khóa dùng chung giữa các instance tranh cử cùng một vai trò leader
+	Key string
+
+	// LeaseDuration là thời hạn của lease; leader mất vai trò nếu không gia hạn được trong khoảng này
+	LeaseDuration time.Duration
+
+	// RenewInterval là chu kỳ gia hạn lease của leader và thử tranh cử của follower
+	RenewInterval time.Duration
+}
+
+// DefaultElectorOptions trả về các tùy chọn mặc định cho leader elector.
+func DefaultElectorOptions() ElectorOptions {
+	return DefaultLeaderElectionConfig().ToElectorOptions()
+}
+
+// validateElectorOptions kiểm tra tính hợp lệ của các tùy chọn elector.
+func validateElectorOptions(options ElectorOptions) error {
+	if options.Key == "" {
+		return ErrInvalidElectionKey
+	}
+	if options.LeaseDuration <= 0 {
+		return ErrInvalidLeaseDuration
+	}
+	if options.RenewInterval <= 0 || options.RenewInterval >= options.LeaseDuration {
+		return ErrInvalidRenewInterval
+	}
+	return nil
+}
+
+// leaseElector là elector dùng chung cho các backend: mỗi instance có một
+// owner token riêng, tranh cử bằng acquire và duy trì vai trò bằng renew.
+//
+// acquire, renew và release trả về false khi lease đang thuộc về instance khác.
+type leaseElector struct {
+	options ElectorOptions
+	token   string
+	acquire func(ctx context.Context, token string) (bool, error)
+	renew   func(ctx context.Context, token string) (bool, error)
+	release func(ctx context.Context, token string) (bool, error)
+	nowFunc func() time.Time
+
+	mu        sync.Mutex
+	leading   bool
+	expiresAt time.Time
+	callbacks []func(isLeader bool)
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// newLeaseElector tạo elector với owner token ngẫu nhiên.
+func newLeaseElector(options ElectorOptions,
+	acquire, renew, release func(ctx context.Context, token string) (bool, error)) (*leaseElector, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+	return &leaseElector{
+		options: options,
+		token:   token,
+		acquire: acquire,
+		renew:   renew,
+		release: release,
+		nowFunc: time.Now,
+	}, nil
+}
+
+// IsLeader triển khai phương thức IsLeader của gocron.Elector interface.
+//
+// Trả về ErrNotLeader nếu instance hiện tại không giữ lease còn hiệu lực.
+func (e *leaseElector) IsLeader(ctx context.Context) error {
+	if !e.Leading() {
+		return ErrNotLeader
+	}
+	return nil
+}
+
+// Leading trả về true nếu instance hiện tại đang giữ lease còn hiệu lực.
+func (e *leaseElector) Leading() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leading && e.nowFunc().Before(e.expiresAt)
+}
+
+// OnLeadershipChange đăng ký callback khi vai trò leader thay đổi.
+func (e *leaseElector) OnLeadershipChange(callback func(isLeader bool)) {
+	if callback == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.callbacks = append(e.callbacks, callback)
+}
+
+// Start bắt đầu vòng lặp tranh cử trong một goroutine riêng.
+func (e *leaseElector) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	go e.campaign(ctx, e.done)
+}
+
+// Stop dừng vòng lặp tranh cử và từ bỏ lease nếu đang là leader.
+func (e *leaseElector) Stop() error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel, e.done = nil, nil
+	wasLeading := e.leading
+	e.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+
+	if !wasLeading {
+		return nil
+	}
+
+	ctx, cancelRelease := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelRelease()
+	_, err := e.release(ctx, e.token)
+	e.setLeading(false, time.Time{})
+	return err
+}
+
+// campaign thử tranh cử ngay lập tức, sau đó gia hạn hoặc tranh cử lại
+// sau mỗi RenewInterval cho đến khi context bị hủy.
+func (e *leaseElector) campaign(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(e.options.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick thực hiện một lượt gia hạn (nếu đang là leader) hoặc tranh cử.
+func (e *leaseElector) tick(ctx context.Context) {
+	e.mu.Lock()
+	leading := e.leading
+	e.mu.Unlock()
+
+	// Sử dụng context với timeout để tránh block vô hạn
+	opCtx, cancel := context.WithTimeout(ctx, e.options.RenewInterval)
+	defer cancel()
+
+	now := e.nowFunc()
+	if leading {
+		renewed, err := e.renew(opCtx, e.token)
+		switch {
+		case err != nil:
+			// Lỗi tạm thời: giữ vai trò leader cho đến khi lease hết hạn
+			e.mu.Lock()
+			expired := !e.nowFunc().Before(e.expiresAt)
+			e.mu.Unlock()
+			if expired {
+				e.setLeading(false, time.Time{})
+			}
+		case renewed:
+			e.setLeading(true, now.Add(e.options.LeaseDuration))
+		default:
+			// Lease đã hết hạn và bị instance khác chiếm giữ
+			e.setLeading(false, time.Time{})
+		}
+		return
+	}
+
+	acquired, err := e.acquire(opCtx, e.token)
+	if err == nil && acquired {
+		e.setLeading(true, now.Add(e.options.LeaseDuration))
+	}
+}
+
+// setLeading cập nhật trạng thái leader và gọi các callback khi trạng thái thay đổi.
+func (e *leaseElector) setLeading(leading bool, expiresAt time.Time) {
+	e.mu.Lock()
+	changed := e.leading != leading
+	e.leading = leading
+	e.expiresAt = expiresAt
+	callbacks := e.callbacks
+	e.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	for _, callback := range callbacks {
+		callback(leading)
+	}
+}
+
+// Error constants cho leader election
+var (
+	// ErrNotLeader được trả về khi instance hiện tại không phải leader.
+	ErrNotLeader = errors.New("scheduler: this instance is not the leader")
+
+	// ErrInvalidElectionKey được trả về khi khóa tranh cử rỗng.
+	ErrInvalidElectionKey = errors.New("scheduler: invalid leader election key")
+
+	// ErrInvalidLeaseDuration được trả về khi thời hạn lease không hợp lệ.
+	ErrInvalidLeaseDuration = errors.New("scheduler: invalid leader lease duration")
+
+	// ErrInvalidRenewInterval được trả về khi chu kỳ gia hạn không nhỏ hơn thời hạn lease.
+	ErrInvalidRenewInterval = errors.New("scheduler: invalid leader renew interval")
+
+	// ErrUnsupportedElectionDriver được trả về khi driver leader election không được hỗ trợ.
+	ErrUnsupportedElectionDriver = errors.New("scheduler: unsupported leader election driver")
+)
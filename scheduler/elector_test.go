@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func testElectorOptions() ElectorOptions {
+	return ElectorOptions{
+		Key:           "test_leader",
+		LeaseDuration: 500 * time.Millisecond,
+		RenewInterval: 50 * time.Millisecond,
+	}
+}
+
+func newTestRedisElector(t *testing.T, mr *miniredis.Miniredis) LeaderElector {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	elector, err := NewRedisElector(client, testElectorOptions())
+	if err != nil {
+		t.Fatalf("Failed to create redis elector: %v", err)
+	}
+	return elector
+}
+
+func waitFor(t *testing.T, condition func() bool, message string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal(message)
+}
+
+func TestValidateElectorOptions(t *testing.T) {
+	if err := validateElectorOptions(DefaultElectorOptions()); err != nil {
+		t.Fatalf("Default options should be valid: %v", err)
+	}
+
+	options := DefaultElectorOptions()
+	options.Key = ""
+	if err := validateElectorOptions(options); err != ErrInvalidElectionKey {
+		t.Errorf("Expected ErrInvalidElectionKey, got %v", err)
+	}
+
+	options = DefaultElectorOptions()
+	options.LeaseDuration = 0
+	if err := validateElectorOptions(options); err != ErrInvalidLeaseDuration {
+		t.Errorf("Expected ErrInvalidLeaseDuration, got %v", err)
+	}
+
+	options = DefaultElectorOptions()
+	options.RenewInterval = options.LeaseDuration
+	if err := validateElectorOptions(options); err != ErrInvalidRenewInterval {
+		t.Errorf("Expected ErrInvalidRenewInterval, got %v", err)
+	}
+}
+
+func TestNewRedisElectorNilClient(t *testing.T) {
+	if _, err := NewRedisElector(nil); err != ErrRedisClientNil {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+}
+
+func TestRedisElectorSingleLeader(t *testing.T) {
+	mr := miniredis.RunT(t)
+	first := newTestRedisElector(t, mr)
+	second := newTestRedisElector(t, mr)
+
+	first.Start()
+	defer first.Stop()
+	waitFor(t, first.Leading, "First elector should become leader")
+
+	second.Start()
+	defer second.Stop()
+	time.Sleep(150 * time.Millisecond)
+
+	if second.Leading() {
+		t.Fatal("Only one elector should be leader")
+	}
+	if err := second.IsLeader(context.Background()); err != ErrNotLeader {
+		t.Errorf("Expected ErrNotLeader from follower, got %v", err)
+	}
+	if err := first.IsLeader(context.Background()); err != nil {
+		t.Errorf("Expected leader to return nil, got %v", err)
+	}
+}
+
+func TestRedisElectorFailover(t *testing.T) {
+	mr := miniredis.RunT(t)
+	first := newTestRedisElector(t, mr)
+	second := newTestRedisElector(t, mr)
+
+	var mu sync.Mutex
+	var changes []bool
+	second.OnLeadershipChange(func(isLeader bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, isLeader)
+	})
+
+	first.Start()
+	waitFor(t, first.Leading, "First elector should become leader")
+	second.Start()
+	defer second.Stop()
+
+	// Leader dừng và từ bỏ lease, follower lên thay
+	if err := first.Stop(); err != nil {
+		t.Fatalf("Failed to stop leader: %v", err)
+	}
+	if first.Leading() {
+		t.Error("Stopped elector should not be leader")
+	}
+	waitFor(t, second.Leading, "Second elector should take over leadership")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(changes) != 1 || !changes[0] {
+		t.Errorf("Expected a single leadership gained callback, got %v", changes)
+	}
+}
+
+func TestRedisElectorLosesLease(t *testing.T) {
+	mr := miniredis.RunT(t)
+	elector := newTestRedisElector(t, mr)
+
+	lost := make(chan struct{})
+	elector.OnLeadershipChange(func(isLeader bool) {
+		if !isLeader {
+			close(lost)
+		}
+	})
+
+	elector.Start()
+	defer elector.Stop()
+	waitFor(t, elector.Leading, "Elector should become leader")
+
+	// Lease hết hạn và bị instance khác chiếm giữ
+	mr.Set("test_leader", "other-owner")
+
+	select {
+	case <-lost:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected leadership lost callback")
+	}
+	if elector.Leading() {
+		t.Error("Elector should not be leader after losing the lease")
+	}
+}
+
+func TestSchedulerLeaderElection(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	leader := NewScheduler().WithLeaderElection(newTestRedisElector(t, mr))
+	follower := NewScheduler().WithLeaderElection(newTestRedisElector(t, mr))
+
+	var mu sync.Mutex
+	runs := map[string]int{}
+	for name, scheduler := range map[string]Manager{"leader": leader, "follower": follower} {
+		name := name
+		_, err := scheduler.Every("50ms").Do(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			runs[name]++
+		})
+		if err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+	}
+
+	leader.StartAsync()
+	defer leader.Stop()
+	waitFor(t, leader.IsLeader, "First scheduler should become leader")
+
+	follower.StartAsync()
+	defer follower.Stop()
+
+	time.Sleep(300 * time.Millisecond)
+
+	if follower.IsLeader() {
+		t.Fatal("Follower should not be leader")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runs["leader"] == 0 {
+		t.Error("Leader should run jobs")
+	}
+	if runs["follower"] != 0 {
+		t.Errorf("Follower should not run jobs, ran %d times", runs["follower"])
+	}
+}
+
+func TestSchedulerIsLeaderWithoutElection(t *testing.T) {
+	if !NewScheduler().IsLeader() {
+		t.Error("Scheduler without leader election should report itself as leader")
+	}
+}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// JobRun là bản ghi một lần chạy của job.
+type JobRun struct {
+	// JobName là tên của job (hoặc tên hàm nếu job không được đặt tên)
+	JobName string `json:"job_name" bson:"job_name"`
+
+	// Tags là các tag của job tại thời điểm chạy
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+
+	// StartedAt là thời điểm bắt đầu chạy
+	StartedAt time.Time `json:"started_at" bson:"started_at"`
+
+	// Duration là thời gian chạy
+	Duration time.Duration `json:"duration" bson:"duration"`
+
+	// Error là thông điệp lỗi nếu lần chạy thất bại, rỗng nếu thành công
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+
+	// Node là định danh của instance đã chạy job
+	Node string `json:"node,omitempty" bson:"node,omitempty"`
+
+	// Steps là trạng thái từng bước khi job là một Workflow
+	Steps []StepRun `json:"steps,omitempty" bson:"steps,omitempty"`
+}
+
+// Succeeded trả về true nếu lần chạy không có lỗi.
+func (r JobRun) Succeeded() bool {
+	return r.Error == ""
+}
+
+// JobStats là thống kê các lần chạy của một job, tính trên các lần chạy còn được lưu giữ.
+type JobStats struct {
+	// Total là tổng số lần chạy
+	Total int `json:"total"`
+
+	// Succeeded là số lần chạy thành công
+	Succeeded int `json:"succeeded"`
+
+	// Failed là số lần chạy thất bại
+	Failed int `json:"failed"`
+
+	// SuccessRate là tỷ lệ thành công trong khoảng [0, 1]
+	SuccessRate float64 `json:"success_rate"`
+
+	// AverageDuration là thời gian chạy trung bình
+	AverageDuration time.Duration `json:"average_duration"`
+}
+
+// newJobStats tính thống kê từ danh sách các lần chạy.
+func newJobStats(runs []JobRun) JobStats {
+	stats := JobStats{Total: len(runs)}
+	if len(runs) == 0 {
+		return stats
+	}
+
+	var total time.Duration
+	for _, run := range runs {
+		if run.Succeeded() {
+			stats.Succeeded++
+		} else {
+			stats.Failed++
+		}
+		total += run.Duration
+	}
+	stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Total)
+	stats.AverageDuration = total / time.Duration(stats.Total)
+	return stats
+}
+
+// HistoryStore lưu lịch sử các lần chạy của job.
+//
+// Mỗi store giữ tối đa MaxRuns lần chạy gần nhất cho mỗi job.
+type HistoryStore interface {
+	// Record lưu một lần chạy.
+	Record(ctx context.Context, run JobRun) error
+
+	// LastRun trả về lần chạy gần nhất của job, hoặc ErrNoJobRuns nếu chưa có.
+	LastRun(ctx context.Context, jobName string) (JobRun, error)
+
+	// Runs trả về tối đa limit lần chạy gần nhất của job, mới nhất trước.
+	// limit <= 0 trả về tất cả các lần chạy còn được lưu giữ.
+	Runs(ctx context.Context, jobName string, limit int) ([]JobRun, error)
+}
+
+// HistoryOptions chứa các tùy chọn cho HistoryStore.
+type HistoryOptions struct {
+	// KeyPrefix là tiền tố của key lưu lịch sử trong Redis
+	KeyPrefix string
+
+	// MaxRuns là số lần chạy tối đa được lưu cho mỗi job
+	MaxRuns int
+}
+
+// DefaultHistoryOptions trả về các tùy chọn mặc định cho HistoryStore.
+func DefaultHistoryOptions() HistoryOptions {
+	return DefaultHistoryConfig().ToHistoryOptions()
+}
+
+// validateHistoryOptions kiểm tra tính hợp lệ của các tùy chọn HistoryStore.
+func validateHistoryOptions(options HistoryOptions) error {
+	if options.MaxRuns <= 0 {
+		return ErrInvalidMaxRuns
+	}
+	return nil
+}
+
+// memoryHistoryStore lưu lịch sử trong bộ nhớ của process.
+type memoryHistoryStore struct {
+	mu      sync.RWMutex
+	maxRuns int
+	runs    map[string][]JobRun
+}
+
+// NewMemoryHistoryStore tạo HistoryStore lưu lịch sử trong bộ nhớ,
+// phù hợp cho kiểm thử và triển khai một instance.
+//
+// Example:
+//
+//	store, err := scheduler.NewMemoryHistoryStore()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithHistory(store)
+func NewMemoryHistoryStore(opts ...HistoryOptions) (HistoryStore, error) {
+	options := DefaultHistoryOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+		if err := validateHistoryOptions(options); err != nil {
+			return nil, err
+		}
+	}
+
+	return &memoryHistoryStore{
+		maxRuns: options.MaxRuns,
+		runs:    make(map[string][]JobRun),
+	}, nil
+}
+
+// Record lưu một lần chạy, loại bỏ lần chạy cũ nhất khi vượt quá MaxRuns.
+func (s *memoryHistoryStore) Record(ctx context.Context, run JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := append([]JobRun{run}, s.runs[run.JobName]...)
+	if len(runs) > s.maxRuns {
+		runs = runs[:s.maxRuns]
+	}
+	s.runs[run.JobName] = runs
+	return nil
+}
+
+// LastRun trả về lần chạy gần nhất của job.
+func (s *memoryHistoryStore) LastRun(ctx context.Context, jobName string) (JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := s.runs[jobName]
+	if len(runs) == 0 {
+		return JobRun{}, ErrNoJobRuns
+	}
+	return runs[0], nil
+}
+
+// Runs trả về các lần chạy gần nhất của job.
+func (s *memoryHistoryStore) Runs(ctx context.Context, jobName string, limit int) ([]JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := s.runs[jobName]
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return append([]JobRun(nil), runs...), nil
+}
+
+// Error constants cho lịch sử chạy job
+var (
+	// ErrHistoryDisabled được trả về khi truy vấn lịch sử mà scheduler chưa có HistoryStore.
+	ErrHistoryDisabled = errors.New("scheduler: job history is not enabled")
+
+	// ErrNoJobRuns được trả về khi job chưa có lần chạy nào được ghi nhận.
+	ErrNoJobRuns = errors.New("scheduler: no recorded runs for job")
+
+	// ErrInvalidMaxRuns được trả về khi số lần chạy lưu giữ không hợp lệ.
+	ErrInvalidMaxRuns = errors.New("scheduler: invalid history max runs")
+
+	// ErrUnsupportedHistoryDriver được trả về khi driver lịch sử không được hỗ trợ.
+	ErrUnsupportedHistoryDriver = errors.New("scheduler: unsupported history driver")
+)
@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMemoryHistoryStore(t *testing.T) {
+	store, err := NewMemoryHistoryStore(HistoryOptions{MaxRuns: 2})
+	if err != nil {
+		t.Fatalf("Failed to create memory history store: %v", err)
+	}
+	testHistoryStore(t, store)
+}
+
+func TestRedisHistoryStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	store, err := NewRedisHistoryStore(client, HistoryOptions{KeyPrefix: "history:", MaxRuns: 2})
+	if err != nil {
+		t.Fatalf("Failed to create redis history store: %v", err)
+	}
+	testHistoryStore(t, store)
+
+	if length, _ := client.LLen(context.Background(), "history:report").Result(); length != 2 {
+		t.Errorf("Expected redis list trimmed to 2 runs, got %d", length)
+	}
+}
+
+// testHistoryStore kiểm tra hành vi chung của các HistoryStore với MaxRuns = 2.
+func testHistoryStore(t *testing.T, store HistoryStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := store.LastRun(ctx, "report"); !errors.Is(err, ErrNoJobRuns) {
+		t.Fatalf("Expected ErrNoJobRuns, got %v", err)
+	}
+
+	start := time.Now().Truncate(time.Millisecond)
+	for i := 0; i < 3; i++ {
+		run := JobRun{
+			JobName:   "report",
+			Tags:      []string{"reports"},
+			StartedAt: start.Add(time.Duration(i) * time.Minute),
+			Duration:  time.Duration(i+1) * time.Second,
+			Node:      "node-1",
+		}
+		if i == 2 {
+			run.Error = "boom"
+		}
+		if err := store.Record(ctx, run); err != nil {
+			t.Fatalf("Failed to record run: %v", err)
+		}
+	}
+
+	last, err := store.LastRun(ctx, "report")
+	if err != nil {
+		t.Fatalf("Failed to get last run: %v", err)
+	}
+	if last.Error != "boom" || last.Succeeded() || last.Node != "node-1" {
+		t.Errorf("Unexpected last run: %+v", last)
+	}
+
+	runs, err := store.Runs(ctx, "report", 0)
+	if err != nil {
+		t.Fatalf("Failed to get runs: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 retained runs, got %d", len(runs))
+	}
+	if !runs[0].StartedAt.After(runs[1].StartedAt) {
+		t.Error("Runs should be ordered newest first")
+	}
+
+	limited, _ := store.Runs(ctx, "report", 1)
+	if len(limited) != 1 {
+		t.Errorf("Expected 1 run with limit, got %d", len(limited))
+	}
+}
+
+func TestNewJobStats(t *testing.T) {
+	stats := newJobStats([]JobRun{
+		{Duration: time.Second},
+		{Duration: 3 * time.Second, Error: "boom"},
+	})
+
+	if stats.Total != 2 || stats.Succeeded != 1 || stats.Failed != 1 {
+		t.Errorf("Unexpected counts: %+v", stats)
+	}
+	if stats.SuccessRate != 0.5 {
+		t.Errorf("Expected success rate 0.5, got %v", stats.SuccessRate)
+	}
+	if stats.AverageDuration != 2*time.Second {
+		t.Errorf("Expected average duration 2s, got %v", stats.AverageDuration)
+	}
+
+	if empty := newJobStats(nil); empty.Total != 0 || empty.SuccessRate != 0 {
+		t.Errorf("Unexpected stats for no runs: %+v", empty)
+	}
+}
+
+func TestSchedulerRecordsHistory(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.History.Enabled = true
+	cfg.History.Node = "node-a"
+
+	scheduler, err := NewSchedulerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create scheduler: %v", err)
+	}
+
+	_, err = scheduler.Every(1).Hours().Name("failing").Tag("reports").Do(func(reason string) error {
+		return errors.New(reason)
+	}, "boom")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	var last JobRun
+	waitFor(t, func() bool {
+		last, err = scheduler.LastRun("failing")
+		return err == nil
+	}, "Expected job run to be recorded")
+
+	if last.Error != "boom" || last.Node != "node-a" {
+		t.Errorf("Unexpected recorded run: %+v", last)
+	}
+	if len(last.Tags) != 1 || last.Tags[0] != "reports" {
+		t.Errorf("Expected tags [reports], got %v", last.Tags)
+	}
+
+	stats, err := scheduler.Stats("failing")
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.Total != 1 || stats.Failed != 1 || stats.SuccessRate != 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestSchedulerHistoryDisabled(t *testing.T) {
+	scheduler := NewScheduler()
+
+	if _, err := scheduler.LastRun("job"); !errors.Is(err, ErrHistoryDisabled) {
+		t.Errorf("Expected ErrHistoryDisabled, got %v", err)
+	}
+	if _, err := scheduler.Runs("job", 10); !errors.Is(err, ErrHistoryDisabled) {
+		t.Errorf("Expected ErrHistoryDisabled, got %v", err)
+	}
+	if _, err := scheduler.Stats("job"); !errors.Is(err, ErrHistoryDisabled) {
+		t.Errorf("Expected ErrHistoryDisabled, got %v", err)
+	}
+}
+
+func namedJobFunction() {}
+
+func TestSchedulerDoKeepsFunctionName(t *testing.T) {
+	scheduler := NewScheduler()
+
+	// Job không đặt tên giữ tên hàm gốc, được gocron dùng làm khóa phân tán
+	job, err := scheduler.Every(1).Hours().Do(namedJobFunction)
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if got := job.GetName(); got != functionName(namedJobFunction) {
+		t.Errorf("Expected job name %s, got %s", functionName(namedJobFunction), got)
+	}
+
+	// Tham số không khớp vẫn trả về lỗi của gocron
+	if _, err := scheduler.Every(1).Hours().Do(namedJobFunction, "extra"); err == nil {
+		t.Error("Expected error for mismatched parameters")
+	}
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// NewHTTPHandler tạo http.Handler trả về JSON để xem và điều khiển các job của manager.
+//
+// Các route (tương đối với nơi handler được gắn, dùng http.StripPrefix khi gắn dưới tiền tố):
+//
+//	GET  /jobs                 danh sách JobInfo
+//	POST /jobs/{name}/pause    tạm dừng job
+//	POST /jobs/{name}/resume   tiếp tục job
+//	POST /jobs/{name}/run      chạy ngay job
+//	POST /tags/{tag}/pause     tạm dừng các job có tag
+//	POST /tags/{tag}/resume    tiếp tục các job có tag
+//
+// Tên job chứa ký tự "/" cần được mã hóa URL (%2F). Handler không xác thực
+// request, hãy bọc nó bằng middleware xác thực của ứng dụng.
+//
+// Example:
+//
+//	mux.Handle("/admin/scheduler/", http.StripPrefix("/admin/scheduler", scheduler.NewHTTPHandler(sched)))
+func NewHTTPHandler(manager Manager) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /jobs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, manager.Jobs())
+	})
+
+	actions := map[string]func(string) error{
+		"POST /jobs/{name}/pause":  manager.PauseJob,
+		"POST /jobs/{name}/resume": manager.ResumeJob,
+		"POST /jobs/{name}/run":    manager.RunNow,
+		"POST /tags/{name}/pause":  manager.PauseByTag,
+		"POST /tags/{name}/resume": manager.ResumeByTag,
+	}
+	for pattern, action := range actions {
+		mux.HandleFunc(pattern, handleJobAction(action))
+	}
+
+	return mux
+}
+
+// handleJobAction tạo handler gọi action với tên job hoặc tag trong đường dẫn.
+func handleJobAction(action func(string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := action(r.PathValue("name")); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrJobNotFound) {
+				status = http.StatusNotFound
+			}
+			writeJSON(w, status, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}
+}
+
+// writeJSON ghi value dưới dạng JSON với mã trạng thái status.
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// Jitter đặt độ trễ ngẫu nhiên tối đa trước mỗi lần chạy của công việc đang cấu hình.
+func (m *manager) Jitter(maxDelay time.Duration) Manager {
+	m.updateChain(func(spec *jobSpec) {
+		spec.jitter = maxDelay
+	})
+	return m
+}
+
+// Spread đặt độ trễ cố định trong khoảng window, tính từ hash của tên job,
+// trước mỗi lần chạy của công việc đang cấu hình.
+func (m *manager) Spread(window time.Duration) Manager {
+	m.updateChain(func(spec *jobSpec) {
+		spec.spread = window
+	})
+	return m
+}
+
+// validateDelay kiểm tra jitter và spread của job: không được âm, và tổng độ trễ
+// tối đa phải ngắn hơn khoảng cách giữa hai lần chạy để các lần chạy không chồng lên nhau.
+func (s jobSpec) validateDelay() error {
+	if s.jitter < 0 {
+		return fmt.Errorf("%w: jitter %v", ErrInvalidJobDelay, s.jitter)
+	}
+	if s.spread < 0 {
+		return fmt.Errorf("%w: spread window %v", ErrInvalidJobDelay, s.spread)
+	}
+	if period, ok := s.period(); ok && s.maxDelay() >= period {
+		return fmt.Errorf("%w: jitter %v and spread window %v must be shorter than the %v between runs",
+			ErrInvalidJobDelay, s.jitter, s.spread, period)
+	}
+	return nil
+}
+
+// maxDelay trả về độ trễ tối đa trước một lần chạy của job.
+func (s jobSpec) maxDelay() time.Duration {
+	return s.jitter + s.spread
+}
+
+// runDelay trả về độ trễ trước lần chạy của job: phần spread giống nhau ở mọi lần
+// chạy của cùng một job trên mọi node, phần jitter ngẫu nhiên mỗi lần chạy.
+func (s jobSpec) runDelay(job *gocron.Job) time.Duration {
+	var delay time.Duration
+	if s.spread > 0 && job != nil {
+		delay += spreadOffset(job.GetName(), s.spread)
+	}
+	if s.jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(s.jitter)))
+	}
+	return delay
+}
+
+// spreadOffset trả về độ lệch trong khoảng [0, window) tính từ hash FNV-1a của key.
+func spreadOffset(key string, window time.Duration) time.Duration {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return time.Duration(hash.Sum64() % uint64(window))
+}
+
+// lockAfterDelay chờ độ trễ của job rồi mới lấy khóa phân tán, để các instance
+// tranh khóa ở các thời điểm khác nhau. Khóa lấy được được giữ tới hết độ trễ
+// tối đa tính từ lúc job được kích hoạt, nên instance chờ lâu hơn thấy khóa bận
+// và không chạy lại lần chạy đó.
+func (m *manager) lockAfterDelay(ctx context.Context, locker gocron.Locker, key string, control *jobControl) (gocron.Lock, error) {
+	triggeredAt := m.now()
+	tracked := &clockRun{dispatch: m.claimDispatch()}
+
+	// Dừng chờ khi job bị xóa (ctx của gocron) hoặc khi scheduler Stop()
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(m.runContext(), cancel)
+	defer stop()
+
+	if !m.sleep(withClockRun(waitCtx, tracked), control.delay()) {
+		tracked.finish()
+		return nil, waitCtx.Err()
+	}
+
+	lock, err := locker.Lock(ctx, key)
+	if err != nil || lock == nil {
+		tracked.finish()
+		return lock, err
+	}
+
+	// Lần chạy tiếp tục dispatch của Clock và không chờ độ trễ thêm lần nữa
+	control.addLockDelayed()
+	m.requeueDispatch(tracked.dispatch)
+	return &delayedLock{Lock: lock, m: m, releaseAt: triggeredAt.Add(control.window)}, nil
+}
+
+// delayedLock là khóa lấy sau độ trễ của job, chỉ được giải phóng khi hết độ trễ
+// tối đa của lần chạy.
+type delayedLock struct {
+	gocron.Lock
+	m         *manager
+	releaseAt time.Time
+}
+
+// Unlock giải phóng khóa, hoặc hẹn giải phóng khi hết độ trễ tối đa của lần chạy.
+func (l *delayedLock) Unlock(ctx context.Context) error {
+	remaining := l.releaseAt.Sub(l.m.now())
+	if remaining <= 0 {
+		return l.Lock.Unlock(ctx)
+	}
+
+	release := func() { _ = l.Lock.Unlock(context.Background()) }
+	if l.m.clock == nil {
+		time.AfterFunc(remaining, release)
+	} else {
+		l.m.clock.AfterFunc(remaining, release)
+	}
+	return nil
+}
+
+// Error constants cho jitter và spread
+var (
+	// ErrInvalidJobDelay được trả về khi jitter hoặc spread window âm, hoặc khi
+	// tổng của chúng không ngắn hơn khoảng cách giữa hai lần chạy của job.
+	ErrInvalidJobDelay = errors.New("scheduler: invalid job jitter or spread")
+)
@@ -0,0 +1,207 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSpreadOffset(t *testing.T) {
+	window := 5 * time.Minute
+
+	offset := spreadOffset("cache.cleanup", window)
+	if offset < 0 || offset >= window {
+		t.Fatalf("Expected offset within window, got %v", offset)
+	}
+	if again := spreadOffset("cache.cleanup", window); again != offset {
+		t.Errorf("Expected stable offset, got %v and %v", offset, again)
+	}
+
+	// Các job khác tên được dàn ra trong window
+	offsets := make(map[time.Duration]bool)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		offsets[spreadOffset(name, window)] = true
+	}
+	if len(offsets) < 4 {
+		t.Errorf("Expected offsets spread across the window, got %d distinct values", len(offsets))
+	}
+}
+
+func TestJobSpecRunDelay(t *testing.T) {
+	spec := jobSpec{jitter: 50 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		if delay := spec.runDelay(nil); delay < 0 || delay >= spec.jitter {
+			t.Fatalf("Expected jitter within [0, %v), got %v", spec.jitter, delay)
+		}
+	}
+
+	if delay := (jobSpec{}).runDelay(nil); delay != 0 {
+		t.Errorf("Expected no delay without jitter or spread, got %v", delay)
+	}
+}
+
+func TestSchedulerSpreadDelaysRun(t *testing.T) {
+	sched := NewScheduler()
+	window := 300 * time.Millisecond
+	offset := spreadOffset("spread", window)
+
+	var runs atomic.Int32
+	if _, err := sched.Every(1).Hours().Name("spread").Spread(window).Do(func() { runs.Add(1) }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if schedule := sched.Jobs()[0].Schedule; schedule != "every 1h0m0s, spread 300ms" {
+		t.Errorf("Unexpected schedule description: %q", schedule)
+	}
+
+	startedAt := time.Now()
+	sched.StartAsync()
+	defer sched.Stop()
+
+	waitFor(t, func() bool { return runs.Load() == 1 }, "spread job did not run")
+	if lastRun := sched.Jobs()[0].LastRun; lastRun.Sub(startedAt) < offset {
+		t.Errorf("Expected run delayed by at least %v, ran after %v", offset, lastRun.Sub(startedAt))
+	}
+}
+
+func TestSchedulerStopCancelsDelayedRun(t *testing.T) {
+	sched := NewScheduler()
+
+	var runs atomic.Int32
+	if _, err := sched.Every(1).Hours().Jitter(30 * time.Minute).Spread(20 * time.Minute).Do(func() { runs.Add(1) }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	time.Sleep(50 * time.Millisecond)
+	sched.Stop()
+
+	if got := runs.Load(); got != 0 {
+		t.Errorf("Expected delayed run to be cancelled by Stop, got %d runs", got)
+	}
+}
+
+func TestSchedulerInvalidJitter(t *testing.T) {
+	sched := NewScheduler()
+
+	if _, err := sched.Every(1).Hours().Jitter(-time.Second).Do(func() {}); !errors.Is(err, ErrInvalidJobDelay) {
+		t.Errorf("Expected ErrInvalidJobDelay, got %v", err)
+	}
+	if _, err := sched.Every(1).Hours().Spread(-time.Second).Do(func() {}); !errors.Is(err, ErrInvalidJobDelay) {
+		t.Errorf("Expected ErrInvalidJobDelay, got %v", err)
+	}
+	// Độ trễ tối đa phải ngắn hơn khoảng cách giữa hai lần chạy
+	if _, err := sched.Every(1).Hours().Jitter(40 * time.Minute).Spread(20 * time.Minute).Do(func() {}); !errors.Is(err, ErrInvalidJobDelay) {
+		t.Errorf("Expected ErrInvalidJobDelay for delay overlapping the next run, got %v", err)
+	}
+	if _, err := sched.Cron("*/5 * * * *").Spread(5 * time.Minute).Do(func() {}); !errors.Is(err, ErrInvalidJobDelay) {
+		t.Errorf("Expected ErrInvalidJobDelay for spread equal to the cron interval, got %v", err)
+	}
+	if jobs := sched.GetScheduler().Jobs(); len(jobs) != 0 {
+		t.Errorf("Expected rejected jobs to be removed, got %d jobs", len(jobs))
+	}
+}
+
+func TestJobSpecPeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   jobSpec
+		period time.Duration
+		ok     bool
+	}{
+		{"every", jobSpec{every: 5, unit: time.Minute}, 5 * time.Minute, true},
+		{"cron", jobSpec{cron: "*/15 * * * *"}, 15 * time.Minute, true},
+		{"cron weekdays", jobSpec{cron: "0 9 * * 1-5"}, 24 * time.Hour, true},
+		{"cron with seconds", jobSpec{cron: "*/10 * * * * *", withSeconds: true}, 10 * time.Second, true},
+		{"daily at", jobSpec{every: 1, unit: 24 * time.Hour, at: []string{"10:00"}}, 24 * time.Hour, true},
+		{"several at", jobSpec{every: 1, unit: 24 * time.Hour, at: []string{"10:00", "18:00"}}, 0, false},
+		{"once", jobSpec{once: time.Now()}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, ok := tt.spec.period()
+			if ok != tt.ok || period != tt.period {
+				t.Errorf("Expected period %v (%v), got %v (%v)", tt.period, tt.ok, period, ok)
+			}
+		})
+	}
+}
+
+func TestSpreadOffsetIgnoresNode(t *testing.T) {
+	window := time.Hour
+
+	// Spread chỉ phụ thuộc tên job nên mọi node dùng cùng độ lệch cho một job
+	want := spreadOffset("cache.cleanup", window)
+	for _, node := range []string{"node-a", "node-b"} {
+		sched := NewScheduler().(*manager)
+		sched.node = node
+		if _, err := sched.Every(2).Hours().Name("cache.cleanup").Spread(window).Do(func() {}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		if delay := sched.controlByName("cache.cleanup").delay(); delay != want {
+			t.Errorf("Expected offset %v on %s, got %v", want, node, delay)
+		}
+	}
+}
+
+func TestSchedulerDelaysBeforeDistributedLock(t *testing.T) {
+	locker, err := NewMemoryLocker()
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+	window := 400 * time.Millisecond
+
+	var runs atomic.Int32
+	for _, node := range []string{"node-c", "node-d"} {
+		sched := NewScheduler().(*manager)
+		sched.node = node
+		sched.WithDistributedLocker(locker)
+		if _, err := sched.Every(1).Hours().Name("report").Spread(window).Do(func() { runs.Add(1) }); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		sched.StartAsync()
+		defer sched.Stop()
+	}
+
+	// Các node chờ spread của job (~331ms) trước khi tranh khóa
+	time.Sleep(100 * time.Millisecond)
+	if _, held := FencingToken(locker, "report"); held {
+		t.Error("Expected the lock to be free while the nodes wait for their spread")
+	}
+
+	// Node đến sau thấy khóa vẫn bị giữ tới hết window và không chạy lại
+	time.Sleep(window)
+	if got := runs.Load(); got != 1 {
+		t.Errorf("Expected exactly one run across nodes, got %d", got)
+	}
+}
+
+func TestControlByNameAfterReapplyingJob(t *testing.T) {
+	RegisterJobHandler("test.respread", func(ctx context.Context) error { return nil })
+	defer RegisterJobHandler("test.respread", nil)
+
+	sched := NewScheduler().(*manager)
+	job := JobConfig{Name: "respread", Interval: "1h", Spread: "10m", Target: "test.respread"}
+	if err := sched.ApplyJobs([]JobConfig{job}); err != nil {
+		t.Fatalf("Failed to apply jobs: %v", err)
+	}
+
+	// Job được tạo lại với spread mới, locker phải dùng window của job mới
+	job.Spread = "20m"
+	if err := sched.ApplyJobs([]JobConfig{job}); err != nil {
+		t.Fatalf("Failed to re-apply jobs: %v", err)
+	}
+
+	control := sched.controlByName("respread")
+	if control == nil {
+		t.Fatal("Expected control for the re-applied job")
+	}
+	if control.window != 20*time.Minute {
+		t.Errorf("Expected window 20m from the re-applied job, got %v", control.window)
+	}
+	if len(sched.controls) != 1 {
+		t.Errorf("Expected the replaced job control to be pruned, got %d controls", len(sched.controls))
+	}
+}
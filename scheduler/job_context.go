@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// JobOption cấu hình cách chạy job tạo bằng DoWithContext.
+type JobOption func(*jobOptions)
+
+// jobOptions chứa các tùy chọn chạy job.
+type jobOptions struct {
+	timeout time.Duration
+	retry   RetryPolicy
+}
+
+// RetryPolicy xác định cách thử lại job khi trả về lỗi, với thời gian chờ
+// tăng theo cấp số nhân giữa các lần thử.
+type RetryPolicy struct {
+	// MaxRetries là số lần thử lại tối đa sau lần chạy đầu tiên
+	MaxRetries int
+
+	// InitialBackoff là thời gian chờ trước lần thử lại đầu tiên
+	InitialBackoff time.Duration
+
+	// MaxBackoff là thời gian chờ tối đa giữa các lần thử, 0 là không giới hạn
+	MaxBackoff time.Duration
+
+	// Multiplier là hệ số nhân thời gian chờ sau mỗi lần thử, tối thiểu 1
+	Multiplier float64
+}
+
+// DefaultRetryPolicy trả về chính sách thử lại mặc định: 3 lần, bắt đầu từ 1 giây,
+// nhân đôi sau mỗi lần và tối đa 1 phút.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     2,
+	}
+}
+
+// backoff trả về thời gian chờ trước lần thử lại thứ retry (bắt đầu từ 1).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(delay)
+}
+
+// WithJobTimeout giới hạn thời gian của mỗi lần chạy; context của job bị hủy khi hết hạn.
+func WithJobTimeout(timeout time.Duration) JobOption {
+	return func(o *jobOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithJobRetry thử lại job theo policy khi job trả về lỗi hoặc panic.
+// Thời gian chờ giữa các lần thử được tính theo Clock của scheduler (xem WithClock).
+// Việc thử lại dừng ngay khi scheduler Stop().
+func WithJobRetry(policy RetryPolicy) JobOption {
+	return func(o *jobOptions) {
+		o.retry = policy
+	}
+}
+
+// PanicError là lỗi được tạo khi job panic, giữ giá trị panic và stack trace.
+type PanicError struct {
+	// Value là giá trị được truyền vào panic
+	Value interface{}
+
+	// Stack là stack trace tại thời điểm panic
+	Stack []byte
+}
+
+// Error trả về thông điệp lỗi của panic.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("scheduler: job panicked: %v", e.Value)
+}
+
+// DoWithContext đặt hàm nhận context để thực thi cho công việc.
+//
+// Example:
+//
+//	sched.Every(5).Minutes().Name("sync").DoWithContext(func(ctx context.Context) error {
+//		return syncService.Run(ctx)
+//	}, scheduler.WithJobTimeout(time.Minute), scheduler.WithJobRetry(scheduler.DefaultRetryPolicy()))
+func (m *manager) DoWithContext(jobFun func(ctx context.Context) error, opts ...JobOption) (*gocron.Job, error) {
+	if jobFun == nil {
+		// Để gocron trả về lỗi và dọn dẹp job đang cấu hình
+		m.takeChain()
+		return m.Scheduler.Do(nil)
+	}
+
+	options := jobOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return m.schedule(functionName(jobFun), func(ctx context.Context, _ *JobRun) error {
+		return m.runWithContext(ctx, jobFun, options)
+	})
+}
+
+// runWithContext chạy job với ctx của lần chạy và thử lại theo chính sách khi gặp lỗi.
+func (m *manager) runWithContext(ctx context.Context, jobFun func(ctx context.Context) error, options jobOptions) error {
+
+	for retry := 0; ; retry++ {
+		err := runAttempt(ctx, jobFun, options.timeout)
+		if err == nil || retry >= options.retry.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		// Chờ theo Clock của scheduler để fake clock điều khiển được các lần thử lại
+		if !m.sleep(ctx, options.retry.backoff(retry+1)) {
+			return err
+		}
+	}
+}
+
+// runAttempt chạy job một lần với timeout và chuyển panic thành *PanicError.
+func runAttempt(ctx context.Context, jobFun func(ctx context.Context) error, timeout time.Duration) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return jobFun(ctx)
+}
+
+// runContext trả về context dùng chung của các job đang chạy.
+func (m *manager) runContext() context.Context {
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+	if m.runCtx == nil {
+		m.runCtx, m.cancelRun = context.WithCancel(context.Background())
+	}
+	return m.runCtx
+}
+
+// startRunContext tạo context mới cho các job nếu context cũ đã bị hủy bởi Stop().
+func (m *manager) startRunContext() {
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+	if m.runCtx == nil || m.runCtx.Err() != nil {
+		m.runCtx, m.cancelRun = context.WithCancel(context.Background())
+	}
+}
+
+// stopRunContext hủy context của các job đang chạy.
+func (m *manager) stopRunContext() {
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+	if m.cancelRun != nil {
+		m.cancelRun()
+	}
+}
@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries:     5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+
+	// Multiplier nhỏ hơn 1 giữ nguyên thời gian chờ
+	constant := RetryPolicy{InitialBackoff: time.Second}
+	if got := constant.backoff(3); got != time.Second {
+		t.Errorf("Expected constant backoff, got %v", got)
+	}
+}
+
+func TestDoWithContextTimeout(t *testing.T) {
+	scheduler := NewScheduler()
+
+	result := make(chan error, 1)
+	_, err := scheduler.Every(1).Hours().DoWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		result <- ctx.Err()
+		return ctx.Err()
+	}, WithJobTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job context was not cancelled by timeout")
+	}
+}
+
+func TestDoWithContextCancelledOnStop(t *testing.T) {
+	scheduler := NewScheduler()
+
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	_, err := scheduler.Every(1).Hours().DoWithContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		result <- ctx.Err()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	<-started
+	scheduler.Stop()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job context was not cancelled by Stop")
+	}
+}
+
+func TestDoWithContextRetry(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var attempts atomic.Int32
+	done := make(chan struct{})
+	_, err := scheduler.Every(1).Hours().DoWithContext(func(ctx context.Context) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("temporary")
+		}
+		close(done)
+		return nil
+	}, WithJobRetry(RetryPolicy{MaxRetries: 3, InitialBackoff: 10 * time.Millisecond, Multiplier: 2}))
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job was not retried until success")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestDoWithContextPanicReportedAsError(t *testing.T) {
+	scheduler := NewScheduler()
+
+	job, err := scheduler.Every(1).Hours().Name("panicky").DoWithContext(func(ctx context.Context) error {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	reported := make(chan error, 1)
+	job.RegisterEventListeners(gocron.WhenJobReturnsError(func(jobName string, err error) {
+		reported <- err
+	}))
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case err := <-reported:
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected *PanicError, got %T: %v", err, err)
+		}
+		if panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+			t.Errorf("Unexpected panic error: %+v", panicErr)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Panic was not reported to event listeners")
+	}
+}
+
+func contextJobFunction(ctx context.Context) error { return nil }
+
+func TestDoWithContextKeepsFunctionName(t *testing.T) {
+	scheduler := NewScheduler()
+
+	job, err := scheduler.Every(1).Hours().DoWithContext(contextJobFunction)
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if got := job.GetName(); got != functionName(contextJobFunction) {
+		t.Errorf("Expected job name %s, got %s", functionName(contextJobFunction), got)
+	}
+
+	if _, err := scheduler.Every(1).Hours().DoWithContext(nil); err == nil {
+		t.Error("Expected error for nil job function")
+	}
+}
@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobHandler là hàm xử lý được đăng ký theo tên để các job khai báo trong
+// config có thể tham chiếu tới qua trường target.
+//
+// Context bị hủy khi scheduler dừng; lỗi trả về và panic được chuyển tới
+// các listener WhenJobReturnsError của gocron.
+type JobHandler func(ctx context.Context) error
+
+// JobConfig định nghĩa một job khai báo trong section scheduler.jobs của config.
+type JobConfig struct {
+	// Name là tên duy nhất của job
+	Name string `mapstructure:"name" yaml:"name"`
+
+	// Cron là biểu thức cron (5 trường, hoặc 6 trường nếu có giây).
+	// Chỉ được dùng một trong hai trường Cron và Interval.
+	Cron string `mapstructure:"cron" yaml:"cron"`
+
+	// Interval là khoảng thời gian lặp theo định dạng time.ParseDuration (ví dụ "5m", "1h30m")
+	Interval string `mapstructure:"interval" yaml:"interval"`
+
+	// Timezone là múi giờ IANA áp dụng cho biểu thức cron (ví dụ "Asia/Ho_Chi_Minh")
+	Timezone string `mapstructure:"timezone" yaml:"timezone"`
+
+	// Tags là danh sách tag gắn cho job
+	Tags []string `mapstructure:"tags" yaml:"tags"`
+
+	// Singleton xác định job có chạy ở chế độ singleton (không chạy đồng thời) không
+	Singleton bool `mapstructure:"singleton" yaml:"singleton"`
+
+	// Target là tên handler đã đăng ký qua RegisterJobHandler
+	Target string `mapstructure:"target" yaml:"target"`
+
+	// Jitter là độ trễ ngẫu nhiên tối đa trước mỗi lần chạy, theo định dạng time.ParseDuration
+	Jitter string `mapstructure:"jitter" yaml:"jitter"`
+
+	// Spread là khoảng thời gian dàn đều lần chạy theo hash của tên job, theo định dạng time.ParseDuration
+	Spread string `mapstructure:"spread" yaml:"spread"`
+
+	// Misfire là chính sách chạy bù các lần chạy bị lỡ khi scheduler khởi động,
+	// cần bật section misfire để lưu thời điểm chạy
+	Misfire MisfirePolicy `mapstructure:"misfire" yaml:"misfire"`
+}
+
+// configJobTagPrefix là tiền tố của tag nội bộ đánh dấu job được tạo từ config.
+const configJobTagPrefix = "config:"
+
+// jobHandlers lưu các handler được đăng ký theo tên.
+var jobHandlers = struct {
+	sync.RWMutex
+	handlers map[string]JobHandler
+}{handlers: make(map[string]JobHandler)}
+
+// RegisterJobHandler đăng ký handler theo tên để dùng làm target cho job khai báo trong config.
+// Đăng ký lại cùng tên sẽ ghi đè handler cũ.
+//
+// Example:
+//
+//	scheduler.RegisterJobHandler("reports.daily", func(ctx context.Context) error {
+//		return reportService.GenerateDaily(ctx)
+//	})
+func RegisterJobHandler(name string, handler JobHandler) {
+	jobHandlers.Lock()
+	defer jobHandlers.Unlock()
+	if handler == nil {
+		delete(jobHandlers.handlers, name)
+		return
+	}
+	jobHandlers.handlers[name] = handler
+}
+
+// lookupJobHandler trả về handler đã đăng ký theo tên.
+func lookupJobHandler(name string) (JobHandler, bool) {
+	jobHandlers.RLock()
+	defer jobHandlers.RUnlock()
+	handler, ok := jobHandlers.handlers[name]
+	return handler, ok
+}
+
+// validate kiểm tra tính hợp lệ của cấu hình job.
+func (cfg JobConfig) validate() error {
+	if cfg.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidJobConfig)
+	}
+	if (cfg.Cron == "") == (cfg.Interval == "") {
+		return fmt.Errorf("%w: job %q must define exactly one of cron or interval", ErrInvalidJobConfig, cfg.Name)
+	}
+	if cfg.Interval != "" {
+		interval, err := time.ParseDuration(cfg.Interval)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("%w: job %q has invalid interval %q", ErrInvalidJobConfig, cfg.Name, cfg.Interval)
+		}
+	}
+	if cfg.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+			return fmt.Errorf("%w: job %q has invalid timezone %q", ErrInvalidJobConfig, cfg.Name, cfg.Timezone)
+		}
+	}
+	for field, value := range map[string]string{"jitter": cfg.Jitter, "spread": cfg.Spread} {
+		if value == "" {
+			continue
+		}
+		if delay, err := time.ParseDuration(value); err != nil || delay < 0 {
+			return fmt.Errorf("%w: job %q has invalid %s %q", ErrInvalidJobConfig, cfg.Name, field, value)
+		}
+	}
+	spec := jobSpec{cron: cfg.Cron, withSeconds: len(strings.Fields(cfg.Cron)) == 6, every: cfg.Interval}
+	spec.jitter, _ = time.ParseDuration(cfg.Jitter)
+	spec.spread, _ = time.ParseDuration(cfg.Spread)
+	if err := spec.validateDelay(); err != nil {
+		return fmt.Errorf("%w: job %q: %v", ErrInvalidJobConfig, cfg.Name, err)
+	}
+	if err := cfg.Misfire.validate(); err != nil {
+		return fmt.Errorf("%w: job %q: %v", ErrInvalidJobConfig, cfg.Name, err)
+	}
+	if cfg.Target == "" {
+		return fmt.Errorf("%w: job %q has no target", ErrInvalidJobConfig, cfg.Name)
+	}
+	if _, ok := lookupJobHandler(cfg.Target); !ok {
+		return fmt.Errorf("%w: %s (job %q)", ErrJobHandlerNotFound, cfg.Target, cfg.Name)
+	}
+	return nil
+}
+
+// ApplyJobs đồng bộ các job khai báo trong config với scheduler.
+//
+// Toàn bộ danh sách được kiểm tra trước khi thay đổi scheduler. Job có định nghĩa mới
+// không hợp lệ (hoặc trùng tên) giữ nguyên định nghĩa đang chạy, và job thay đổi
+// nhưng không đăng ký được định nghĩa mới sẽ được khôi phục định nghĩa cũ.
+func (m *manager) ApplyJobs(jobs []JobConfig) error {
+	m.jobsMu.Lock()
+	defer m.jobsMu.Unlock()
+
+	if m.configJobs == nil {
+		m.configJobs = make(map[string]JobConfig)
+	}
+
+	var errs []error
+	desired := make(map[string]JobConfig, len(jobs))
+	invalid := make(map[string]bool)
+	for _, job := range jobs {
+		if err := job.validate(); err != nil {
+			errs = append(errs, err)
+			invalid[job.Name] = true
+			continue
+		}
+		if _, exists := desired[job.Name]; exists {
+			errs = append(errs, fmt.Errorf("%w: duplicate job name %q", ErrInvalidJobConfig, job.Name))
+			invalid[job.Name] = true
+			continue
+		}
+		desired[job.Name] = job
+	}
+	for name := range invalid {
+		delete(desired, name)
+	}
+
+	// Xóa các job không còn trong config hoặc đã thay đổi, giữ job có định nghĩa mới không hợp lệ
+	previous := make(map[string]JobConfig)
+	for name, applied := range m.configJobs {
+		if invalid[name] {
+			continue
+		}
+		if job, ok := desired[name]; ok && reflect.DeepEqual(job, applied) {
+			continue
+		}
+		if err := m.Scheduler.RemoveByTag(configJobTagPrefix + name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(m.configJobs, name)
+		previous[name] = applied
+	}
+
+	// Thêm các job mới hoặc đã thay đổi
+	for name, job := range desired {
+		if _, ok := m.configJobs[name]; ok {
+			continue
+		}
+		if err := m.scheduleConfigJob(job); err != nil {
+			errs = append(errs, fmt.Errorf("scheduler: failed to schedule job %q: %w", name, err))
+			job, ok := previous[name]
+			if !ok {
+				continue
+			}
+			if err := m.scheduleConfigJob(job); err != nil {
+				errs = append(errs, fmt.Errorf("scheduler: failed to restore job %q: %w", name, err))
+				continue
+			}
+		}
+		m.configJobs[name] = job
+	}
+
+	return errors.Join(errs...)
+}
+
+// scheduleConfigJob đăng ký một job khai báo vào gocron.
+func (m *manager) scheduleConfigJob(job JobConfig) error {
+	if job.Cron != "" {
+		expression := job.Cron
+		if job.Timezone != "" {
+			expression = "CRON_TZ=" + job.Timezone + " " + expression
+		}
+		if len(strings.Fields(job.Cron)) == 6 {
+			m.CronWithSeconds(expression)
+		} else {
+			m.Cron(expression)
+		}
+	} else {
+		m.Every(job.Interval)
+	}
+
+	tags := append([]string{configJobTagPrefix + job.Name}, job.Tags...)
+	m.Name(job.Name).Tag(tags...).Misfire(job.Misfire)
+	// gocron bỏ qua locker và elector với job ở chế độ singleton, nên khi chạy phân
+	// tán các lần chạy của job được tuần tự hóa trong hàm job thay vì dùng SingletonMode
+	var serial chan struct{}
+	if job.Singleton {
+		if m.locker != nil || m.elector != nil {
+			serial = make(chan struct{}, 1)
+		} else {
+			m.SingletonMode()
+		}
+	}
+	if job.Jitter != "" {
+		jitter, _ := time.ParseDuration(job.Jitter)
+		m.Jitter(jitter)
+	}
+	if job.Spread != "" {
+		spread, _ := time.ParseDuration(job.Spread)
+		m.Spread(spread)
+	}
+
+	target := job.Target
+	_, err := m.DoWithContext(func(ctx context.Context) error {
+		// Tra cứu handler tại thời điểm chạy để nhận handler đăng ký lại mới nhất
+		handler, ok := lookupJobHandler(target)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrJobHandlerNotFound, target)
+		}
+		if serial != nil {
+			select {
+			case serial <- struct{}{}:
+				defer func() { <-serial }()
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return handler(ctx)
+	})
+	return err
+}
+
+// Error constants cho job khai báo
+var (
+	// ErrInvalidJobConfig được trả về khi cấu hình job không hợp lệ.
+	ErrInvalidJobConfig = errors.New("scheduler: invalid job config")
+
+	// ErrJobHandlerNotFound được trả về khi target của job chưa được đăng ký.
+	ErrJobHandlerNotFound = errors.New("scheduler: job handler not registered")
+)
@@ -0,0 +1,242 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-fork/di"
+	configmocks "github.com/go-fork/providers/config/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJobConfigValidate(t *testing.T) {
+	RegisterJobHandler("test.validate", func(ctx context.Context) error { return nil })
+	defer RegisterJobHandler("test.validate", nil)
+
+	tests := []struct {
+		name    string
+		job     JobConfig
+		wantErr error
+	}{
+		{"valid cron", JobConfig{Name: "a", Cron: "*/5 * * * *", Target: "test.validate"}, nil},
+		{"valid interval", JobConfig{Name: "a", Interval: "30s", Target: "test.validate"}, nil},
+		{"missing name", JobConfig{Cron: "* * * * *", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"cron and interval", JobConfig{Name: "a", Cron: "* * * * *", Interval: "1m", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"no schedule", JobConfig{Name: "a", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"invalid interval", JobConfig{Name: "a", Interval: "soon", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"invalid timezone", JobConfig{Name: "a", Cron: "* * * * *", Timezone: "Mars/Base", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"invalid misfire", JobConfig{Name: "a", Cron: "* * * * *", Target: "test.validate", Misfire: MisfirePolicy{Mode: MisfireRunAll}}, ErrInvalidJobConfig},
+		{"valid jitter and spread", JobConfig{Name: "a", Interval: "5m", Jitter: "30s", Spread: "4m", Target: "test.validate"}, nil},
+		{"delay overlapping next run", JobConfig{Name: "a", Interval: "5m", Jitter: "30s", Spread: "5m", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"spread as long as cron interval", JobConfig{Name: "a", Cron: "0 * * * *", Spread: "1h", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"invalid jitter", JobConfig{Name: "a", Interval: "5m", Jitter: "-1s", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"invalid spread", JobConfig{Name: "a", Interval: "5m", Spread: "later", Target: "test.validate"}, ErrInvalidJobConfig},
+		{"missing target", JobConfig{Name: "a", Interval: "1m"}, ErrInvalidJobConfig},
+		{"unknown target", JobConfig{Name: "a", Interval: "1m", Target: "test.unknown"}, ErrJobHandlerNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.job.validate()
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestApplyJobs(t *testing.T) {
+	RegisterJobHandler("test.apply", func(ctx context.Context) error { return nil })
+	defer RegisterJobHandler("test.apply", nil)
+
+	scheduler := NewScheduler()
+
+	err := scheduler.ApplyJobs([]JobConfig{
+		{Name: "cleanup", Cron: "0 3 * * *", Timezone: "Asia/Ho_Chi_Minh", Tags: []string{"maintenance"}, Target: "test.apply"},
+		{Name: "heartbeat", Interval: "1m", Singleton: true, Target: "test.apply"},
+		{Name: "broken", Interval: "1m", Target: "test.missing"},
+	})
+	if !errors.Is(err, ErrJobHandlerNotFound) {
+		t.Fatalf("Expected ErrJobHandlerNotFound for invalid job, got %v", err)
+	}
+
+	jobs, err := scheduler.FindJobsByTag("maintenance")
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("Expected cleanup job tagged maintenance, got %v (%v)", jobs, err)
+	}
+	if jobs[0].GetName() != "cleanup" {
+		t.Errorf("Expected job name cleanup, got %s", jobs[0].GetName())
+	}
+	if _, err := scheduler.FindJobsByTag(configJobTagPrefix + "heartbeat"); err != nil {
+		t.Errorf("Expected heartbeat job: %v", err)
+	}
+
+	// Áp dụng lại: thay đổi cleanup, giữ heartbeat, xóa job không còn trong config
+	original, _ := scheduler.FindJobsByTag(configJobTagPrefix + "heartbeat")
+	err = scheduler.ApplyJobs([]JobConfig{
+		{Name: "heartbeat", Interval: "1m", Singleton: true, Target: "test.apply"},
+		{Name: "report", Cron: "0 8 * * *", Target: "test.apply"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to re-apply jobs: %v", err)
+	}
+
+	if _, err := scheduler.FindJobsByTag("maintenance"); err == nil {
+		t.Error("Removed job should no longer be scheduled")
+	}
+	current, _ := scheduler.FindJobsByTag(configJobTagPrefix + "heartbeat")
+	if len(current) != 1 || current[0] != original[0] {
+		t.Error("Unchanged job should not be rescheduled")
+	}
+	if _, err := scheduler.FindJobsByTag(configJobTagPrefix + "report"); err != nil {
+		t.Errorf("Expected new report job: %v", err)
+	}
+	if got := len(scheduler.GetScheduler().Jobs()); got != 2 {
+		t.Errorf("Expected 2 jobs, got %d", got)
+	}
+}
+
+func TestApplyJobsKeepsJobWithInvalidReload(t *testing.T) {
+	RegisterJobHandler("test.reload", func(ctx context.Context) error { return nil })
+	defer RegisterJobHandler("test.reload", nil)
+
+	scheduler := NewScheduler()
+	if err := scheduler.ApplyJobs([]JobConfig{
+		{Name: "sync", Interval: "1m", Tags: []string{"v1"}, Target: "test.reload"},
+		{Name: "report", Interval: "1h", Target: "test.reload"},
+	}); err != nil {
+		t.Fatalf("Failed to apply jobs: %v", err)
+	}
+	original, _ := scheduler.FindJobsByTag(configJobTagPrefix + "sync")
+
+	// Định nghĩa mới của sync không hợp lệ, report bị khai báo trùng tên
+	err := scheduler.ApplyJobs([]JobConfig{
+		{Name: "sync", Interval: "1m", Tags: []string{"v2"}, Target: "test.missing"},
+		{Name: "report", Interval: "1h", Target: "test.reload"},
+		{Name: "report", Interval: "2h", Target: "test.reload"},
+	})
+	if !errors.Is(err, ErrJobHandlerNotFound) || !errors.Is(err, ErrInvalidJobConfig) {
+		t.Fatalf("Expected errors for invalid and duplicate jobs, got %v", err)
+	}
+
+	current, err := scheduler.FindJobsByTag("v1")
+	if err != nil || len(current) != 1 || current[0] != original[0] {
+		t.Errorf("Job with an invalid new definition should keep running unchanged, got %v (%v)", current, err)
+	}
+	if _, err := scheduler.FindJobsByTag(configJobTagPrefix + "report"); err != nil {
+		t.Errorf("Job with a duplicate new definition should keep running: %v", err)
+	}
+	if got := len(scheduler.GetScheduler().Jobs()); got != 2 {
+		t.Errorf("Expected 2 jobs, got %d", got)
+	}
+}
+
+func TestApplyJobsRunsHandler(t *testing.T) {
+	var calls atomic.Int32
+	RegisterJobHandler("test.run", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	defer RegisterJobHandler("test.run", nil)
+
+	scheduler := NewScheduler()
+	if err := scheduler.ApplyJobs([]JobConfig{{Name: "run", Interval: "1h", Target: "test.run"}}); err != nil {
+		t.Fatalf("Failed to apply jobs: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	waitFor(t, func() bool { return calls.Load() > 0 }, "Declarative job handler should run")
+}
+
+func TestApplyJobsSingletonUsesDistributedLocker(t *testing.T) {
+	var runs atomic.Int32
+	RegisterJobHandler("test.singleton", func(ctx context.Context) error {
+		runs.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	defer RegisterJobHandler("test.singleton", nil)
+
+	locker, err := NewMemoryLocker()
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+	for _, node := range []string{"node-a", "node-b"} {
+		sched := NewScheduler().(*manager)
+		sched.node = node
+		sched.WithDistributedLocker(locker)
+		job := JobConfig{Name: "singleton", Interval: "1h", Singleton: true, Target: "test.singleton"}
+		if err := sched.ApplyJobs([]JobConfig{job}); err != nil {
+			t.Fatalf("Failed to apply jobs: %v", err)
+		}
+		sched.StartAsync()
+		defer sched.Stop()
+	}
+
+	// Job singleton vẫn phải tranh khóa phân tán nên chỉ chạy trên một node
+	waitFor(t, func() bool { return runs.Load() > 0 }, "singleton job did not run")
+	time.Sleep(300 * time.Millisecond)
+	if got := runs.Load(); got != 1 {
+		t.Errorf("Expected the singleton job to run on exactly one node, got %d runs", got)
+	}
+}
+
+func TestServiceProviderBootLoadsJobs(t *testing.T) {
+	RegisterJobHandler("test.boot", func(ctx context.Context) error { return nil })
+	defer RegisterJobHandler("test.boot", nil)
+
+	container := di.New()
+	configManager := configmocks.NewMockManager(t)
+	container.Instance("config", configManager)
+
+	configManager.On("UnmarshalKey", "scheduler", mock.Anything).Run(func(args mock.Arguments) {
+		cfg := args.Get(1).(*Config)
+		cfg.AutoStart = false
+		cfg.Jobs = []JobConfig{{Name: "boot", Interval: "1h", Target: "test.boot"}}
+	}).Return(nil)
+
+	var onChange func(fsnotify.Event)
+	configManager.On("OnConfigChange", mock.Anything).Run(func(args mock.Arguments) {
+		onChange = args.Get(0).(func(fsnotify.Event))
+	}).Return()
+
+	app := &MockApp{container: container}
+	provider := NewServiceProvider()
+	provider.Register(app)
+	provider.Boot(app)
+
+	scheduler := container.MustMake("scheduler").(Manager)
+	if _, err := scheduler.FindJobsByTag(configJobTagPrefix + "boot"); err != nil {
+		t.Fatalf("Expected job loaded from config: %v", err)
+	}
+	if scheduler.IsRunning() {
+		t.Error("Scheduler should not auto start")
+	}
+
+	// Config thay đổi: job cũ bị thay bằng job mới
+	configManager.On("UnmarshalKey", "scheduler.jobs", mock.Anything).Run(func(args mock.Arguments) {
+		jobs := args.Get(1).(*[]JobConfig)
+		*jobs = []JobConfig{{Name: "reloaded", Interval: "2h", Target: "test.boot"}}
+	}).Return(nil)
+
+	if onChange == nil {
+		t.Fatal("Expected OnConfigChange callback to be registered")
+	}
+	onChange(fsnotify.Event{Name: "app.yaml", Op: fsnotify.Write})
+
+	if _, err := scheduler.FindJobsByTag(configJobTagPrefix + "boot"); err == nil {
+		t.Error("Old job should be removed after config change")
+	}
+	if _, err := scheduler.FindJobsByTag(configJobTagPrefix + "reloaded"); err != nil {
+		t.Errorf("Expected reloaded job: %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// FencedLock là một gocron.Lock mang owner token và fencing token.
+//
+// Owner token xác định instance đang giữ khóa, đảm bảo chỉ chủ sở hữu mới
+// có thể gia hạn hoặc giải phóng khóa. Fencing token là số tăng dần mỗi lần
+// khóa được cấp, cho phép hệ thống downstream từ chối thao tác từ các
+// instance đã mất khóa (ví dụ: khóa hết hạn trong khi job vẫn đang chạy).
+type FencedLock interface {
+	gocron.Lock
+
+	// Key trả về khóa (tên job) được lock.
+	Key() string
+
+	// Token trả về owner token duy nhất của lần lấy khóa này.
+	Token() string
+
+	// FencingToken trả về fencing token tăng dần của lần lấy khóa này.
+	FencingToken() int64
+
+	// Lost trả về channel được đóng khi phát hiện khóa đã mất
+	// (hết hạn và bị instance khác chiếm giữ).
+	Lost() <-chan struct{}
+}
+
+// FencedLocker là một gocron.Locker cho phép truy xuất khóa đang được giữ
+// bởi instance hiện tại, để job có thể đọc fencing token của mình.
+type FencedLocker interface {
+	gocron.Locker
+
+	// HeldLock trả về khóa đang được instance hiện tại giữ cho key.
+	HeldLock(key string) (FencedLock, bool)
+}
+
+// heldLocks lưu các khóa đang được giữ theo key, dùng chung cho các locker.
+type heldLocks struct {
+	mu    sync.RWMutex
+	locks map[string]FencedLock
+}
+
+// set lưu khóa đang được giữ cho key.
+func (h *heldLocks) set(key string, lock FencedLock) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.locks == nil {
+		h.locks = make(map[string]FencedLock)
+	}
+	h.locks[key] = lock
+}
+
+// remove xóa khóa khỏi danh sách nếu nó vẫn là khóa đang được giữ cho key.
+func (h *heldLocks) remove(key string, lock FencedLock) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if current, ok := h.locks[key]; ok && current == lock {
+		delete(h.locks, key)
+	}
+}
+
+// get trả về khóa đang được giữ cho key.
+func (h *heldLocks) get(key string) (FencedLock, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	lock, ok := h.locks[key]
+	return lock, ok
+}
+
+// FencingToken trả về fencing token của khóa mà locker đang giữ cho key.
+// Trả về false nếu locker không hỗ trợ fencing hoặc không giữ khóa cho key.
+func FencingToken(locker gocron.Locker, key string) (int64, bool) {
+	fenced, ok := locker.(FencedLocker)
+	if !ok {
+		return 0, false
+	}
+	lock, ok := fenced.HeldLock(key)
+	if !ok {
+		return 0, false
+	}
+	return lock.FencingToken(), true
+}
+
+// leaseLock là khóa dạng lease dùng chung cho các locker: khóa có thời hạn,
+// được gia hạn định kỳ bởi một goroutine riêng và chỉ chủ sở hữu (owner token)
+// mới có thể gia hạn hoặc giải phóng.
+type leaseLock struct {
+	key          string
+	token        string
+	fencingToken int64
+	held         *heldLocks
+	renew        func(ctx context.Context) (bool, error)
+	release      func(ctx context.Context) (bool, error)
+	cancelRenew  context.CancelFunc
+	renewContext context.Context
+	lost         chan struct{}
+	lostOnce     sync.Once
+}
+
+// newLeaseLock tạo khóa, đăng ký vào danh sách khóa đang giữ và bắt đầu
+// vòng lặp gia hạn sau mỗi 2/3 thời hạn của khóa.
+//
+// renew và release trả về false khi owner token không còn khớp.
+func newLeaseLock(held *heldLocks, key, token string, fencingToken int64, duration time.Duration,
+	renew, release func(ctx context.Context) (bool, error)) *leaseLock {
+	renewCtx, cancelFn := context.WithCancel(context.Background())
+	lock := &leaseLock{
+		key:          key,
+		token:        token,
+		fencingToken: fencingToken,
+		held:         held,
+		renew:        renew,
+		release:      release,
+		renewContext: renewCtx,
+		cancelRenew:  cancelFn,
+		lost:         make(chan struct{}),
+	}
+	held.set(key, lock)
+
+	// Bắt đầu quá trình tự động gia hạn khóa
+	go lock.startRenewLoop(duration / 3 * 2)
+
+	return lock
+}
+
+// Key trả về khóa (tên job) được lock.
+func (l *leaseLock) Key() string {
+	return l.key
+}
+
+// Token trả về owner token của khóa.
+func (l *leaseLock) Token() string {
+	return l.token
+}
+
+// FencingToken trả về fencing token của khóa.
+func (l *leaseLock) FencingToken() int64 {
+	return l.fencingToken
+}
+
+// Lost trả về channel được đóng khi khóa bị mất.
+func (l *leaseLock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// markLost đánh dấu khóa đã mất.
+func (l *leaseLock) markLost() {
+	l.lostOnce.Do(func() {
+		close(l.lost)
+	})
+	l.held.remove(l.key, l)
+}
+
+// startRenewLoop tự động gia hạn khóa trước khi hết hạn.
+// Điều này ngăn khóa hết hạn trong khi job vẫn đang chạy. Việc gia hạn chỉ
+// thành công khi owner token còn khớp; nếu không, khóa được đánh dấu là đã mất.
+func (l *leaseLock) startRenewLoop(renewInterval time.Duration) {
+	ticker := time.NewTicker(renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.renewContext.Done():
+			return
+		case <-ticker.C:
+			// Sử dụng context với timeout để tránh block vô hạn
+			ctx, cancel := context.WithTimeout(l.renewContext, 5*time.Second)
+			renewed, err := l.renew(ctx)
+			cancel()
+			if err != nil {
+				// Lỗi tạm thời, thử lại ở lần tick tiếp theo
+				continue
+			}
+			if !renewed {
+				// Khóa đã hết hạn hoặc bị instance khác chiếm giữ
+				l.markLost()
+				l.cancelRenew()
+				return
+			}
+		}
+	}
+}
+
+// Unlock triển khai phương thức Unlock của gocron.Lock interface.
+//
+// Trả về ErrLockNotHeld nếu khóa không còn thuộc về owner token này.
+func (l *leaseLock) Unlock(ctx context.Context) error {
+	// Dừng vòng lặp gia hạn trước
+	l.cancelRenew()
+	l.held.remove(l.key, l)
+
+	released, err := l.release(ctx)
+	if err != nil {
+		return err
+	}
+	if !released {
+		l.markLost()
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// newLockToken tạo một owner token ngẫu nhiên.
+func newLockToken() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
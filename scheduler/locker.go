@@ -11,23 +11,48 @@ import (
 
 // RedisLockerOptions đã được di chuyển vào config.go
 
+// Các Lua script đảm bảo thao tác trên khóa là nguyên tử và chỉ chủ sở hữu
+// (owner token khớp) mới có thể gia hạn hoặc giải phóng khóa.
+var (
+	// redisAcquireScript đặt khóa với owner token nếu chưa tồn tại và trả về
+	// fencing token mới, hoặc 0 nếu khóa đang được giữ.
+	redisAcquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`)
+
+	// redisUnlockScript chỉ xóa khóa khi owner token khớp.
+	redisUnlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+	// redisRenewScript chỉ gia hạn khóa khi owner token khớp.
+	redisRenewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
+
 // redisLocker triển khai gocron.Locker interface sử dụng Redis làm backend.
 type redisLocker struct {
 	client  *redis.Client
 	options RedisLockerOptionsTime
-}
-
-// redisLock triển khai gocron.Lock interface.
-type redisLock struct {
-	locker       *redisLocker
-	key          string
-	cancelRenew  context.CancelFunc
-	renewContext context.Context
+	held    heldLocks
 }
 
 // NewRedisLocker tạo một Redis Locker mới để sử dụng với gocron.
 // Nó có thể được chuyển vào phương thức WithDistributedLocker của scheduler.
 //
+// Mỗi lần lấy khóa sẽ lưu một owner token ngẫu nhiên và cấp một fencing token
+// tăng dần. Locker trả về cũng triển khai FencedLocker.
+//
 // Example:
 //
 //	redisClient := redis.NewClient(&redis.Options{
@@ -79,11 +104,17 @@ func NewRedisLocker(client *redis.Client, opts ...RedisLockerOptions) (gocron.Lo
 // Lock triển khai phương thức Lock của gocron.Locker interface.
 func (r *redisLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
 	fullKey := r.options.KeyPrefix + key
+	fenceKey := fullKey + ":fence"
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
 	retries := 0
 
 	for {
-		// Cố gắng set key với expiration
-		success, err := r.client.SetNX(ctx, fullKey, "locked", r.options.LockDuration).Result()
+		// Cố gắng set key với owner token, expiration và cấp fencing token
+		fencingToken, err := redisAcquireScript.Run(ctx, r.client,
+			[]string{fullKey, fenceKey}, token, r.options.LockDuration.Milliseconds()).Int64()
 
 		// Nếu có lỗi không liên quan đến kết nối
 		if err != nil && err != redis.ErrClosed && err != context.Canceled {
@@ -91,19 +122,8 @@ func (r *redisLocker) Lock(ctx context.Context, key string) (gocron.Lock, error)
 		}
 
 		// Nếu lock thành công
-		if success {
-			renewCtx, cancelFn := context.WithCancel(context.Background())
-			lock := &redisLock{
-				locker:       r,
-				key:          key,
-				renewContext: renewCtx,
-				cancelRenew:  cancelFn,
-			}
-
-			// Bắt đầu quá trình tự động gia hạn khóa
-			go lock.startRenewLoop()
-
-			return lock, nil
+		if err == nil && fencingToken > 0 {
+			return r.newLock(key, fullKey, token, fencingToken), nil
 		}
 
 		// Nếu đã thử tối đa số lần
@@ -122,43 +142,27 @@ func (r *redisLocker) Lock(ctx context.Context, key string) (gocron.Lock, error)
 	}
 }
 
-// startRenewLoop bắt đầu một goroutine để tự động gia hạn khóa trước khi hết hạn.
-// Điều này ngăn khóa hết hạn trong khi job vẫn đang chạy.
-func (r *redisLock) startRenewLoop() {
-	renewInterval := r.locker.options.LockDuration / 3 * 2 // Gia hạn sau 2/3 thời gian hết hạn
-	ticker := time.NewTicker(renewInterval)
-	defer ticker.Stop()
-
-	fullKey := r.locker.options.KeyPrefix + r.key
-
-	for {
-		select {
-		case <-r.renewContext.Done():
-			return
-		case <-ticker.C:
-			// Gia hạn khóa bằng cách đặt thời gian hết hạn mới
-			// Sử dụng context với timeout để tránh block vô hạn
-			ctx, cancel := context.WithTimeout(r.renewContext, 5*time.Second)
-			err := r.locker.client.Expire(ctx, fullKey, r.locker.options.LockDuration).Err()
-			cancel()
-			if err != nil {
-				// Log lỗi nếu cần thiết, nhưng không làm gián đoạn vòng lặp
-				continue
-			}
-		}
-	}
+// HeldLock trả về khóa đang được instance hiện tại giữ cho key.
+func (r *redisLocker) HeldLock(key string) (FencedLock, bool) {
+	return r.held.get(key)
 }
 
-// Unlock triển khai phương thức Unlock của gocron.Lock interface.
-func (r *redisLock) Unlock(ctx context.Context) error {
-	// Dừng vòng lặp gia hạn trước
-	if r.cancelRenew != nil {
-		r.cancelRenew()
+// newLock tạo khóa với gia hạn compare-and-extend và giải phóng compare-and-delete.
+//
+// Khóa chỉ bị xóa khi owner token còn khớp. Nếu khóa đã hết hạn và được
+// instance khác lấy, Unlock trả về ErrLockNotHeld và không ảnh hưởng đến
+// khóa của instance đó.
+func (r *redisLocker) newLock(key, fullKey, token string, fencingToken int64) *leaseLock {
+	renew := func(ctx context.Context) (bool, error) {
+		renewed, err := redisRenewScript.Run(ctx, r.client,
+			[]string{fullKey}, token, r.options.LockDuration.Milliseconds()).Int64()
+		return renewed == 1, err
 	}
-
-	// Sau đó xóa khóa từ Redis
-	fullKey := r.locker.options.KeyPrefix + r.key
-	return r.locker.client.Del(ctx, fullKey).Err()
+	release := func(ctx context.Context) (bool, error) {
+		deleted, err := redisUnlockScript.Run(ctx, r.client, []string{fullKey}, token).Int64()
+		return deleted == 1, err
+	}
+	return newLeaseLock(&r.held, key, token, fencingToken, r.options.LockDuration, renew, release)
 }
 
 // validateRedisLockerOptions kiểm tra tính hợp lệ của các tùy chọn Redis Locker.
@@ -200,4 +204,32 @@ var (
 
 	// ErrInvalidKeyPrefix được trả về khi KeyPrefix không hợp lệ.
 	ErrInvalidKeyPrefix = errors.New("scheduler: invalid key prefix")
+
+	// ErrMongoManagerNil được trả về khi MongoDB manager nil.
+	ErrMongoManagerNil = errors.New("scheduler: mongodb manager is nil")
+
+	// ErrFailedToConnectToMongo được trả về khi không thể khởi tạo collection khóa trên MongoDB.
+	ErrFailedToConnectToMongo = errors.New("scheduler: failed to connect to mongodb")
+
+	// ErrInvalidCollection được trả về khi tên collection không hợp lệ.
+	ErrInvalidCollection = errors.New("scheduler: invalid collection name")
+
+	// ErrUnsupportedLockDriver được trả về khi driver distributed lock không được hỗ trợ.
+	ErrUnsupportedLockDriver = errors.New("scheduler: unsupported distributed lock driver")
+
+	// ErrInvalidTimezone được trả về khi múi giờ trong cấu hình không hợp lệ.
+	ErrInvalidTimezone = errors.New("scheduler: invalid timezone")
+
+	// ErrInvalidMaxConcurrentJobs được trả về khi giới hạn số job đồng thời âm.
+	ErrInvalidMaxConcurrentJobs = errors.New("scheduler: invalid max concurrent jobs")
+
+	// ErrInvalidConcurrencyMode được trả về khi chế độ giới hạn đồng thời không được hỗ trợ.
+	ErrInvalidConcurrencyMode = errors.New("scheduler: invalid concurrency mode")
+
+	// ErrSingletonModeNotDistributed được trả về khi bật singleton_mode cùng distributed
+	// locking hoặc leader election, vì gocron không khóa job ở chế độ singleton.
+	ErrSingletonModeNotDistributed = errors.New("scheduler: singleton mode cannot be combined with distributed locking or leader election")
+
+	// ErrLockNotHeld được trả về khi giải phóng một khóa không còn thuộc về instance hiện tại.
+	ErrLockNotHeld = errors.New("scheduler: lock is no longer held by this owner")
 )
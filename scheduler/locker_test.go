@@ -3,7 +3,9 @@ package scheduler
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-co-op/gocron"
 	"github.com/redis/go-redis/v9"
 )
@@ -202,3 +204,186 @@ func TestRedisLockerInterface(t *testing.T) {
 		t.Error("Lock was not unlocked")
 	}
 }
+
+// newTestRedisLocker tạo Redis locker dùng miniredis với thời gian khóa 1 giây
+func newTestRedisLocker(t *testing.T, mr *miniredis.Miniredis) FencedLocker {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	locker, err := NewRedisLocker(client, RedisLockerOptions{
+		KeyPrefix:    "test_lock:",
+		LockDuration: 1,
+		MaxRetries:   0,
+		RetryDelay:   0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create redis locker: %v", err)
+	}
+
+	fenced, ok := locker.(FencedLocker)
+	if !ok {
+		t.Fatal("Redis locker should implement FencedLocker")
+	}
+	return fenced
+}
+
+func TestRedisLockerStoresOwnerToken(t *testing.T) {
+	mr := miniredis.RunT(t)
+	locker := newTestRedisLocker(t, mr)
+	ctx := context.Background()
+
+	lock, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	fenced := lock.(FencedLock)
+	value, err := mr.Get("test_lock:job")
+	if err != nil {
+		t.Fatalf("Lock key not found: %v", err)
+	}
+	if value != fenced.Token() || value == "locked" {
+		t.Errorf("Expected owner token %q stored in redis, got %q", fenced.Token(), value)
+	}
+
+	held, ok := locker.HeldLock("job")
+	if !ok || held != fenced {
+		t.Error("HeldLock should return the acquired lock")
+	}
+
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	if mr.Exists("test_lock:job") {
+		t.Error("Lock key should be deleted after unlock")
+	}
+	if _, ok := locker.HeldLock("job"); ok {
+		t.Error("HeldLock should return false after unlock")
+	}
+}
+
+func TestRedisLockerRejectsConcurrentLock(t *testing.T) {
+	mr := miniredis.RunT(t)
+	nodeA := newTestRedisLocker(t, mr)
+	nodeB := newTestRedisLocker(t, mr)
+	ctx := context.Background()
+
+	lock, err := nodeA.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lock.Unlock(ctx)
+
+	if _, err := nodeB.Lock(ctx, "job"); err != ErrFailedToAcquireLock {
+		t.Errorf("Expected ErrFailedToAcquireLock, got %v", err)
+	}
+}
+
+func TestRedisLockerFencingTokenIncreases(t *testing.T) {
+	mr := miniredis.RunT(t)
+	locker := newTestRedisLocker(t, mr)
+	ctx := context.Background()
+
+	first, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	firstToken, ok := FencingToken(locker, "job")
+	if !ok {
+		t.Fatal("FencingToken should be available while lock is held")
+	}
+	if err := first.Unlock(ctx); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	second, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer second.Unlock(ctx)
+
+	if second.(FencedLock).FencingToken() <= firstToken {
+		t.Errorf("Expected fencing token to increase, got %d after %d",
+			second.(FencedLock).FencingToken(), firstToken)
+	}
+}
+
+func TestRedisLockerExpiryDuringLongJob(t *testing.T) {
+	mr := miniredis.RunT(t)
+	nodeA := newTestRedisLocker(t, mr)
+	nodeB := newTestRedisLocker(t, mr)
+	ctx := context.Background()
+
+	// Node A lấy khóa và bắt đầu một job dài
+	lockA, err := nodeA.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Node A failed to acquire lock: %v", err)
+	}
+
+	// Khóa của node A hết hạn trước khi kịp gia hạn (ví dụ: GC pause, mất kết nối)
+	mr.FastForward(2 * time.Second)
+
+	// Node B lấy được khóa sau khi khóa của A hết hạn
+	lockB, err := nodeB.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Node B failed to acquire lock after expiry: %v", err)
+	}
+
+	fencedA := lockA.(FencedLock)
+	fencedB := lockB.(FencedLock)
+	if fencedB.FencingToken() <= fencedA.FencingToken() {
+		t.Errorf("Node B fencing token %d should be greater than node A token %d",
+			fencedB.FencingToken(), fencedA.FencingToken())
+	}
+
+	// Vòng lặp gia hạn của node A phát hiện khóa đã mất
+	select {
+	case <-fencedA.Lost():
+	case <-time.After(3 * time.Second):
+		t.Fatal("Node A should detect that its lock was lost")
+	}
+
+	// Gia hạn của node A không được kéo dài khóa của node B
+	if ttl := mr.TTL("test_lock:job"); ttl > time.Second {
+		t.Errorf("Node A must not extend node B's lock, ttl = %v", ttl)
+	}
+
+	// Job dài của node A kết thúc, Unlock không được xóa khóa của node B
+	if err := lockA.Unlock(ctx); err != ErrLockNotHeld {
+		t.Errorf("Expected ErrLockNotHeld, got %v", err)
+	}
+	value, err := mr.Get("test_lock:job")
+	if err != nil || value != fencedB.Token() {
+		t.Fatalf("Node B lock should still be held, got %q (%v)", value, err)
+	}
+
+	if err := lockB.Unlock(ctx); err != nil {
+		t.Errorf("Node B failed to unlock: %v", err)
+	}
+}
+
+func TestRedisLockerRenewsHeldLock(t *testing.T) {
+	mr := miniredis.RunT(t)
+	locker := newTestRedisLocker(t, mr)
+	ctx := context.Background()
+
+	lock, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lock.Unlock(ctx)
+
+	// Giảm TTL còn lại, vòng lặp gia hạn phải đặt lại TTL đầy đủ
+	mr.FastForward(800 * time.Millisecond)
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if mr.TTL("test_lock:job") > 500*time.Millisecond {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Errorf("Lock should have been renewed, ttl = %v", mr.TTL("test_lock:job"))
+}
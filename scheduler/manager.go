@@ -1,6 +1,13 @@
 package scheduler
 
 import (
+	"context"
+	"log"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-co-op/gocron"
@@ -12,6 +19,20 @@ type Manager interface {
 	// Hữu ích khi chạy scheduler trên nhiều máy chủ trong môi trường phân tán.
 	WithDistributedLocker(locker gocron.Locker) Manager
 
+	// HeldLock trả về khóa phân tán mà instance hiện tại đang giữ cho job có tên key.
+	// Job có thể dùng khóa này để đọc fencing token hoặc theo dõi việc mất khóa.
+	// Trả về false nếu không có locker, locker không hỗ trợ FencedLocker hoặc
+	// khóa không được giữ.
+	HeldLock(key string) (FencedLock, bool)
+
+	// WithLeaderElection bật chế độ leader election: chỉ instance đang là leader
+	// mới thực thi job. Elector được khởi động cùng scheduler và từ bỏ lease khi Stop.
+	WithLeaderElection(elector LeaderElector) Manager
+
+	// IsLeader kiểm tra instance hiện tại có phải leader không, hữu ích cho
+	// health endpoint. Luôn trả về true khi không bật leader election.
+	IsLeader() bool
+
 	// Every tạo một công việc mới với khoảng thời gian được chỉ định.
 	// Trả về Manager để hỗ trợ fluent interface.
 	Every(interval interface{}) Manager
@@ -65,10 +86,41 @@ type Manager interface {
 	// Trả về Manager để hỗ trợ fluent interface.
 	SingletonMode() Manager
 
+	// Jitter thêm độ trễ ngẫu nhiên trong khoảng [0, maxDelay) trước mỗi lần chạy
+	// của công việc, để các job cùng lịch trên nhiều service không chạy cùng lúc.
+	// Với distributed locker, độ trễ được chờ trước khi lấy khóa phân tán.
+	// Trả về Manager để hỗ trợ fluent interface.
+	Jitter(maxDelay time.Duration) Manager
+
+	// Spread thêm độ trễ cố định trong khoảng [0, window) trước mỗi lần chạy của
+	// công việc, tính từ hash của tên job nên các job khác tên không chạy cùng lúc
+	// và mọi instance dùng cùng độ trễ cho một job. Tổng jitter và spread phải ngắn hơn khoảng
+	// cách giữa hai lần chạy, nếu không Do trả về ErrInvalidJobDelay.
+	// Trả về Manager để hỗ trợ fluent interface.
+	Spread(window time.Duration) Manager
+
+	// Misfire đặt chính sách chạy bù cho công việc: khi scheduler khởi động, các
+	// lần chạy bị lỡ kể từ thời điểm chạy gần nhất trong LastRunStore được bỏ qua,
+	// chạy bù một lần hoặc chạy bù từng lần. Chỉ áp dụng cho job chạy theo cron
+	// hoặc theo khoảng thời gian cố định.
+	// Trả về Manager để hỗ trợ fluent interface.
+	Misfire(policy MisfirePolicy) Manager
+
 	// Do đặt hàm để thực thi cho công việc với các tham số tùy chọn.
 	// Trả về Job và error nếu có.
 	Do(jobFun interface{}, params ...interface{}) (*gocron.Job, error)
 
+	// DoWithContext đặt hàm nhận context để thực thi cho công việc.
+	// Context bị hủy khi scheduler Stop() hoặc hết thời gian WithJobTimeout.
+	// Lỗi được thử lại theo WithJobRetry và panic được chuyển thành *PanicError,
+	// báo qua listener WhenJobReturnsError của gocron.
+	DoWithContext(jobFun func(ctx context.Context) error, opts ...JobOption) (*gocron.Job, error)
+
+	// DoWorkflow đặt workflow làm hàm thực thi cho công việc: lịch của job kích hoạt
+	// các bước gốc, bước phụ thuộc chỉ chạy khi các bước trước thành công. Tên workflow
+	// là tên job mặc định và trạng thái từng bước được ghi vào JobRun.Steps.
+	DoWorkflow(workflow *Workflow, opts ...JobOption) (*gocron.Job, error)
+
 	// Name đặt tên cho công việc đang được cấu hình.
 	// Trả về Manager để hỗ trợ fluent interface.
 	Name(name string) Manager
@@ -102,93 +154,359 @@ type Manager interface {
 
 	// RegisterEventListeners đăng ký các listener cho các sự kiện.
 	RegisterEventListeners(eventListeners ...gocron.EventListener)
+
+	// WithHistory thiết lập HistoryStore để ghi lại mỗi lần chạy job
+	// (tên, tags, thời điểm bắt đầu, thời gian chạy, lỗi, node).
+	WithHistory(store HistoryStore) Manager
+
+	// LastRun trả về lần chạy gần nhất của job.
+	// Trả về ErrHistoryDisabled nếu chưa thiết lập HistoryStore.
+	LastRun(name string) (JobRun, error)
+
+	// Runs trả về tối đa limit lần chạy gần nhất của job, mới nhất trước.
+	Runs(name string, limit int) ([]JobRun, error)
+
+	// Stats trả về thống kê (số lần chạy, tỷ lệ thành công, thời gian trung bình)
+	// trên các lần chạy còn được lưu giữ của job.
+	Stats(name string) (JobStats, error)
+
+	// ApplyJobs đồng bộ các job khai báo trong config với scheduler: thêm job mới,
+	// lên lịch lại job đã thay đổi và xóa job không còn trong danh sách.
+	// Job không hợp lệ bị bỏ qua và lỗi của chúng được gộp vào error trả về.
+	ApplyJobs(jobs []JobConfig) error
+
+	// Jobs trả về trạng thái của tất cả các job (tên, tags, lịch chạy, lần chạy kế
+	// tiếp, lần chạy gần nhất, số lần chạy, đang chạy, tạm dừng), sắp xếp theo tên.
+	Jobs() []JobInfo
+
+	// PauseJob tạm dừng các job có tên name: job giữ lịch chạy nhưng bỏ qua
+	// các lần được kích hoạt cho tới khi ResumeJob.
+	// Trả về ErrJobNotFound nếu không có job nào có tên name.
+	PauseJob(name string) error
+
+	// ResumeJob tiếp tục các job có tên name đã bị tạm dừng.
+	ResumeJob(name string) error
+
+	// RunNow chạy ngay các job có tên name trên instance hiện tại trong goroutine
+	// riêng, kể cả khi job đang bị tạm dừng. Lần chạy không qua distributed locker.
+	RunNow(name string) error
+
+	// PauseByTag tạm dừng các job có tag.
+	// Trả về ErrJobNotFound nếu không có job nào có tag.
+	PauseByTag(tag string) error
+
+	// ResumeByTag tiếp tục các job có tag đã bị tạm dừng.
+	ResumeByTag(tag string) error
+
+	// WithJobStore thiết lập JobStore lưu các job chạy một lần tạo bởi ScheduleOnce.
+	WithJobStore(store JobStore) Manager
+
+	// ScheduleOnce lên lịch chạy handler đã đăng ký qua RegisterJobHandler một lần
+	// vào thời điểm at với payload (đọc bằng OncePayload). Job được lưu trong JobStore
+	// và nạp lại khi scheduler khởi động, nên không bị mất khi process khởi động lại;
+	// job đã quá hạn được chạy ngay. Lên lịch lại với cùng tên sẽ thay thế job cũ.
+	// Khi chạy nhiều instance, distributed locker đảm bảo job chỉ chạy một lần.
+	// Trả về ErrJobStoreDisabled nếu chưa thiết lập JobStore.
+	ScheduleOnce(name string, at time.Time, handlerName string, payload []byte) error
+
+	// CancelOnce hủy job chạy một lần theo tên.
+	// Trả về ErrJobNotFound nếu job không tồn tại hoặc đã chạy.
+	CancelOnce(name string) error
+
+	// WithLastRunStore thiết lập LastRunStore lưu thời điểm chạy gần nhất của các
+	// job có chính sách chạy bù, dùng để phát hiện lần chạy bị lỡ khi khởi động.
+	WithLastRunStore(store LastRunStore) Manager
 }
 
 // manager triển khai interface Manager bằng cách nhúng gocron.Scheduler.
 type manager struct {
 	*gocron.Scheduler
+	locker  gocron.Locker
+	elector LeaderElector
+
+	// historyMu bảo vệ history và node khi được thiết lập trong lúc job đang chạy
+	historyMu sync.RWMutex
+	history   HistoryStore
+	node      string
+
+	// runMu bảo vệ context dùng chung của các job, bị hủy khi Stop()
+	runMu     sync.Mutex
+	runCtx    context.Context
+	cancelRun context.CancelFunc
+
+	// jobsMu bảo vệ configJobs khi config được áp dụng lại từ goroutine khác
+	jobsMu     sync.Mutex
+	configJobs map[string]JobConfig
+
+	// chainMu bảo vệ lịch chạy và chính sách chạy bù của job đang cấu hình
+	chainMu     sync.Mutex
+	chain       jobSpec
+	chainPolicy MisfirePolicy
+
+	// misfireMu bảo vệ lastRuns và misfireJobs
+	misfireMu   sync.Mutex
+	lastRuns    LastRunStore
+	misfireJobs map[*gocron.Job]misfireJob
+
+	// controlMu bảo vệ controls, trạng thái tạm dừng và số lần chạy của các job
+	controlMu sync.Mutex
+	controls  map[*gocron.Job]*jobControl
+
+	// onceMu bảo vệ jobStore và onceJobs (job chạy một lần được lên lịch theo tên)
+	onceMu   sync.Mutex
+	jobStore JobStore
+	onceJobs map[string]*gocron.Job
+
+	// clock thay đồng hồ hệ thống khi được thiết lập qua WithClock
+	clock Clock
+
+	// dispatchMu bảo vệ dispatches: các lần timer của clock kích hoạt job chưa được job nhận
+	dispatchMu sync.Mutex
+	dispatches []*clockDispatch
 }
 
 // NewScheduler tạo một đối tượng Manager mới sử dụng gocron làm backend.
-// Nhận tham số config để cấu hình scheduler.
+// Nhận tham số config tùy chọn để cấu hình scheduler giống như NewSchedulerWithConfig.
 func NewScheduler(cfg ...Config) Manager {
-	scheduler := gocron.NewScheduler(time.Local)
-	return &manager{
-		Scheduler: scheduler,
+	if len(cfg) == 0 {
+		return &manager{
+			Scheduler: gocron.NewScheduler(time.Local),
+		}
 	}
+	return NewSchedulerWithConfig(cfg[0])
 }
 
 // NewSchedulerWithConfig tạo một đối tượng Manager mới với cấu hình cụ thể.
-func NewSchedulerWithConfig(cfg Config) Manager {
-	scheduler := gocron.NewScheduler(time.Local)
-	return &manager{
+//
+// Cấu hình được áp dụng đầy đủ: múi giờ (Timezone), chế độ singleton mặc định
+// (SingletonMode), giới hạn số job chạy đồng thời (Concurrency), distributed
+// locking (DistributedLock) và leader election (LeaderElection). ServiceProvider
+// cũng tạo scheduler qua hàm này nên scheduler tạo thủ công hoạt động giống hệt.
+//
+// Phần cấu hình không áp dụng được (ví dụ thiếu Redis client cho distributed
+// locking) được bỏ qua và lỗi được ghi log, scheduler vẫn được tạo. Dùng
+// NewSchedulerFromConfig để nhận lỗi thay vì bỏ qua.
+//
+// Example:
+//
+//	cfg := scheduler.DefaultConfig()
+//	cfg.Timezone = "Asia/Ho_Chi_Minh"
+//	cfg.DistributedLock.Enabled = true
+//	sched := scheduler.NewSchedulerWithConfig(cfg, scheduler.WithRedisClient(redisClient))
+func NewSchedulerWithConfig(cfg Config, opts ...Option) Manager {
+	manager, _ := newSchedulerFromConfig(cfg, opts, func(err error) error {
+		log.Printf("scheduler: %v", err)
+		return nil
+	})
+	return manager
+}
+
+// NewSchedulerFromConfig tạo Manager giống NewSchedulerWithConfig nhưng trả về lỗi
+// khi cấu hình không hợp lệ hoặc không tạo được locker, elector hay store đã bật.
+//
+// Example:
+//
+//	sched, err := scheduler.NewSchedulerFromConfig(cfg, scheduler.WithRedisClient(redisClient))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func NewSchedulerFromConfig(cfg Config, opts ...Option) (Manager, error) {
+	return newSchedulerFromConfig(cfg, opts, func(err error) error {
+		return err
+	})
+}
+
+// newSchedulerFromConfig áp dụng cfg cho scheduler mới. Lỗi của từng phần cấu hình
+// được chuyển cho fail: fail trả về lỗi để dừng việc tạo scheduler, hoặc nil để
+// bỏ qua phần cấu hình đó.
+func newSchedulerFromConfig(cfg Config, opts []Option, fail func(error) error) (Manager, error) {
+	options := schedulerOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// gocron không áp dụng locker và elector cho job ở chế độ singleton
+	if cfg.SingletonMode && (cfg.DistributedLock.Enabled || cfg.LeaderElection.Enabled) {
+		if err := fail(ErrSingletonModeNotDistributed); err != nil {
+			return nil, err
+		}
+		cfg.SingletonMode = false
+	}
+
+	scheduler, err := newGocronScheduler(cfg)
+	if err != nil {
+		if err := fail(err); err != nil {
+			return nil, err
+		}
+		scheduler = gocron.NewScheduler(time.Local)
+	}
+	m := &manager{
 		Scheduler: scheduler,
 	}
+	if options.clock != nil {
+		m.setClock(options.clock)
+	}
+
+	// Cấu hình distributed locking nếu được bật
+	if cfg.DistributedLock.Enabled {
+		locker, err := newDistributedLocker(cfg, options)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+		} else {
+			m.WithDistributedLocker(locker)
+		}
+	}
+
+	// Cấu hình leader election nếu được bật
+	if cfg.LeaderElection.Enabled {
+		elector, err := newLeaderElector(cfg, options)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+		} else {
+			m.WithLeaderElection(elector)
+		}
+	}
+
+	// Cấu hình lịch sử chạy job nếu được bật
+	if cfg.History.Enabled {
+		store, err := newHistoryStore(cfg, options)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+		} else {
+			m.node = cfg.History.Node
+			m.WithHistory(store)
+		}
+	}
+
+	// Cấu hình lưu thời điểm chạy cho chính sách chạy bù nếu được bật
+	if cfg.Misfire.Enabled {
+		store, err := newLastRunStore(cfg, options)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+		} else {
+			m.WithLastRunStore(store)
+		}
+	}
+
+	// Cấu hình lưu job chạy một lần nếu được bật
+	if cfg.JobStore.Enabled {
+		store, err := newJobStore(cfg, options)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+		} else {
+			m.WithJobStore(store)
+		}
+	}
+
+	return m, nil
 }
 
 // Every tạo một công việc mới với khoảng thời gian được chỉ định.
 func (m *manager) Every(interval interface{}) Manager {
 	m.Scheduler.Every(interval)
+	m.updateChain(func(spec *jobSpec) {
+		spec.every = interval
+	})
 	return m
 }
 
 // Second chỉ định đơn vị thời gian là giây (đơn lẻ).
 func (m *manager) Second() Manager {
 	m.Scheduler.Second()
+	m.updateChain(func(spec *jobSpec) {
+		spec.unit = time.Second
+	})
 	return m
 }
 
 // Seconds chỉ định đơn vị thời gian là giây.
 func (m *manager) Seconds() Manager {
 	m.Scheduler.Seconds()
+	m.updateChain(func(spec *jobSpec) {
+		spec.unit = time.Second
+	})
 	return m
 }
 
 // Minutes chỉ định đơn vị thời gian là phút.
 func (m *manager) Minutes() Manager {
 	m.Scheduler.Minutes()
+	m.updateChain(func(spec *jobSpec) {
+		spec.unit = time.Minute
+	})
 	return m
 }
 
 // Hours chỉ định đơn vị thời gian là giờ.
 func (m *manager) Hours() Manager {
 	m.Scheduler.Hours()
+	m.updateChain(func(spec *jobSpec) {
+		spec.unit = time.Hour
+	})
 	return m
 }
 
 // Days chỉ định đơn vị thời gian là ngày.
 func (m *manager) Days() Manager {
 	m.Scheduler.Days()
+	m.updateChain(func(spec *jobSpec) {
+		spec.unit = 24 * time.Hour
+	})
 	return m
 }
 
 // Weeks chỉ định đơn vị thời gian là tuần.
 func (m *manager) Weeks() Manager {
 	m.Scheduler.Weeks()
+	m.updateChain(func(spec *jobSpec) {
+		spec.unit = 7 * 24 * time.Hour
+	})
 	return m
 }
 
 // At chỉ định thời điểm trong ngày để chạy công việc.
 func (m *manager) At(time string) Manager {
 	m.Scheduler.At(time)
+	m.updateChain(func(spec *jobSpec) {
+		spec.at = append(spec.at, time)
+	})
 	return m
 }
 
 // StartAt chỉ định thời điểm bắt đầu cho công việc.
 func (m *manager) StartAt(startTime time.Time) Manager {
 	m.Scheduler.StartAt(startTime)
+	m.updateChain(func(spec *jobSpec) {
+		spec.startAt = startTime
+	})
 	return m
 }
 
 // Cron thiết lập biểu thức cron cho công việc.
 func (m *manager) Cron(cronExpression string) Manager {
 	m.Scheduler.Cron(cronExpression)
+	m.updateChain(func(spec *jobSpec) {
+		spec.cron, spec.withSeconds = cronExpression, false
+	})
 	return m
 }
 
 // CronWithSeconds thiết lập biểu thức cron có hỗ trợ giây.
 func (m *manager) CronWithSeconds(cronExpression string) Manager {
 	m.Scheduler.CronWithSeconds(cronExpression)
+	m.updateChain(func(spec *jobSpec) {
+		spec.cron, spec.withSeconds = cronExpression, true
+	})
 	return m
 }
 
@@ -205,8 +523,132 @@ func (m *manager) SingletonMode() Manager {
 }
 
 // Do đặt hàm để thực thi cho công việc.
+//
+// Hàm được bọc để ghi lại lịch sử chạy khi có HistoryStore. Lỗi trả về từ hàm
+// vẫn được chuyển tới các listener của gocron như khi gọi trực tiếp.
 func (m *manager) Do(jobFun interface{}, params ...interface{}) (*gocron.Job, error) {
-	return m.Scheduler.Do(jobFun, params...)
+	fn := reflect.ValueOf(jobFun)
+	if jobFun == nil || fn.Kind() != reflect.Func || fn.Type().NumIn() != len(params) {
+		// Để gocron trả về lỗi và dọn dẹp job đang cấu hình
+		m.takeChain()
+		return m.Scheduler.Do(jobFun, params...)
+	}
+
+	return m.schedule(functionName(jobFun), func(context.Context, *JobRun) error {
+		return callJobFunc(fn, params)
+	})
+}
+
+// schedule đăng ký hàm run cho job đang cấu hình, ghi lại lịch sử mỗi lần chạy
+// và giữ funcName làm tên job mặc định. run có thể bổ sung thông tin (ví dụ
+// trạng thái các bước của workflow) vào bản ghi lịch sử của lần chạy. ctx của
+// lần chạy bị hủy khi Stop() và phải được dùng khi chờ theo Clock (xem sleep).
+func (m *manager) schedule(funcName string, run func(ctx context.Context, record *JobRun) error) (*gocron.Job, error) {
+	spec, policy := m.takeChain()
+	if err := spec.validateDelay(); err != nil {
+		// Dọn dẹp job đang cấu hình trước khi trả về lỗi
+		_, _ = m.Scheduler.Do(nil)
+		return nil, err
+	}
+	trackLastRun := policy.limit() > 0
+	var current atomic.Pointer[gocron.Job]
+	control := &jobControl{
+		schedule: spec.String(),
+		delay:    func() time.Duration { return spec.runDelay(current.Load()) },
+		window:   spec.maxDelay(),
+	}
+
+	execute := func(ctx context.Context) error {
+		startedAt := m.now()
+		control.start(startedAt)
+		defer control.finish()
+
+		if trackLastRun {
+			m.recordLastRun(current.Load(), startedAt)
+		}
+		record := JobRun{StartedAt: startedAt}
+		err := run(ctx, &record)
+		record.Duration = m.now().Sub(startedAt)
+		m.recordRun(current.Load(), funcName, record, err)
+		return err
+	}
+	// dispatch chạy job theo lịch; tracked giữ lần kích hoạt của Clock, nil nếu
+	// lần chạy không do timer của Clock kích hoạt (ví dụ chạy bù)
+	dispatch := func(tracked *clockRun) error {
+		defer tracked.finish()
+		ctx := withClockRun(m.runContext(), tracked)
+
+		// Với distributed locker, độ trễ đã được chờ trước khi lấy khóa (xem lockAfterDelay)
+		if !control.takeLockDelayed() {
+			if delay := control.delay(); delay > 0 && !m.sleep(ctx, delay) {
+				return nil
+			}
+		}
+
+		// Job bị tạm dừng vẫn giữ lịch chạy nhưng bỏ qua các lần được kích hoạt
+		if control.isPaused() {
+			return nil
+		}
+		return execute(ctx)
+	}
+	wrapped := func() error {
+		return dispatch(&clockRun{dispatch: m.claimDispatch()})
+	}
+
+	job, err := m.Scheduler.Do(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	// Giữ tên hàm gốc làm tên job mặc định, vì gocron dùng tên này làm khóa phân tán
+	if job.GetName() == functionName(wrapped) {
+		job.Name(funcName)
+	}
+	current.Store(job)
+
+	if err := m.trackMisfire(job, spec, policy, func() error { return dispatch(nil) }); err != nil {
+		m.Scheduler.RemoveByReference(job)
+		return nil, err
+	}
+	control.run = func() error { return execute(m.runContext()) }
+	m.trackControl(job, control)
+	return job, nil
+}
+
+// updateChain cập nhật lịch chạy của job đang cấu hình.
+func (m *manager) updateChain(update func(spec *jobSpec)) {
+	m.chainMu.Lock()
+	defer m.chainMu.Unlock()
+	update(&m.chain)
+}
+
+// takeChain trả về lịch chạy và chính sách chạy bù của job đang cấu hình,
+// đồng thời xóa chúng để chuẩn bị cho job tiếp theo.
+func (m *manager) takeChain() (jobSpec, MisfirePolicy) {
+	m.chainMu.Lock()
+	defer m.chainMu.Unlock()
+	spec, policy := m.chain, m.chainPolicy
+	m.chain, m.chainPolicy = jobSpec{}, MisfirePolicy{}
+	return spec, policy
+}
+
+// callJobFunc gọi hàm của job với các tham số và trả về lỗi nếu hàm trả về error.
+func callJobFunc(fn reflect.Value, params []interface{}) error {
+	in := make([]reflect.Value, len(params))
+	for i, param := range params {
+		in[i] = reflect.ValueOf(param)
+	}
+	for _, value := range fn.Call(in) {
+		if err, ok := value.Interface().(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// functionName trả về tên đầy đủ của hàm, giống cách gocron đặt tên job mặc định.
+func functionName(fn interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
 }
 
 // Name đặt tên cho công việc đang được cấu hình.
@@ -231,18 +673,40 @@ func (m *manager) FindJobsByTag(tags ...string) ([]*gocron.Job, error) {
 }
 
 // StartAsync bắt đầu scheduler trong một goroutine riêng.
+//
+// Các job có chính sách chạy bù được chạy bù ngay khi khởi động, hoặc khi
+// instance trở thành leader nếu bật leader election. Các job chạy một lần được
+// nạp lại từ JobStore.
 func (m *manager) StartAsync() {
+	m.startRunContext()
+	m.startElector()
+	if m.elector == nil {
+		m.catchUpMissedRuns()
+	}
+	m.loadOnceJobs()
 	m.Scheduler.StartAsync()
 }
 
 // StartBlocking bắt đầu scheduler và chặn luồng hiện tại.
 func (m *manager) StartBlocking() {
+	m.startRunContext()
+	m.startElector()
+	if m.elector == nil {
+		m.catchUpMissedRuns()
+	}
+	m.loadOnceJobs()
 	m.Scheduler.StartBlocking()
 }
 
-// Stop dừng scheduler.
+// Stop dừng scheduler và từ bỏ vai trò leader nếu bật leader election.
+// Context của các job tạo bằng DoWithContext bị hủy trước khi chờ job dừng.
 func (m *manager) Stop() {
+	m.stopRunContext()
 	m.Scheduler.Stop()
+	m.releaseDispatches()
+	if m.elector != nil {
+		_ = m.elector.Stop()
+	}
 }
 
 // Clear xóa tất cả các công việc đã đăng ký.
@@ -257,10 +721,122 @@ func (m *manager) GetScheduler() *gocron.Scheduler {
 
 // WithDistributedLocker thiết lập distributed locker cho scheduler.
 func (m *manager) WithDistributedLocker(locker gocron.Locker) Manager {
-	m.Scheduler.WithDistributedLocker(locker)
+	m.Scheduler.WithDistributedLocker(dispatchLocker{Locker: locker, m: m})
+	m.locker = locker
+	return m
+}
+
+// HeldLock trả về khóa phân tán mà instance hiện tại đang giữ cho job có tên key.
+func (m *manager) HeldLock(key string) (FencedLock, bool) {
+	fenced, ok := m.locker.(FencedLocker)
+	if !ok {
+		return nil, false
+	}
+	return fenced.HeldLock(key)
+}
+
+// WithLeaderElection bật chế độ leader election cho scheduler.
+func (m *manager) WithLeaderElection(elector LeaderElector) Manager {
+	m.Scheduler.WithDistributedElector(dispatchElector{Elector: elector, m: m})
+	m.elector = elector
+	// Leader mới chạy bù các lần chạy bị lỡ trong lúc chưa có leader
+	elector.OnLeadershipChange(func(isLeader bool) {
+		if isLeader {
+			go m.catchUpMissedRuns()
+		}
+	})
+	if m.Scheduler.IsRunning() {
+		m.startElector()
+	}
+	return m
+}
+
+// WithHistory thiết lập HistoryStore để ghi lại mỗi lần chạy job.
+func (m *manager) WithHistory(store HistoryStore) Manager {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	m.history = store
+	if m.node == "" {
+		m.node, _ = os.Hostname()
+	}
 	return m
 }
 
+// LastRun trả về lần chạy gần nhất của job.
+func (m *manager) LastRun(name string) (JobRun, error) {
+	store := m.historyStore()
+	if store == nil {
+		return JobRun{}, ErrHistoryDisabled
+	}
+	return store.LastRun(context.Background(), name)
+}
+
+// Runs trả về các lần chạy gần nhất của job.
+func (m *manager) Runs(name string, limit int) ([]JobRun, error) {
+	store := m.historyStore()
+	if store == nil {
+		return nil, ErrHistoryDisabled
+	}
+	return store.Runs(context.Background(), name, limit)
+}
+
+// Stats trả về thống kê các lần chạy của job.
+func (m *manager) Stats(name string) (JobStats, error) {
+	runs, err := m.Runs(name, 0)
+	if err != nil {
+		return JobStats{}, err
+	}
+	return newJobStats(runs), nil
+}
+
+// historyStore trả về HistoryStore hiện tại.
+func (m *manager) historyStore() HistoryStore {
+	m.historyMu.RLock()
+	defer m.historyMu.RUnlock()
+	return m.history
+}
+
+// recordRun ghi lại một lần chạy vào HistoryStore nếu được thiết lập.
+// Lỗi khi ghi lịch sử không ảnh hưởng tới kết quả của job.
+func (m *manager) recordRun(job *gocron.Job, funcName string, run JobRun, err error) {
+	m.historyMu.RLock()
+	store, node := m.history, m.node
+	m.historyMu.RUnlock()
+	if store == nil {
+		return
+	}
+
+	run.JobName = funcName
+	run.Node = node
+	if job != nil {
+		run.JobName = job.GetName()
+		run.Tags = job.Tags()
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = store.Record(ctx, run)
+}
+
+// IsLeader kiểm tra instance hiện tại có phải leader không.
+func (m *manager) IsLeader() bool {
+	if m.elector == nil {
+		return true
+	}
+	return m.elector.Leading()
+}
+
+// startElector khởi động vòng lặp tranh cử nếu bật leader election.
+func (m *manager) startElector() {
+	if m.elector != nil {
+		m.elector.Start()
+	}
+}
+
 // RegisterEventListeners đăng ký các listener cho các sự kiện.
 func (m *manager) RegisterEventListeners(eventListeners ...gocron.EventListener) {
 	m.Scheduler.RegisterEventListeners(eventListeners...)
@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -227,3 +229,206 @@ type mockLock struct{}
 func (m *mockLock) Unlock(ctx context.Context) error {
 	return nil
 }
+
+func TestSchedulerHeldLock(t *testing.T) {
+	scheduler := NewScheduler()
+
+	// Không có locker thì không có khóa nào được giữ
+	if _, ok := scheduler.HeldLock("job"); ok {
+		t.Fatal("HeldLock should return false without a distributed locker")
+	}
+
+	// Locker không hỗ trợ fencing
+	scheduler.WithDistributedLocker(&mockLocker{})
+	if _, ok := scheduler.HeldLock("job"); ok {
+		t.Fatal("HeldLock should return false for a locker without fencing support")
+	}
+}
+
+func TestNewSchedulerWithConfigAppliesConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Timezone = "Asia/Ho_Chi_Minh"
+	cfg.Concurrency.MaxJobs = 1
+	cfg.Concurrency.Mode = "wait"
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Driver = "memory"
+
+	scheduler, err := NewSchedulerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create scheduler: %v", err)
+	}
+
+	if got := scheduler.GetScheduler().Location().String(); got != "Asia/Ho_Chi_Minh" {
+		t.Errorf("Expected location Asia/Ho_Chi_Minh, got %s", got)
+	}
+
+	// Locker được tạo từ cấu hình, job có thể đọc khóa đang giữ
+	held := make(chan bool, 1)
+	_, err = scheduler.Every(1).Hours().Name("locked").Do(func() {
+		_, ok := scheduler.HeldLock("locked")
+		held <- ok
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case ok := <-held:
+		if !ok {
+			t.Error("Job should hold the lock from the configured locker")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job did not run")
+	}
+}
+
+func TestNewSchedulerWithConfigLimitsConcurrency(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Concurrency.MaxJobs = 1
+
+	scheduler, err := NewSchedulerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create scheduler: %v", err)
+	}
+
+	var mu sync.Mutex
+	running, maxRunning, runs := 0, 0, 0
+	job := func() {
+		mu.Lock()
+		running++
+		runs++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(100 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := scheduler.Every("20ms").Do(job); err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+	}
+
+	scheduler.StartAsync()
+	time.Sleep(300 * time.Millisecond)
+	scheduler.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runs == 0 {
+		t.Fatal("Expected jobs to run")
+	}
+	if maxRunning != 1 {
+		t.Errorf("Expected at most 1 concurrent job, got %d", maxRunning)
+	}
+}
+
+func TestNewSchedulerWithConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr error
+	}{
+		{"invalid timezone", func(cfg *Config) { cfg.Timezone = "Mars/Base" }, ErrInvalidTimezone},
+		{"negative max jobs", func(cfg *Config) { cfg.Concurrency.MaxJobs = -1 }, ErrInvalidMaxConcurrentJobs},
+		{"invalid mode", func(cfg *Config) {
+			cfg.Concurrency.MaxJobs = 2
+			cfg.Concurrency.Mode = "drop"
+		}, ErrInvalidConcurrencyMode},
+		{"singleton with locker", func(cfg *Config) {
+			cfg.SingletonMode = true
+			cfg.DistributedLock.Enabled = true
+			cfg.DistributedLock.Driver = "memory"
+		}, ErrSingletonModeNotDistributed},
+		{"redis locker without client", func(cfg *Config) { cfg.DistributedLock.Enabled = true }, ErrRedisClientNil},
+		{"mongodb elector without manager", func(cfg *Config) {
+			cfg.LeaderElection.Enabled = true
+			cfg.LeaderElection.Driver = "mongodb"
+		}, ErrMongoManagerNil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if _, err := NewSchedulerFromConfig(cfg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewSchedulerWithConfigSkipsInvalidConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Timezone = "Mars/Base"
+	cfg.Concurrency.MaxJobs = 2
+	cfg.DistributedLock.Enabled = true
+
+	// Phần cấu hình không áp dụng được bị bỏ qua thay vì panic
+	scheduler := NewSchedulerWithConfig(cfg).(*manager)
+	if scheduler.Location() != time.Local {
+		t.Errorf("Expected fallback location %v, got %v", time.Local, scheduler.Location())
+	}
+	if scheduler.locker != nil {
+		t.Error("Expected the redis locker without client to be skipped")
+	}
+	if NewScheduler(cfg) == nil {
+		t.Error("NewScheduler should return a scheduler for invalid config")
+	}
+
+	cfg = DefaultConfig()
+	cfg.SingletonMode = true
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Driver = "memory"
+	if scheduler := NewSchedulerWithConfig(cfg).(*manager); scheduler.locker == nil {
+		t.Error("Expected the distributed locker to be kept over singleton mode")
+	}
+}
+
+func TestNewSchedulerWithConfigSingletonMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SingletonMode = true
+
+	scheduler, err := NewSchedulerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create scheduler: %v", err)
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	_, err = scheduler.Every("20ms").Do(func() {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(100 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	time.Sleep(250 * time.Millisecond)
+	scheduler.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxRunning != 1 {
+		t.Errorf("Expected singleton job to never overlap, got %d concurrent runs", maxRunning)
+	}
+}
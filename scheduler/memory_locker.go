@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// memoryLocker triển khai gocron.Locker lưu khóa trong bộ nhớ của process.
+//
+// Locker này chỉ đảm bảo loại trừ lẫn nhau giữa các scheduler trong cùng
+// một process, phù hợp cho kiểm thử và triển khai một instance.
+type memoryLocker struct {
+	mu      sync.Mutex
+	options RedisLockerOptionsTime
+	entries map[string]memoryLockEntry
+	fencing map[string]int64
+	held    heldLocks
+	nowFunc func() time.Time
+}
+
+// memoryLockEntry lưu trạng thái của một khóa trong bộ nhớ.
+type memoryLockEntry struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewMemoryLocker tạo một Locker trong bộ nhớ để sử dụng với gocron.
+//
+// Các tùy chọn có cùng ý nghĩa với Redis Locker: KeyPrefix, LockDuration,
+// MaxRetries và RetryDelay. Locker trả về cũng triển khai FencedLocker.
+//
+// Example:
+//
+//	locker, err := scheduler.NewMemoryLocker()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithDistributedLocker(locker)
+func NewMemoryLocker(opts ...RedisLockerOptions) (gocron.Locker, error) {
+	options := DefaultRedisLockerOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+		if err := validateRedisLockerOptions(options); err != nil {
+			return nil, err
+		}
+	}
+
+	return &memoryLocker{
+		options: options.ToTimeDuration(),
+		entries: make(map[string]memoryLockEntry),
+		fencing: make(map[string]int64),
+		nowFunc: time.Now,
+	}, nil
+}
+
+// Lock triển khai phương thức Lock của gocron.Locker interface.
+func (m *memoryLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	fullKey := m.options.KeyPrefix + key
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	for retries := 0; ; retries++ {
+		if fencingToken, ok := m.tryAcquire(fullKey, token); ok {
+			return m.newLock(key, fullKey, token, fencingToken), nil
+		}
+
+		// Nếu đã thử tối đa số lần
+		if retries >= m.options.MaxRetries {
+			return nil, ErrFailedToAcquireLock
+		}
+
+		// Chờ một khoảng thời gian trước khi thử lại
+		select {
+		case <-time.After(m.options.RetryDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// HeldLock trả về khóa đang được giữ cho key.
+func (m *memoryLocker) HeldLock(key string) (FencedLock, bool) {
+	return m.held.get(key)
+}
+
+// tryAcquire lấy khóa nếu chưa có ai giữ hoặc khóa cũ đã hết hạn.
+func (m *memoryLocker) tryAcquire(fullKey, token string) (int64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.nowFunc()
+	if entry, ok := m.entries[fullKey]; ok && now.Before(entry.expiresAt) {
+		return 0, false
+	}
+
+	m.fencing[fullKey]++
+	m.entries[fullKey] = memoryLockEntry{
+		token:     token,
+		expiresAt: now.Add(m.options.LockDuration),
+	}
+	return m.fencing[fullKey], true
+}
+
+// newLock tạo khóa với gia hạn và giải phóng chỉ khi owner token còn khớp.
+func (m *memoryLocker) newLock(key, fullKey, token string, fencingToken int64) *leaseLock {
+	renew := func(ctx context.Context) (bool, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		now := m.nowFunc()
+		entry, ok := m.entries[fullKey]
+		if !ok || entry.token != token || !now.Before(entry.expiresAt) {
+			return false, nil
+		}
+		entry.expiresAt = now.Add(m.options.LockDuration)
+		m.entries[fullKey] = entry
+		return true, nil
+	}
+	release := func(ctx context.Context) (bool, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		entry, ok := m.entries[fullKey]
+		if !ok || entry.token != token || !m.nowFunc().Before(entry.expiresAt) {
+			return false, nil
+		}
+		delete(m.entries, fullKey)
+		return true, nil
+	}
+	return newLeaseLock(&m.held, key, token, fencingToken, m.options.LockDuration, renew, release)
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestMemoryLocker tạo memory locker với đồng hồ có thể điều khiển
+func newTestMemoryLocker(t *testing.T) (*memoryLocker, func(d time.Duration)) {
+	t.Helper()
+
+	locker, err := NewMemoryLocker(RedisLockerOptions{
+		KeyPrefix:    "test_lock:",
+		LockDuration: 30,
+		MaxRetries:   0,
+		RetryDelay:   0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create memory locker: %v", err)
+	}
+
+	var mu sync.Mutex
+	now := time.Now()
+	memory := locker.(*memoryLocker)
+	memory.nowFunc = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	return memory, advance
+}
+
+func TestMemoryLockerLockUnlock(t *testing.T) {
+	locker, _ := newTestMemoryLocker(t)
+	ctx := context.Background()
+
+	lock, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	if _, err := locker.Lock(ctx, "job"); err != ErrFailedToAcquireLock {
+		t.Errorf("Expected ErrFailedToAcquireLock, got %v", err)
+	}
+
+	held, ok := locker.HeldLock("job")
+	if !ok || held.FencingToken() != 1 {
+		t.Errorf("Expected held lock with fencing token 1, got %v", held)
+	}
+
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	second, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock after unlock: %v", err)
+	}
+	defer second.Unlock(ctx)
+
+	if second.(FencedLock).FencingToken() != 2 {
+		t.Errorf("Expected fencing token 2, got %d", second.(FencedLock).FencingToken())
+	}
+}
+
+func TestMemoryLockerExpiry(t *testing.T) {
+	locker, advance := newTestMemoryLocker(t)
+	ctx := context.Background()
+
+	first, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// Khóa hết hạn, instance khác có thể lấy khóa
+	advance(31 * time.Second)
+
+	second, err := locker.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire expired lock: %v", err)
+	}
+
+	// Chủ sở hữu cũ không được xóa khóa mới
+	if err := first.Unlock(ctx); err != ErrLockNotHeld {
+		t.Errorf("Expected ErrLockNotHeld, got %v", err)
+	}
+	select {
+	case <-first.(FencedLock).Lost():
+	default:
+		t.Error("First lock should be marked as lost")
+	}
+
+	if err := second.Unlock(ctx); err != nil {
+		t.Errorf("Failed to unlock second lock: %v", err)
+	}
+}
+
+func TestMemoryLockerInvalidOptions(t *testing.T) {
+	_, err := NewMemoryLocker(RedisLockerOptions{KeyPrefix: "test:", LockDuration: 0})
+	if err != ErrInvalidLockDuration {
+		t.Errorf("Expected ErrInvalidLockDuration, got %v", err)
+	}
+}
+
+func TestNewMongoLockerValidation(t *testing.T) {
+	if _, err := NewMongoLocker(nil, "locks"); err != ErrMongoManagerNil {
+		t.Errorf("Expected ErrMongoManagerNil, got %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// MisfireMode xác định cách xử lý các lần chạy bị lỡ khi scheduler không chạy
+// (ví dụ trong lúc deploy hoặc khi instance bị dừng).
+type MisfireMode string
+
+const (
+	// MisfireSkip bỏ qua các lần chạy bị lỡ và chờ lịch tiếp theo (mặc định).
+	MisfireSkip MisfireMode = "skip"
+
+	// MisfireRunOnce chạy bù một lần duy nhất nếu có ít nhất một lần chạy bị lỡ.
+	MisfireRunOnce MisfireMode = "run_once"
+
+	// MisfireRunAll chạy bù từng lần chạy bị lỡ, tối đa MaxRuns lần.
+	MisfireRunAll MisfireMode = "run_all"
+)
+
+// misfireLockPrefix là tiền tố khóa phân tán dùng khi chạy bù, để chỉ một
+// instance chạy bù cho mỗi job.
+const misfireLockPrefix = "misfire:"
+
+// MisfirePolicy là chính sách chạy bù của một job.
+type MisfirePolicy struct {
+	// Mode là cách xử lý các lần chạy bị lỡ: "skip", "run_once" hoặc "run_all"
+	Mode MisfireMode `mapstructure:"mode" yaml:"mode"`
+
+	// MaxRuns là số lần chạy bù tối đa, bắt buộc với "run_all"
+	MaxRuns int `mapstructure:"max_runs" yaml:"max_runs"`
+}
+
+// validate kiểm tra tính hợp lệ của chính sách chạy bù.
+func (p MisfirePolicy) validate() error {
+	switch p.Mode {
+	case "", MisfireSkip, MisfireRunOnce:
+		return nil
+	case MisfireRunAll:
+		if p.MaxRuns <= 0 {
+			return fmt.Errorf("%w: run_all requires max_runs > 0", ErrInvalidMisfirePolicy)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidMisfirePolicy, p.Mode)
+	}
+}
+
+// limit trả về số lần chạy bù tối đa theo chính sách.
+func (p MisfirePolicy) limit() int {
+	switch p.Mode {
+	case MisfireRunOnce:
+		return 1
+	case MisfireRunAll:
+		return p.MaxRuns
+	default:
+		return 0
+	}
+}
+
+// LastRunStore lưu thời điểm chạy gần nhất của các job, dùng để phát hiện
+// các lần chạy bị lỡ khi scheduler khởi động lại.
+type LastRunStore interface {
+	// LastRunAt trả về thời điểm chạy gần nhất của job, hoặc ErrNoJobRuns nếu chưa có.
+	LastRunAt(ctx context.Context, jobName string) (time.Time, error)
+
+	// SetLastRunAt ghi nhận thời điểm chạy gần nhất của job.
+	SetLastRunAt(ctx context.Context, jobName string, at time.Time) error
+}
+
+// LastRunOptions chứa các tùy chọn cho LastRunStore.
+type LastRunOptions struct {
+	// KeyPrefix là tiền tố của key lưu thời điểm chạy trong Redis
+	KeyPrefix string
+}
+
+// DefaultLastRunOptions trả về các tùy chọn mặc định cho LastRunStore.
+func DefaultLastRunOptions() LastRunOptions {
+	return DefaultMisfireConfig().ToLastRunOptions()
+}
+
+// memoryLastRunStore lưu thời điểm chạy trong bộ nhớ của process.
+type memoryLastRunStore struct {
+	mu   sync.RWMutex
+	runs map[string]time.Time
+}
+
+// NewMemoryLastRunStore tạo LastRunStore lưu trong bộ nhớ, phù hợp cho kiểm thử.
+// Dữ liệu mất khi process dừng nên không phát hiện được lần chạy bị lỡ qua các lần khởi động.
+func NewMemoryLastRunStore() LastRunStore {
+	return &memoryLastRunStore{
+		runs: make(map[string]time.Time),
+	}
+}
+
+// LastRunAt trả về thời điểm chạy gần nhất của job.
+func (s *memoryLastRunStore) LastRunAt(ctx context.Context, jobName string) (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	at, ok := s.runs[jobName]
+	if !ok {
+		return time.Time{}, ErrNoJobRuns
+	}
+	return at, nil
+}
+
+// SetLastRunAt ghi nhận thời điểm chạy gần nhất của job, bỏ qua thời điểm cũ hơn giá trị đã lưu.
+func (s *memoryLastRunStore) SetLastRunAt(ctx context.Context, jobName string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.runs[jobName]; !ok || at.After(current) {
+		s.runs[jobName] = at
+	}
+	return nil
+}
+
+// misfireJob chứa thông tin cần để chạy bù một job.
+type misfireJob struct {
+	policy    MisfirePolicy
+	next      func(time.Time) time.Time
+	immediate bool
+	run       func() error
+}
+
+// Misfire đặt chính sách chạy bù cho công việc đang được cấu hình.
+func (m *manager) Misfire(policy MisfirePolicy) Manager {
+	m.chainMu.Lock()
+	defer m.chainMu.Unlock()
+	m.chainPolicy = policy
+	return m
+}
+
+// WithLastRunStore thiết lập LastRunStore để lưu thời điểm chạy của các job có chính sách chạy bù.
+func (m *manager) WithLastRunStore(store LastRunStore) Manager {
+	m.misfireMu.Lock()
+	defer m.misfireMu.Unlock()
+	m.lastRuns = store
+	return m
+}
+
+// lastRunStore trả về LastRunStore hiện tại.
+func (m *manager) lastRunStore() LastRunStore {
+	m.misfireMu.Lock()
+	defer m.misfireMu.Unlock()
+	return m.lastRuns
+}
+
+// trackMisfire đăng ký job cần chạy bù khi scheduler khởi động.
+func (m *manager) trackMisfire(job *gocron.Job, spec jobSpec, policy MisfirePolicy, run func() error) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	if policy.limit() == 0 {
+		return nil
+	}
+
+	next, err := spec.nextFunc(m.Scheduler.Location())
+	if err != nil {
+		return err
+	}
+
+	m.misfireMu.Lock()
+	defer m.misfireMu.Unlock()
+	if m.misfireJobs == nil {
+		m.misfireJobs = make(map[*gocron.Job]misfireJob)
+	}
+	m.misfireJobs[job] = misfireJob{
+		policy:    policy,
+		next:      next,
+		immediate: spec.runsImmediately(),
+		run:       run,
+	}
+	return nil
+}
+
+// recordLastRun ghi nhận thời điểm chạy của job vào LastRunStore nếu được thiết lập.
+// Lỗi khi ghi không ảnh hưởng tới kết quả của job.
+func (m *manager) recordLastRun(job *gocron.Job, startedAt time.Time) {
+	store := m.lastRunStore()
+	if store == nil || job == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = store.SetLastRunAt(ctx, job.GetName(), startedAt)
+}
+
+// catchUpMissedRuns chạy bù các job có chính sách chạy bù.
+//
+// Số lần chạy bù được tính trước khi trả về (trước khi gocron chạy job lần đầu và
+// ghi đè thời điểm chạy gần nhất), sau đó mỗi job được chạy bù trong một goroutine
+// riêng. Được gọi khi scheduler khởi động, hoặc khi instance trở thành leader nếu
+// bật leader election.
+func (m *manager) catchUpMissedRuns() {
+	store := m.lastRunStore()
+	if store == nil {
+		return
+	}
+
+	// Chỉ giữ lại các job còn trong scheduler
+	current := make(map[*gocron.Job]bool)
+	for _, job := range m.Scheduler.Jobs() {
+		current[job] = true
+	}
+
+	m.misfireMu.Lock()
+	jobs := make(map[*gocron.Job]misfireJob, len(m.misfireJobs))
+	for job, misfire := range m.misfireJobs {
+		if !current[job] {
+			delete(m.misfireJobs, job)
+			continue
+		}
+		jobs[job] = misfire
+	}
+	m.misfireMu.Unlock()
+
+	ctx := m.runContext()
+	for job, misfire := range jobs {
+		runs := m.claimMissedRuns(ctx, store, job.GetName(), misfire)
+		if runs <= 0 {
+			continue
+		}
+		go func(run func() error, runs int) {
+			for i := 0; i < runs && ctx.Err() == nil; i++ {
+				_ = run()
+			}
+		}(misfire.run, runs)
+	}
+}
+
+// claimMissedRuns tính số lần cần chạy bù cho job và ghi nhận thời điểm hiện tại
+// vào LastRunStore để các instance khác không chạy bù lặp lại.
+//
+// Khi có distributed locker, việc tính toán được thực hiện dưới khóa phân tán;
+// instance không lấy được khóa bỏ qua việc chạy bù.
+func (m *manager) claimMissedRuns(ctx context.Context, store LastRunStore, name string, misfire misfireJob) int {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if m.locker != nil {
+		lock, err := m.locker.Lock(ctx, misfireLockPrefix+name)
+		if err != nil {
+			return 0
+		}
+		defer func() { _ = lock.Unlock(ctx) }()
+	}
+
+	last, err := store.LastRunAt(ctx, name)
+	if err != nil {
+		// Job chưa từng chạy hoặc không đọc được thời điểm chạy: không chạy bù
+		return 0
+	}
+
+	now := m.now()
+	runs := missedRuns(misfire.next, last, now, misfire.policy.limit())
+	if misfire.immediate {
+		// gocron đã chạy ngay job theo khoảng thời gian khi khởi động
+		runs--
+	}
+	if runs <= 0 {
+		return 0
+	}
+
+	if err := store.SetLastRunAt(ctx, name, now); err != nil {
+		return 0
+	}
+	return runs
+}
+
+// missedRuns đếm số thời điểm chạy trong khoảng (last, now], tối đa limit.
+func missedRuns(next func(time.Time) time.Time, last, now time.Time, limit int) int {
+	count := 0
+	for t := next(last); count < limit && !t.IsZero() && !t.After(now); t = next(t) {
+		count++
+	}
+	return count
+}
+
+// Error constants cho chạy bù
+var (
+	// ErrInvalidMisfirePolicy được trả về khi chính sách chạy bù không hợp lệ.
+	ErrInvalidMisfirePolicy = errors.New("scheduler: invalid misfire policy")
+
+	// ErrUnsupportedMisfireSchedule được trả về khi đặt chính sách chạy bù cho job
+	// có lịch không tính được thời điểm chạy (ví dụ job dùng At).
+	ErrUnsupportedMisfireSchedule = errors.New("scheduler: misfire policy requires a cron or fixed interval schedule")
+
+	// ErrUnsupportedLastRunDriver được trả về khi driver lưu thời điểm chạy không được hỗ trợ.
+	ErrUnsupportedLastRunDriver = errors.New("scheduler: unsupported misfire store driver")
+)
@@ -0,0 +1,284 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMisfirePolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  MisfirePolicy
+		wantErr bool
+	}{
+		{"empty", MisfirePolicy{}, false},
+		{"skip", MisfirePolicy{Mode: MisfireSkip}, false},
+		{"run once", MisfirePolicy{Mode: MisfireRunOnce}, false},
+		{"run all", MisfirePolicy{Mode: MisfireRunAll, MaxRuns: 3}, false},
+		{"run all without max runs", MisfirePolicy{Mode: MisfireRunAll}, true},
+		{"unknown mode", MisfirePolicy{Mode: "later"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.validate()
+			if tt.wantErr != errors.Is(err, ErrInvalidMisfirePolicy) {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMissedRuns(t *testing.T) {
+	spec := jobSpec{cron: "0 * * * *"}
+	next, err := spec.nextFunc(time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to parse cron spec: %v", err)
+	}
+
+	last := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	if got := missedRuns(next, last, now, 10); got != 4 {
+		t.Errorf("Expected 4 missed runs, got %d", got)
+	}
+	if got := missedRuns(next, last, now, 2); got != 2 {
+		t.Errorf("Expected missed runs capped at 2, got %d", got)
+	}
+	if got := missedRuns(next, now, now, 10); got != 0 {
+		t.Errorf("Expected no missed runs, got %d", got)
+	}
+}
+
+func TestJobSpecNextFunc(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	interval, err := jobSpec{every: 2, unit: time.Hour}.nextFunc(time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to build interval spec: %v", err)
+	}
+	if got := interval(base); !got.Equal(base.Add(2 * time.Hour)) {
+		t.Errorf("Expected next run after 2h, got %v", got)
+	}
+
+	seconds, err := jobSpec{cron: "*/30 * * * * *", withSeconds: true}.nextFunc(time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to build cron spec with seconds: %v", err)
+	}
+	if got := seconds(base); !got.Equal(base.Add(30 * time.Second)) {
+		t.Errorf("Expected next run after 30s, got %v", got)
+	}
+
+	if _, err := (jobSpec{every: 1, unit: 24 * time.Hour, at: []string{"10:00"}}).nextFunc(time.UTC); !errors.Is(err, ErrUnsupportedMisfireSchedule) {
+		t.Errorf("Expected ErrUnsupportedMisfireSchedule for At schedule, got %v", err)
+	}
+}
+
+func TestMemoryLastRunStore(t *testing.T) {
+	testLastRunStore(t, NewMemoryLastRunStore())
+}
+
+func TestRedisLastRunStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	store, err := NewRedisLastRunStore(client, LastRunOptions{KeyPrefix: "last_run:"})
+	if err != nil {
+		t.Fatalf("Failed to create redis last run store: %v", err)
+	}
+	testLastRunStore(t, store)
+
+	if !mr.Exists("last_run:report") {
+		t.Error("Expected last run stored under key prefix")
+	}
+}
+
+// testLastRunStore kiểm tra hành vi chung của các LastRunStore.
+func testLastRunStore(t *testing.T, store LastRunStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := store.LastRunAt(ctx, "report"); !errors.Is(err, ErrNoJobRuns) {
+		t.Fatalf("Expected ErrNoJobRuns, got %v", err)
+	}
+
+	at := time.Now().Truncate(time.Millisecond)
+	if err := store.SetLastRunAt(ctx, "report", at); err != nil {
+		t.Fatalf("Failed to set last run: %v", err)
+	}
+	// Thời điểm cũ hơn không ghi đè thời điểm đã lưu
+	if err := store.SetLastRunAt(ctx, "report", at.Add(-time.Hour)); err != nil {
+		t.Fatalf("Failed to set last run: %v", err)
+	}
+
+	got, err := store.LastRunAt(ctx, "report")
+	if err != nil {
+		t.Fatalf("Failed to get last run: %v", err)
+	}
+	if !got.Equal(at) {
+		t.Errorf("Expected last run %v, got %v", at, got)
+	}
+}
+
+func TestSchedulerMisfireCatchUp(t *testing.T) {
+	// Lần chạy gần nhất cách đây hơn 3 giờ: bỏ lỡ 3 mốc đầu giờ
+	last := time.Now().Truncate(time.Hour).Add(-3 * time.Hour).Add(time.Minute)
+
+	tests := []struct {
+		name   string
+		policy MisfirePolicy
+		want   int32
+	}{
+		{"skip", MisfirePolicy{Mode: MisfireSkip}, 0},
+		{"run once", MisfirePolicy{Mode: MisfireRunOnce}, 1},
+		{"run all", MisfirePolicy{Mode: MisfireRunAll, MaxRuns: 10}, 3},
+		{"run all capped", MisfirePolicy{Mode: MisfireRunAll, MaxRuns: 2}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryLastRunStore()
+			if err := store.SetLastRunAt(context.Background(), "hourly", last); err != nil {
+				t.Fatalf("Failed to set last run: %v", err)
+			}
+
+			sched := NewScheduler().WithLastRunStore(store)
+			var runs atomic.Int32
+			_, err := sched.Cron("0 * * * *").Name("hourly").Misfire(tt.policy).Do(func() {
+				runs.Add(1)
+			})
+			if err != nil {
+				t.Fatalf("Failed to schedule job: %v", err)
+			}
+
+			sched.StartAsync()
+			defer sched.Stop()
+
+			if tt.want > 0 {
+				waitFor(t, func() bool { return runs.Load() == tt.want }, "missed runs were not caught up")
+			}
+			time.Sleep(50 * time.Millisecond)
+			if got := runs.Load(); got != tt.want {
+				t.Errorf("Expected %d catch-up runs, got %d", tt.want, got)
+			}
+
+			if tt.want > 0 {
+				at, _ := store.LastRunAt(context.Background(), "hourly")
+				if !at.After(last) {
+					t.Error("Expected last run to be updated after catch-up")
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulerMisfireIntervalCountsImmediateRun(t *testing.T) {
+	store := NewMemoryLastRunStore()
+	if err := store.SetLastRunAt(context.Background(), "interval", time.Now().Add(-3*time.Hour-30*time.Minute)); err != nil {
+		t.Fatalf("Failed to set last run: %v", err)
+	}
+
+	sched := NewScheduler().WithLastRunStore(store)
+	var runs atomic.Int32
+	_, err := sched.Every(1).Hours().Name("interval").Misfire(MisfirePolicy{Mode: MisfireRunAll, MaxRuns: 10}).Do(func() {
+		runs.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	// 3 lần bị lỡ: gocron chạy ngay một lần khi khởi động, scheduler chạy bù hai lần
+	waitFor(t, func() bool { return runs.Load() == 3 }, "missed runs were not caught up")
+	time.Sleep(50 * time.Millisecond)
+	if got := runs.Load(); got != 3 {
+		t.Errorf("Expected 3 runs, got %d", got)
+	}
+}
+
+func TestSchedulerMisfireFirstStartDoesNotCatchUp(t *testing.T) {
+	store := NewMemoryLastRunStore()
+	sched := NewScheduler().WithLastRunStore(store)
+
+	var runs atomic.Int32
+	_, err := sched.Cron("0 0 1 1 *").Name("yearly").Misfire(MisfirePolicy{Mode: MisfireRunOnce}).Do(func() {
+		runs.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Errorf("Expected no catch-up run without a recorded last run, got %d", got)
+	}
+}
+
+func TestSchedulerMisfireInvalid(t *testing.T) {
+	sched := NewScheduler()
+
+	_, err := sched.Every(1).Days().At("10:00").Misfire(MisfirePolicy{Mode: MisfireRunOnce}).Do(func() {})
+	if !errors.Is(err, ErrUnsupportedMisfireSchedule) {
+		t.Errorf("Expected ErrUnsupportedMisfireSchedule, got %v", err)
+	}
+
+	_, err = sched.Cron("* * * * *").Misfire(MisfirePolicy{Mode: MisfireRunAll}).Do(func() {})
+	if !errors.Is(err, ErrInvalidMisfirePolicy) {
+		t.Errorf("Expected ErrInvalidMisfirePolicy, got %v", err)
+	}
+
+	if jobs := sched.GetScheduler().Jobs(); len(jobs) != 0 {
+		t.Errorf("Expected rejected jobs to be removed, got %d jobs", len(jobs))
+	}
+}
+
+func TestSchedulerMisfireSharedStoreCatchesUpOnce(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	last := time.Now().Truncate(time.Hour).Add(-2 * time.Hour).Add(time.Minute)
+	var runs atomic.Int32
+	for i := 0; i < 2; i++ {
+		store, err := NewRedisLastRunStore(client)
+		if err != nil {
+			t.Fatalf("Failed to create redis last run store: %v", err)
+		}
+		if i == 0 {
+			if err := store.SetLastRunAt(context.Background(), "hourly", last); err != nil {
+				t.Fatalf("Failed to set last run: %v", err)
+			}
+		}
+		locker, err := NewRedisLocker(client)
+		if err != nil {
+			t.Fatalf("Failed to create redis locker: %v", err)
+		}
+
+		sched := NewScheduler().WithDistributedLocker(locker).WithLastRunStore(store)
+		_, err = sched.Cron("0 * * * *").Name("hourly").Misfire(MisfirePolicy{Mode: MisfireRunAll, MaxRuns: 10}).Do(func() {
+			runs.Add(1)
+		})
+		if err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		sched.StartAsync()
+		defer sched.Stop()
+	}
+
+	waitFor(t, func() bool { return runs.Load() == 2 }, "missed runs were not caught up")
+	time.Sleep(50 * time.Millisecond)
+	if got := runs.Load(); got != 2 {
+		t.Errorf("Expected missed runs caught up once across instances, got %d", got)
+	}
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	gocron "github.com/go-co-op/gocron"
 	mock "github.com/stretchr/testify/mock"
 
@@ -24,6 +26,52 @@ func (_m *MockManager) EXPECT() *MockManager_Expecter {
 	return &MockManager_Expecter{mock: &_m.Mock}
 }
 
+// ApplyJobs provides a mock function with given fields: jobs
+func (_m *MockManager) ApplyJobs(jobs []scheduler.JobConfig) error {
+	ret := _m.Called(jobs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyJobs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]scheduler.JobConfig) error); ok {
+		r0 = rf(jobs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_ApplyJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyJobs'
+type MockManager_ApplyJobs_Call struct {
+	*mock.Call
+}
+
+// ApplyJobs is a helper method to define mock.On call
+//   - jobs []scheduler.JobConfig
+func (_e *MockManager_Expecter) ApplyJobs(jobs interface{}) *MockManager_ApplyJobs_Call {
+	return &MockManager_ApplyJobs_Call{Call: _e.mock.On("ApplyJobs", jobs)}
+}
+
+func (_c *MockManager_ApplyJobs_Call) Run(run func(jobs []scheduler.JobConfig)) *MockManager_ApplyJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]scheduler.JobConfig))
+	})
+	return _c
+}
+
+func (_c *MockManager_ApplyJobs_Call) Return(_a0 error) *MockManager_ApplyJobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_ApplyJobs_Call) RunAndReturn(run func([]scheduler.JobConfig) error) *MockManager_ApplyJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // At provides a mock function with given fields: _a0
 func (_m *MockManager) At(_a0 string) scheduler.Manager {
 	ret := _m.Called(_a0)
@@ -72,6 +120,52 @@ func (_c *MockManager_At_Call) RunAndReturn(run func(string) scheduler.Manager)
 	return _c
 }
 
+// CancelOnce provides a mock function with given fields: name
+func (_m *MockManager) CancelOnce(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelOnce")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_CancelOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelOnce'
+type MockManager_CancelOnce_Call struct {
+	*mock.Call
+}
+
+// CancelOnce is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) CancelOnce(name interface{}) *MockManager_CancelOnce_Call {
+	return &MockManager_CancelOnce_Call{Call: _e.mock.On("CancelOnce", name)}
+}
+
+func (_c *MockManager_CancelOnce_Call) Run(run func(name string)) *MockManager_CancelOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_CancelOnce_Call) Return(_a0 error) *MockManager_CancelOnce_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_CancelOnce_Call) RunAndReturn(run func(string) error) *MockManager_CancelOnce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Clear provides a mock function with no fields
 func (_m *MockManager) Clear() {
 	_m.Called()
@@ -316,6 +410,152 @@ func (_c *MockManager_Do_Call) RunAndReturn(run func(interface{}, ...interface{}
 	return _c
 }
 
+// DoWithContext provides a mock function with given fields: jobFun, opts
+func (_m *MockManager) DoWithContext(jobFun func(context.Context) error, opts ...scheduler.JobOption) (*gocron.Job, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobFun)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoWithContext")
+	}
+
+	var r0 *gocron.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(func(context.Context) error, ...scheduler.JobOption) (*gocron.Job, error)); ok {
+		return rf(jobFun, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(func(context.Context) error, ...scheduler.JobOption) *gocron.Job); ok {
+		r0 = rf(jobFun, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocron.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(func(context.Context) error, ...scheduler.JobOption) error); ok {
+		r1 = rf(jobFun, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_DoWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DoWithContext'
+type MockManager_DoWithContext_Call struct {
+	*mock.Call
+}
+
+// DoWithContext is a helper method to define mock.On call
+//   - jobFun func(context.Context) error
+//   - opts ...scheduler.JobOption
+func (_e *MockManager_Expecter) DoWithContext(jobFun interface{}, opts ...interface{}) *MockManager_DoWithContext_Call {
+	return &MockManager_DoWithContext_Call{Call: _e.mock.On("DoWithContext",
+		append([]interface{}{jobFun}, opts...)...)}
+}
+
+func (_c *MockManager_DoWithContext_Call) Run(run func(jobFun func(context.Context) error, opts ...scheduler.JobOption)) *MockManager_DoWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]scheduler.JobOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(scheduler.JobOption)
+			}
+		}
+		run(args[0].(func(context.Context) error), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_DoWithContext_Call) Return(_a0 *gocron.Job, _a1 error) *MockManager_DoWithContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_DoWithContext_Call) RunAndReturn(run func(func(context.Context) error, ...scheduler.JobOption) (*gocron.Job, error)) *MockManager_DoWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DoWorkflow provides a mock function with given fields: workflow, opts
+func (_m *MockManager) DoWorkflow(workflow *scheduler.Workflow, opts ...scheduler.JobOption) (*gocron.Job, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, workflow)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoWorkflow")
+	}
+
+	var r0 *gocron.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*scheduler.Workflow, ...scheduler.JobOption) (*gocron.Job, error)); ok {
+		return rf(workflow, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(*scheduler.Workflow, ...scheduler.JobOption) *gocron.Job); ok {
+		r0 = rf(workflow, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocron.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*scheduler.Workflow, ...scheduler.JobOption) error); ok {
+		r1 = rf(workflow, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_DoWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DoWorkflow'
+type MockManager_DoWorkflow_Call struct {
+	*mock.Call
+}
+
+// DoWorkflow is a helper method to define mock.On call
+//   - workflow *scheduler.Workflow
+//   - opts ...scheduler.JobOption
+func (_e *MockManager_Expecter) DoWorkflow(workflow interface{}, opts ...interface{}) *MockManager_DoWorkflow_Call {
+	return &MockManager_DoWorkflow_Call{Call: _e.mock.On("DoWorkflow",
+		append([]interface{}{workflow}, opts...)...)}
+}
+
+func (_c *MockManager_DoWorkflow_Call) Run(run func(workflow *scheduler.Workflow, opts ...scheduler.JobOption)) *MockManager_DoWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]scheduler.JobOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(scheduler.JobOption)
+			}
+		}
+		run(args[0].(*scheduler.Workflow), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_DoWorkflow_Call) Return(_a0 *gocron.Job, _a1 error) *MockManager_DoWorkflow_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_DoWorkflow_Call) RunAndReturn(run func(*scheduler.Workflow, ...scheduler.JobOption) (*gocron.Job, error)) *MockManager_DoWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Every provides a mock function with given fields: interval
 func (_m *MockManager) Every(interval interface{}) scheduler.Manager {
 	ret := _m.Called(interval)
@@ -482,6 +722,64 @@ func (_c *MockManager_GetScheduler_Call) RunAndReturn(run func() *gocron.Schedul
 	return _c
 }
 
+// HeldLock provides a mock function with given fields: key
+func (_m *MockManager) HeldLock(key string) (scheduler.FencedLock, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeldLock")
+	}
+
+	var r0 scheduler.FencedLock
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (scheduler.FencedLock, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) scheduler.FencedLock); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.FencedLock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_HeldLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeldLock'
+type MockManager_HeldLock_Call struct {
+	*mock.Call
+}
+
+// HeldLock is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) HeldLock(key interface{}) *MockManager_HeldLock_Call {
+	return &MockManager_HeldLock_Call{Call: _e.mock.On("HeldLock", key)}
+}
+
+func (_c *MockManager_HeldLock_Call) Run(run func(key string)) *MockManager_HeldLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_HeldLock_Call) Return(_a0 scheduler.FencedLock, _a1 bool) *MockManager_HeldLock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_HeldLock_Call) RunAndReturn(run func(string) (scheduler.FencedLock, bool)) *MockManager_HeldLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Hours provides a mock function with no fields
 func (_m *MockManager) Hours() scheduler.Manager {
 	ret := _m.Called()
@@ -529,12 +827,12 @@ func (_c *MockManager_Hours_Call) RunAndReturn(run func() scheduler.Manager) *Mo
 	return _c
 }
 
-// IsRunning provides a mock function with no fields
-func (_m *MockManager) IsRunning() bool {
+// IsLeader provides a mock function with no fields
+func (_m *MockManager) IsLeader() bool {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsRunning")
+		panic("no return value specified for IsLeader")
 	}
 
 	var r0 bool
@@ -547,91 +845,89 @@ func (_m *MockManager) IsRunning() bool {
 	return r0
 }
 
-// MockManager_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
-type MockManager_IsRunning_Call struct {
+// MockManager_IsLeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLeader'
+type MockManager_IsLeader_Call struct {
 	*mock.Call
 }
 
-// IsRunning is a helper method to define mock.On call
-func (_e *MockManager_Expecter) IsRunning() *MockManager_IsRunning_Call {
-	return &MockManager_IsRunning_Call{Call: _e.mock.On("IsRunning")}
+// IsLeader is a helper method to define mock.On call
+func (_e *MockManager_Expecter) IsLeader() *MockManager_IsLeader_Call {
+	return &MockManager_IsLeader_Call{Call: _e.mock.On("IsLeader")}
 }
 
-func (_c *MockManager_IsRunning_Call) Run(run func()) *MockManager_IsRunning_Call {
+func (_c *MockManager_IsLeader_Call) Run(run func()) *MockManager_IsLeader_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockManager_IsRunning_Call) Return(_a0 bool) *MockManager_IsRunning_Call {
+func (_c *MockManager_IsLeader_Call) Return(_a0 bool) *MockManager_IsLeader_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_IsRunning_Call) RunAndReturn(run func() bool) *MockManager_IsRunning_Call {
+func (_c *MockManager_IsLeader_Call) RunAndReturn(run func() bool) *MockManager_IsLeader_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Minutes provides a mock function with no fields
-func (_m *MockManager) Minutes() scheduler.Manager {
+// IsRunning provides a mock function with no fields
+func (_m *MockManager) IsRunning() bool {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Minutes")
+		panic("no return value specified for IsRunning")
 	}
 
-	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(scheduler.Manager)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// MockManager_Minutes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Minutes'
-type MockManager_Minutes_Call struct {
+// MockManager_IsRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRunning'
+type MockManager_IsRunning_Call struct {
 	*mock.Call
 }
 
-// Minutes is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Minutes() *MockManager_Minutes_Call {
-	return &MockManager_Minutes_Call{Call: _e.mock.On("Minutes")}
+// IsRunning is a helper method to define mock.On call
+func (_e *MockManager_Expecter) IsRunning() *MockManager_IsRunning_Call {
+	return &MockManager_IsRunning_Call{Call: _e.mock.On("IsRunning")}
 }
 
-func (_c *MockManager_Minutes_Call) Run(run func()) *MockManager_Minutes_Call {
+func (_c *MockManager_IsRunning_Call) Run(run func()) *MockManager_IsRunning_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockManager_Minutes_Call) Return(_a0 scheduler.Manager) *MockManager_Minutes_Call {
+func (_c *MockManager_IsRunning_Call) Return(_a0 bool) *MockManager_IsRunning_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Minutes_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Minutes_Call {
+func (_c *MockManager_IsRunning_Call) RunAndReturn(run func() bool) *MockManager_IsRunning_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Name provides a mock function with given fields: name
-func (_m *MockManager) Name(name string) scheduler.Manager {
-	ret := _m.Called(name)
+// Jitter provides a mock function with given fields: maxDelay
+func (_m *MockManager) Jitter(maxDelay time.Duration) scheduler.Manager {
+	ret := _m.Called(maxDelay)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Name")
+		panic("no return value specified for Jitter")
 	}
 
 	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func(string) scheduler.Manager); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(time.Duration) scheduler.Manager); ok {
+		r0 = rf(maxDelay)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(scheduler.Manager)
@@ -641,39 +937,377 @@ func (_m *MockManager) Name(name string) scheduler.Manager {
 	return r0
 }
 
-// MockManager_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
-type MockManager_Name_Call struct {
+// MockManager_Jitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Jitter'
+type MockManager_Jitter_Call struct {
 	*mock.Call
 }
 
-// Name is a helper method to define mock.On call
-//   - name string
-func (_e *MockManager_Expecter) Name(name interface{}) *MockManager_Name_Call {
-	return &MockManager_Name_Call{Call: _e.mock.On("Name", name)}
+// Jitter is a helper method to define mock.On call
+//   - maxDelay time.Duration
+func (_e *MockManager_Expecter) Jitter(maxDelay interface{}) *MockManager_Jitter_Call {
+	return &MockManager_Jitter_Call{Call: _e.mock.On("Jitter", maxDelay)}
 }
 
-func (_c *MockManager_Name_Call) Run(run func(name string)) *MockManager_Name_Call {
+func (_c *MockManager_Jitter_Call) Run(run func(maxDelay time.Duration)) *MockManager_Jitter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_Name_Call) Return(_a0 scheduler.Manager) *MockManager_Name_Call {
+func (_c *MockManager_Jitter_Call) Return(_a0 scheduler.Manager) *MockManager_Jitter_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Name_Call) RunAndReturn(run func(string) scheduler.Manager) *MockManager_Name_Call {
+func (_c *MockManager_Jitter_Call) RunAndReturn(run func(time.Duration) scheduler.Manager) *MockManager_Jitter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegisterEventListeners provides a mock function with given fields: eventListeners
-func (_m *MockManager) RegisterEventListeners(eventListeners ...gocron.EventListener) {
-	_va := make([]interface{}, len(eventListeners))
-	for _i := range eventListeners {
-		_va[_i] = eventListeners[_i]
+// Jobs provides a mock function with no fields
+func (_m *MockManager) Jobs() []scheduler.JobInfo {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Jobs")
+	}
+
+	var r0 []scheduler.JobInfo
+	if rf, ok := ret.Get(0).(func() []scheduler.JobInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scheduler.JobInfo)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Jobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Jobs'
+type MockManager_Jobs_Call struct {
+	*mock.Call
+}
+
+// Jobs is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Jobs() *MockManager_Jobs_Call {
+	return &MockManager_Jobs_Call{Call: _e.mock.On("Jobs")}
+}
+
+func (_c *MockManager_Jobs_Call) Run(run func()) *MockManager_Jobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Jobs_Call) Return(_a0 []scheduler.JobInfo) *MockManager_Jobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Jobs_Call) RunAndReturn(run func() []scheduler.JobInfo) *MockManager_Jobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastRun provides a mock function with given fields: name
+func (_m *MockManager) LastRun(name string) (scheduler.JobRun, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastRun")
+	}
+
+	var r0 scheduler.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (scheduler.JobRun, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) scheduler.JobRun); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(scheduler.JobRun)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_LastRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastRun'
+type MockManager_LastRun_Call struct {
+	*mock.Call
+}
+
+// LastRun is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) LastRun(name interface{}) *MockManager_LastRun_Call {
+	return &MockManager_LastRun_Call{Call: _e.mock.On("LastRun", name)}
+}
+
+func (_c *MockManager_LastRun_Call) Run(run func(name string)) *MockManager_LastRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_LastRun_Call) Return(_a0 scheduler.JobRun, _a1 error) *MockManager_LastRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_LastRun_Call) RunAndReturn(run func(string) (scheduler.JobRun, error)) *MockManager_LastRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Minutes provides a mock function with no fields
+func (_m *MockManager) Minutes() scheduler.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Minutes")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Minutes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Minutes'
+type MockManager_Minutes_Call struct {
+	*mock.Call
+}
+
+// Minutes is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Minutes() *MockManager_Minutes_Call {
+	return &MockManager_Minutes_Call{Call: _e.mock.On("Minutes")}
+}
+
+func (_c *MockManager_Minutes_Call) Run(run func()) *MockManager_Minutes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Minutes_Call) Return(_a0 scheduler.Manager) *MockManager_Minutes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Minutes_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Minutes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Misfire provides a mock function with given fields: policy
+func (_m *MockManager) Misfire(policy scheduler.MisfirePolicy) scheduler.Manager {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Misfire")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.MisfirePolicy) scheduler.Manager); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Misfire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Misfire'
+type MockManager_Misfire_Call struct {
+	*mock.Call
+}
+
+// Misfire is a helper method to define mock.On call
+//   - policy scheduler.MisfirePolicy
+func (_e *MockManager_Expecter) Misfire(policy interface{}) *MockManager_Misfire_Call {
+	return &MockManager_Misfire_Call{Call: _e.mock.On("Misfire", policy)}
+}
+
+func (_c *MockManager_Misfire_Call) Run(run func(policy scheduler.MisfirePolicy)) *MockManager_Misfire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.MisfirePolicy))
+	})
+	return _c
+}
+
+func (_c *MockManager_Misfire_Call) Return(_a0 scheduler.Manager) *MockManager_Misfire_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Misfire_Call) RunAndReturn(run func(scheduler.MisfirePolicy) scheduler.Manager) *MockManager_Misfire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with given fields: name
+func (_m *MockManager) Name(name string) scheduler.Manager {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(string) scheduler.Manager); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockManager_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Name(name interface{}) *MockManager_Name_Call {
+	return &MockManager_Name_Call{Call: _e.mock.On("Name", name)}
+}
+
+func (_c *MockManager_Name_Call) Run(run func(name string)) *MockManager_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Name_Call) Return(_a0 scheduler.Manager) *MockManager_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Name_Call) RunAndReturn(run func(string) scheduler.Manager) *MockManager_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseByTag provides a mock function with given fields: tag
+func (_m *MockManager) PauseByTag(tag string) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_PauseByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseByTag'
+type MockManager_PauseByTag_Call struct {
+	*mock.Call
+}
+
+// PauseByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) PauseByTag(tag interface{}) *MockManager_PauseByTag_Call {
+	return &MockManager_PauseByTag_Call{Call: _e.mock.On("PauseByTag", tag)}
+}
+
+func (_c *MockManager_PauseByTag_Call) Run(run func(tag string)) *MockManager_PauseByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_PauseByTag_Call) Return(_a0 error) *MockManager_PauseByTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_PauseByTag_Call) RunAndReturn(run func(string) error) *MockManager_PauseByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseJob provides a mock function with given fields: name
+func (_m *MockManager) PauseJob(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_PauseJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseJob'
+type MockManager_PauseJob_Call struct {
+	*mock.Call
+}
+
+// PauseJob is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) PauseJob(name interface{}) *MockManager_PauseJob_Call {
+	return &MockManager_PauseJob_Call{Call: _e.mock.On("PauseJob", name)}
+}
+
+func (_c *MockManager_PauseJob_Call) Run(run func(name string)) *MockManager_PauseJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_PauseJob_Call) Return(_a0 error) *MockManager_PauseJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_PauseJob_Call) RunAndReturn(run func(string) error) *MockManager_PauseJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterEventListeners provides a mock function with given fields: eventListeners
+func (_m *MockManager) RegisterEventListeners(eventListeners ...gocron.EventListener) {
+	_va := make([]interface{}, len(eventListeners))
+	for _i := range eventListeners {
+		_va[_i] = eventListeners[_i]
 	}
 	var _ca []interface{}
 	_ca = append(_ca, _va...)
@@ -720,64 +1354,314 @@ func (_m *MockManager) RemoveByTag(tag string) error {
 	ret := _m.Called(tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveByTag")
+		panic("no return value specified for RemoveByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_RemoveByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByTag'
+type MockManager_RemoveByTag_Call struct {
+	*mock.Call
+}
+
+// RemoveByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) RemoveByTag(tag interface{}) *MockManager_RemoveByTag_Call {
+	return &MockManager_RemoveByTag_Call{Call: _e.mock.On("RemoveByTag", tag)}
+}
+
+func (_c *MockManager_RemoveByTag_Call) Run(run func(tag string)) *MockManager_RemoveByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_RemoveByTag_Call) Return(_a0 error) *MockManager_RemoveByTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_RemoveByTag_Call) RunAndReturn(run func(string) error) *MockManager_RemoveByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveByTags provides a mock function with given fields: tags
+func (_m *MockManager) RemoveByTags(tags ...string) error {
+	_va := make([]interface{}, len(tags))
+	for _i := range tags {
+		_va[_i] = tags[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveByTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...string) error); ok {
+		r0 = rf(tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_RemoveByTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByTags'
+type MockManager_RemoveByTags_Call struct {
+	*mock.Call
+}
+
+// RemoveByTags is a helper method to define mock.On call
+//   - tags ...string
+func (_e *MockManager_Expecter) RemoveByTags(tags ...interface{}) *MockManager_RemoveByTags_Call {
+	return &MockManager_RemoveByTags_Call{Call: _e.mock.On("RemoveByTags",
+		append([]interface{}{}, tags...)...)}
+}
+
+func (_c *MockManager_RemoveByTags_Call) Run(run func(tags ...string)) *MockManager_RemoveByTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_RemoveByTags_Call) Return(_a0 error) *MockManager_RemoveByTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_RemoveByTags_Call) RunAndReturn(run func(...string) error) *MockManager_RemoveByTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeByTag provides a mock function with given fields: tag
+func (_m *MockManager) ResumeByTag(tag string) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_ResumeByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeByTag'
+type MockManager_ResumeByTag_Call struct {
+	*mock.Call
+}
+
+// ResumeByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) ResumeByTag(tag interface{}) *MockManager_ResumeByTag_Call {
+	return &MockManager_ResumeByTag_Call{Call: _e.mock.On("ResumeByTag", tag)}
+}
+
+func (_c *MockManager_ResumeByTag_Call) Run(run func(tag string)) *MockManager_ResumeByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_ResumeByTag_Call) Return(_a0 error) *MockManager_ResumeByTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_ResumeByTag_Call) RunAndReturn(run func(string) error) *MockManager_ResumeByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeJob provides a mock function with given fields: name
+func (_m *MockManager) ResumeJob(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_ResumeJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeJob'
+type MockManager_ResumeJob_Call struct {
+	*mock.Call
+}
+
+// ResumeJob is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) ResumeJob(name interface{}) *MockManager_ResumeJob_Call {
+	return &MockManager_ResumeJob_Call{Call: _e.mock.On("ResumeJob", name)}
+}
+
+func (_c *MockManager_ResumeJob_Call) Run(run func(name string)) *MockManager_ResumeJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_ResumeJob_Call) Return(_a0 error) *MockManager_ResumeJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_ResumeJob_Call) RunAndReturn(run func(string) error) *MockManager_ResumeJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunNow provides a mock function with given fields: name
+func (_m *MockManager) RunNow(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunNow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_RunNow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunNow'
+type MockManager_RunNow_Call struct {
+	*mock.Call
+}
+
+// RunNow is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) RunNow(name interface{}) *MockManager_RunNow_Call {
+	return &MockManager_RunNow_Call{Call: _e.mock.On("RunNow", name)}
+}
+
+func (_c *MockManager_RunNow_Call) Run(run func(name string)) *MockManager_RunNow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_RunNow_Call) Return(_a0 error) *MockManager_RunNow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_RunNow_Call) RunAndReturn(run func(string) error) *MockManager_RunNow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Runs provides a mock function with given fields: name, limit
+func (_m *MockManager) Runs(name string, limit int) ([]scheduler.JobRun, error) {
+	ret := _m.Called(name, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Runs")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(tag)
+	var r0 []scheduler.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]scheduler.JobRun, error)); ok {
+		return rf(name, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []scheduler.JobRun); ok {
+		r0 = rf(name, limit)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scheduler.JobRun)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(name, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_RemoveByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByTag'
-type MockManager_RemoveByTag_Call struct {
+// MockManager_Runs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Runs'
+type MockManager_Runs_Call struct {
 	*mock.Call
 }
 
-// RemoveByTag is a helper method to define mock.On call
-//   - tag string
-func (_e *MockManager_Expecter) RemoveByTag(tag interface{}) *MockManager_RemoveByTag_Call {
-	return &MockManager_RemoveByTag_Call{Call: _e.mock.On("RemoveByTag", tag)}
+// Runs is a helper method to define mock.On call
+//   - name string
+//   - limit int
+func (_e *MockManager_Expecter) Runs(name interface{}, limit interface{}) *MockManager_Runs_Call {
+	return &MockManager_Runs_Call{Call: _e.mock.On("Runs", name, limit)}
 }
 
-func (_c *MockManager_RemoveByTag_Call) Run(run func(tag string)) *MockManager_RemoveByTag_Call {
+func (_c *MockManager_Runs_Call) Run(run func(name string, limit int)) *MockManager_Runs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockManager_RemoveByTag_Call) Return(_a0 error) *MockManager_RemoveByTag_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_Runs_Call) Return(_a0 []scheduler.JobRun, _a1 error) *MockManager_Runs_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_RemoveByTag_Call) RunAndReturn(run func(string) error) *MockManager_RemoveByTag_Call {
+func (_c *MockManager_Runs_Call) RunAndReturn(run func(string, int) ([]scheduler.JobRun, error)) *MockManager_Runs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveByTags provides a mock function with given fields: tags
-func (_m *MockManager) RemoveByTags(tags ...string) error {
-	_va := make([]interface{}, len(tags))
-	for _i := range tags {
-		_va[_i] = tags[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// ScheduleOnce provides a mock function with given fields: name, at, handlerName, payload
+func (_m *MockManager) ScheduleOnce(name string, at time.Time, handlerName string, payload []byte) error {
+	ret := _m.Called(name, at, handlerName, payload)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveByTags")
+		panic("no return value specified for ScheduleOnce")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(...string) error); ok {
-		r0 = rf(tags...)
+	if rf, ok := ret.Get(0).(func(string, time.Time, string, []byte) error); ok {
+		r0 = rf(name, at, handlerName, payload)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -785,37 +1669,33 @@ func (_m *MockManager) RemoveByTags(tags ...string) error {
 	return r0
 }
 
-// MockManager_RemoveByTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByTags'
-type MockManager_RemoveByTags_Call struct {
+// MockManager_ScheduleOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleOnce'
+type MockManager_ScheduleOnce_Call struct {
 	*mock.Call
 }
 
-// RemoveByTags is a helper method to define mock.On call
-//   - tags ...string
-func (_e *MockManager_Expecter) RemoveByTags(tags ...interface{}) *MockManager_RemoveByTags_Call {
-	return &MockManager_RemoveByTags_Call{Call: _e.mock.On("RemoveByTags",
-		append([]interface{}{}, tags...)...)}
+// ScheduleOnce is a helper method to define mock.On call
+//   - name string
+//   - at time.Time
+//   - handlerName string
+//   - payload []byte
+func (_e *MockManager_Expecter) ScheduleOnce(name interface{}, at interface{}, handlerName interface{}, payload interface{}) *MockManager_ScheduleOnce_Call {
+	return &MockManager_ScheduleOnce_Call{Call: _e.mock.On("ScheduleOnce", name, at, handlerName, payload)}
 }
 
-func (_c *MockManager_RemoveByTags_Call) Run(run func(tags ...string)) *MockManager_RemoveByTags_Call {
+func (_c *MockManager_ScheduleOnce_Call) Run(run func(name string, at time.Time, handlerName string, payload []byte)) *MockManager_ScheduleOnce_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]string, len(args)-0)
-		for i, a := range args[0:] {
-			if a != nil {
-				variadicArgs[i] = a.(string)
-			}
-		}
-		run(variadicArgs...)
+		run(args[0].(string), args[1].(time.Time), args[2].(string), args[3].([]byte))
 	})
 	return _c
 }
 
-func (_c *MockManager_RemoveByTags_Call) Return(_a0 error) *MockManager_RemoveByTags_Call {
+func (_c *MockManager_ScheduleOnce_Call) Return(_a0 error) *MockManager_ScheduleOnce_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_RemoveByTags_Call) RunAndReturn(run func(...string) error) *MockManager_RemoveByTags_Call {
+func (_c *MockManager_ScheduleOnce_Call) RunAndReturn(run func(string, time.Time, string, []byte) error) *MockManager_ScheduleOnce_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -961,6 +1841,54 @@ func (_c *MockManager_SingletonMode_Call) RunAndReturn(run func() scheduler.Mana
 	return _c
 }
 
+// Spread provides a mock function with given fields: window
+func (_m *MockManager) Spread(window time.Duration) scheduler.Manager {
+	ret := _m.Called(window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Spread")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(time.Duration) scheduler.Manager); ok {
+		r0 = rf(window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Spread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Spread'
+type MockManager_Spread_Call struct {
+	*mock.Call
+}
+
+// Spread is a helper method to define mock.On call
+//   - window time.Duration
+func (_e *MockManager_Expecter) Spread(window interface{}) *MockManager_Spread_Call {
+	return &MockManager_Spread_Call{Call: _e.mock.On("Spread", window)}
+}
+
+func (_c *MockManager_Spread_Call) Run(run func(window time.Duration)) *MockManager_Spread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Spread_Call) Return(_a0 scheduler.Manager) *MockManager_Spread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Spread_Call) RunAndReturn(run func(time.Duration) scheduler.Manager) *MockManager_Spread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartAsync provides a mock function with no fields
 func (_m *MockManager) StartAsync() {
 	_m.Called()
@@ -1073,6 +2001,62 @@ func (_c *MockManager_StartBlocking_Call) RunAndReturn(run func()) *MockManager_
 	return _c
 }
 
+// Stats provides a mock function with given fields: name
+func (_m *MockManager) Stats(name string) (scheduler.JobStats, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 scheduler.JobStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (scheduler.JobStats, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) scheduler.JobStats); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(scheduler.JobStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockManager_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Stats(name interface{}) *MockManager_Stats_Call {
+	return &MockManager_Stats_Call{Call: _e.mock.On("Stats", name)}
+}
+
+func (_c *MockManager_Stats_Call) Run(run func(name string)) *MockManager_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Stats_Call) Return(_a0 scheduler.JobStats, _a1 error) *MockManager_Stats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Stats_Call) RunAndReturn(run func(string) (scheduler.JobStats, error)) *MockManager_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stop provides a mock function with no fields
 func (_m *MockManager) Stop() {
 	_m.Called()
@@ -1261,6 +2245,198 @@ func (_c *MockManager_WithDistributedLocker_Call) RunAndReturn(run func(gocron.L
 	return _c
 }
 
+// WithHistory provides a mock function with given fields: store
+func (_m *MockManager) WithHistory(store scheduler.HistoryStore) scheduler.Manager {
+	ret := _m.Called(store)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithHistory")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.HistoryStore) scheduler.Manager); ok {
+		r0 = rf(store)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_WithHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithHistory'
+type MockManager_WithHistory_Call struct {
+	*mock.Call
+}
+
+// WithHistory is a helper method to define mock.On call
+//   - store scheduler.HistoryStore
+func (_e *MockManager_Expecter) WithHistory(store interface{}) *MockManager_WithHistory_Call {
+	return &MockManager_WithHistory_Call{Call: _e.mock.On("WithHistory", store)}
+}
+
+func (_c *MockManager_WithHistory_Call) Run(run func(store scheduler.HistoryStore)) *MockManager_WithHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.HistoryStore))
+	})
+	return _c
+}
+
+func (_c *MockManager_WithHistory_Call) Return(_a0 scheduler.Manager) *MockManager_WithHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_WithHistory_Call) RunAndReturn(run func(scheduler.HistoryStore) scheduler.Manager) *MockManager_WithHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithJobStore provides a mock function with given fields: store
+func (_m *MockManager) WithJobStore(store scheduler.JobStore) scheduler.Manager {
+	ret := _m.Called(store)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithJobStore")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.JobStore) scheduler.Manager); ok {
+		r0 = rf(store)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_WithJobStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithJobStore'
+type MockManager_WithJobStore_Call struct {
+	*mock.Call
+}
+
+// WithJobStore is a helper method to define mock.On call
+//   - store scheduler.JobStore
+func (_e *MockManager_Expecter) WithJobStore(store interface{}) *MockManager_WithJobStore_Call {
+	return &MockManager_WithJobStore_Call{Call: _e.mock.On("WithJobStore", store)}
+}
+
+func (_c *MockManager_WithJobStore_Call) Run(run func(store scheduler.JobStore)) *MockManager_WithJobStore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.JobStore))
+	})
+	return _c
+}
+
+func (_c *MockManager_WithJobStore_Call) Return(_a0 scheduler.Manager) *MockManager_WithJobStore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_WithJobStore_Call) RunAndReturn(run func(scheduler.JobStore) scheduler.Manager) *MockManager_WithJobStore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithLastRunStore provides a mock function with given fields: store
+func (_m *MockManager) WithLastRunStore(store scheduler.LastRunStore) scheduler.Manager {
+	ret := _m.Called(store)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithLastRunStore")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.LastRunStore) scheduler.Manager); ok {
+		r0 = rf(store)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_WithLastRunStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithLastRunStore'
+type MockManager_WithLastRunStore_Call struct {
+	*mock.Call
+}
+
+// WithLastRunStore is a helper method to define mock.On call
+//   - store scheduler.LastRunStore
+func (_e *MockManager_Expecter) WithLastRunStore(store interface{}) *MockManager_WithLastRunStore_Call {
+	return &MockManager_WithLastRunStore_Call{Call: _e.mock.On("WithLastRunStore", store)}
+}
+
+func (_c *MockManager_WithLastRunStore_Call) Run(run func(store scheduler.LastRunStore)) *MockManager_WithLastRunStore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.LastRunStore))
+	})
+	return _c
+}
+
+func (_c *MockManager_WithLastRunStore_Call) Return(_a0 scheduler.Manager) *MockManager_WithLastRunStore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_WithLastRunStore_Call) RunAndReturn(run func(scheduler.LastRunStore) scheduler.Manager) *MockManager_WithLastRunStore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithLeaderElection provides a mock function with given fields: elector
+func (_m *MockManager) WithLeaderElection(elector scheduler.LeaderElector) scheduler.Manager {
+	ret := _m.Called(elector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithLeaderElection")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.LeaderElector) scheduler.Manager); ok {
+		r0 = rf(elector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_WithLeaderElection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithLeaderElection'
+type MockManager_WithLeaderElection_Call struct {
+	*mock.Call
+}
+
+// WithLeaderElection is a helper method to define mock.On call
+//   - elector scheduler.LeaderElector
+func (_e *MockManager_Expecter) WithLeaderElection(elector interface{}) *MockManager_WithLeaderElection_Call {
+	return &MockManager_WithLeaderElection_Call{Call: _e.mock.On("WithLeaderElection", elector)}
+}
+
+func (_c *MockManager_WithLeaderElection_Call) Run(run func(elector scheduler.LeaderElector)) *MockManager_WithLeaderElection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.LeaderElector))
+	})
+	return _c
+}
+
+func (_c *MockManager_WithLeaderElection_Call) Return(_a0 scheduler.Manager) *MockManager_WithLeaderElection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_WithLeaderElection_Call) RunAndReturn(run func(scheduler.LeaderElector) scheduler.Manager) *MockManager_WithLeaderElection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockManager creates a new instance of MockManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockManager(t interface {
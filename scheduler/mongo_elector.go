@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoLeaseDocument là document lưu lease của leader trong MongoDB.
+type mongoLeaseDocument struct {
+	Key       string    `bson:"key"`
+	Token     string    `bson:"token"`
+	RenewedAt time.Time `bson:"renewed_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// NewMongoElector tạo một LeaderElector sử dụng MongoDB làm backend.
+// Nó có thể được chuyển vào phương thức WithLeaderElection của scheduler.
+//
+// Mỗi lease là một document với unique index trên trường key và TTL index
+// trên trường expires_at. Các tùy chọn có cùng ý nghĩa với Redis elector.
+//
+// Example:
+//
+//	mongoManager := container.MustMake("mongodb").(mongodb.Manager)
+//	elector, err := scheduler.NewMongoElector(mongoManager, "scheduler_leaders")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithLeaderElection(elector)
+func NewMongoElector(manager MongoManager, collection string, opts ...ElectorOptions) (LeaderElector, error) {
+	if manager == nil {
+		return nil, ErrMongoManagerNil
+	}
+	if collection == "" {
+		return nil, ErrInvalidCollection
+	}
+
+	electorOptions := DefaultElectorOptions()
+	if len(opts) > 0 {
+		electorOptions = opts[0]
+		if err := validateElectorOptions(electorOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	leases := manager.Collection(collection)
+
+	// Tạo unique index và TTL index cho collection lease
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := leases.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedToConnectToMongo, err)
+	}
+
+	key := electorOptions.Key
+	// acquire lấy lease khi đã hết hạn hoặc đang thuộc về owner token này.
+	// Nếu lease đang được instance khác giữ, filter không khớp và thao tác
+	// insert vi phạm unique index trên key.
+	acquire := func(ctx context.Context, token string) (bool, error) {
+		now := time.Now()
+		filter := bson.M{
+			"key": key,
+			"$or": bson.A{
+				bson.M{"expires_at": bson.M{"$lte": now}},
+				bson.M{"token": token},
+			},
+		}
+		update := bson.M{
+			"$set": mongoLeaseDocument{
+				Key:       key,
+				Token:     token,
+				RenewedAt: now,
+				ExpiresAt: now.Add(electorOptions.LeaseDuration),
+			},
+		}
+		_, err := leases.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	renew := func(ctx context.Context, token string) (bool, error) {
+		now := time.Now()
+		result, err := leases.UpdateOne(ctx,
+			bson.M{"key": key, "token": token, "expires_at": bson.M{"$gt": now}},
+			bson.M{"$set": bson.M{"renewed_at": now, "expires_at": now.Add(electorOptions.LeaseDuration)}},
+		)
+		if err != nil {
+			return false, err
+		}
+		return result.MatchedCount == 1, nil
+	}
+	release := func(ctx context.Context, token string) (bool, error) {
+		result, err := leases.DeleteOne(ctx, bson.M{"key": key, "token": token})
+		if err != nil {
+			return false, err
+		}
+		return result.DeletedCount == 1, nil
+	}
+
+	return newLeaseElector(electorOptions, acquire, renew, release)
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoHistoryStore lưu lịch sử chạy job trong MongoDB.
+//
+// Mỗi lần chạy là một document; sau mỗi lần ghi, các document cũ hơn
+// MaxRuns lần chạy gần nhất của job bị xóa.
+type mongoHistoryStore struct {
+	runs    *mongo.Collection
+	maxRuns int
+}
+
+// NewMongoHistoryStore tạo HistoryStore sử dụng MongoDB làm backend.
+//
+// Example:
+//
+//	mongoManager := container.MustMake("mongodb").(mongodb.Manager)
+//	store, err := scheduler.NewMongoHistoryStore(mongoManager, "scheduler_runs")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithHistory(store)
+func NewMongoHistoryStore(manager MongoManager, collection string, opts ...HistoryOptions) (HistoryStore, error) {
+	if manager == nil {
+		return nil, ErrMongoManagerNil
+	}
+	if collection == "" {
+		return nil, ErrInvalidCollection
+	}
+
+	historyOptions := DefaultHistoryOptions()
+	if len(opts) > 0 {
+		historyOptions = opts[0]
+		if err := validateHistoryOptions(historyOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	store := &mongoHistoryStore{
+		runs:    manager.Collection(collection),
+		maxRuns: historyOptions.MaxRuns,
+	}
+
+	// Tạo index cho truy vấn lần chạy gần nhất theo job
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := store.runs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "job_name", Value: 1}, {Key: "started_at", Value: -1}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedToConnectToMongo, err)
+	}
+
+	return store, nil
+}
+
+// Record lưu một lần chạy và xóa các lần chạy vượt quá MaxRuns.
+func (s *mongoHistoryStore) Record(ctx context.Context, run JobRun) error {
+	if _, err := s.runs.InsertOne(ctx, run); err != nil {
+		return err
+	}
+
+	// Tìm lần chạy cũ nhất còn được giữ và xóa các lần chạy cũ hơn
+	var oldest JobRun
+	err := s.runs.FindOne(ctx,
+		bson.M{"job_name": run.JobName},
+		options.FindOne().SetSort(bson.D{{Key: "started_at", Value: -1}}).SetSkip(int64(s.maxRuns-1)),
+	).Decode(&oldest)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = s.runs.DeleteMany(ctx, bson.M{
+		"job_name":   run.JobName,
+		"started_at": bson.M{"$lt": oldest.StartedAt},
+	})
+	return err
+}
+
+// LastRun trả về lần chạy gần nhất của job.
+func (s *mongoHistoryStore) LastRun(ctx context.Context, jobName string) (JobRun, error) {
+	var run JobRun
+	err := s.runs.FindOne(ctx,
+		bson.M{"job_name": jobName},
+		options.FindOne().SetSort(bson.D{{Key: "started_at", Value: -1}}),
+	).Decode(&run)
+	if err == mongo.ErrNoDocuments {
+		return JobRun{}, ErrNoJobRuns
+	}
+	if err != nil {
+		return JobRun{}, err
+	}
+	return run, nil
+}
+
+// Runs trả về các lần chạy gần nhất của job.
+func (s *mongoHistoryStore) Runs(ctx context.Context, jobName string, limit int) ([]JobRun, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+
+	cursor, err := s.runs.Find(ctx, bson.M{"job_name": jobName}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []JobRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,215 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoManager là tập con của mongodb.Manager mà scheduler cần để truy cập collection.
+//
+// mongodb.Manager từ MongoDB provider thỏa mãn interface này, nên có thể
+// truyền trực tiếp vào NewMongoLocker.
+type MongoManager interface {
+	// Collection trả về collection theo tên trong database mặc định.
+	Collection(name string) *mongo.Collection
+}
+
+// mongoLocker triển khai gocron.Locker interface sử dụng MongoDB làm backend.
+//
+// Mỗi khóa là một document với unique index trên trường key. Document được giữ lại
+// sau khi giải phóng (chỉ đánh dấu hết hạn) vì nó mang bộ đếm fencing token của key;
+// fencing token được tăng trong cùng thao tác cập nhật nguyên tử với việc lấy khóa.
+// Document của key không được dùng lại quá mongoLockRetention sẽ bị TTL index xóa.
+type mongoLocker struct {
+	locks   *mongo.Collection
+	options RedisLockerOptionsTime
+	held    heldLocks
+}
+
+// mongoLockRetention là thời gian document khóa được giữ lại sau khi lease hết hạn
+// trước khi TTL index xóa nó.
+//
+// Xóa document sẽ đặt lại bộ đếm fencing token của key, nên document chỉ bị xóa khi
+// key đã không được dùng trong khoảng thời gian này; không còn instance nào có thể
+// vẫn giữ một khóa cũ của key sau khoảng thời gian đó.
+const mongoLockRetention = 24 * time.Hour
+
+// mongoLockDocument là document lưu trạng thái khóa trong MongoDB.
+type mongoLockDocument struct {
+	Key          string    `bson:"key"`
+	Token        string    `bson:"token"`
+	FencingToken int64     `bson:"fencing_token"`
+	LockedAt     time.Time `bson:"locked_at"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+	PurgeAt      time.Time `bson:"purge_at"`
+}
+
+// NewMongoLocker tạo một MongoDB Locker mới để sử dụng với gocron.
+// Nó có thể được chuyển vào phương thức WithDistributedLocker của scheduler.
+//
+// Các tùy chọn có cùng ý nghĩa với Redis Locker. Khóa hết hạn theo LockDuration
+// và được gia hạn định kỳ khi job còn chạy. Locker trả về cũng triển khai FencedLocker.
+//
+// Example:
+//
+//	mongoManager := container.MustMake("mongodb").(mongodb.Manager)
+//	locker, err := scheduler.NewMongoLocker(mongoManager, "scheduler_locks")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithDistributedLocker(locker)
+func NewMongoLocker(manager MongoManager, collection string, opts ...RedisLockerOptions) (gocron.Locker, error) {
+	if manager == nil {
+		return nil, ErrMongoManagerNil
+	}
+	if collection == "" {
+		return nil, ErrInvalidCollection
+	}
+
+	// Sử dụng tùy chọn mặc định
+	lockerOptions := DefaultRedisLockerOptions()
+	if len(opts) > 0 {
+		lockerOptions = opts[0]
+
+		// Validate các giá trị options
+		if err := validateRedisLockerOptions(lockerOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	locker := &mongoLocker{
+		locks:   manager.Collection(collection),
+		options: lockerOptions.ToTimeDuration(),
+	}
+
+	// Tạo unique index cho collection khóa và TTL index trên purge_at để dọn
+	// document của các key không còn được dùng
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := locker.locks.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "purge_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedToConnectToMongo, err)
+	}
+
+	return locker, nil
+}
+
+// Lock triển khai phương thức Lock của gocron.Locker interface.
+func (m *mongoLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	fullKey := m.options.KeyPrefix + key
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	for retries := 0; ; retries++ {
+		fencingToken, acquired, err := m.tryAcquire(ctx, fullKey, token)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return m.newLock(key, fullKey, token, fencingToken), nil
+		}
+
+		// Nếu đã thử tối đa số lần
+		if retries >= m.options.MaxRetries {
+			return nil, ErrFailedToAcquireLock
+		}
+
+		// Chờ một khoảng thời gian trước khi thử lại
+		select {
+		case <-time.After(m.options.RetryDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// HeldLock trả về khóa đang được instance hiện tại giữ cho key.
+func (m *mongoLocker) HeldLock(key string) (FencedLock, bool) {
+	return m.held.get(key)
+}
+
+// tryAcquire lấy khóa nếu document chưa tồn tại hoặc đã hết hạn.
+//
+// Upsert với điều kiện expires_at <= now: nếu khóa đang được giữ, filter không
+// khớp và thao tác insert vi phạm unique index trên key, nghĩa là khóa bận.
+// Fencing token được tăng bằng $inc trong cùng thao tác, nên chỉ instance lấy được
+// khóa mới nhận token và các token luôn tăng theo thứ tự lấy khóa.
+func (m *mongoLocker) tryAcquire(ctx context.Context, fullKey, token string) (int64, bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"key":        fullKey,
+		"expires_at": bson.M{"$lte": now},
+	}
+	expiresAt := now.Add(m.options.LockDuration)
+	update := bson.M{
+		"$set": bson.M{
+			"token":      token,
+			"locked_at":  now,
+			"expires_at": expiresAt,
+			"purge_at":   expiresAt.Add(mongoLockRetention),
+		},
+		"$inc": bson.M{"fencing_token": int64(1)},
+	}
+
+	var document mongoLockDocument
+	err := m.locks.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&document)
+	if mongo.IsDuplicateKeyError(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return document.FencingToken, true, nil
+}
+
+// newLock tạo khóa với gia hạn và giải phóng chỉ khi owner token còn khớp.
+func (m *mongoLocker) newLock(key, fullKey, token string, fencingToken int64) *leaseLock {
+	renew := func(ctx context.Context) (bool, error) {
+		now := time.Now()
+		expiresAt := now.Add(m.options.LockDuration)
+		result, err := m.locks.UpdateOne(ctx,
+			bson.M{"key": fullKey, "token": token, "expires_at": bson.M{"$gt": now}},
+			bson.M{"$set": bson.M{"expires_at": expiresAt, "purge_at": expiresAt.Add(mongoLockRetention)}},
+		)
+		if err != nil {
+			return false, err
+		}
+		return result.MatchedCount == 1, nil
+	}
+	release := func(ctx context.Context) (bool, error) {
+		// Đánh dấu hết hạn thay vì xóa để giữ bộ đếm fencing token
+		result, err := m.locks.UpdateOne(ctx,
+			bson.M{"key": fullKey, "token": token},
+			bson.M{"$set": bson.M{
+				"token":      "",
+				"expires_at": time.Unix(0, 0),
+				"purge_at":   time.Now().Add(mongoLockRetention),
+			}},
+		)
+		if err != nil {
+			return false, err
+		}
+		return result.MatchedCount == 1, nil
+	}
+	return newLeaseLock(&m.held, key, token, fencingToken, m.options.LockDuration, renew, release)
+}
@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testMongoManager trả về collection trong một database cố định.
+type testMongoManager struct {
+	db *mongo.Database
+}
+
+func (m testMongoManager) Collection(name string) *mongo.Collection {
+	return m.db.Collection(name)
+}
+
+func TestMongoLockerAcquiresWithAtomicFencingToken(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("acquire", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		locker, err := NewMongoLocker(testMongoManager{db: mt.DB}, "locks")
+		if err != nil {
+			t.Fatalf("Failed to create mongo locker: %v", err)
+		}
+
+		// Document của key không còn dùng được dọn bằng TTL index trên purge_at
+		created := mt.GetStartedEvent()
+		if created == nil || created.CommandName != "createIndexes" {
+			t.Fatalf("Expected createIndexes command, got %v", created)
+		}
+		indexes, _ := created.Command.Lookup("indexes").Array().Values()
+		hasTTL := false
+		for _, index := range indexes {
+			document := index.Document()
+			if _, err := document.LookupErr("key", "purge_at"); err != nil {
+				continue
+			}
+			if ttl, ok := document.Lookup("expireAfterSeconds").AsInt64OK(); ok && ttl == 0 {
+				hasTTL = true
+			}
+		}
+		if !hasTTL {
+			t.Errorf("Expected a TTL index on purge_at, got %s", created.Command)
+		}
+		mt.ClearEvents()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+			{Key: "key", Value: "scheduler_lock:job"},
+			{Key: "token", Value: "owner"},
+			{Key: "fencing_token", Value: int64(7)},
+		}}))
+		lock, err := locker.Lock(context.Background(), "job")
+		if err != nil {
+			t.Fatalf("Failed to acquire lock: %v", err)
+		}
+
+		if token := lock.(FencedLock).FencingToken(); token != 7 {
+			t.Errorf("Expected fencing token 7, got %d", token)
+		}
+
+		// Lấy khóa và cấp fencing token trong cùng một lệnh findAndModify
+		started := mt.GetStartedEvent()
+		if started == nil || started.CommandName != "findAndModify" {
+			t.Fatalf("Expected a single findAndModify command, got %v", started)
+		}
+		if next := mt.GetStartedEvent(); next != nil {
+			t.Errorf("Expected no other command, got %s", next.CommandName)
+		}
+		update := started.Command.Lookup("update").Document()
+		if _, err := update.LookupErr("$inc", "fencing_token"); err != nil {
+			t.Errorf("Expected $inc on fencing_token in the acquire update, got %s", update)
+		}
+		if upsert, _ := started.Command.Lookup("upsert").BooleanOK(); !upsert {
+			t.Error("Expected acquire to upsert the lock document")
+		}
+		if _, err := update.LookupErr("$set", "purge_at"); err != nil {
+			t.Errorf("Expected acquire to set purge_at for the TTL index, got %s", update)
+		}
+
+		// Giải phóng khóa đánh dấu hết hạn thay vì xóa document
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		if err := lock.Unlock(context.Background()); err != nil {
+			t.Errorf("Failed to unlock: %v", err)
+		}
+		if released := mt.GetStartedEvent(); released == nil || released.CommandName != "update" {
+			t.Errorf("Expected release to update the lock document, got %v", released)
+		}
+	})
+
+	mt.Run("busy", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		locker, err := NewMongoLocker(testMongoManager{db: mt.DB}, "locks", RedisLockerOptions{
+			KeyPrefix:    "test:",
+			LockDuration: 30,
+			MaxRetries:   0,
+			RetryDelay:   1,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create mongo locker: %v", err)
+		}
+
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code:    11000,
+			Name:    "DuplicateKey",
+			Message: "E11000 duplicate key error",
+		}))
+		if _, err := locker.Lock(context.Background(), "job"); err != ErrFailedToAcquireLock {
+			t.Errorf("Expected ErrFailedToAcquireLock, got %v", err)
+		}
+	})
+}
+
+// newTestMongoDatabase kết nối tới MongoDB tại localhost, bỏ qua test nếu không có server.
+func newTestMongoDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping MongoDB integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().
+		ApplyURI("mongodb://localhost:27017").
+		SetServerSelectionTimeout(2*time.Second))
+	if err != nil {
+		t.Skip("MongoDB not available, skipping integration test")
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		t.Skip("MongoDB not accessible, skipping integration test")
+	}
+
+	db := client.Database(fmt.Sprintf("scheduler_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	return db
+}
+
+func TestMongoLockerFencingTokenIncreases(t *testing.T) {
+	db := newTestMongoDatabase(t)
+	ctx := context.Background()
+	opts := RedisLockerOptions{KeyPrefix: "test:", LockDuration: 30, MaxRetries: 0, RetryDelay: 10}
+
+	nodeA, err := NewMongoLocker(testMongoManager{db: db}, "locks", opts)
+	if err != nil {
+		t.Fatalf("Failed to create locker A: %v", err)
+	}
+	nodeB, err := NewMongoLocker(testMongoManager{db: db}, "locks", opts)
+	if err != nil {
+		t.Fatalf("Failed to create locker B: %v", err)
+	}
+
+	first, err := nodeA.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire first lock: %v", err)
+	}
+
+	// Lần lấy khóa thất bại không được tiêu tốn fencing token
+	if _, err := nodeB.Lock(ctx, "job"); err != ErrFailedToAcquireLock {
+		t.Fatalf("Expected ErrFailedToAcquireLock while the lock is held, got %v", err)
+	}
+
+	if err := first.Unlock(ctx); err != nil {
+		t.Fatalf("Failed to unlock first lock: %v", err)
+	}
+
+	second, err := nodeB.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire second lock: %v", err)
+	}
+	defer second.Unlock(ctx)
+
+	firstToken := first.(FencedLock).FencingToken()
+	secondToken := second.(FencedLock).FencingToken()
+	if secondToken != firstToken+1 {
+		t.Errorf("Expected fencing token %d after %d, got %d", firstToken+1, firstToken, secondToken)
+	}
+}
+
+func TestMongoLockerUnlockAfterLoss(t *testing.T) {
+	db := newTestMongoDatabase(t)
+	ctx := context.Background()
+	opts := RedisLockerOptions{KeyPrefix: "test:", LockDuration: 1, MaxRetries: 0, RetryDelay: 10}
+
+	nodeA, err := NewMongoLocker(testMongoManager{db: db}, "locks", opts)
+	if err != nil {
+		t.Fatalf("Failed to create locker A: %v", err)
+	}
+	nodeB, err := NewMongoLocker(testMongoManager{db: db}, "locks", opts)
+	if err != nil {
+		t.Fatalf("Failed to create locker B: %v", err)
+	}
+
+	first, err := nodeA.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire first lock: %v", err)
+	}
+
+	// Mô phỏng khóa hết hạn trong khi node A vẫn chạy
+	_, err = db.Collection("locks").UpdateOne(ctx,
+		bson.M{"key": "test:job"},
+		bson.M{"$set": bson.M{"expires_at": time.Now().Add(-time.Second)}},
+	)
+	if err != nil {
+		t.Fatalf("Failed to expire lock: %v", err)
+	}
+
+	second, err := nodeB.Lock(ctx, "job")
+	if err != nil {
+		t.Fatalf("Node B should acquire the expired lock: %v", err)
+	}
+	defer second.Unlock(ctx)
+
+	if err := first.Unlock(ctx); err != ErrLockNotHeld {
+		t.Errorf("Expected ErrLockNotHeld when unlocking a lost lock, got %v", err)
+	}
+	if second.(FencedLock).FencingToken() <= first.(FencedLock).FencingToken() {
+		t.Errorf("Node B fencing token %d should be greater than node A token %d",
+			second.(FencedLock).FencingToken(), first.(FencedLock).FencingToken())
+	}
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoLastRunDocument là document lưu thời điểm chạy gần nhất của một job.
+type mongoLastRunDocument struct {
+	JobName   string    `bson:"_id"`
+	LastRunAt time.Time `bson:"last_run_at"`
+}
+
+// mongoLastRunStore lưu thời điểm chạy gần nhất của job trong MongoDB,
+// mỗi job là một document với _id là tên job.
+type mongoLastRunStore struct {
+	runs *mongo.Collection
+}
+
+// NewMongoLastRunStore tạo LastRunStore sử dụng MongoDB làm backend.
+//
+// Example:
+//
+//	mongoManager := container.MustMake("mongodb").(mongodb.Manager)
+//	store, err := scheduler.NewMongoLastRunStore(mongoManager, "scheduler_last_runs")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithLastRunStore(store)
+func NewMongoLastRunStore(manager MongoManager, collection string) (LastRunStore, error) {
+	if manager == nil {
+		return nil, ErrMongoManagerNil
+	}
+	if collection == "" {
+		return nil, ErrInvalidCollection
+	}
+
+	return &mongoLastRunStore{
+		runs: manager.Collection(collection),
+	}, nil
+}
+
+// LastRunAt trả về thời điểm chạy gần nhất của job.
+func (s *mongoLastRunStore) LastRunAt(ctx context.Context, jobName string) (time.Time, error) {
+	var doc mongoLastRunDocument
+	err := s.runs.FindOne(ctx, bson.M{"_id": jobName}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return time.Time{}, ErrNoJobRuns
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return doc.LastRunAt, nil
+}
+
+// SetLastRunAt ghi nhận thời điểm chạy gần nhất của job.
+// Toán tử $max giữ thời điểm lớn nhất khi nhiều instance ghi đồng thời.
+func (s *mongoLastRunStore) SetLastRunAt(ctx context.Context, jobName string, at time.Time) error {
+	update := func() error {
+		_, err := s.runs.UpdateOne(ctx,
+			bson.M{"_id": jobName},
+			bson.M{"$max": bson.M{"last_run_at": at}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	}
+
+	err := update()
+	if mongo.IsDuplicateKeyError(err) {
+		// Upsert đồng thời từ instance khác đã tạo document, cập nhật lại
+		err = update()
+	}
+	return err
+}
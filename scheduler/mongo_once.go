@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoJobStore lưu các job chạy một lần trong MongoDB, mỗi job là một
+// document với _id là tên job.
+type mongoJobStore struct {
+	jobs *mongo.Collection
+}
+
+// NewMongoJobStore tạo JobStore sử dụng MongoDB làm backend.
+//
+// Example:
+//
+//	mongoManager := container.MustMake("mongodb").(mongodb.Manager)
+//	store, err := scheduler.NewMongoJobStore(mongoManager, "scheduler_once_jobs")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithJobStore(store)
+func NewMongoJobStore(manager MongoManager, collection string) (JobStore, error) {
+	if manager == nil {
+		return nil, ErrMongoManagerNil
+	}
+	if collection == "" {
+		return nil, ErrInvalidCollection
+	}
+
+	return &mongoJobStore{
+		jobs: manager.Collection(collection),
+	}, nil
+}
+
+// Save lưu job, ghi đè job cùng tên nếu đã tồn tại.
+func (s *mongoJobStore) Save(ctx context.Context, job OnceJob) error {
+	_, err := s.jobs.ReplaceOne(ctx, bson.M{"_id": job.Name}, job, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// Upsert đồng thời từ instance khác đã tạo document, ghi đè lại
+		_, err = s.jobs.ReplaceOne(ctx, bson.M{"_id": job.Name}, job, options.Replace().SetUpsert(true))
+	}
+	return err
+}
+
+// Get trả về job theo tên.
+func (s *mongoJobStore) Get(ctx context.Context, name string) (OnceJob, error) {
+	var job OnceJob
+	err := s.jobs.FindOne(ctx, bson.M{"_id": name}).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return OnceJob{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if err != nil {
+		return OnceJob{}, err
+	}
+	return job, nil
+}
+
+// List trả về tất cả các job đang chờ chạy, sắp xếp theo RunAt.
+func (s *mongoJobStore) List(ctx context.Context) ([]OnceJob, error) {
+	cursor, err := s.jobs.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "run_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []OnceJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Delete xóa job theo tên.
+func (s *mongoJobStore) Delete(ctx context.Context, name string) error {
+	result, err := s.jobs.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return nil
+}
+
+// Complete xóa job nếu job chưa bị lên lịch lại.
+func (s *mongoJobStore) Complete(ctx context.Context, job OnceJob) error {
+	_, err := s.jobs.DeleteOne(ctx, bson.M{"_id": job.Name, "id": job.ID})
+	return err
+}
@@ -0,0 +1,344 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// onceJobTag là tag nội bộ đánh dấu job chạy một lần được tạo bởi ScheduleOnce.
+const onceJobTag = "scheduler:once"
+
+// OnceJob là một job chạy một lần được lưu trong JobStore, để job không bị mất
+// khi process khởi động lại.
+type OnceJob struct {
+	// Name là tên duy nhất của job, dùng để hủy hoặc lên lịch lại
+	Name string `json:"name" bson:"_id"`
+
+	// ID định danh lần lên lịch, thay đổi mỗi khi job được lên lịch lại với cùng tên
+	ID string `json:"id" bson:"id"`
+
+	// RunAt là thời điểm job cần chạy
+	RunAt time.Time `json:"run_at" bson:"run_at"`
+
+	// Handler là tên handler đã đăng ký qua RegisterJobHandler
+	Handler string `json:"handler" bson:"handler"`
+
+	// Payload là dữ liệu truyền cho handler, đọc bằng OncePayload
+	Payload []byte `json:"payload,omitempty" bson:"payload,omitempty"`
+
+	// CreatedAt là thời điểm job được lên lịch
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// JobStore lưu các job chạy một lần cho tới khi chúng chạy xong hoặc bị hủy.
+type JobStore interface {
+	// Save lưu job, ghi đè job cùng tên nếu đã tồn tại.
+	Save(ctx context.Context, job OnceJob) error
+
+	// Get trả về job theo tên, hoặc ErrJobNotFound nếu không tồn tại.
+	Get(ctx context.Context, name string) (OnceJob, error)
+
+	// List trả về tất cả các job đang chờ chạy, sắp xếp theo RunAt.
+	List(ctx context.Context) ([]OnceJob, error)
+
+	// Delete xóa job theo tên, trả về ErrJobNotFound nếu không tồn tại.
+	Delete(ctx context.Context, name string) error
+
+	// Complete xóa job sau khi chạy nếu job chưa bị lên lịch lại (cùng ID).
+	Complete(ctx context.Context, job OnceJob) error
+}
+
+// JobStoreOptions chứa các tùy chọn cho JobStore.
+type JobStoreOptions struct {
+	// KeyPrefix là tiền tố của key lưu các job trong Redis
+	KeyPrefix string
+}
+
+// DefaultJobStoreOptions trả về các tùy chọn mặc định cho JobStore.
+func DefaultJobStoreOptions() JobStoreOptions {
+	return DefaultJobStoreConfig().ToJobStoreOptions()
+}
+
+// memoryJobStore lưu các job chạy một lần trong bộ nhớ của process.
+type memoryJobStore struct {
+	mu   sync.RWMutex
+	jobs map[string]OnceJob
+}
+
+// NewMemoryJobStore tạo JobStore lưu trong bộ nhớ, phù hợp cho kiểm thử.
+// Dữ liệu mất khi process dừng nên job không được giữ qua các lần khởi động.
+func NewMemoryJobStore() JobStore {
+	return &memoryJobStore{
+		jobs: make(map[string]OnceJob),
+	}
+}
+
+// Save lưu job, ghi đè job cùng tên nếu đã tồn tại.
+func (s *memoryJobStore) Save(ctx context.Context, job OnceJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.Name] = job
+	return nil
+}
+
+// Get trả về job theo tên.
+func (s *memoryJobStore) Get(ctx context.Context, name string) (OnceJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return OnceJob{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return job, nil
+}
+
+// List trả về tất cả các job đang chờ chạy, sắp xếp theo RunAt.
+func (s *memoryJobStore) List(ctx context.Context) ([]OnceJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jobs := make([]OnceJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	sortOnceJobs(jobs)
+	return jobs, nil
+}
+
+// Delete xóa job theo tên.
+func (s *memoryJobStore) Delete(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	delete(s.jobs, name)
+	return nil
+}
+
+// Complete xóa job nếu job chưa bị lên lịch lại.
+func (s *memoryJobStore) Complete(ctx context.Context, job OnceJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.jobs[job.Name]; ok && current.ID == job.ID {
+		delete(s.jobs, job.Name)
+	}
+	return nil
+}
+
+// sortOnceJobs sắp xếp các job theo thời điểm chạy, rồi theo tên.
+func sortOnceJobs(jobs []OnceJob) {
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].RunAt.Equal(jobs[j].RunAt) {
+			return jobs[i].RunAt.Before(jobs[j].RunAt)
+		}
+		return jobs[i].Name < jobs[j].Name
+	})
+}
+
+// oncePayloadKey là key của payload job chạy một lần trong context.
+type oncePayloadKey struct{}
+
+// OncePayload trả về payload của job chạy một lần đang được thực thi,
+// hoặc nil nếu context không thuộc job tạo bởi ScheduleOnce.
+//
+// Example:
+//
+//	scheduler.RegisterJobHandler("reminders.send", func(ctx context.Context) error {
+//		var reminder Reminder
+//		if err := json.Unmarshal(scheduler.OncePayload(ctx), &reminder); err != nil {
+//			return err
+//		}
+//		return reminderService.Send(ctx, reminder)
+//	})
+func OncePayload(ctx context.Context) []byte {
+	payload, _ := ctx.Value(oncePayloadKey{}).([]byte)
+	return payload
+}
+
+// WithJobStore thiết lập JobStore lưu các job chạy một lần tạo bởi ScheduleOnce.
+func (m *manager) WithJobStore(store JobStore) Manager {
+	m.onceMu.Lock()
+	defer m.onceMu.Unlock()
+	m.jobStore = store
+	return m
+}
+
+// onceJobStore trả về JobStore hiện tại.
+func (m *manager) onceJobStore() JobStore {
+	m.onceMu.Lock()
+	defer m.onceMu.Unlock()
+	return m.jobStore
+}
+
+// ScheduleOnce lưu job chạy một lần vào JobStore và lên lịch nếu scheduler đang chạy.
+func (m *manager) ScheduleOnce(name string, at time.Time, handlerName string, payload []byte) error {
+	store := m.onceJobStore()
+	if store == nil {
+		return ErrJobStoreDisabled
+	}
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidOnceJob)
+	}
+	if at.IsZero() {
+		return fmt.Errorf("%w: job %q has no run time", ErrInvalidOnceJob, name)
+	}
+	if _, ok := lookupJobHandler(handlerName); !ok {
+		return fmt.Errorf("%w: %s (job %q)", ErrJobHandlerNotFound, handlerName, name)
+	}
+
+	id, err := newLockToken()
+	if err != nil {
+		return err
+	}
+	job := OnceJob{
+		Name:      name,
+		ID:        id,
+		RunAt:     at,
+		Handler:   handlerName,
+		Payload:   payload,
+		CreatedAt: m.now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.Save(ctx, job); err != nil {
+		return err
+	}
+
+	// Scheduler chưa chạy: job được nạp từ JobStore khi khởi động
+	if !m.Scheduler.IsRunning() {
+		return nil
+	}
+	return m.scheduleOnceJob(store, job)
+}
+
+// CancelOnce xóa job chạy một lần khỏi JobStore và khỏi scheduler.
+func (m *manager) CancelOnce(name string) error {
+	store := m.onceJobStore()
+	if store == nil {
+		return ErrJobStoreDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	m.onceMu.Lock()
+	defer m.onceMu.Unlock()
+	if job, ok := m.onceJobs[name]; ok {
+		m.Scheduler.RemoveByReference(job)
+		delete(m.onceJobs, name)
+	}
+	return nil
+}
+
+// loadOnceJobs nạp các job chạy một lần từ JobStore khi scheduler khởi động.
+// Job đã quá thời điểm chạy (ví dụ trong lúc process dừng) được chạy ngay.
+func (m *manager) loadOnceJobs() {
+	store := m.onceJobStore()
+	if store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	jobs, err := store.List(ctx)
+	if err != nil {
+		return
+	}
+	for _, job := range jobs {
+		_ = m.scheduleOnceJob(store, job)
+	}
+}
+
+// scheduleOnceJob lên lịch job chạy một lần trong gocron, thay thế job cùng tên
+// đã được lên lịch trước đó.
+//
+// Job được lên lịch trên mọi instance; distributed locker của gocron (khóa theo tên
+// job) cùng việc kiểm tra JobStore trước khi chạy đảm bảo job chỉ chạy một lần.
+func (m *manager) scheduleOnceJob(store JobStore, job OnceJob) error {
+	m.onceMu.Lock()
+	defer m.onceMu.Unlock()
+
+	if existing, ok := m.onceJobs[job.Name]; ok {
+		m.Scheduler.RemoveByReference(existing)
+		delete(m.onceJobs, job.Name)
+	}
+
+	delay := job.RunAt.Sub(m.now())
+	if delay > 0 {
+		m.Every(delay)
+		m.Scheduler.WaitForSchedule()
+	} else {
+		// Job đến hạn: gocron chạy ngay job theo khoảng thời gian khi được thêm
+		m.Every(time.Hour)
+	}
+	m.updateChain(func(spec *jobSpec) {
+		spec.once = job.RunAt
+	})
+	m.Scheduler.LimitRunsTo(1)
+
+	scheduled, err := m.Name(job.Name).Tag(onceJobTag).DoWithContext(func(ctx context.Context) error {
+		return m.runOnceJob(ctx, store, job)
+	})
+	if err != nil {
+		return err
+	}
+	if m.onceJobs == nil {
+		m.onceJobs = make(map[string]*gocron.Job)
+	}
+	m.onceJobs[job.Name] = scheduled
+	return nil
+}
+
+// runOnceJob chạy job nếu job vẫn còn trong JobStore với cùng ID, sau đó xóa job.
+//
+// Job không còn trong JobStore đã chạy trên instance khác, bị hủy hoặc được lên lịch
+// lại nên bị bỏ qua. Job được xóa kể cả khi handler trả về lỗi để không chạy lại;
+// job chỉ chạy lại nếu process dừng giữa chừng.
+func (m *manager) runOnceJob(ctx context.Context, store JobStore, job OnceJob) error {
+	current, err := store.Get(ctx, job.Name)
+	if errors.Is(err, ErrJobNotFound) || (err == nil && current.ID != job.ID) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	handler, ok := lookupJobHandler(job.Handler)
+	if !ok {
+		return fmt.Errorf("%w: %s (job %q)", ErrJobHandlerNotFound, job.Handler, job.Name)
+	}
+	err = handler(context.WithValue(ctx, oncePayloadKey{}, job.Payload))
+
+	completeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if completeErr := store.Complete(completeCtx, job); completeErr != nil && err == nil {
+		err = completeErr
+	}
+	return err
+}
+
+// Error constants cho job chạy một lần
+var (
+	// ErrJobStoreDisabled được trả về khi dùng ScheduleOnce mà scheduler chưa có JobStore.
+	ErrJobStoreDisabled = errors.New("scheduler: job store is not enabled")
+
+	// ErrInvalidOnceJob được trả về khi job chạy một lần không hợp lệ.
+	ErrInvalidOnceJob = errors.New("scheduler: invalid one-off job")
+
+	// ErrUnsupportedJobStoreDriver được trả về khi driver của job store không được hỗ trợ.
+	ErrUnsupportedJobStoreDriver = errors.New("scheduler: unsupported job store driver")
+)
@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMemoryJobStore(t *testing.T) {
+	testJobStore(t, NewMemoryJobStore())
+}
+
+func TestRedisJobStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	store, err := NewRedisJobStore(client, JobStoreOptions{KeyPrefix: "once:"})
+	if err != nil {
+		t.Fatalf("Failed to create redis job store: %v", err)
+	}
+	testJobStore(t, store)
+
+	if _, err := NewRedisJobStore(client, JobStoreOptions{}); !errors.Is(err, ErrInvalidKeyPrefix) {
+		t.Errorf("Expected ErrInvalidKeyPrefix, got %v", err)
+	}
+}
+
+// testJobStore kiểm tra hành vi chung của các JobStore.
+func testJobStore(t *testing.T, store JobStore) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+
+	later := OnceJob{Name: "later", ID: "1", RunAt: now.Add(time.Hour), Handler: "h"}
+	sooner := OnceJob{Name: "sooner", ID: "1", RunAt: now.Add(time.Minute), Handler: "h", Payload: []byte(`{"id":7}`)}
+	for _, job := range []OnceJob{later, sooner} {
+		if err := store.Save(ctx, job); err != nil {
+			t.Fatalf("Failed to save job: %v", err)
+		}
+	}
+
+	jobs, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Name != "sooner" || string(jobs[0].Payload) != `{"id":7}` || !jobs[0].RunAt.Equal(sooner.RunAt) {
+		t.Errorf("Unexpected jobs: %+v", jobs)
+	}
+
+	// Lên lịch lại với ID mới: Complete của lần chạy cũ không xóa job mới
+	rescheduled := later
+	rescheduled.ID = "2"
+	if err := store.Save(ctx, rescheduled); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if err := store.Complete(ctx, later); err != nil {
+		t.Fatalf("Failed to complete job: %v", err)
+	}
+	got, err := store.Get(ctx, "later")
+	if err != nil || got.ID != "2" {
+		t.Errorf("Expected rescheduled job kept, got %+v, %v", got, err)
+	}
+	if err := store.Complete(ctx, rescheduled); err != nil {
+		t.Fatalf("Failed to complete job: %v", err)
+	}
+	if _, err := store.Get(ctx, "later"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected completed job removed, got %v", err)
+	}
+
+	if err := store.Delete(ctx, "sooner"); err != nil {
+		t.Fatalf("Failed to delete job: %v", err)
+	}
+	if err := store.Delete(ctx, "sooner"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestSchedulerScheduleOnce(t *testing.T) {
+	var payload atomic.Value
+	var runs atomic.Int32
+	RegisterJobHandler("test.once", func(ctx context.Context) error {
+		payload.Store(string(OncePayload(ctx)))
+		runs.Add(1)
+		return nil
+	})
+	defer RegisterJobHandler("test.once", nil)
+
+	store := NewMemoryJobStore()
+	sched := NewScheduler().WithJobStore(store)
+	sched.StartAsync()
+	defer sched.Stop()
+
+	if err := sched.ScheduleOnce("reminder", time.Now().Add(100*time.Millisecond), "test.once", []byte("user-1")); err != nil {
+		t.Fatalf("Failed to schedule one-off job: %v", err)
+	}
+	if jobs := sched.Jobs(); len(jobs) != 1 || jobs[0].Name != "reminder" {
+		t.Errorf("Expected one-off job listed, got %+v", jobs)
+	}
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("Expected job to wait for its run time, got %d runs", got)
+	}
+
+	waitFor(t, func() bool { return runs.Load() == 1 }, "one-off job did not run")
+	if got := payload.Load(); got != "user-1" {
+		t.Errorf("Expected payload user-1, got %v", got)
+	}
+
+	waitFor(t, func() bool {
+		jobs, _ := store.List(context.Background())
+		return len(jobs) == 0
+	}, "one-off job was not removed from the store")
+	time.Sleep(100 * time.Millisecond)
+	if got := runs.Load(); got != 1 {
+		t.Errorf("Expected job to run once, got %d", got)
+	}
+}
+
+func TestSchedulerScheduleOnceReloadedAtStart(t *testing.T) {
+	var runs atomic.Int32
+	RegisterJobHandler("test.once.reload", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	defer RegisterJobHandler("test.once.reload", nil)
+
+	// Job được lên lịch trước khi khởi động (hoặc bởi process trước đó)
+	store := NewMemoryJobStore()
+	sched := NewScheduler().WithJobStore(store)
+	if err := sched.ScheduleOnce("overdue", time.Now().Add(-time.Minute), "test.once.reload", nil); err != nil {
+		t.Fatalf("Failed to schedule one-off job: %v", err)
+	}
+	if err := sched.ScheduleOnce("cancelled", time.Now().Add(50*time.Millisecond), "test.once.reload", nil); err != nil {
+		t.Fatalf("Failed to schedule one-off job: %v", err)
+	}
+
+	restarted := NewScheduler().WithJobStore(store)
+	restarted.StartAsync()
+	defer restarted.Stop()
+
+	if err := restarted.CancelOnce("cancelled"); err != nil {
+		t.Fatalf("Failed to cancel one-off job: %v", err)
+	}
+	if err := restarted.CancelOnce("cancelled"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+
+	waitFor(t, func() bool { return runs.Load() == 1 }, "overdue job did not run at start")
+	time.Sleep(150 * time.Millisecond)
+	if got := runs.Load(); got != 1 {
+		t.Errorf("Expected only the overdue job to run, got %d runs", got)
+	}
+}
+
+func TestSchedulerScheduleOnceSharedStoreRunsOnce(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	var runs atomic.Int32
+	RegisterJobHandler("test.once.shared", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	defer RegisterJobHandler("test.once.shared", nil)
+
+	for i := 0; i < 3; i++ {
+		store, err := NewRedisJobStore(client)
+		if err != nil {
+			t.Fatalf("Failed to create redis job store: %v", err)
+		}
+		locker, err := NewRedisLocker(client)
+		if err != nil {
+			t.Fatalf("Failed to create redis locker: %v", err)
+		}
+		sched := NewScheduler().WithDistributedLocker(locker).WithJobStore(store)
+		if i == 0 {
+			if err := sched.ScheduleOnce("invoice", time.Now(), "test.once.shared", nil); err != nil {
+				t.Fatalf("Failed to schedule one-off job: %v", err)
+			}
+		}
+		sched.StartAsync()
+		defer sched.Stop()
+	}
+
+	waitFor(t, func() bool { return runs.Load() == 1 }, "one-off job did not run")
+	time.Sleep(100 * time.Millisecond)
+	if got := runs.Load(); got != 1 {
+		t.Errorf("Expected job to run once across instances, got %d", got)
+	}
+}
+
+func TestSchedulerScheduleOnceInvalid(t *testing.T) {
+	RegisterJobHandler("test.once.invalid", func(ctx context.Context) error { return nil })
+	defer RegisterJobHandler("test.once.invalid", nil)
+
+	if err := NewScheduler().ScheduleOnce("a", time.Now(), "test.once.invalid", nil); !errors.Is(err, ErrJobStoreDisabled) {
+		t.Errorf("Expected ErrJobStoreDisabled, got %v", err)
+	}
+
+	sched := NewScheduler().WithJobStore(NewMemoryJobStore())
+	if err := sched.ScheduleOnce("a", time.Now(), "missing", nil); !errors.Is(err, ErrJobHandlerNotFound) {
+		t.Errorf("Expected ErrJobHandlerNotFound, got %v", err)
+	}
+	if err := sched.ScheduleOnce("", time.Now(), "test.once.invalid", nil); !errors.Is(err, ErrInvalidOnceJob) {
+		t.Errorf("Expected ErrInvalidOnceJob, got %v", err)
+	}
+	if err := sched.ScheduleOnce("a", time.Time{}, "test.once.invalid", nil); !errors.Is(err, ErrInvalidOnceJob) {
+		t.Errorf("Expected ErrInvalidOnceJob, got %v", err)
+	}
+}
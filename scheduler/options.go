@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/redis/go-redis/v9"
+)
+
+// Option cung cấp các phụ thuộc bên ngoài cho NewSchedulerWithConfig,
+// chẳng hạn client cho backend của distributed locking và leader election.
+type Option func(*schedulerOptions)
+
+// schedulerOptions chứa các phụ thuộc được cung cấp qua Option.
+type schedulerOptions struct {
+	redisClient  *redis.Client
+	mongoManager MongoManager
+	clock        Clock
+}
+
+// WithRedisClient cung cấp Redis client cho driver "redis" của
+// distributed_lock, leader_election, history và misfire.
+func WithRedisClient(client *redis.Client) Option {
+	return func(o *schedulerOptions) {
+		o.redisClient = client
+	}
+}
+
+// WithMongoManager cung cấp MongoDB manager cho driver "mongodb" của
+// distributed_lock, leader_election, history và misfire.
+func WithMongoManager(manager MongoManager) Option {
+	return func(o *schedulerOptions) {
+		o.mongoManager = manager
+	}
+}
+
+// WithClock thay đồng hồ hệ thống của scheduler bằng clock, thường là fake clock
+// của package scheduler/testing để kiểm thử job mà không cần sleep.
+//
+// Example:
+//
+//	clock := schedulertesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	sched := scheduler.NewSchedulerWithConfig(scheduler.DefaultConfig(), scheduler.WithClock(clock))
+//	sched.Every(1).Hours().Do(job)
+//	sched.StartAsync()
+//	clock.Advance(time.Hour) // job chạy xong trước khi Advance trả về
+func WithClock(clock Clock) Option {
+	return func(o *schedulerOptions) {
+		o.clock = clock
+	}
+}
+
+// newGocronScheduler tạo gocron.Scheduler với múi giờ, chế độ singleton và
+// giới hạn đồng thời theo cấu hình.
+func newGocronScheduler(cfg Config) (*gocron.Scheduler, error) {
+	location, err := loadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler := gocron.NewScheduler(location)
+	if cfg.SingletonMode {
+		scheduler.SingletonModeAll()
+	}
+
+	if cfg.Concurrency.MaxJobs < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMaxConcurrentJobs, cfg.Concurrency.MaxJobs)
+	}
+	if cfg.Concurrency.MaxJobs > 0 {
+		switch cfg.Concurrency.Mode {
+		case "", "reschedule":
+			scheduler.SetMaxConcurrentJobs(cfg.Concurrency.MaxJobs, gocron.RescheduleMode)
+		case "wait":
+			scheduler.SetMaxConcurrentJobs(cfg.Concurrency.MaxJobs, gocron.WaitMode)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidConcurrencyMode, cfg.Concurrency.Mode)
+		}
+	}
+
+	return scheduler, nil
+}
+
+// loadLocation trả về múi giờ theo tên, time.Local nếu tên rỗng hoặc "Local".
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" || timezone == "Local" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, timezone)
+	}
+	return location, nil
+}
+
+// newDistributedLocker tạo locker theo driver được cấu hình trong distributed_lock.
+//
+// Driver "redis" cần WithRedisClient, "mongodb" cần WithMongoManager
+// và "memory" tạo locker trong bộ nhớ.
+func newDistributedLocker(cfg Config, opts schedulerOptions) (gocron.Locker, error) {
+	switch cfg.DistributedLock.Driver {
+	case "", "redis":
+		return NewRedisLocker(opts.redisClient, cfg.Options)
+	case "mongodb":
+		return NewMongoLocker(opts.mongoManager, cfg.DistributedLock.Collection, cfg.Options)
+	case "memory":
+		return NewMemoryLocker(cfg.Options)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLockDriver, cfg.DistributedLock.Driver)
+	}
+}
+
+// newLeaderElector tạo leader elector theo driver được cấu hình trong leader_election.
+func newLeaderElector(cfg Config, opts schedulerOptions) (LeaderElector, error) {
+	options := cfg.LeaderElection.ToElectorOptions()
+	switch cfg.LeaderElection.Driver {
+	case "", "redis":
+		return NewRedisElector(opts.redisClient, options)
+	case "mongodb":
+		return NewMongoElector(opts.mongoManager, cfg.LeaderElection.Collection, options)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedElectionDriver, cfg.LeaderElection.Driver)
+	}
+}
+
+// newHistoryStore tạo HistoryStore theo driver được cấu hình trong history.
+func newHistoryStore(cfg Config, opts schedulerOptions) (HistoryStore, error) {
+	options := cfg.History.ToHistoryOptions()
+	switch cfg.History.Driver {
+	case "", "memory":
+		return NewMemoryHistoryStore(options)
+	case "redis":
+		return NewRedisHistoryStore(opts.redisClient, options)
+	case "mongodb":
+		return NewMongoHistoryStore(opts.mongoManager, cfg.History.Collection, options)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedHistoryDriver, cfg.History.Driver)
+	}
+}
+
+// newLastRunStore tạo LastRunStore theo driver được cấu hình trong misfire.
+func newLastRunStore(cfg Config, opts schedulerOptions) (LastRunStore, error) {
+	switch cfg.Misfire.Driver {
+	case "", "redis":
+		return NewRedisLastRunStore(opts.redisClient, cfg.Misfire.ToLastRunOptions())
+	case "mongodb":
+		return NewMongoLastRunStore(opts.mongoManager, cfg.Misfire.Collection)
+	case "memory":
+		return NewMemoryLastRunStore(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLastRunDriver, cfg.Misfire.Driver)
+	}
+}
+
+// newJobStore tạo JobStore theo driver được cấu hình trong job_store.
+func newJobStore(cfg Config, opts schedulerOptions) (JobStore, error) {
+	switch cfg.JobStore.Driver {
+	case "", "redis":
+		return NewRedisJobStore(opts.redisClient, cfg.JobStore.ToJobStoreOptions())
+	case "mongodb":
+		return NewMongoJobStore(opts.mongoManager, cfg.JobStore.Collection)
+	case "memory":
+		return NewMemoryJobStore(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedJobStoreDriver, cfg.JobStore.Driver)
+	}
+}
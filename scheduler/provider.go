@@ -1,9 +1,14 @@
 package scheduler
 
 import (
+	"errors"
+	"log"
+
+	"github.com/fsnotify/fsnotify"
 	"github.com/go-fork/di"
 	"github.com/go-fork/providers/config"
 	"github.com/go-fork/providers/redis"
+	goredis "github.com/redis/go-redis/v9"
 )
 
 // ServiceProvider cung cấp dịch vụ scheduler và tích hợp với DI container.
@@ -75,22 +80,13 @@ func (p *ServiceProvider) Register(app interface{}) {
 		}
 	}
 
-	// Tạo scheduler manager với cấu hình
-	manager := NewSchedulerWithConfig(cfg)
-
-	// Cấu hình distributed locking nếu được bật
-	if cfg.DistributedLock.Enabled {
-		if redisInstance, err := container.Make("redis"); err == nil {
-			// Thử lấy redis client từ redis provider
-			if redisManager, ok := redisInstance.(redis.Manager); ok {
-				if redisClient, err := redisManager.Client(); err == nil {
-					if locker, err := NewRedisLocker(redisClient, cfg.Options); err == nil {
-						manager = manager.WithDistributedLocker(locker)
-					}
-				}
-			}
-		}
+	// Tạo scheduler manager với cấu hình, client của backend được lấy từ container.
+	// Backend không có sẵn được bỏ qua và scheduler chạy không có thành phần cần nó.
+	options, err := containerOptions(container, cfg)
+	if err != nil {
+		log.Printf("scheduler: %v", err)
 	}
+	manager := NewSchedulerWithConfig(cfg, options...)
 
 	// Đăng ký scheduler manager vào container
 	container.Instance("scheduler", manager)
@@ -104,7 +100,8 @@ func (p *ServiceProvider) Register(app interface{}) {
 //
 // Trong trường hợp của SchedulerServiceProvider, có thể dùng Boot để:
 // 1. Lấy scheduler manager từ container
-// 2. Bắt đầu scheduler trong chế độ async để nó sẵn sàng xử lý các task
+// 2. Nạp các job khai báo trong section scheduler.jobs và áp dụng lại khi config thay đổi
+// 3. Bắt đầu scheduler trong chế độ async để nó sẵn sàng xử lý các task
 //
 // Params:
 //   - app: interface{} - Đối tượng ứng dụng phải implement interface:
@@ -130,12 +127,7 @@ func (p *ServiceProvider) Boot(app interface{}) {
 		return // Không phải loại scheduler manager
 	}
 
-	// Kiểm tra xem scheduler đã được start chưa
-	if scheduler.IsRunning() {
-		return // Scheduler đã được start rồi
-	}
-
-	// Lấy cấu hình để kiểm tra AutoStart
+	// Lấy cấu hình để nạp job khai báo và kiểm tra AutoStart
 	cfg := DefaultConfig()
 
 	// Thử lấy cấu hình từ config provider (optional)
@@ -143,15 +135,105 @@ func (p *ServiceProvider) Boot(app interface{}) {
 		if configManager, ok := configInstance.(config.Manager); ok {
 			// Load cấu hình từ file config, nếu lỗi thì sử dụng default
 			configManager.UnmarshalKey("scheduler", &cfg)
+
+			// Áp dụng lại các job khai báo mỗi khi file config thay đổi
+			configManager.OnConfigChange(func(event fsnotify.Event) {
+				var jobs []JobConfig
+				if err := configManager.UnmarshalKey("scheduler.jobs", &jobs); err != nil {
+					log.Printf("scheduler: failed to reload jobs from config: %v", err)
+					return
+				}
+				if err := scheduler.ApplyJobs(jobs); err != nil {
+					log.Printf("scheduler: %v", err)
+				}
+			})
 		}
 	}
 
+	// Nạp các job khai báo trong config
+	if err := scheduler.ApplyJobs(cfg.Jobs); err != nil {
+		log.Printf("scheduler: %v", err)
+	}
+
+	// Kiểm tra xem scheduler đã được start chưa
+	if scheduler.IsRunning() {
+		return // Scheduler đã được start rồi
+	}
+
 	// Chỉ auto start nếu được cấu hình để làm vậy
 	if cfg.AutoStart {
 		scheduler.StartAsync()
 	}
 }
 
+// containerOptions lấy client cho các driver redis/mongodb được sử dụng bởi
+// distributed_lock, leader_election, history, misfire và job_store từ redis provider và mongodb provider.
+// Option của các backend lấy được vẫn được trả về cùng lỗi của backend không lấy được.
+func containerOptions(container *di.Container, cfg Config) ([]Option, error) {
+	drivers := make(map[string]bool)
+	if cfg.DistributedLock.Enabled {
+		drivers[cfg.DistributedLock.Driver] = true
+	}
+	if cfg.LeaderElection.Enabled {
+		drivers[cfg.LeaderElection.Driver] = true
+	}
+	if cfg.History.Enabled && cfg.History.Driver != "" && cfg.History.Driver != "memory" {
+		drivers[cfg.History.Driver] = true
+	}
+	if cfg.Misfire.Enabled && cfg.Misfire.Driver != "memory" {
+		drivers[cfg.Misfire.Driver] = true
+	}
+	if cfg.JobStore.Enabled && cfg.JobStore.Driver != "memory" {
+		drivers[cfg.JobStore.Driver] = true
+	}
+
+	var options []Option
+	var errs []error
+	if drivers[""] || drivers["redis"] {
+		client, err := containerRedisClient(container)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			options = append(options, WithRedisClient(client))
+		}
+	}
+	if drivers["mongodb"] {
+		manager, err := containerMongoManager(container)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			options = append(options, WithMongoManager(manager))
+		}
+	}
+	return options, errors.Join(errs...)
+}
+
+// containerRedisClient lấy Redis client từ redis provider.
+func containerRedisClient(container *di.Container) (*goredis.Client, error) {
+	redisInstance, err := container.Make("redis")
+	if err != nil {
+		return nil, err
+	}
+	redisManager, ok := redisInstance.(redis.Manager)
+	if !ok {
+		return nil, ErrRedisClientNil
+	}
+	return redisManager.Client()
+}
+
+// containerMongoManager lấy MongoDB manager từ mongodb provider.
+func containerMongoManager(container *di.Container) (MongoManager, error) {
+	mongoInstance, err := container.Make("mongodb")
+	if err != nil {
+		return nil, err
+	}
+	mongoManager, ok := mongoInstance.(MongoManager)
+	if !ok {
+		return nil, ErrMongoManagerNil
+	}
+	return mongoManager, nil
+}
+
 func (p *ServiceProvider) Requires() []string {
 	return []string{
 		"config",
@@ -1,9 +1,12 @@
 package scheduler
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/go-fork/di"
+	configmocks "github.com/go-fork/providers/config/mocks"
+	"github.com/stretchr/testify/mock"
 )
 
 // MockApp implements the interface required by ServiceProvider
@@ -42,6 +45,26 @@ func TestServiceProviderRegister(t *testing.T) {
 	}
 }
 
+func TestServiceProviderRegisterWithoutRedis(t *testing.T) {
+	container := di.New()
+	configManager := configmocks.NewMockManager(t)
+	container.Instance("config", configManager)
+	configManager.On("UnmarshalKey", "scheduler", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*Config).DistributedLock.Enabled = true
+	}).Return(nil)
+
+	// Thiếu redis provider: scheduler vẫn được đăng ký, không có distributed locker
+	NewServiceProvider().Register(&MockApp{container: container})
+
+	instance, err := container.Make("scheduler")
+	if err != nil {
+		t.Fatalf("Failed to get scheduler from container: %v", err)
+	}
+	if scheduler := instance.(*manager); scheduler.locker != nil {
+		t.Error("Expected the scheduler to run without distributed locker")
+	}
+}
+
 func TestServiceProviderRegisterWithNilApp(t *testing.T) {
 	provider := NewServiceProvider()
 
@@ -173,3 +196,118 @@ func TestNewServiceProvider(t *testing.T) {
 	// Kiểm tra provider implement đúng interface
 	var _ di.ServiceProvider = provider
 }
+
+func TestNewDistributedLocker(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Driver = "memory"
+	locker, err := newDistributedLocker(cfg, schedulerOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create memory locker: %v", err)
+	}
+	if _, ok := locker.(FencedLocker); !ok {
+		t.Error("Memory locker should implement FencedLocker")
+	}
+
+	cfg.DistributedLock.Driver = "redis"
+	if _, err := newDistributedLocker(cfg, schedulerOptions{}); !errors.Is(err, ErrRedisClientNil) {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+
+	cfg.DistributedLock.Driver = "mongodb"
+	if _, err := newDistributedLocker(cfg, schedulerOptions{}); !errors.Is(err, ErrMongoManagerNil) {
+		t.Errorf("Expected ErrMongoManagerNil, got %v", err)
+	}
+
+	cfg.DistributedLock.Driver = "etcd"
+	if _, err := newDistributedLocker(cfg, schedulerOptions{}); !errors.Is(err, ErrUnsupportedLockDriver) {
+		t.Errorf("Expected ErrUnsupportedLockDriver, got %v", err)
+	}
+}
+
+func TestNewLeaderElector(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := newLeaderElector(cfg, schedulerOptions{}); !errors.Is(err, ErrRedisClientNil) {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+
+	cfg.LeaderElection.Driver = "mongodb"
+	if _, err := newLeaderElector(cfg, schedulerOptions{}); !errors.Is(err, ErrMongoManagerNil) {
+		t.Errorf("Expected ErrMongoManagerNil, got %v", err)
+	}
+
+	cfg.LeaderElection.Driver = "etcd"
+	if _, err := newLeaderElector(cfg, schedulerOptions{}); !errors.Is(err, ErrUnsupportedElectionDriver) {
+		t.Errorf("Expected ErrUnsupportedElectionDriver, got %v", err)
+	}
+}
+
+func TestNewLastRunStore(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := newLastRunStore(cfg, schedulerOptions{}); !errors.Is(err, ErrRedisClientNil) {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+
+	cfg.Misfire.Driver = "mongodb"
+	if _, err := newLastRunStore(cfg, schedulerOptions{}); !errors.Is(err, ErrMongoManagerNil) {
+		t.Errorf("Expected ErrMongoManagerNil, got %v", err)
+	}
+
+	cfg.Misfire.Driver = "memory"
+	if _, err := newLastRunStore(cfg, schedulerOptions{}); err != nil {
+		t.Errorf("Failed to create memory last run store: %v", err)
+	}
+
+	cfg.Misfire.Driver = "etcd"
+	if _, err := newLastRunStore(cfg, schedulerOptions{}); !errors.Is(err, ErrUnsupportedLastRunDriver) {
+		t.Errorf("Expected ErrUnsupportedLastRunDriver, got %v", err)
+	}
+}
+
+func TestNewJobStore(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := newJobStore(cfg, schedulerOptions{}); !errors.Is(err, ErrRedisClientNil) {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+
+	cfg.JobStore.Driver = "mongodb"
+	if _, err := newJobStore(cfg, schedulerOptions{}); !errors.Is(err, ErrMongoManagerNil) {
+		t.Errorf("Expected ErrMongoManagerNil, got %v", err)
+	}
+
+	cfg.JobStore.Driver = "memory"
+	if _, err := newJobStore(cfg, schedulerOptions{}); err != nil {
+		t.Errorf("Failed to create memory job store: %v", err)
+	}
+
+	cfg.JobStore.Driver = "etcd"
+	if _, err := newJobStore(cfg, schedulerOptions{}); !errors.Is(err, ErrUnsupportedJobStoreDriver) {
+		t.Errorf("Expected ErrUnsupportedJobStoreDriver, got %v", err)
+	}
+}
+
+func TestContainerOptions(t *testing.T) {
+	container := di.New()
+
+	// Không cần client khi distributed locking và leader election đều tắt
+	options, err := containerOptions(container, DefaultConfig())
+	if err != nil || len(options) != 0 {
+		t.Fatalf("Expected no options, got %d (%v)", len(options), err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Driver = "memory"
+	if _, err := containerOptions(container, cfg); err != nil {
+		t.Errorf("Memory driver should not require a client: %v", err)
+	}
+
+	cfg.LeaderElection.Enabled = true
+	if _, err := containerOptions(container, cfg); err == nil {
+		t.Error("Expected error when redis provider is not registered")
+	}
+
+	cfg.LeaderElection.Driver = "mongodb"
+	if _, err := containerOptions(container, cfg); err == nil {
+		t.Error("Expected error when mongodb provider is not registered")
+	}
+}
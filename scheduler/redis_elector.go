@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisElectScript lấy lease nếu chưa có ai giữ, hoặc gia hạn nếu lease đã
+// thuộc về owner token này. Trả về 1 nếu instance giữ lease sau thao tác.
+var redisElectScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return 1
+end
+if current == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// NewRedisElector tạo một LeaderElector sử dụng Redis làm backend.
+// Nó có thể được chuyển vào phương thức WithLeaderElection của scheduler.
+//
+// Lease được lưu tại Key với owner token của instance và thời hạn LeaseDuration.
+// Leader gia hạn lease sau mỗi RenewInterval bằng thao tác compare-and-extend,
+// follower thử lấy lease cùng chu kỳ.
+//
+// Example:
+//
+//	elector, err := scheduler.NewRedisElector(redisClient)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	elector.OnLeadershipChange(func(isLeader bool) {
+//		log.Printf("leader: %v", isLeader)
+//	})
+//	sched.WithLeaderElection(elector)
+func NewRedisElector(client *redis.Client, opts ...ElectorOptions) (LeaderElector, error) {
+	if client == nil {
+		return nil, ErrRedisClientNil
+	}
+
+	options := DefaultElectorOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+		if err := validateElectorOptions(options); err != nil {
+			return nil, err
+		}
+	}
+
+	// Kiểm tra kết nối đến Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, ErrFailedToConnectToRedis
+	}
+
+	leaseMillis := options.LeaseDuration.Milliseconds()
+	acquire := func(ctx context.Context, token string) (bool, error) {
+		acquired, err := redisElectScript.Run(ctx, client, []string{options.Key}, token, leaseMillis).Int64()
+		return acquired == 1, err
+	}
+	renew := func(ctx context.Context, token string) (bool, error) {
+		renewed, err := redisRenewScript.Run(ctx, client, []string{options.Key}, token, leaseMillis).Int64()
+		return renewed == 1, err
+	}
+	release := func(ctx context.Context, token string) (bool, error) {
+		deleted, err := redisUnlockScript.Run(ctx, client, []string{options.Key}, token).Int64()
+		return deleted == 1, err
+	}
+
+	return newLeaseElector(options, acquire, renew, release)
+}
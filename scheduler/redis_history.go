@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisHistoryStore lưu lịch sử chạy job trong Redis.
+//
+// Mỗi job có một list tại KeyPrefix + tên job, phần tử là JobRun dạng JSON,
+// mới nhất ở đầu list và được cắt bớt còn MaxRuns phần tử sau mỗi lần ghi.
+type redisHistoryStore struct {
+	client  *redis.Client
+	options HistoryOptions
+}
+
+// NewRedisHistoryStore tạo HistoryStore sử dụng Redis làm backend.
+//
+// Example:
+//
+//	store, err := scheduler.NewRedisHistoryStore(redisClient)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithHistory(store)
+func NewRedisHistoryStore(client *redis.Client, opts ...HistoryOptions) (HistoryStore, error) {
+	if client == nil {
+		return nil, ErrRedisClientNil
+	}
+
+	options := DefaultHistoryOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+		if err := validateHistoryOptions(options); err != nil {
+			return nil, err
+		}
+		if options.KeyPrefix == "" {
+			return nil, ErrInvalidKeyPrefix
+		}
+	}
+
+	// Kiểm tra kết nối đến Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, ErrFailedToConnectToRedis
+	}
+
+	return &redisHistoryStore{
+		client:  client,
+		options: options,
+	}, nil
+}
+
+// Record lưu một lần chạy và cắt bớt list còn MaxRuns phần tử.
+func (s *redisHistoryStore) Record(ctx context.Context, run JobRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	key := s.options.KeyPrefix + run.JobName
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, int64(s.options.MaxRuns-1))
+		return nil
+	})
+	return err
+}
+
+// LastRun trả về lần chạy gần nhất của job.
+func (s *redisHistoryStore) LastRun(ctx context.Context, jobName string) (JobRun, error) {
+	data, err := s.client.LIndex(ctx, s.options.KeyPrefix+jobName, 0).Bytes()
+	if err == redis.Nil {
+		return JobRun{}, ErrNoJobRuns
+	}
+	if err != nil {
+		return JobRun{}, err
+	}
+
+	var run JobRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return JobRun{}, err
+	}
+	return run, nil
+}
+
+// Runs trả về các lần chạy gần nhất của job.
+func (s *redisHistoryStore) Runs(ctx context.Context, jobName string, limit int) ([]JobRun, error) {
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(limit - 1)
+	}
+
+	items, err := s.client.LRange(ctx, s.options.KeyPrefix+jobName, 0, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]JobRun, 0, len(items))
+	for _, item := range items {
+		var run JobRun
+		if err := json.Unmarshal([]byte(item), &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisSetLastRunScript chỉ ghi thời điểm chạy mới nếu lớn hơn giá trị đã lưu,
+// để các instance ghi đồng thời không làm lùi thời điểm chạy gần nhất.
+var redisSetLastRunScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and tonumber(current) >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1])
+return 1
+`)
+
+// redisLastRunStore lưu thời điểm chạy gần nhất của job trong Redis.
+//
+// Mỗi job có một key tại KeyPrefix + tên job, giá trị là Unix nanoseconds.
+type redisLastRunStore struct {
+	client  *redis.Client
+	options LastRunOptions
+}
+
+// NewRedisLastRunStore tạo LastRunStore sử dụng Redis làm backend.
+//
+// Example:
+//
+//	store, err := scheduler.NewRedisLastRunStore(redisClient)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithLastRunStore(store)
+func NewRedisLastRunStore(client *redis.Client, opts ...LastRunOptions) (LastRunStore, error) {
+	if client == nil {
+		return nil, ErrRedisClientNil
+	}
+
+	options := DefaultLastRunOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+		if options.KeyPrefix == "" {
+			return nil, ErrInvalidKeyPrefix
+		}
+	}
+
+	// Kiểm tra kết nối đến Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, ErrFailedToConnectToRedis
+	}
+
+	return &redisLastRunStore{
+		client:  client,
+		options: options,
+	}, nil
+}
+
+// LastRunAt trả về thời điểm chạy gần nhất của job.
+func (s *redisLastRunStore) LastRunAt(ctx context.Context, jobName string) (time.Time, error) {
+	nanos, err := s.client.Get(ctx, s.options.KeyPrefix+jobName).Int64()
+	if err == redis.Nil {
+		return time.Time{}, ErrNoJobRuns
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
+
+// SetLastRunAt ghi nhận thời điểm chạy gần nhất của job.
+func (s *redisLastRunStore) SetLastRunAt(ctx context.Context, jobName string, at time.Time) error {
+	return redisSetLastRunScript.Run(ctx, s.client, []string{s.options.KeyPrefix + jobName}, at.UnixNano()).Err()
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCompleteOnceScript chỉ xóa job nếu ID của job đã lưu khớp với ID của
+// lần chạy, để không xóa job vừa được lên lịch lại với cùng tên.
+var redisCompleteOnceScript = redis.NewScript(`
+local current = redis.call("HGET", KEYS[1], ARGV[1])
+if not current then
+	return 0
+end
+if cjson.decode(current)["id"] ~= ARGV[2] then
+	return 0
+end
+return redis.call("HDEL", KEYS[1], ARGV[1])
+`)
+
+// redisJobStore lưu các job chạy một lần trong Redis.
+//
+// Tất cả các job nằm trong một hash tại KeyPrefix + "jobs", field là tên job
+// và giá trị là OnceJob dạng JSON.
+type redisJobStore struct {
+	client  *redis.Client
+	options JobStoreOptions
+}
+
+// NewRedisJobStore tạo JobStore sử dụng Redis làm backend.
+//
+// Example:
+//
+//	store, err := scheduler.NewRedisJobStore(redisClient)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithJobStore(store)
+func NewRedisJobStore(client *redis.Client, opts ...JobStoreOptions) (JobStore, error) {
+	if client == nil {
+		return nil, ErrRedisClientNil
+	}
+
+	options := DefaultJobStoreOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+		if options.KeyPrefix == "" {
+			return nil, ErrInvalidKeyPrefix
+		}
+	}
+
+	// Kiểm tra kết nối đến Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, ErrFailedToConnectToRedis
+	}
+
+	return &redisJobStore{
+		client:  client,
+		options: options,
+	}, nil
+}
+
+// key trả về key của hash chứa các job.
+func (s *redisJobStore) key() string {
+	return s.options.KeyPrefix + "jobs"
+}
+
+// Save lưu job, ghi đè job cùng tên nếu đã tồn tại.
+func (s *redisJobStore) Save(ctx context.Context, job OnceJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key(), job.Name, data).Err()
+}
+
+// Get trả về job theo tên.
+func (s *redisJobStore) Get(ctx context.Context, name string) (OnceJob, error) {
+	data, err := s.client.HGet(ctx, s.key(), name).Bytes()
+	if err == redis.Nil {
+		return OnceJob{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if err != nil {
+		return OnceJob{}, err
+	}
+
+	var job OnceJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return OnceJob{}, err
+	}
+	return job, nil
+}
+
+// List trả về tất cả các job đang chờ chạy, sắp xếp theo RunAt.
+func (s *redisJobStore) List(ctx context.Context) ([]OnceJob, error) {
+	values, err := s.client.HGetAll(ctx, s.key()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]OnceJob, 0, len(values))
+	for _, value := range values {
+		var job OnceJob
+		if err := json.Unmarshal([]byte(value), &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	sortOnceJobs(jobs)
+	return jobs, nil
+}
+
+// Delete xóa job theo tên.
+func (s *redisJobStore) Delete(ctx context.Context, name string) error {
+	deleted, err := s.client.HDel(ctx, s.key(), name).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return nil
+}
+
+// Complete xóa job nếu job chưa bị lên lịch lại.
+func (s *redisJobStore) Complete(ctx context.Context, job OnceJob) error {
+	return redisCompleteOnceScript.Run(ctx, s.client, []string{s.key()}, job.Name, job.ID).Err()
+}
@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// jobSpec ghi lại lịch chạy của job đang được cấu hình qua fluent API, để
+// scheduler có thể tính các thời điểm chạy mà gocron không công khai.
+type jobSpec struct {
+	every       interface{}
+	unit        time.Duration
+	at          []string
+	startAt     time.Time
+	cron        string
+	withSeconds bool
+	once        time.Time
+	jitter      time.Duration
+	spread      time.Duration
+}
+
+// interval trả về khoảng thời gian lặp của job chạy theo Every, hoặc false
+// nếu job chạy theo cron, theo thời điểm cố định (At) hoặc chưa đủ thông tin.
+func (s jobSpec) interval() (time.Duration, bool) {
+	if s.cron != "" || len(s.at) > 0 {
+		return 0, false
+	}
+
+	switch every := s.every.(type) {
+	case int:
+		if s.unit <= 0 || every <= 0 {
+			return 0, false
+		}
+		return time.Duration(every) * s.unit, true
+	case time.Duration:
+		return every, every > 0
+	case string:
+		d, err := time.ParseDuration(every)
+		return d, err == nil && d > 0
+	default:
+		return 0, false
+	}
+}
+
+// period trả về khoảng cách ngắn nhất giữa hai lần chạy liên tiếp của job, hoặc
+// false nếu không xác định được (job chạy một lần, nhiều thời điểm At hoặc chưa
+// đủ thông tin). Với cron, khoảng cách được tính trên periodSamples lần chạy tới.
+func (s jobSpec) period() (time.Duration, bool) {
+	if s.cron != "" {
+		next, err := s.nextFunc(time.UTC)
+		if err != nil {
+			return 0, false
+		}
+
+		var shortest time.Duration
+		previous := next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+		for i := 0; i < periodSamples && !previous.IsZero(); i++ {
+			current := next(previous)
+			if current.IsZero() {
+				break
+			}
+			if gap := current.Sub(previous); shortest == 0 || gap < shortest {
+				shortest = gap
+			}
+			previous = current
+		}
+		return shortest, shortest > 0
+	}
+
+	if len(s.at) > 0 {
+		every, ok := s.every.(int)
+		if !ok || len(s.at) > 1 || every <= 0 || s.unit <= 0 {
+			return 0, false
+		}
+		return time.Duration(every) * s.unit, true
+	}
+	return s.interval()
+}
+
+// periodSamples là số lần chạy của biểu thức cron được xét khi tính period.
+const periodSamples = 1000
+
+// runsImmediately cho biết gocron có chạy job ngay khi scheduler khởi động không.
+// gocron chạy ngay các job theo khoảng thời gian không đặt StartAt.
+func (s jobSpec) runsImmediately() bool {
+	_, ok := s.interval()
+	return ok && s.startAt.IsZero()
+}
+
+// nextFunc trả về hàm tính thời điểm chạy kế tiếp sau một thời điểm cho trước.
+//
+// Biểu thức cron được phân tích giống gocron: dùng múi giờ của scheduler trừ khi
+// biểu thức có tiền tố CRON_TZ= hoặc TZ=.
+func (s jobSpec) nextFunc(location *time.Location) (func(time.Time) time.Time, error) {
+	if s.cron != "" {
+		expression := s.cron
+		if !strings.HasPrefix(expression, "TZ=") && !strings.HasPrefix(expression, "CRON_TZ=") {
+			expression = fmt.Sprintf("CRON_TZ=%s %s", location.String(), expression)
+		}
+
+		var (
+			schedule cron.Schedule
+			err      error
+		)
+		if s.withSeconds {
+			parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+			schedule, err = parser.Parse(expression)
+		} else {
+			schedule, err = cron.ParseStandard(expression)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return schedule.Next, nil
+	}
+
+	interval, ok := s.interval()
+	if !ok {
+		return nil, ErrUnsupportedMisfireSchedule
+	}
+	return func(t time.Time) time.Time {
+		return t.Add(interval)
+	}, nil
+}
+
+// String mô tả lịch chạy của job, ví dụ "cron: 0 * * * *", "every 5m0s",
+// "every 1 day(s) at 10:00" hoặc "once at 2024-01-01T18:00:00Z", kèm spread và
+// jitter nếu có. Trả về chuỗi rỗng nếu không có thông tin lịch chạy.
+func (s jobSpec) String() string {
+	description := s.schedule()
+	if description == "" {
+		return ""
+	}
+	if s.spread > 0 {
+		description += ", spread " + s.spread.String()
+	}
+	if s.jitter > 0 {
+		description += ", jitter " + s.jitter.String()
+	}
+	return description
+}
+
+// schedule mô tả lịch chạy của job, không gồm jitter và spread.
+func (s jobSpec) schedule() string {
+	if !s.once.IsZero() {
+		return "once at " + s.once.Format(time.RFC3339)
+	}
+	if s.cron != "" {
+		if s.withSeconds {
+			return "cron_with_seconds: " + s.cron
+		}
+		return "cron: " + s.cron
+	}
+
+	var description string
+	if interval, ok := s.interval(); ok {
+		description = "every " + interval.String()
+	} else if len(s.at) > 0 {
+		description = fmt.Sprintf("every %v %s at %s", s.every, unitName(s.unit), strings.Join(s.at, ", "))
+	} else {
+		return ""
+	}
+	if !s.startAt.IsZero() {
+		description += " starting " + s.startAt.Format(time.RFC3339)
+	}
+	return description
+}
+
+// unitName trả về tên đơn vị thời gian của job chạy theo Every.
+func unitName(unit time.Duration) string {
+	switch unit {
+	case 7 * 24 * time.Hour:
+		return "week(s)"
+	case 24 * time.Hour:
+		return "day(s)"
+	default:
+		return unit.String()
+	}
+}
@@ -0,0 +1,177 @@
+// Package testing cung cấp các công cụ kiểm thử cho scheduler, trong đó FakeClock
+// là đồng hồ điều khiển được giúp kiểm thử job theo lịch mà không cần sleep.
+//
+// Ví dụ:
+//
+//	import schedulertesting "github.com/go-fork/providers/scheduler/testing"
+//
+//	clock := schedulertesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	sched := scheduler.NewSchedulerWithConfig(scheduler.DefaultConfig(), scheduler.WithClock(clock))
+//	sched.Cron("0 * * * *").Do(job)
+//	sched.StartAsync()
+//	defer sched.Stop()
+//
+//	clock.Advance(time.Hour) // job chạy xong trước khi Advance trả về
+package testing
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-fork/providers/scheduler"
+)
+
+var (
+	_ scheduler.Clock                = (*FakeClock)(nil)
+	_ scheduler.DispatchErrorHandler = (*FakeClock)(nil)
+)
+
+// FakeClock là scheduler.Clock chỉ tiến khi được gọi Advance hoặc Set.
+//
+// Các timer đến hạn được kích hoạt tuần tự theo thời điểm đến hạn, ngay trong
+// goroutine gọi Advance/Set; khi timer kích hoạt, Now() trả về đúng thời điểm
+// đến hạn của timer đó. Scheduler tạo với scheduler.WithClock chờ job được kích
+// hoạt chạy xong trước khi timer trả về, nên job chạy đồng bộ với Advance/Set.
+//
+// Job không được gọi Advance, Set hoặc Sleep của chính FakeClock đang kích hoạt nó.
+// Job không chạy xong trong thời gian chờ của scheduler được ghi nhận qua Err.
+type FakeClock struct {
+	// advanceMu tuần tự hóa các lần gọi Advance/Set
+	advanceMu sync.Mutex
+
+	mu     sync.Mutex
+	now    time.Time
+	seq    int
+	timers []*fakeTimer
+	errs   []error
+}
+
+// fakeTimer là timer được tạo bởi FakeClock.AfterFunc.
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	seq   int
+	f     func()
+}
+
+// NewFakeClock tạo FakeClock bắt đầu tại thời điểm now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now trả về thời điểm hiện tại của FakeClock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep chờ đến khi FakeClock được tiến thêm ít nhất d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	done := make(chan struct{})
+	c.AfterFunc(d, func() { close(done) })
+	<-done
+}
+
+// AfterFunc đăng ký f được gọi khi FakeClock tiến tới Now() + d.
+// Timer với d <= 0 được kích hoạt ở lần gọi Advance/Set tiếp theo.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) scheduler.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	timer := &fakeTimer{clock: c, when: c.now.Add(d), seq: c.seq, f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance tiến FakeClock thêm d và kích hoạt các timer đến hạn.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set đặt FakeClock tới thời điểm t và kích hoạt các timer đến hạn theo thứ tự.
+// Timer được tạo trong lúc kích hoạt (ví dụ lịch chạy tiếp theo của job) cũng được
+// kích hoạt nếu đến hạn trước t. Đặt về thời điểm trước Now() không kích hoạt timer nào.
+func (c *FakeClock) Set(t time.Time) {
+	c.advanceMu.Lock()
+	defer c.advanceMu.Unlock()
+
+	for {
+		c.mu.Lock()
+		timer := c.nextDue(t)
+		if timer == nil {
+			c.now = t
+			c.mu.Unlock()
+			return
+		}
+		if timer.when.After(c.now) {
+			c.now = timer.when
+		}
+		c.mu.Unlock()
+
+		timer.f()
+	}
+}
+
+// PendingTimers trả về số timer chưa được kích hoạt.
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// HandleDispatchError ghi nhận lỗi của scheduler khi job được FakeClock kích hoạt
+// không chạy xong trong thời gian chờ.
+func (c *FakeClock) HandleDispatchError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+// Err trả về các lỗi được ghi nhận qua HandleDispatchError, nil nếu không có.
+// Test nên kiểm tra Err sau Advance/Set để phát hiện job bị chạy trễ hoặc bị mất.
+func (c *FakeClock) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return errors.Join(c.errs...)
+}
+
+// nextDue lấy ra timer đến hạn sớm nhất không muộn hơn t, hoặc nil nếu không có.
+// Phải được gọi khi giữ c.mu.
+func (c *FakeClock) nextDue(t time.Time) *fakeTimer {
+	if len(c.timers) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		if c.timers[i].when.Equal(c.timers[j].when) {
+			return c.timers[i].seq < c.timers[j].seq
+		}
+		return c.timers[i].when.Before(c.timers[j].when)
+	})
+
+	timer := c.timers[0]
+	if timer.when.After(t) {
+		return nil
+	}
+	c.timers = c.timers[1:]
+	return timer
+}
+
+// Stop hủy timer, trả về false nếu timer đã được kích hoạt hoặc đã bị hủy.
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-fork/providers/scheduler"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClockFiresTimersInOrder(t *testing.T) {
+	clock := NewFakeClock(epoch)
+
+	var fired []time.Duration
+	for _, d := range []time.Duration{3 * time.Second, time.Second, 2 * time.Second} {
+		d := d
+		clock.AfterFunc(d, func() {
+			if got := clock.Now(); !got.Equal(epoch.Add(d)) {
+				t.Errorf("Expected Now() %v while firing, got %v", epoch.Add(d), got)
+			}
+			fired = append(fired, d)
+		})
+	}
+
+	clock.Advance(2 * time.Second)
+	if len(fired) != 2 || fired[0] != time.Second || fired[1] != 2*time.Second {
+		t.Fatalf("Expected timers at 1s and 2s to fire in order, got %v", fired)
+	}
+	if !clock.Now().Equal(epoch.Add(2 * time.Second)) {
+		t.Errorf("Expected clock at +2s, got %v", clock.Now())
+	}
+
+	clock.Set(epoch.Add(time.Minute))
+	if len(fired) != 3 || clock.PendingTimers() != 0 {
+		t.Errorf("Expected all timers fired, got %v with %d pending", fired, clock.PendingTimers())
+	}
+}
+
+func TestFakeClockStop(t *testing.T) {
+	clock := NewFakeClock(epoch)
+
+	var fired atomic.Bool
+	timer := clock.AfterFunc(time.Second, func() { fired.Store(true) })
+	if !timer.Stop() {
+		t.Fatal("Expected Stop to cancel pending timer")
+	}
+	if timer.Stop() {
+		t.Error("Expected second Stop to return false")
+	}
+
+	clock.Advance(time.Minute)
+	if fired.Load() {
+		t.Error("Stopped timer should not fire")
+	}
+}
+
+func TestFakeClockFiresTimersCreatedWhileFiring(t *testing.T) {
+	clock := NewFakeClock(epoch)
+
+	count := 0
+	var tick func()
+	tick = func() {
+		count++
+		clock.AfterFunc(time.Second, tick)
+	}
+	clock.AfterFunc(time.Second, tick)
+
+	clock.Advance(5 * time.Second)
+	if count != 5 {
+		t.Errorf("Expected 5 ticks, got %d", count)
+	}
+}
+
+// newTestScheduler tạo scheduler sử dụng FakeClock.
+func newTestScheduler(t *testing.T, clock *FakeClock) scheduler.Manager {
+	t.Helper()
+	cfg := scheduler.DefaultConfig()
+	cfg.Timezone = "UTC"
+	sched, err := scheduler.NewSchedulerFromConfig(cfg, scheduler.WithClock(clock))
+	if err != nil {
+		t.Fatalf("Failed to create scheduler: %v", err)
+	}
+	return sched
+}
+
+func TestSchedulerWithFakeClockCron(t *testing.T) {
+	clock := NewFakeClock(epoch.Add(30 * time.Minute))
+	sched := newTestScheduler(t, clock)
+
+	var runs atomic.Int32
+	var lastRunAt atomic.Value
+	if _, err := sched.Cron("0 * * * *").Name("hourly").Do(func() {
+		runs.Add(1)
+		lastRunAt.Store(clock.Now())
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	clock.Advance(29 * time.Minute)
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("Expected no runs before 01:00, got %d", got)
+	}
+
+	// Job chạy đồng bộ: không cần chờ sau khi Advance trả về
+	clock.Advance(3 * time.Hour)
+	if got := runs.Load(); got != 3 {
+		t.Errorf("Expected 3 hourly runs, got %d", got)
+	}
+	if got := lastRunAt.Load().(time.Time); !got.Equal(epoch.Add(3 * time.Hour)) {
+		t.Errorf("Expected last run at 03:00, got %v", got)
+	}
+	if err := clock.Err(); err != nil {
+		t.Errorf("Expected every run to finish while the clock waited, got %v", err)
+	}
+}
+
+func TestSchedulerWithFakeClockInterval(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	sched := newTestScheduler(t, clock)
+
+	var runs atomic.Int32
+	job, err := sched.Every(10).Minutes().StartAt(epoch.Add(10 * time.Minute)).Do(func() {
+		runs.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	clock.Advance(time.Hour)
+	if got := runs.Load(); got != 6 {
+		t.Errorf("Expected 6 runs in an hour, got %d", got)
+	}
+	if next := job.NextRun(); !next.Equal(epoch.Add(70 * time.Minute)) {
+		t.Errorf("Expected next run at 01:10, got %v", next)
+	}
+
+	sched.Clear()
+	clock.Advance(time.Hour)
+	if got := runs.Load(); got != 6 {
+		t.Errorf("Expected no runs after Clear, got %d", got)
+	}
+}
+
+func TestSchedulerWithFakeClockSpread(t *testing.T) {
+	clock := NewFakeClock(epoch.Add(30 * time.Minute))
+	sched := newTestScheduler(t, clock)
+
+	var runs []time.Time
+	if _, err := sched.Cron("0 * * * *").Name("cleanup").Spread(10 * time.Minute).Do(func() {
+		runs = append(runs, clock.Now())
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	// Timer chờ của spread cũng chạy đồng bộ: không cần chờ sau khi Advance trả về
+	clock.Advance(2 * time.Hour)
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(runs))
+	}
+
+	first := runs[0].Sub(epoch.Add(time.Hour))
+	if first <= 0 || first >= 10*time.Minute {
+		t.Errorf("Expected first run spread within 10 minutes after 01:00, got %v", first)
+	}
+	if gap := runs[1].Sub(runs[0]); gap != time.Hour {
+		t.Errorf("Expected the same offset every hour, got gap %v", gap)
+	}
+}
+
+func TestSchedulerWithFakeClockRetry(t *testing.T) {
+	clock := NewFakeClock(epoch.Add(30 * time.Minute))
+	sched := newTestScheduler(t, clock)
+
+	var attempts []time.Time
+	if _, err := sched.Cron("0 * * * *").Name("sync").DoWithContext(func(ctx context.Context) error {
+		attempts = append(attempts, clock.Now())
+		return errors.New("sync failed")
+	}, scheduler.WithJobRetry(scheduler.RetryPolicy{
+		MaxRetries:     2,
+		InitialBackoff: time.Minute,
+		Multiplier:     2,
+	})); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	// Thời gian chờ giữa các lần thử chỉ trôi khi fake clock được tiến
+	clock.Advance(30 * time.Minute)
+	if len(attempts) != 1 {
+		t.Fatalf("Expected only the first attempt at 01:00, got %v", attempts)
+	}
+
+	clock.Advance(3 * time.Minute)
+	if len(attempts) != 3 {
+		t.Fatalf("Expected 3 attempts by 01:03, got %v", attempts)
+	}
+	for i, want := range []time.Duration{time.Hour, time.Hour + time.Minute, time.Hour + 3*time.Minute} {
+		if !attempts[i].Equal(epoch.Add(want)) {
+			t.Errorf("Expected attempt %d at %v, got %v", i+1, epoch.Add(want), attempts[i])
+		}
+	}
+	if err := clock.Err(); err != nil {
+		t.Errorf("Expected no dispatch errors, got %v", err)
+	}
+}
+
+func TestSchedulerWithFakeClockSpreadAndLocker(t *testing.T) {
+	clock := NewFakeClock(epoch.Add(30 * time.Minute))
+	sched := newTestScheduler(t, clock)
+	locker, err := scheduler.NewMemoryLocker()
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+	sched.WithDistributedLocker(locker)
+
+	var runs []time.Time
+	if _, err := sched.Cron("0 * * * *").Name("cleanup").Spread(10 * time.Minute).Do(func() {
+		runs = append(runs, clock.Now())
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	sched.StartAsync()
+	defer sched.Stop()
+
+	// Spread được chờ trước khi lấy khóa, cũng đồng bộ với Advance
+	clock.Advance(2 * time.Hour)
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(runs))
+	}
+	if first := runs[0].Sub(epoch.Add(time.Hour)); first <= 0 || first >= 10*time.Minute {
+		t.Errorf("Expected first run spread within 10 minutes after 01:00, got %v", first)
+	}
+	if err := clock.Err(); err != nil {
+		t.Errorf("Expected no dispatch errors, got %v", err)
+	}
+}
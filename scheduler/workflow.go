@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// StepStatus là trạng thái của một bước trong lần chạy workflow.
+type StepStatus string
+
+const (
+	// StepSucceeded cho biết bước đã chạy thành công.
+	StepSucceeded StepStatus = "succeeded"
+
+	// StepFailed cho biết bước trả về lỗi hoặc panic.
+	StepFailed StepStatus = "failed"
+
+	// StepSkipped cho biết bước không chạy vì một bước phụ thuộc không thành công
+	// hoặc workflow bị hủy trước khi bước bắt đầu.
+	StepSkipped StepStatus = "skipped"
+)
+
+// StepRun là kết quả của một bước trong lần chạy workflow.
+type StepRun struct {
+	// Name là tên của bước
+	Name string `json:"name" bson:"name"`
+
+	// Status là trạng thái của bước
+	Status StepStatus `json:"status" bson:"status"`
+
+	// StartedAt là thời điểm bước bắt đầu chạy, rỗng nếu bước bị bỏ qua
+	StartedAt time.Time `json:"started_at,omitempty" bson:"started_at,omitempty"`
+
+	// Duration là thời gian chạy của bước
+	Duration time.Duration `json:"duration,omitempty" bson:"duration,omitempty"`
+
+	// Error là thông điệp lỗi nếu bước thất bại
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// workflowStep là một bước của workflow.
+type workflowStep struct {
+	name      string
+	handler   JobHandler
+	dependsOn []string
+}
+
+// Workflow là một DAG các bước: mỗi bước chỉ chạy khi tất cả các bước nó phụ thuộc
+// đã chạy thành công, các bước độc lập chạy song song.
+//
+// Workflow được lên lịch như một job qua Manager.DoWorkflow: lịch của job (ví dụ cron)
+// kích hoạt các bước gốc, và mỗi lần chạy được ghi vào lịch sử cùng trạng thái từng bước.
+//
+// Example:
+//
+//	pipeline := scheduler.NewWorkflow("nightly").
+//		Step("export", exportData).
+//		Step("transform", transformData, "export").
+//		Step("publish", publishData, "transform")
+//
+//	sched.Cron("0 2 * * *").DoWorkflow(pipeline)
+type Workflow struct {
+	name  string
+	steps []workflowStep
+	err   error
+}
+
+// NewWorkflow tạo Workflow rỗng với tên cho trước.
+func NewWorkflow(name string) *Workflow {
+	return &Workflow{name: name}
+}
+
+// Name trả về tên của workflow.
+func (w *Workflow) Name() string {
+	return w.name
+}
+
+// Step thêm một bước với handler và danh sách các bước mà nó phụ thuộc.
+// Lỗi cấu hình (tên trùng, handler nil) được trả về bởi Validate hoặc DoWorkflow.
+func (w *Workflow) Step(name string, handler JobHandler, dependsOn ...string) *Workflow {
+	switch {
+	case name == "":
+		w.err = errors.Join(w.err, fmt.Errorf("%w: step name is required", ErrInvalidWorkflow))
+	case handler == nil:
+		w.err = errors.Join(w.err, fmt.Errorf("%w: step %q has no handler", ErrInvalidWorkflow, name))
+	case w.step(name) != nil:
+		w.err = errors.Join(w.err, fmt.Errorf("%w: duplicate step %q", ErrInvalidWorkflow, name))
+	default:
+		w.steps = append(w.steps, workflowStep{
+			name:      name,
+			handler:   handler,
+			dependsOn: append([]string(nil), dependsOn...),
+		})
+	}
+	return w
+}
+
+// step trả về bước theo tên, hoặc nil nếu không tồn tại.
+func (w *Workflow) step(name string) *workflowStep {
+	for i := range w.steps {
+		if w.steps[i].name == name {
+			return &w.steps[i]
+		}
+	}
+	return nil
+}
+
+// Validate kiểm tra workflow: có ít nhất một bước, các phụ thuộc tồn tại và không có chu trình.
+func (w *Workflow) Validate() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWorkflow)
+	}
+	if len(w.steps) == 0 {
+		return fmt.Errorf("%w: workflow %q has no steps", ErrInvalidWorkflow, w.name)
+	}
+
+	// Thuật toán Kahn: mọi bước phải được sắp xếp topo nếu không có chu trình
+	pending := make(map[string]int, len(w.steps))
+	dependents := make(map[string][]string, len(w.steps))
+	for _, step := range w.steps {
+		for _, dependency := range step.dependsOn {
+			if w.step(dependency) == nil {
+				return fmt.Errorf("%w: step %q depends on unknown step %q", ErrInvalidWorkflow, step.name, dependency)
+			}
+			dependents[dependency] = append(dependents[dependency], step.name)
+		}
+		pending[step.name] = len(step.dependsOn)
+	}
+
+	var ready []string
+	for _, step := range w.steps {
+		if pending[step.name] == 0 {
+			ready = append(ready, step.name)
+		}
+	}
+	sorted := 0
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		sorted++
+		for _, dependent := range dependents[name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if sorted != len(w.steps) {
+		return fmt.Errorf("%w: workflow %q contains a dependency cycle", ErrInvalidWorkflow, w.name)
+	}
+	return nil
+}
+
+// Run chạy workflow một lần và trả về kết quả từng bước theo thứ tự khai báo.
+//
+// Trả về lỗi bọc ErrWorkflowStepFailed nếu có bước thất bại.
+func (w *Workflow) Run(ctx context.Context) ([]StepRun, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w.run(ctx, time.Now)
+}
+
+// run chạy các bước, mỗi bước trong một goroutine chờ các bước phụ thuộc hoàn tất.
+func (w *Workflow) run(ctx context.Context, now func() time.Time) ([]StepRun, error) {
+	results := make([]StepRun, len(w.steps))
+	index := make(map[string]int, len(w.steps))
+	done := make(map[string]chan struct{}, len(w.steps))
+	for i, step := range w.steps {
+		index[step.name] = i
+		done[step.name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for i, step := range w.steps {
+		wg.Add(1)
+		go func(i int, step workflowStep) {
+			defer wg.Done()
+			defer close(done[step.name])
+
+			result := StepRun{Name: step.name, Status: StepSkipped}
+			defer func() { results[i] = result }()
+
+			// Chờ các bước phụ thuộc; kết quả của chúng được ghi trước khi done bị đóng
+			for _, dependency := range step.dependsOn {
+				<-done[dependency]
+				if results[index[dependency]].Status != StepSucceeded {
+					return
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			result.StartedAt = now()
+			err := runAttempt(ctx, step.handler, 0)
+			result.Duration = now().Sub(result.StartedAt)
+			if err != nil {
+				result.Status = StepFailed
+				result.Error = err.Error()
+				return
+			}
+			result.Status = StepSucceeded
+		}(i, step)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Status == StepFailed {
+			errs = append(errs, fmt.Errorf("%w: %s: %s", ErrWorkflowStepFailed, result.Name, result.Error))
+		}
+	}
+	if len(errs) == 0 && ctx.Err() != nil {
+		for _, result := range results {
+			if result.Status == StepSkipped {
+				return results, fmt.Errorf("%w: %v", ErrWorkflowCancelled, ctx.Err())
+			}
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// DoWorkflow đặt workflow làm hàm thực thi cho công việc đang cấu hình.
+func (m *manager) DoWorkflow(workflow *Workflow, opts ...JobOption) (*gocron.Job, error) {
+	if workflow == nil {
+		// Để gocron trả về lỗi và dọn dẹp job đang cấu hình
+		m.takeChain()
+		return m.Scheduler.Do(nil)
+	}
+	if err := workflow.Validate(); err != nil {
+		// Dọn dẹp job đang cấu hình trước khi trả về lỗi của workflow
+		m.takeChain()
+		_, _ = m.Scheduler.Do(nil)
+		return nil, err
+	}
+
+	options := jobOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return m.schedule(workflow.name, func(ctx context.Context, record *JobRun) error {
+		return m.runWithContext(ctx, func(ctx context.Context) error {
+			steps, err := workflow.run(ctx, m.now)
+			record.Steps = steps
+			return err
+		}, options)
+	})
+}
+
+// Error constants cho workflow
+var (
+	// ErrInvalidWorkflow được trả về khi workflow không hợp lệ (không có bước,
+	// bước trùng tên, phụ thuộc không tồn tại hoặc có chu trình).
+	ErrInvalidWorkflow = errors.New("scheduler: invalid workflow")
+
+	// ErrWorkflowStepFailed được trả về khi một bước của workflow thất bại.
+	ErrWorkflowStepFailed = errors.New("scheduler: workflow step failed")
+
+	// ErrWorkflowCancelled được trả về khi workflow bị hủy trước khi tất cả các bước chạy.
+	ErrWorkflowCancelled = errors.New("scheduler: workflow cancelled")
+)
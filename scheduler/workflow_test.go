@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// recordingStep trả về handler ghi lại tên bước vào order khi chạy.
+func recordingStep(mu *sync.Mutex, order *[]string, name string, err error) JobHandler {
+	return func(ctx context.Context) error {
+		mu.Lock()
+		*order = append(*order, name)
+		mu.Unlock()
+		return err
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		wantErr  bool
+	}{
+		{"valid", NewWorkflow("w").Step("a", noop).Step("b", noop, "a"), false},
+		{"no steps", NewWorkflow("w"), true},
+		{"no name", NewWorkflow("").Step("a", noop), true},
+		{"nil handler", NewWorkflow("w").Step("a", nil), true},
+		{"duplicate step", NewWorkflow("w").Step("a", noop).Step("a", noop), true},
+		{"unknown dependency", NewWorkflow("w").Step("a", noop, "missing"), true},
+		{"cycle", NewWorkflow("w").Step("a", noop, "c").Step("b", noop, "a").Step("c", noop, "b"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.Validate()
+			if tt.wantErr != errors.Is(err, ErrInvalidWorkflow) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowRunsInDependencyOrder(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	// Khai báo ngược thứ tự để đảm bảo thứ tự chạy theo phụ thuộc
+	workflow := NewWorkflow("nightly").
+		Step("publish", recordingStep(&mu, &order, "publish", nil), "transform").
+		Step("transform", recordingStep(&mu, &order, "transform", nil), "export").
+		Step("export", recordingStep(&mu, &order, "export", nil))
+
+	steps, err := workflow.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected workflow to succeed, got %v", err)
+	}
+
+	if len(order) != 3 || order[0] != "export" || order[1] != "transform" || order[2] != "publish" {
+		t.Errorf("Unexpected execution order: %v", order)
+	}
+	for _, step := range steps {
+		if step.Status != StepSucceeded || step.StartedAt.IsZero() {
+			t.Errorf("Expected step %s succeeded, got %+v", step.Name, step)
+		}
+	}
+}
+
+func TestWorkflowSkipsDownstreamOfFailure(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	workflow := NewWorkflow("nightly").
+		Step("export", recordingStep(&mu, &order, "export", nil)).
+		Step("transform", recordingStep(&mu, &order, "transform", errors.New("bad data")), "export").
+		Step("publish", recordingStep(&mu, &order, "publish", nil), "transform").
+		Step("audit", recordingStep(&mu, &order, "audit", nil), "export")
+
+	steps, err := workflow.Run(context.Background())
+	if !errors.Is(err, ErrWorkflowStepFailed) {
+		t.Fatalf("Expected ErrWorkflowStepFailed, got %v", err)
+	}
+
+	want := map[string]StepStatus{
+		"export":    StepSucceeded,
+		"transform": StepFailed,
+		"publish":   StepSkipped,
+		"audit":     StepSucceeded,
+	}
+	for _, step := range steps {
+		if step.Status != want[step.Name] {
+			t.Errorf("Expected step %s to be %s, got %s", step.Name, want[step.Name], step.Status)
+		}
+	}
+	if steps[1].Error != "bad data" {
+		t.Errorf("Expected failed step error recorded, got %q", steps[1].Error)
+	}
+}
+
+func TestWorkflowCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	workflow := NewWorkflow("w").Step("a", func(ctx context.Context) error { return nil })
+	steps, err := workflow.Run(ctx)
+	if !errors.Is(err, ErrWorkflowCancelled) {
+		t.Fatalf("Expected ErrWorkflowCancelled, got %v", err)
+	}
+	if steps[0].Status != StepSkipped {
+		t.Errorf("Expected step skipped, got %s", steps[0].Status)
+	}
+}
+
+func TestSchedulerDoWorkflowRecordsSteps(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.History.Enabled = true
+	scheduler, err := NewSchedulerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create scheduler: %v", err)
+	}
+
+	noop := func(ctx context.Context) error { return nil }
+	workflow := NewWorkflow("nightly").
+		Step("export", noop).
+		Step("publish", func(ctx context.Context) error { return errors.New("boom") }, "export")
+
+	job, err := scheduler.Every(1).Hours().DoWorkflow(workflow)
+	if err != nil {
+		t.Fatalf("Failed to schedule workflow: %v", err)
+	}
+	if job.GetName() != "nightly" {
+		t.Errorf("Expected workflow name as job name, got %q", job.GetName())
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	var last JobRun
+	waitFor(t, func() bool {
+		last, err = scheduler.LastRun("nightly")
+		return err == nil
+	}, "Expected workflow run to be recorded")
+
+	if last.Succeeded() || len(last.Steps) != 2 {
+		t.Fatalf("Unexpected workflow run: %+v", last)
+	}
+	if last.Steps[0].Status != StepSucceeded || last.Steps[1].Status != StepFailed {
+		t.Errorf("Unexpected step statuses: %+v", last.Steps)
+	}
+}
+
+func TestSchedulerDoWorkflowInvalid(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.Every(1).Hours().DoWorkflow(NewWorkflow("empty"))
+	if !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("Expected ErrInvalidWorkflow, got %v", err)
+	}
+	if jobs := scheduler.GetScheduler().Jobs(); len(jobs) != 0 {
+		t.Errorf("Expected invalid workflow job to be removed, got %d jobs", len(jobs))
+	}
+}